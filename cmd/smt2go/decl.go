@@ -0,0 +1,296 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// A sortExpr is a sort in an SMT-LIB declaration.
+type sortExpr struct {
+	kind sortKind
+
+	bits     int       // For sortBV
+	dom, rng *sortExpr // For sortArray
+	name     string    // For sortDatatype and sortUninterpreted
+}
+
+type sortKind int
+
+const (
+	sortBool sortKind = iota
+	sortInt
+	sortReal
+	sortString
+	sortBV
+	sortArray
+	sortDatatype
+	sortUninterpreted
+)
+
+// A datatype is a declared datatype.
+type datatype struct {
+	name string
+	cons []constructor
+}
+
+type constructor struct {
+	name   string
+	fields []field
+}
+
+type field struct {
+	name string
+	sort *sortExpr
+}
+
+// A function is a declared function or constant.
+type function struct {
+	name   string
+	args   []*sortExpr
+	result *sortExpr
+}
+
+// A decl is one declaration command. Exactly one of its fields is
+// set.
+type decl struct {
+	datatypes []*datatype // A group of mutually recursive datatypes
+	sort      string      // An uninterpreted sort
+	fn        *function
+}
+
+// parseDecls parses the declarations in the SMT-LIB 2 script src.
+func parseDecls(src []byte) ([]decl, error) {
+	cmds, err := parseSexprs(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &declParser{sorts: make(map[string]sortKind)}
+	for _, cmd := range cmds {
+		if err := p.command(cmd); err != nil {
+			return nil, fmt.Errorf("line %d: %v", cmd.line, err)
+		}
+	}
+	return p.decls, nil
+}
+
+type declParser struct {
+	decls []decl
+
+	// sorts maps the names of the sorts declared so far to
+	// sortDatatype or sortUninterpreted.
+	sorts map[string]sortKind
+}
+
+func (p *declParser) command(cmd sexpr) error {
+	if !cmd.isList || len(cmd.list) == 0 || cmd.list[0].isList {
+		return fmt.Errorf("malformed command %s", cmd)
+	}
+	args := cmd.list[1:]
+	switch cmd.list[0].atom {
+	case "declare-datatypes":
+		return p.declareDatatypes(args)
+
+	case "declare-datatype":
+		if len(args) != 2 || !isSymbol(args[0]) {
+			return fmt.Errorf("malformed declare-datatype")
+		}
+		return p.datatypes([]string{args[0].atom}, []sexpr{args[1]})
+
+	case "declare-sort":
+		if len(args) < 1 || len(args) > 2 || !isSymbol(args[0]) {
+			return fmt.Errorf("malformed declare-sort")
+		}
+		if len(args) == 2 && args[1].atom != "0" {
+			return fmt.Errorf("sort %s: parametric sorts are not supported", args[0].atom)
+		}
+		name := args[0].atom
+		if err := p.declareSort(name, sortUninterpreted); err != nil {
+			return err
+		}
+		p.decls = append(p.decls, decl{sort: name})
+
+	case "declare-fun":
+		if len(args) != 3 || !isSymbol(args[0]) || !args[1].isList {
+			return fmt.Errorf("malformed declare-fun")
+		}
+		f := &function{name: args[0].atom}
+		for _, a := range args[1].list {
+			s, err := p.sortExpr(a)
+			if err != nil {
+				return err
+			}
+			f.args = append(f.args, s)
+		}
+		var err error
+		if f.result, err = p.sortExpr(args[2]); err != nil {
+			return err
+		}
+		p.decls = append(p.decls, decl{fn: f})
+
+	case "declare-const":
+		if len(args) != 2 || !isSymbol(args[0]) {
+			return fmt.Errorf("malformed declare-const")
+		}
+		res, err := p.sortExpr(args[1])
+		if err != nil {
+			return err
+		}
+		p.decls = append(p.decls, decl{fn: &function{name: args[0].atom, result: res}})
+
+	case "define-sort":
+		return fmt.Errorf("define-sort is not supported")
+	}
+	return nil
+}
+
+// declareDatatypes parses the arguments of declare-datatypes in
+// either the SMT-LIB 2.6 form
+//
+//	((Name 0) ...) (((cons (field Sort) ...) ...) ...)
+//
+// or the older form
+//
+//	() ((Name (cons (field Sort) ...) ...) ...)
+func (p *declParser) declareDatatypes(args []sexpr) error {
+	if len(args) != 2 || !args[0].isList || !args[1].isList {
+		return fmt.Errorf("malformed declare-datatypes")
+	}
+	var names []string
+	var bodies []sexpr
+	if len(args[0].list) == 0 {
+		// Old form with no sort parameters.
+		for _, d := range args[1].list {
+			if !d.isList || len(d.list) == 0 || !isSymbol(d.list[0]) {
+				return fmt.Errorf("malformed datatype %s", d)
+			}
+			names = append(names, d.list[0].atom)
+			bodies = append(bodies, sexpr{isList: true, list: d.list[1:], line: d.line})
+		}
+	} else {
+		if len(args[0].list) != len(args[1].list) {
+			return fmt.Errorf("declare-datatypes has %d sorts but %d definitions", len(args[0].list), len(args[1].list))
+		}
+		for _, s := range args[0].list {
+			if !s.isList || len(s.list) != 2 || !isSymbol(s.list[0]) {
+				if isSymbol(s) {
+					return fmt.Errorf("parametric datatypes are not supported")
+				}
+				return fmt.Errorf("malformed datatype %s", s)
+			}
+			if s.list[1].atom != "0" {
+				return fmt.Errorf("datatype %s: parametric datatypes are not supported", s.list[0].atom)
+			}
+			names = append(names, s.list[0].atom)
+		}
+		bodies = args[1].list
+	}
+	return p.datatypes(names, bodies)
+}
+
+// datatypes declares the mutually recursive datatypes names, where
+// bodies[i] is the list of constructors of names[i].
+func (p *declParser) datatypes(names []string, bodies []sexpr) error {
+	// Declare all of the names first so the constructors can refer
+	// to any of them.
+	for _, name := range names {
+		if err := p.declareSort(name, sortDatatype); err != nil {
+			return err
+		}
+	}
+	var dts []*datatype
+	for i, body := range bodies {
+		dt := &datatype{name: names[i]}
+		if !body.isList {
+			return fmt.Errorf("malformed datatype %s", dt.name)
+		}
+		if len(body.list) > 0 && body.list[0].atom == "par" {
+			return fmt.Errorf("datatype %s: parametric datatypes are not supported", dt.name)
+		}
+		for _, c := range body.list {
+			if isSymbol(c) {
+				// A constructor with no fields, as in the
+				// old form.
+				dt.cons = append(dt.cons, constructor{name: c.atom})
+				continue
+			}
+			if !c.isList || len(c.list) == 0 || !isSymbol(c.list[0]) {
+				return fmt.Errorf("datatype %s: malformed constructor %s", dt.name, c)
+			}
+			con := constructor{name: c.list[0].atom}
+			for _, f := range c.list[1:] {
+				if !f.isList || len(f.list) != 2 || !isSymbol(f.list[0]) {
+					return fmt.Errorf("constructor %s: malformed field %s", con.name, f)
+				}
+				s, err := p.sortExpr(f.list[1])
+				if err != nil {
+					return err
+				}
+				con.fields = append(con.fields, field{f.list[0].atom, s})
+			}
+			dt.cons = append(dt.cons, con)
+		}
+		if len(dt.cons) == 0 {
+			return fmt.Errorf("datatype %s has no constructors", dt.name)
+		}
+		dts = append(dts, dt)
+	}
+	p.decls = append(p.decls, decl{datatypes: dts})
+	return nil
+}
+
+func (p *declParser) declareSort(name string, kind sortKind) error {
+	if _, ok := p.sorts[name]; ok {
+		return fmt.Errorf("sort %s declared twice", name)
+	}
+	p.sorts[name] = kind
+	return nil
+}
+
+// sortExpr parses a sort.
+func (p *declParser) sortExpr(e sexpr) (*sortExpr, error) {
+	if !e.isList {
+		switch e.atom {
+		case "Bool":
+			return &sortExpr{kind: sortBool}, nil
+		case "Int":
+			return &sortExpr{kind: sortInt}, nil
+		case "Real":
+			return &sortExpr{kind: sortReal}, nil
+		case "String":
+			return &sortExpr{kind: sortString}, nil
+		}
+		if kind, ok := p.sorts[e.atom]; ok {
+			return &sortExpr{kind: kind, name: e.atom}, nil
+		}
+		return nil, fmt.Errorf("unknown sort %s", e.atom)
+	}
+
+	l := e.list
+	switch {
+	case len(l) == 3 && l[0].atom == "_" && l[1].atom == "BitVec":
+		bits, err := strconv.Atoi(l[2].atom)
+		if err != nil || bits <= 0 {
+			return nil, fmt.Errorf("bad bit-vector width in %s", e)
+		}
+		return &sortExpr{kind: sortBV, bits: bits}, nil
+	case len(l) == 3 && l[0].atom == "Array":
+		dom, err := p.sortExpr(l[1])
+		if err != nil {
+			return nil, err
+		}
+		rng, err := p.sortExpr(l[2])
+		if err != nil {
+			return nil, err
+		}
+		return &sortExpr{kind: sortArray, dom: dom, rng: rng}, nil
+	}
+	return nil, fmt.Errorf("unsupported sort %s", e)
+}
+
+func isSymbol(e sexpr) bool {
+	return !e.isList && e.atom != ""
+}
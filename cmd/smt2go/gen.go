@@ -0,0 +1,438 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// generate returns the Go source for decls, which were read from the
+// file named source.
+func generate(decls []decl, pkg, typeName, source string) ([]byte, error) {
+	g := &generator{
+		typeName: typeName,
+		used:     make(map[string]string),
+		goTypes:  make(map[string]string),
+		sortVars: make(map[string]string),
+	}
+	if err := g.gen(decls, pkg, source); err != nil {
+		return nil, err
+	}
+	out, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v\n%s", err, g.buf.Bytes())
+	}
+	return out, nil
+}
+
+type generator struct {
+	buf      bytes.Buffer
+	typeName string
+
+	// used maps each Go identifier used at package scope or on the
+	// declarations type to the SMT-LIB name it came from.
+	used map[string]string
+
+	goTypes  map[string]string // SMT-LIB sort name -> Go type
+	sortVars map[string]string // SMT-LIB sort name -> declarations field
+
+	methods []method
+}
+
+// A method is a method of the declarations type that applies a
+// FuncDecl.
+type method struct {
+	doc    string
+	name   string // Go method name
+	field  string // FuncDecl field of the declarations type
+	params []param
+	result *sortExpr
+}
+
+type param struct {
+	name string
+	sort *sortExpr
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// use records that the Go identifier id is derived from the SMT-LIB
+// name smt and reports an error if it is already in use.
+func (g *generator) use(id, smt string) error {
+	if prev, ok := g.used[id]; ok {
+		return fmt.Errorf("Go name %s of %s conflicts with %s", id, smt, prev)
+	}
+	g.used[id] = smt
+	return nil
+}
+
+func (g *generator) gen(decls []decl, pkg, source string) error {
+	if err := g.use(g.typeName, "the declarations type"); err != nil {
+		return err
+	}
+	if err := g.use("New"+g.typeName, "the declarations constructor"); err != nil {
+		return err
+	}
+
+	// Name all sorts first, since methods refer to them.
+	for _, d := range decls {
+		var names []string
+		if d.sort != "" {
+			names = []string{d.sort}
+		}
+		for _, dt := range d.datatypes {
+			names = append(names, dt.name)
+		}
+		for _, name := range names {
+			id, err := goName(name)
+			if err != nil {
+				return err
+			}
+			g.sortVars[name] = id + "Sort"
+			if err := g.use(id+"Sort", name); err != nil {
+				return err
+			}
+			if len(d.datatypes) > 0 {
+				g.goTypes[name] = id
+				if err := g.use(id, name); err != nil {
+					return err
+				}
+				if err := g.use(id+"Const", name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	var body bytes.Buffer
+	for _, d := range decls {
+		var err error
+		switch {
+		case d.sort != "":
+			fmt.Fprintf(&body, "d.%s = ctx.UninterpretedSort(%q)\n", g.sortVars[d.sort], d.sort)
+		case d.fn != nil:
+			err = g.function(&body, d.fn)
+		default:
+			err = g.datatypes(&body, d.datatypes)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	g.printf("// Code generated by smt2go from %s. DO NOT EDIT.\n\n", source)
+	g.printf("package %s\n\n", pkg)
+	g.printf("import \"github.com/ralscha/go-z3/z3\"\n\n")
+
+	for _, d := range decls {
+		for _, dt := range d.datatypes {
+			t := g.goTypes[dt.name]
+			g.printf("// %s is a value of datatype %s.\n", t, dt.name)
+			g.printf("type %s struct{ z3.Datatype }\n\n", t)
+			g.printf("// Eq returns a Value that is true if x and y are equal.\n")
+			g.printf("func (x %s) Eq(y %s) z3.Bool { return x.Datatype.Eq(y.Datatype) }\n\n", t, t)
+			g.printf("// NE returns a Value that is true if x and y are not equal.\n")
+			g.printf("func (x %s) NE(y %s) z3.Bool { return x.Datatype.NE(y.Datatype) }\n\n", t, t)
+		}
+	}
+
+	g.printf("// %s holds the sorts and functions declared in %s.\n", g.typeName, source)
+	g.printf("type %s struct {\n", g.typeName)
+	g.printf("ctx *z3.Context\n\n")
+	for _, d := range decls {
+		if d.sort != "" {
+			g.printf("%s z3.Sort\n", g.sortVars[d.sort])
+		}
+		for _, dt := range d.datatypes {
+			g.printf("%s z3.Sort\n", g.sortVars[dt.name])
+		}
+	}
+	g.printf("\n")
+	for _, m := range g.methods {
+		g.printf("%s z3.FuncDecl\n", m.field)
+	}
+	g.printf("}\n\n")
+
+	g.printf("// New%s declares the sorts and functions of %s in ctx.\n", g.typeName, source)
+	g.printf("func New%s(ctx *z3.Context) *%s {\n", g.typeName, g.typeName)
+	g.printf("d := &%s{ctx: ctx}\n", g.typeName)
+	g.buf.Write(body.Bytes())
+	g.printf("return d\n}\n")
+
+	for _, d := range decls {
+		for _, dt := range d.datatypes {
+			t := g.goTypes[dt.name]
+			g.printf("\n// %sConst returns a constant named name of datatype %s.\n", t, dt.name)
+			g.printf("func (d *%s) %sConst(name string) %s {\n", g.typeName, t, t)
+			g.printf("return %s{d.ctx.Const(name, d.%s).(z3.Datatype)}\n}\n", t, g.sortVars[dt.name])
+		}
+	}
+
+	for _, m := range g.methods {
+		g.printf("\n// %s\n", m.doc)
+		g.printf("func (d *%s) %s(", g.typeName, m.name)
+		var args []string
+		for i, p := range m.params {
+			if i > 0 {
+				g.printf(", ")
+			}
+			g.printf("%s %s", p.name, g.goType(p.sort))
+			args = append(args, p.name)
+		}
+		g.printf(") %s {\n", g.goType(m.result))
+		g.printf("return %s\n}\n", g.convert(m.result, fmt.Sprintf("d.%s.Apply(%s)", m.field, strings.Join(args, ", "))))
+	}
+	return nil
+}
+
+// addMethod adds a method named name that applies a FuncDecl derived
+// from the SMT-LIB name smt.
+func (g *generator) addMethod(name, smt, doc string, params []param, result *sortExpr) (string, error) {
+	if err := g.use(name, smt); err != nil {
+		return "", err
+	}
+	field := "decl" + name
+	g.methods = append(g.methods, method{doc, name, field, params, result})
+	return field, nil
+}
+
+func (g *generator) function(w *bytes.Buffer, fn *function) error {
+	name, err := goName(fn.name)
+	if err != nil {
+		return err
+	}
+	var params []param
+	var doms []string
+	for i, a := range fn.args {
+		params = append(params, param{fmt.Sprintf("a%d", i), a})
+		doms = append(doms, g.sortCode(a))
+	}
+	doc := fmt.Sprintf("%s applies function %s.", name, fn.name)
+	if len(fn.args) == 0 {
+		doc = fmt.Sprintf("%s returns constant %s.", name, fn.name)
+	}
+	field, err := g.addMethod(name, fn.name, doc, params, fn.result)
+	if err != nil {
+		return err
+	}
+	dom := "nil"
+	if len(doms) > 0 {
+		dom = "[]z3.Sort{" + strings.Join(doms, ", ") + "}"
+	}
+	fmt.Fprintf(w, "d.%s = ctx.FuncDecl(%q, %s, %s)\n", field, fn.name, dom, g.sortCode(fn.result))
+	return nil
+}
+
+func (g *generator) datatypes(w *bytes.Buffer, dts []*datatype) error {
+	group := make(map[string]bool)
+	for _, dt := range dts {
+		group[dt.name] = true
+	}
+
+	// Declare the sorts.
+	fmt.Fprintf(w, "{\n")
+	fmt.Fprintf(w, "sorts := ctx.DatatypeSorts(\n")
+	for _, dt := range dts {
+		fmt.Fprintf(w, "z3.DatatypeDecl{Name: %q, Constructors: []z3.ConstructorDecl{\n", dt.name)
+		for _, c := range dt.cons {
+			if len(c.fields) == 0 {
+				fmt.Fprintf(w, "{Name: %q},\n", c.name)
+				continue
+			}
+			fmt.Fprintf(w, "{Name: %q, Fields: []z3.FieldDecl{\n", c.name)
+			for _, f := range c.fields {
+				if f.sort.kind == sortDatatype && group[f.sort.name] {
+					fmt.Fprintf(w, "{Name: %q, Ref: %q},\n", f.name, f.sort.name)
+					continue
+				}
+				if f.sort.mentions(group) {
+					return fmt.Errorf("field %s of %s: datatypes being declared may only be used directly as field sorts", f.name, c.name)
+				}
+				fmt.Fprintf(w, "{Name: %q, Sort: %s},\n", f.name, g.sortCode(f.sort))
+			}
+			fmt.Fprintf(w, "}},\n")
+		}
+		fmt.Fprintf(w, "}},\n")
+	}
+	fmt.Fprintf(w, ")\n")
+
+	// Fetch the constructors, recognizers, and accessors.
+	define := func(first *bool) string {
+		if *first {
+			*first = false
+			return ":="
+		}
+		return "="
+	}
+	firstCons, firstAcc := true, true
+	for i, dt := range dts {
+		sv := g.sortVars[dt.name]
+		self := &sortExpr{kind: sortDatatype, name: dt.name}
+		fmt.Fprintf(w, "d.%s = sorts[%d]\n", sv, i)
+		fmt.Fprintf(w, "cons, recs %s d.%s.DatatypeConstructors(), d.%s.DatatypeRecognizers()\n", define(&firstCons), sv, sv)
+		for j, c := range dt.cons {
+			cname, err := goName(c.name)
+			if err != nil {
+				return err
+			}
+			var params []param
+			seen := make(map[string]bool)
+			for _, f := range c.fields {
+				p, err := goParam(f.name)
+				if err != nil {
+					return err
+				}
+				if seen[p] {
+					return fmt.Errorf("constructor %s has two fields named %s in Go", c.name, p)
+				}
+				seen[p] = true
+				params = append(params, param{p, f.sort})
+			}
+			doc := fmt.Sprintf("%s applies constructor %s of %s.", cname, c.name, dt.name)
+			consField, err := g.addMethod(cname, c.name, doc, params, self)
+			if err != nil {
+				return err
+			}
+			doc = fmt.Sprintf("Is%s returns a Value that is true if x was built by constructor %s.", cname, c.name)
+			recField, err := g.addMethod("Is"+cname, c.name, doc, []param{{"x", self}}, &sortExpr{kind: sortBool})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "d.%s, d.%s = cons[%d], recs[%d]\n", consField, recField, j, j)
+			if len(c.fields) == 0 {
+				continue
+			}
+			var accFields []string
+			for _, f := range c.fields {
+				fname, err := goName(f.name)
+				if err != nil {
+					return err
+				}
+				doc := fmt.Sprintf("%s returns field %s of x, which must be built by constructor %s.", fname, f.name, c.name)
+				field, err := g.addMethod(fname, f.name, doc, []param{{"x", self}}, f.sort)
+				if err != nil {
+					return err
+				}
+				accFields = append(accFields, "d."+field)
+			}
+			fmt.Fprintf(w, "acc %s d.%s.DatatypeAccessors(%d)\n", define(&firstAcc), sv, j)
+			for k, f := range accFields {
+				fmt.Fprintf(w, "%s = acc[%d]\n", f, k)
+			}
+		}
+	}
+	fmt.Fprintf(w, "}\n")
+	return nil
+}
+
+// mentions reports whether s refers to any of the datatypes in names.
+func (s *sortExpr) mentions(names map[string]bool) bool {
+	switch s.kind {
+	case sortDatatype:
+		return names[s.name]
+	case sortArray:
+		return s.dom.mentions(names) || s.rng.mentions(names)
+	}
+	return false
+}
+
+// sortCode returns a Go expression for sort s, in a context where ctx
+// is the Context and d is the declarations.
+func (g *generator) sortCode(s *sortExpr) string {
+	switch s.kind {
+	case sortBool:
+		return "ctx.BoolSort()"
+	case sortInt:
+		return "ctx.IntSort()"
+	case sortReal:
+		return "ctx.RealSort()"
+	case sortString:
+		return "ctx.StringSort()"
+	case sortBV:
+		return fmt.Sprintf("ctx.BVSort(%d)", s.bits)
+	case sortArray:
+		return fmt.Sprintf("ctx.ArraySort(%s, %s)", g.sortCode(s.dom), g.sortCode(s.rng))
+	}
+	return "d." + g.sortVars[s.name]
+}
+
+// goType returns the Go type of values of sort s.
+func (g *generator) goType(s *sortExpr) string {
+	switch s.kind {
+	case sortBool:
+		return "z3.Bool"
+	case sortInt:
+		return "z3.Int"
+	case sortReal:
+		return "z3.Real"
+	case sortString:
+		return "z3.String"
+	case sortBV:
+		return "z3.BV"
+	case sortArray:
+		return "z3.Array"
+	case sortDatatype:
+		return g.goTypes[s.name]
+	}
+	return "z3.Uninterpreted"
+}
+
+// convert returns a Go expression that converts the z3.Value expr of
+// sort s to its Go type.
+func (g *generator) convert(s *sortExpr, expr string) string {
+	if s.kind == sortDatatype {
+		return fmt.Sprintf("%s{%s.(z3.Datatype)}", g.goTypes[s.name], expr)
+	}
+	return fmt.Sprintf("%s.(%s)", expr, g.goType(s))
+}
+
+// goName returns an exported Go identifier for the SMT-LIB symbol
+// name by splitting it at characters that cannot appear in Go
+// identifiers and capitalizing each part, so "is-empty" becomes
+// "IsEmpty".
+func goName(name string) (string, error) {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, p := range parts {
+		r := []rune(p)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	id := b.String()
+	if id == "" {
+		return "", fmt.Errorf("cannot derive a Go name from %q", name)
+	}
+	if unicode.IsDigit([]rune(id)[0]) {
+		id = "X" + id
+	}
+	return id, nil
+}
+
+// goParam returns a Go parameter name for the SMT-LIB symbol name.
+func goParam(name string) (string, error) {
+	id, err := goName(name)
+	if err != nil {
+		return "", err
+	}
+	r := []rune(id)
+	if id[0] != 'X' || len(r) == 1 || !unicode.IsDigit(r[1]) {
+		r[0] = unicode.ToLower(r[0])
+	}
+	id = string(r)
+	// Avoid keywords and the names the generated code uses.
+	if token.Lookup(id).IsKeyword() || id == "d" || id == "z3" {
+		id += "_"
+	}
+	return id, nil
+}
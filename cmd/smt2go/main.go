@@ -0,0 +1,103 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Smt2go generates typed Go bindings for the sorts and functions
+// declared in an SMT-LIB 2 file.
+//
+// Usage:
+//
+//	smt2go [-o out.go] [-pkg name] [-type name] file.smt2
+//
+// Smt2go reads the declare-datatypes, declare-datatype, declare-sort,
+// declare-fun, and declare-const commands of file.smt2 and ignores
+// other commands. It writes a Go file with:
+//
+//   - A Go type for each datatype, wrapping a z3.Datatype.
+//   - A struct type (default Decls) holding the declared sorts, with a
+//     constructor that declares them in a z3.Context.
+//   - Methods on that struct for each constructor, recognizer, field
+//     accessor, function, and constant, with Go types for their
+//     arguments and results.
+//
+// For example, the declarations
+//
+//	(declare-datatypes ((List 0)) (((nil) (cons (head Int) (tail List)))))
+//	(declare-const xs List)
+//
+// produce methods including
+//
+//	func (d *Decls) Cons(head z3.Int, tail List) List
+//	func (d *Decls) IsCons(x List) z3.Bool
+//	func (d *Decls) Head(x List) z3.Int
+//	func (d *Decls) Xs() List
+//
+// Smt2go is meant to be run by go generate:
+//
+//	//go:generate go run github.com/ralscha/go-z3/cmd/smt2go -o model.go model.smt2
+//
+// The supported sorts are Bool, Int, Real, String, bit-vectors,
+// arrays, uninterpreted sorts, and declared datatypes. Parametric
+// datatypes and sorts are not supported.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+var (
+	flagOut  = flag.String("o", "", "write output to `file` (default standard output)")
+	flagPkg  = flag.String("pkg", "", "package `name` of the output (default $GOPACKAGE, or main)")
+	flagType = flag.String("type", "Decls", "`name` of the generated declarations type")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: smt2go [-o out.go] [-pkg name] [-type name] file.smt2\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	filename := flag.Arg(0)
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	decls, err := parseDecls(src)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filename, err)
+		os.Exit(1)
+	}
+
+	pkg := *flagPkg
+	if pkg == "" {
+		pkg = os.Getenv("GOPACKAGE")
+	}
+	if pkg == "" {
+		pkg = "main"
+	}
+	out, err := generate(decls, pkg, *flagType, filepath.Base(filename))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filename, err)
+		os.Exit(1)
+	}
+
+	if *flagOut == "" {
+		_, err = os.Stdout.Write(out)
+	} else {
+		err = ioutil.WriteFile(*flagOut, out, 0666)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
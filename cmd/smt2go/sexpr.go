@@ -0,0 +1,154 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import "fmt"
+
+// An sexpr is an SMT-LIB S-expression: either an atom, such as a
+// symbol or a numeral, or a parenthesized list.
+type sexpr struct {
+	atom string  // The atom, with quoting bars removed
+	list []sexpr // The elements, if this is a list
+	line int
+
+	isList bool
+}
+
+func (e sexpr) String() string {
+	if !e.isList {
+		return e.atom
+	}
+	s := "("
+	for i, x := range e.list {
+		if i > 0 {
+			s += " "
+		}
+		s += x.String()
+	}
+	return s + ")"
+}
+
+// parseSexprs parses the S-expressions in src.
+func parseSexprs(src []byte) ([]sexpr, error) {
+	p := &sexprParser{src: src, line: 1}
+	var es []sexpr
+	for {
+		p.skipSpace()
+		if p.pos == len(p.src) {
+			return es, nil
+		}
+		e, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+}
+
+type sexprParser struct {
+	src  []byte
+	pos  int
+	line int
+}
+
+func (p *sexprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips white space and comments.
+func (p *sexprParser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == '\n':
+			p.line++
+		case c == ' ' || c == '\t' || c == '\r':
+		case c == ';':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		default:
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *sexprParser) parse() (sexpr, error) {
+	e := sexpr{line: p.line}
+	switch c := p.src[p.pos]; c {
+	case '(':
+		p.pos++
+		e.isList = true
+		for {
+			p.skipSpace()
+			if p.pos == len(p.src) {
+				return e, p.errorf("unclosed list starting on line %d", e.line)
+			}
+			if p.src[p.pos] == ')' {
+				p.pos++
+				return e, nil
+			}
+			x, err := p.parse()
+			if err != nil {
+				return e, err
+			}
+			e.list = append(e.list, x)
+		}
+	case ')':
+		return e, p.errorf("unexpected )")
+	case '|':
+		// Quoted symbol.
+		end := p.pos + 1
+		for end < len(p.src) && p.src[end] != '|' {
+			if p.src[end] == '\n' {
+				p.line++
+			}
+			end++
+		}
+		if end == len(p.src) {
+			return e, p.errorf("unterminated quoted symbol")
+		}
+		e.atom = string(p.src[p.pos+1 : end])
+		p.pos = end + 1
+	case '"':
+		// String literal, in which "" is an escaped quote.
+		end := p.pos + 1
+		for {
+			if end == len(p.src) {
+				return e, p.errorf("unterminated string")
+			}
+			if p.src[end] == '"' {
+				if end+1 < len(p.src) && p.src[end+1] == '"' {
+					end += 2
+					continue
+				}
+				break
+			}
+			if p.src[end] == '\n' {
+				p.line++
+			}
+			end++
+		}
+		e.atom = string(p.src[p.pos : end+1])
+		p.pos = end + 1
+	default:
+		end := p.pos
+		for end < len(p.src) && !isDelim(p.src[end]) {
+			end++
+		}
+		e.atom = string(p.src[p.pos:end])
+		p.pos = end
+	}
+	return e, nil
+}
+
+func isDelim(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '(', ')', ';', '"', '|':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSrc = `
+; A list of integers and a tree over it.
+(declare-sort U 0)
+(declare-datatypes ((Color 0)) (((red) (green) (blue))))
+(declare-datatypes ((List 0)) (((nil) (cons (head Int) (tail List)))))
+(declare-datatypes () ((Tree (leaf (val U)) (node (children Forest)))
+                       (Forest (fnil) (fcons (first Tree) (rest Forest)))))
+(declare-fun |paint-of| (Int Color) Bool)
+(declare-const xs List)
+(declare-const bits (_ BitVec 8))
+(declare-const m (Array Int List))
+(assert (= xs nil))
+(check-sat)
+`
+
+func TestParseDecls(t *testing.T) {
+	decls, err := parseDecls([]byte(testSrc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range decls {
+		switch {
+		case d.sort != "":
+			got = append(got, "sort "+d.sort)
+		case d.fn != nil:
+			got = append(got, "fn "+d.fn.name)
+		default:
+			s := "datatypes"
+			for _, dt := range d.datatypes {
+				s += " " + dt.name
+			}
+			got = append(got, s)
+		}
+	}
+	want := []string{"sort U", "datatypes Color", "datatypes List", "datatypes Tree Forest", "fn paint-of", "fn xs", "fn bits", "fn m"}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	tree := decls[3].datatypes[0]
+	if len(tree.cons) != 2 || tree.cons[1].fields[0].sort.name != "Forest" {
+		t.Errorf("bad Tree datatype %+v", tree)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	decls, err := parseDecls([]byte(testSrc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := generate(decls, "model", "Decls", "test.smt2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := string(out)
+	for _, want := range []string{
+		"// Code generated by smt2go from test.smt2. DO NOT EDIT.",
+		"type List struct{ z3.Datatype }",
+		"func NewDecls(ctx *z3.Context) *Decls {",
+		`d.USort = ctx.UninterpretedSort("U")`,
+		`{Name: "tail", Ref: "List"},`,
+		`{Name: "children", Ref: "Forest"},`,
+		`{Name: "val", Sort: d.USort},`,
+		"func (d *Decls) Cons(head z3.Int, tail List) List {",
+		"func (d *Decls) IsNil(x List) z3.Bool {",
+		"func (d *Decls) Head(x List) z3.Int {",
+		"func (d *Decls) Val(x Tree) z3.Uninterpreted {",
+		"func (d *Decls) PaintOf(a0 z3.Int, a1 Color) z3.Bool {",
+		`d.declPaintOf = ctx.FuncDecl("paint-of", []z3.Sort{ctx.IntSort(), d.ColorSort}, ctx.BoolSort())`,
+		"func (d *Decls) Xs() List {",
+		"func (d *Decls) Bits() z3.BV {",
+		`d.declM = ctx.FuncDecl("m", nil, ctx.ArraySort(ctx.IntSort(), d.ListSort))`,
+		"func (d *Decls) ListConst(name string) List {",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+	if t.Failed() {
+		t.Log(src)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, test := range []struct{ src, err string }{
+		{"(declare-fun f (Int) Foo)", "unknown sort Foo"},
+		{"(declare-sort S 1)", "parametric sorts are not supported"},
+		{"(declare-datatypes ((L 1)) ((par (T) ((nil)))))", "parametric datatypes are not supported"},
+		{"(declare-const x (Seq Int))", "unsupported sort (Seq Int)"},
+		{"(declare-const x Int", "unclosed list"},
+		{"(declare-const is-x Int) (declare-const |is x| Int)", "conflicts with is-x"},
+		{"(declare-datatypes ((L 0)) (((nil) (cons (m (Array Int L))))))", "may only be used directly"},
+	} {
+		decls, err := parseDecls([]byte(test.src))
+		if err == nil {
+			_, err = generate(decls, "p", "Decls", "test.smt2")
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got error %v, want %q", test.src, err, test.err)
+		}
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for _, test := range []struct{ in, name, param string }{
+		{"cons", "Cons", "cons"},
+		{"is-empty", "IsEmpty", "isEmpty"},
+		{"x.y_z", "XYZ", "xYZ"},
+		{"2d", "X2d", "X2d"},
+		{"type", "Type", "type_"},
+		{"d", "D", "d_"},
+	} {
+		name, err := goName(test.in)
+		if err != nil || name != test.name {
+			t.Errorf("goName(%q) = %q, %v; want %q", test.in, name, err, test.name)
+		}
+		param, err := goParam(test.in)
+		if err != nil || param != test.param {
+			t.Errorf("goParam(%q) = %q, %v; want %q", test.in, param, err, test.param)
+		}
+	}
+	if _, err := goName("<="); err == nil {
+		t.Errorf("goName(\"<=\") succeeded")
+	}
+}
@@ -1,3 +1,10 @@
 module github.com/ralscha/go-z3
 
 go 1.25.5
+
+require golang.org/x/tools v0.40.0
+
+require (
+	golang.org/x/mod v0.31.0 // indirect
+	golang.org/x/sync v0.19.0 // indirect
+)
@@ -0,0 +1,111 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import "github.com/ralscha/go-z3/z3"
+
+// Bytes implements symbolic byte slices, []byte. It is a Slice of
+// Uint8, so it supports indexing, appending, and bounds checks in an
+// Env like any other Slice.
+//
+// Bytes converts to and from z3.String, to analyze Go code that mixes
+// []byte and string. A Go string is a sequence of bytes, not of
+// Unicode code points, so a Go string s is modeled as the z3.String
+// whose characters are the bytes of s: character codes range from 0
+// to 255, and non-ASCII text appears in its UTF-8 encoding, exactly
+// as Go's []byte(s) sees it. Use ByteString to create such strings
+// from Go strings and IsByteString to constrain symbolic ones.
+type Bytes = Slice[Uint8]
+
+// BytesOf returns the concrete contents b as a Bytes.
+func BytesOf(ctx *z3.Context, b []byte) Bytes {
+	elems := make([]Uint8, len(b))
+	for i, x := range b {
+		elems[i] = Uint8{C: x}
+	}
+	return SliceOf(ctx, elems...)
+}
+
+// AnyBytes returns a Bytes with unconstrained contents and an
+// unconstrained length between 0 and MaxAnySliceLen.
+func AnyBytes(ctx *z3.Context, name string) Bytes {
+	return AnySlice[Uint8](ctx, name)
+}
+
+// ByteString returns the z3.String modeling the Go string s: each
+// byte of s becomes one character.
+func ByteString(ctx *z3.Context, s string) z3.String {
+	rs := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		rs[i] = rune(s[i])
+	}
+	return ctx.FromRunes(rs)
+}
+
+// IsByteString returns the condition under which s models a Go
+// string, that is, every character of s is a byte.
+func IsByteString(s z3.String) z3.Bool {
+	ctx := s.Context()
+	lo, hi := ctx.FromRunes([]rune{0}), ctx.FromRunes([]rune{0xff})
+	return s.InRE(ctx.RERange(lo, hi).Star())
+}
+
+// BytesFromString returns the Bytes of the Go string modeled by s, as
+// Go's []byte(s). Characters of s that are not bytes are truncated to
+// their low 8 bits; see IsByteString.
+func BytesFromString(s z3.String) Bytes {
+	ctx := s.Context()
+	if rs, ok := s.AsRunes(); ok {
+		b := make([]byte, len(rs))
+		for i, r := range rs {
+			b[i] = byte(r)
+		}
+		return BytesOf(ctx, b)
+	}
+	c := getCache(ctx)
+	i := ctx.FreshConst("i", c.sortInt).(z3.BV)
+	arr := ctx.Lambda([]z3.Value{i}, s.At(i.SToInt()).ToCode().ToBV(8))
+	return Bytes{c, arr, Int{S: s.Length().ToBV(64)}}
+}
+
+// BytesToString returns the z3.String modeling the Go string
+// string(b).
+func BytesToString(b Bytes) z3.String {
+	ctx := b.c.z3
+	if b.len.IsConcrete() {
+		// Build the string character by character.
+		res := ctx.FromString("")
+		if b.len.C == 0 {
+			return res
+		}
+		parts := make([]z3.String, b.len.C)
+		for i := range parts {
+			parts[i] = ctx.StringFromCode(b.index(Int{C: i}).sym(b.c).UToInt())
+		}
+		return parts[0].Concat(parts[1:]...)
+	}
+	f := b.c.bytesToStringFunc()
+	return f.Apply(b.arr, ctx.Int(0), b.len.S.SToInt()).(z3.String)
+}
+
+// bytesToStringFunc returns the recursive function str(a, i, n) that
+// converts elements i through n-1 of the byte array a to a string,
+// defining it the first time c needs it.
+func (c *cache) bytesToStringFunc() z3.FuncDecl {
+	if c.bytesToString != nil {
+		return *c.bytesToString
+	}
+	ctx := c.z3
+	sortArr := ctx.ArraySort(c.sortInt, c.sortUint8)
+	is := ctx.IntSort()
+	f := ctx.RecFuncDecl("bytes_to_string", []z3.Sort{sortArr, is, is}, ctx.StringSort())
+	a := ctx.FreshConst("a", sortArr).(z3.Array)
+	i, n := ctx.FreshConst("i", is).(z3.Int), ctx.FreshConst("n", is).(z3.Int)
+	char := ctx.StringFromCode(a.Select(i.ToBV(64)).(z3.BV).UToInt())
+	rest := f.Apply(a, i.Add(ctx.Int(1)), n).(z3.String)
+	f.AddRecDef([]z3.Value{a, i, n}, n.LE(i).IfThenElse(ctx.FromString(""), char.Concat(rest)))
+	c.bytesToString = &f
+	return f
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// evalBytes returns the concrete contents of b in m.
+func evalBytes(m *z3.Model, b Bytes) []byte {
+	var res []byte
+	for _, x := range b.Eval(m) {
+		res = append(res, x.C)
+	}
+	return res
+}
+
+func TestBytesConcrete(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	b := BytesOf(ctx, []byte("go")).Append(Uint8{C: '!'})
+	// The UTF-8 encoding of "é" is two bytes.
+	s := BytesFromString(ByteString(ctx, "é"))
+
+	solver := z3.NewSolver(ctx)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal(err)
+	}
+	m := solver.Model()
+	if got := evalBytes(m, b); !bytes.Equal(got, []byte("go!")) {
+		t.Errorf("b = %q, want %q", got, "go!")
+	}
+	if got := evalBytes(m, s); !bytes.Equal(got, []byte("é")) {
+		t.Errorf("[]byte(\"é\") = %v, want %v", got, []byte("é"))
+	}
+}
+
+func TestIsByteString(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	for _, tt := range []struct {
+		s    z3.String
+		want bool
+	}{
+		{ByteString(ctx, "\x00héllo\xff"), true},
+		{ctx.FromRunes([]rune{'a', 0x100}), false},
+	} {
+		solver := z3.NewSolver(ctx)
+		solver.Assert(IsByteString(tt.s))
+		if sat, err := solver.Check(); sat != tt.want || err != nil {
+			t.Errorf("IsByteString(%v): got %v, %v; want %v", tt.s, sat, err, tt.want)
+		}
+	}
+}
+
+func TestBytesString(t *testing.T) {
+	ctx := z3.NewContext(nil)
+
+	// Find a string whose bytes start with "id=" and have length 5,
+	// and that converts back to itself.
+	s := ctx.StringConst("s")
+	b := BytesFromString(s)
+	solver := z3.NewSolver(ctx)
+	solver.Assert(IsByteString(s))
+	solver.Assert(b.Len().Eq(Int{C: 5}).S)
+	for i, c := range []byte("id=") {
+		solver.Assert(b.Index(Int{C: i}).Eq(Uint8{C: c}).S)
+	}
+	solver.Assert(BytesToString(b).NE(s))
+	if sat, err := solver.Check(); sat || err != nil {
+		t.Errorf("round trip differs: got %v, %v; want unsat", sat, err)
+	}
+
+	// Symbolic length: string(b) for b = append(x, 'k').
+	x := AnyBytes(ctx, "x").Append(Uint8{C: 'k'})
+	solver.Reset()
+	solver.Assert(BytesToString(x).Eq(ByteString(ctx, "ok")))
+	if sat, err := solver.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	if got := evalBytes(solver.Model(), x); !bytes.Equal(got, []byte("ok")) {
+		t.Errorf("x = %q, want %q", got, "ok")
+	}
+}
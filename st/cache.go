@@ -12,6 +12,17 @@ type cache struct {
 	z3 *z3.Context
 
 	sorts
+
+	// vars are the variables created by the Any functions, in
+	// order.
+	vars []Input
+
+	// env is the Env that owns this context, if any.
+	env *Env
+
+	// bytesToString is the recursive function that converts Bytes
+	// to strings, once it has been defined.
+	bytesToString *z3.FuncDecl
 }
 
 type cacheKeyType struct{}
@@ -27,3 +38,7 @@ func getCache(ctx *z3.Context) *cache {
 	}
 	return c
 }
+
+func (c *cache) addVar(name string, x interface{}) {
+	c.vars = append(c.vars, Input{name, x})
+}
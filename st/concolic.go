@@ -0,0 +1,117 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import "github.com/ralscha/go-z3/z3"
+
+// Concolic drives concolic execution of Go code written with this
+// package.
+//
+// During concolic execution, symbolic inputs (such as those from
+// AnyInt) also have concrete values, given by Model. Every value
+// computed from the inputs is therefore both symbolic and, via its Eval
+// method, concrete. Code branches on symbolic conditions by calling
+// Branch, which follows the concrete value and records the symbolic
+// condition in the path condition. Flip and Explore then ask the
+// solver for inputs that drive execution down other paths.
+type Concolic struct {
+	ctx    *z3.Context
+	solver *z3.Solver
+	model  *z3.Model
+	path   []Bool
+}
+
+// NewConcolic returns a Concolic for ctx. Initially, the inputs have
+// arbitrary concrete values.
+func NewConcolic(ctx *z3.Context) *Concolic {
+	c := &Concolic{ctx: ctx, solver: z3.NewSolver(ctx)}
+	c.solve(nil)
+	return c
+}
+
+// solve sets c's model to one that satisfies conds and resets the path
+// condition. It returns false if conds are unsatisfiable, in which case
+// c is unchanged.
+func (c *Concolic) solve(conds []Bool) bool {
+	c.solver.Reset()
+	for _, cond := range conds {
+		c.solver.Assert(cond.S)
+	}
+	if sat, _ := c.solver.Check(); !sat {
+		return false
+	}
+	c.model = c.solver.Model()
+	c.path = nil
+	return true
+}
+
+// Model returns the model that assigns the current concrete values of
+// the inputs. Use a value's Eval method to get its concrete value.
+func (c *Concolic) Model() *z3.Model {
+	return c.model
+}
+
+// Branch returns the concrete value of cond and adds cond or its
+// negation, whichever is true, to the path condition. Use it in place
+// of an if or loop condition:
+//
+//	if c.Branch(x.LT(y)) { ... }
+func (c *Concolic) Branch(cond Bool) bool {
+	if cond.IsConcrete() {
+		return cond.C
+	}
+	v := cond.Eval(c.model)
+	if !v {
+		cond = cond.Not()
+	}
+	c.path = append(c.path, cond)
+	return v
+}
+
+// Path returns the path condition: the conditions recorded by Branch
+// since the inputs were last changed.
+func (c *Concolic) Path() []Bool {
+	return append([]Bool(nil), c.path...)
+}
+
+// Flip changes the inputs to ones that satisfy the first i conditions
+// of the path condition and the negation of condition i, so that the
+// next execution takes the other side of branch i. It returns false
+// if there are no such inputs, in which case the inputs are unchanged.
+// Flip resets the path condition.
+func (c *Concolic) Flip(i int) bool {
+	conds := append(c.Path()[:i:i], c.path[i].Not())
+	return c.solve(conds)
+}
+
+// Explore calls f repeatedly with inputs that drive it down different
+// paths, until every feasible path has been explored or f has been
+// called limit times. f must use c.Branch for every branch on a
+// symbolic condition and must be deterministic given the inputs.
+// Explore returns the number of times f was called.
+//
+// Explore starts from the current inputs. To enumerate the inputs
+// that were used, evaluate them in c.Model() from within f.
+func (c *Concolic) Explore(limit int, f func(c *Concolic)) int {
+	// Each item is a path prefix to satisfy. Branches within the
+	// prefix have already been explored and are not flipped again.
+	queue := [][]Bool{nil}
+	runs := 0
+	for len(queue) > 0 && runs < limit {
+		prefix := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if runs > 0 && !c.solve(prefix) {
+			continue
+		}
+		c.path = nil
+		f(c)
+		runs++
+		path := c.Path()
+		for i := len(prefix); i < len(path); i++ {
+			queue = append(queue, append(path[:i:i], path[i].Not()))
+		}
+	}
+	return runs
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestConcolic(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x, y := AnyInt32(ctx, "x"), AnyInt32(ctx, "y")
+	classify := func(c *Concolic) string {
+		if c.Branch(x.LT(Int32{C: 10})) {
+			if c.Branch(x.Add(y).Eq(Int32{C: 1000})) {
+				return "small-sum"
+			}
+			return "small"
+		}
+		if c.Branch(Bool{C: true}) && c.Branch(y.Mul(Int32{C: 2}).Eq(x)) {
+			return "double"
+		}
+		return "large"
+	}
+
+	c := NewConcolic(ctx)
+	seen := make(map[string]bool)
+	n := c.Explore(100, func(c *Concolic) {
+		path := classify(c)
+		seen[path] = true
+		// Check that the concrete values agree with the path.
+		xv, yv := x.Eval(c.Model()), y.Eval(c.Model())
+		want := "large"
+		switch {
+		case xv < 10 && xv+yv == 1000:
+			want = "small-sum"
+		case xv < 10:
+			want = "small"
+		case yv*2 == xv:
+			want = "double"
+		}
+		if path != want {
+			t.Errorf("x=%d y=%d took path %s, want %s", xv, yv, path, want)
+		}
+	})
+	if n != 4 || len(seen) != 4 {
+		t.Errorf("explored %d runs covering %v, want 4 paths", n, seen)
+	}
+
+	// Flip the first branch of the last path by hand.
+	classify(c)
+	before := x.LT(Int32{C: 10}).Eval(c.Model())
+	if !c.Flip(0) {
+		t.Fatal("Flip(0) failed")
+	}
+	if len(c.Path()) != 0 || c.Branch(x.LT(Int32{C: 10})) == before {
+		t.Error("Flip(0) did not change the first branch")
+	}
+}
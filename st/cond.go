@@ -0,0 +1,23 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+// Cond returns then if pred is true and els otherwise, like the
+// expression "pred ? then : els" in C.
+//
+// If pred is concrete, Cond simply returns then or els. Otherwise, the
+// result is a symbolic if-then-else term. This merges the two sides of
+// a branch into one value, rather than exploring each side separately
+// as Concolic does.
+func Cond[T Elem[T]](pred Bool, then, els T) T {
+	if pred.IsConcrete() {
+		if pred.C {
+			return then
+		}
+		return els
+	}
+	c := getCache(pred.S.Context())
+	return then.elemFrom(pred.S.IfThenElse(then.elemValue(c), els.elemValue(c)))
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestCond(t *testing.T) {
+	ctx := z3.NewContext(nil)
+
+	// Concrete predicates do not build terms.
+	if got := Cond(Bool{C: true}, Int{C: 1}, AnyInt(ctx, "y")); !got.IsConcrete() || got.C != 1 {
+		t.Errorf("Cond(true, 1, y) = %v, want 1", got)
+	}
+	if got := Cond(Bool{C: false}, AnyInt(ctx, "y"), Int{C: 2}); !got.IsConcrete() || got.C != 2 {
+		t.Errorf("Cond(false, y, 2) = %v, want 2", got)
+	}
+
+	// abs(x) without forking.
+	x := AnyInt16(ctx, "x")
+	abs := Cond(x.LT(Int16{C: 0}), x.Neg(), x)
+	solver := z3.NewSolver(ctx)
+	solver.Assert(x.Eq(Int16{C: -5}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal("unsat:", err)
+	}
+	if got := abs.Eval(solver.Model()); got != 5 {
+		t.Errorf("abs(-5) = %d", got)
+	}
+
+	// Mixed concrete and symbolic arms.
+	n := AnyInteger(ctx, "n")
+	z := Cond(n.GT(Integer{C: big.NewInt(10)}), Integer{C: big.NewInt(10)}, n)
+	solver.Reset()
+	solver.Assert(n.Eq(Integer{C: big.NewInt(99)}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal("unsat:", err)
+	}
+	if got := z.Eval(solver.Model()); got.Int64() != 10 {
+		t.Errorf("min(99, 10) = %v", got)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import "github.com/ralscha/go-z3/z3"
+
+// Env is an environment for symbolic analysis. It owns a Z3 context
+// and a solver, and collects the side conditions of operations on
+// values in that context, such as division by zero or out of bounds
+// slice indexes. This way, code under analysis can be written with
+// the methods of this package without passing a solver around, and
+// the analysis can check afterward which operations can fail.
+//
+// Side conditions are collected for symbolic operations only, and
+// without regard to the branch the operation is on. Use Assume to rule
+// out inputs that cannot reach an operation.
+type Env struct {
+	ctx      *z3.Context
+	solver   *z3.Solver
+	overflow bool
+	conds    []SideCond
+}
+
+// A SideCond is a condition under which an operation fails.
+type SideCond struct {
+	// Msg describes the failure, such as "integer divide by zero".
+	Msg string
+
+	// Cond is the condition under which the operation fails.
+	Cond Bool
+}
+
+// A Violation is a side condition that can fail, along with a model
+// of inputs under which it fails.
+type Violation struct {
+	SideCond
+	Model *z3.Model
+}
+
+// NewEnv returns an Env with a new context created with config, which
+// may be nil.
+func NewEnv(config *z3.Config) *Env {
+	ctx := z3.NewContext(config)
+	e := &Env{ctx: ctx, solver: z3.NewSolver(ctx)}
+	getCache(ctx).env = e
+	return e
+}
+
+// Context returns e's context.
+func (e *Env) Context() *z3.Context {
+	return e.ctx
+}
+
+// Solver returns e's solver. Its assertions are the assumptions made
+// with Assume.
+func (e *Env) Solver() *z3.Solver {
+	return e.solver
+}
+
+// SetOverflowChecks sets whether Add, Sub, and Mul on fixed-size
+// integers record "integer overflow" side conditions. This is off by
+// default, since Go code often wraps around on purpose.
+func (e *Env) SetOverflowChecks(on bool) {
+	e.overflow = on
+}
+
+// Assume restricts the inputs under consideration to those that
+// satisfy cond, such as the preconditions of the code under analysis.
+func (e *Env) Assume(cond Bool) {
+	e.solver.Assert(cond.sym(getCache(e.ctx)))
+}
+
+// Require records that cond must hold. If it does not, the side
+// condition fails with message msg.
+func (e *Env) Require(cond Bool, msg string) {
+	e.record(msg, cond.Not())
+}
+
+// record records a side condition, unless it can never fail.
+func (e *Env) record(msg string, cond Bool) {
+	if cond.IsConcrete() && !cond.C {
+		return
+	}
+	e.conds = append(e.conds, SideCond{msg, cond})
+}
+
+// SideConds returns the side conditions recorded so far, in the order
+// they were recorded.
+func (e *Env) SideConds() []SideCond {
+	return append([]SideCond(nil), e.conds...)
+}
+
+// Violations returns the recorded side conditions that fail for some
+// inputs that satisfy the assumptions. If the solver cannot decide a
+// side condition, Violations returns the violations found so far and
+// the solver's error.
+func (e *Env) Violations() ([]Violation, error) {
+	c := getCache(e.ctx)
+	var vs []Violation
+	for _, sc := range e.conds {
+		e.solver.Push()
+		e.solver.Assert(sc.Cond.sym(c))
+		sat, err := e.solver.Check()
+		if sat {
+			vs = append(vs, Violation{sc, e.solver.Model()})
+		}
+		e.solver.Pop()
+		if err != nil {
+			return vs, err
+		}
+	}
+	return vs, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import "testing"
+
+func TestEnv(t *testing.T) {
+	e := NewEnv(nil)
+	ctx := e.Context()
+	x, y := AnyInt32(ctx, "x"), AnyInt32(ctx, "y")
+	s := AnySlice[Int](ctx, "s")
+	e.Assume(x.GE(Int32{C: 0}).And(x.LT(Int32{C: 100})))
+	e.Assume(s.Len().Eq(Int{C: 10}))
+
+	x.Add(y)
+	if n := len(e.SideConds()); n != 0 {
+		t.Errorf("overflow recorded with checks off: %d side conditions", n)
+	}
+	x.Quo(Int32{C: 3})
+	x.Rem(y)
+	s.Index(x.ToInt())
+	s.Store(Int{C: 3}, Int{C: 1})
+	e.Require(x.NE(Int32{C: 42}), "x is 42")
+	e.Require(x.LT(Int32{C: 100}), "x too large")
+	e.SetOverflowChecks(true)
+	x.Mul(Int32{C: 2})
+	x.Mul(y)
+
+	var msgs []string
+	for _, sc := range e.SideConds() {
+		msgs = append(msgs, sc.Msg)
+	}
+	want := []string{"integer divide by zero", "index out of range", "index out of range", "x is 42", "x too large", "integer overflow", "integer overflow"}
+	if len(msgs) != len(want) {
+		t.Fatalf("side conditions %q, want %q", msgs, want)
+	}
+
+	vs, err := e.Violations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range vs {
+		got = append(got, v.Msg)
+		switch v.Msg {
+		case "integer divide by zero":
+			if yv := y.Eval(v.Model); yv != 0 {
+				t.Errorf("divide by zero with y = %d", yv)
+			}
+		case "index out of range":
+			if xv := x.Eval(v.Model); xv < 10 {
+				t.Errorf("index out of range with x = %d", xv)
+			}
+		case "x is 42":
+			if xv := x.Eval(v.Model); xv != 42 {
+				t.Errorf("x is 42 with x = %d", xv)
+			}
+		}
+	}
+	// s[3] is in bounds, x < 100 is assumed, and x*2 cannot overflow.
+	want = []string{"integer divide by zero", "index out of range", "x is 42", "integer overflow"}
+	if len(got) != len(want) {
+		t.Fatalf("violations %q, want %q", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("violations %q, want %q", got, want)
+		}
+	}
+}
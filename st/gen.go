@@ -12,6 +12,7 @@ import (
 	"flag"
 	"fmt"
 	"go/format"
+	"go/token"
 	"io"
 	"io/ioutil"
 	"os"
@@ -36,6 +37,7 @@ package st
 import (
 	"fmt"
 	"math/big"
+
 	"github.com/ralscha/go-z3/z3"
 )
 
@@ -63,9 +65,17 @@ import (
 		for _, binop := range ops.BinOps {
 			if binop.Flags&typ.Flags != 0 {
 				genBinOp(w, typ, binop)
+				if binop.Tok == token.QUO || binop.Tok == token.REM {
+					genDivCheck(w, typ, binop)
+				}
 			}
 		}
 
+		if typ.Flags&ops.IsInteger != 0 {
+			genShiftChecks(w, typ)
+			genOverflows(w, typ)
+		}
+
 		for _, unop := range ops.UnOps {
 			if unop.Flags&typ.Flags != 0 && unop.Flags&ops.OpPos == 0 {
 				genUnOp(w, typ, unop)
@@ -116,7 +126,9 @@ func genDecl(w *bytes.Buffer, t ops.Type) {
 	fmt.Fprintf(w, "func Any%s(ctx *z3.Context, name string) %s {\n", t.StName, t.StName)
 	fmt.Fprintf(w, "	cache := getCache(ctx)\n")
 	fmt.Fprintf(w, "	sym := cache.z3.FreshConst(name, cache.sort%s).(%s)\n", t.StName, symtype)
-	fmt.Fprintf(w, "	return %s{S: sym}\n", t.StName)
+	fmt.Fprintf(w, "	x := %s{S: sym}\n", t.StName)
+	fmt.Fprintf(w, "	cache.addVar(name, x)\n")
+	fmt.Fprintf(w, "	return x\n")
 	fmt.Fprintf(w, "}\n\n")
 
 	fmt.Fprintf(w, "// String returns x as a string.\n")
@@ -169,6 +181,17 @@ func genDecl(w *bytes.Buffer, t ops.Type) {
 		fmt.Fprintf(w, "return c.z3.FromBigRat(x.C)\n")
 	}
 	fmt.Fprintf(w, "}\n\n")
+
+	// Methods for Elem.
+	fmt.Fprintf(w, "func (%s) elemSort(c *cache) z3.Sort { return c.sort%s }\n\n", t.StName, t.StName)
+	fmt.Fprintf(w, "func (x %s) elemValue(c *cache) z3.Value { return x.sym(c) }\n\n", t.StName)
+	fmt.Fprintf(w, "func (%s) elemFrom(v z3.Value) %s { return %s{S: v.(%s)} }\n\n", t.StName, t.StName, t.StName, symtype)
+	fmt.Fprintf(w, "func (x %s) elemEval(m *z3.Model) %s { return %s{C: x.Eval(m)} }\n\n", t.StName, t.StName, t.StName)
+	if symtype == "z3.Bool" {
+		fmt.Fprintf(w, "func (%s) elemZero(c *cache) z3.Value { return c.z3.FromBool(false) }\n\n", t.StName)
+	} else {
+		fmt.Fprintf(w, "func (%s) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sort%s) }\n\n", t.StName, t.StName)
+	}
 }
 
 func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
@@ -202,6 +225,7 @@ func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 	fmt.Fprintf(w, "ctx := x.S.Context()\n")
 	fmt.Fprintf(w, "if ctx == nil { ctx = y.S.Context() }\n")
 	fmt.Fprintf(w, "cache := getCache(ctx)\n")
+	genSideConds(w, t, op)
 	symop := op.Method
 	if symop == "Quo" && t.Flags&(ops.IsInteger|ops.IsBigRat) != 0 {
 		// On bit-vectors and reals, Go's / operator is
@@ -227,7 +251,14 @@ func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 		// Short-circuit if right is concrete.
 		fmt.Fprintf(w, "if y.IsConcrete() {\n")
 		fmt.Fprintf(w, "	if y.C >= %d {\n", t.Bits)
-		fmt.Fprintf(w, "		return %s{C: 0}\n", resType)
+		if symop == "SRsh" {
+			// Go's >> of a signed value fills with the
+			// sign bit, which is also what a shift by
+			// one less than the width does.
+			fmt.Fprintf(w, "		y.C = %d\n", t.Bits-1)
+		} else {
+			fmt.Fprintf(w, "		return %s{C: 0}\n", resType)
+		}
 		fmt.Fprintf(w, "	}\n")
 		if t.Bits == 64 {
 			fmt.Fprintf(w, "}\n")
@@ -281,6 +312,118 @@ func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 	fmt.Fprintf(w, "}\n\n")
 }
 
+// genShiftChecks generates methods that return the side conditions of
+// Go's shift operators, for finding shifts that panic or discard every
+// bit of their operand.
+func genShiftChecks(w *bytes.Buffer, t ops.Type) {
+	fmt.Fprintf(w, "// ShiftCount returns x as a shift count, along with the condition\n")
+	fmt.Fprintf(w, "// under which x is negative. A Go shift by a negative count panics.\n")
+	fmt.Fprintf(w, "func (x %s) ShiftCount() (Uint64, Bool) {\n", t.StName)
+	if t.Flags&ops.IsUnsigned != 0 {
+		fmt.Fprintf(w, "	return x.ToUint64(), Bool{C: false}\n")
+	} else {
+		fmt.Fprintf(w, "	return x.ToUint64(), x.LT(%s{C: 0})\n", t.StName)
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	for _, op := range []string{"Lsh", "Rsh"} {
+		fmt.Fprintf(w, "// %sChecked returns x.%s(y), along with the condition under which\n", op, op)
+		fmt.Fprintf(w, "// y is at least the width of x.\n")
+		fmt.Fprintf(w, "func (x %s) %sChecked(y Uint64) (%s, Bool) {\n", t.StName, op, t.StName)
+		fmt.Fprintf(w, "	return x.%s(y), y.GE(Uint64{C: %d})\n", op, t.Bits)
+		fmt.Fprintf(w, "}\n\n")
+	}
+}
+
+// genSideConds generates code to record the side conditions of a
+// symbolic operation in the context's Env.
+func genSideConds(w *bytes.Buffer, t ops.Type, op ops.Op) {
+	switch {
+	case op.Tok == token.QUO || op.Tok == token.REM:
+		msg := "integer divide by zero"
+		if t.Flags&ops.IsBigRat != 0 {
+			msg = "division by zero"
+		}
+		fmt.Fprintf(w, "if cache.env != nil {\n")
+		fmt.Fprintf(w, "	cache.env.record(%q, y.Eq(%s))\n", msg, zeroLit(t))
+		fmt.Fprintf(w, "}\n")
+	case t.Flags&ops.IsInteger != 0 && (op.Tok == token.ADD || op.Tok == token.SUB || op.Tok == token.MUL):
+		fmt.Fprintf(w, "if cache.env != nil && cache.env.overflow {\n")
+		fmt.Fprintf(w, "	cache.env.record(\"integer overflow\", x.%sOverflows(y))\n", op.Method)
+		fmt.Fprintf(w, "}\n")
+	}
+}
+
+// zeroLit returns a Go expression for the concrete zero value of t.
+func zeroLit(t ops.Type) string {
+	if t.Flags&(ops.IsBigInt|ops.IsBigRat) != 0 {
+		return fmt.Sprintf("%s{C: new(%s)}", t.StName, strings.TrimLeft(t.ConType, "*"))
+	}
+	return t.StName + "{C: 0}"
+}
+
+// genDivCheck generates a method that returns the result of division
+// operator op along with the condition under which it panics in Go.
+func genDivCheck(w *bytes.Buffer, t ops.Type, op ops.Op) {
+	fmt.Fprintf(w, "// %sChecked returns x.%s(y), along with the condition under which\n", op.Method, op.Method)
+	fmt.Fprintf(w, "// y is 0, in which case Go's %s panics. If y is concretely 0, the\n", op.Op)
+	fmt.Fprintf(w, "// result is 0 instead of panicking.\n")
+	fmt.Fprintf(w, "func (x %s) %sChecked(y %s) (%s, Bool) {\n", t.StName, op.Method, t.StName, t.StName)
+	fmt.Fprintf(w, "	zero := %s\n", zeroLit(t))
+	fmt.Fprintf(w, "	divZero := y.Eq(zero)\n")
+	fmt.Fprintf(w, "	if divZero.IsConcrete() && divZero.C {\n")
+	fmt.Fprintf(w, "		return zero, divZero\n")
+	fmt.Fprintf(w, "	}\n")
+	fmt.Fprintf(w, "	return x.%s(y), divZero\n", op.Method)
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// genOverflows generates methods that return the conditions under
+// which fixed-size integer arithmetic wraps around.
+func genOverflows(w *bytes.Buffer, t ops.Type) {
+	signed := t.Flags&ops.IsUnsigned == 0
+	for _, op := range []struct{ method, op, check string }{
+		{"Add", "+", "xs.AddNoOverflow(ys, %[1]v)"},
+		{"Sub", "-", "xs.SubNoUnderflow(ys, %[1]v)"},
+		{"Mul", "*", "xs.MulNoOverflow(ys, %[1]v)"},
+	} {
+		check := fmt.Sprintf(op.check, signed)
+		if signed {
+			// Z3 checks the two directions of signed
+			// overflow separately.
+			switch op.method {
+			case "Add":
+				check += ".And(xs.AddNoUnderflow(ys))"
+			case "Sub":
+				check += ".And(xs.SubNoOverflow(ys))"
+			case "Mul":
+				// Z3's signed multiplication overflow
+				// predicates give wrong answers for
+				// some negative operands, so compute
+				// the exact product at double width.
+				check = fmt.Sprintf("wide.Eq(wide.Extract(%d, 0).SignExtend(%d))", t.Bits-1, t.Bits)
+			}
+		}
+		fmt.Fprintf(w, "// %sOverflows returns the condition under which x %s y overflows:\n", op.method, op.op)
+		fmt.Fprintf(w, "// the exact result is outside the range of %s, so x.%s(y) wraps\n", t.ConType, op.method)
+		fmt.Fprintf(w, "// around.\n")
+		fmt.Fprintf(w, "func (x %s) %sOverflows(y %s) Bool {\n", t.StName, op.method, t.StName)
+		fmt.Fprintf(w, "	if x.IsConcrete() && y.IsConcrete() {\n")
+		fmt.Fprintf(w, "		exact := x.ToInteger().%s(y.ToInteger())\n", op.method)
+		fmt.Fprintf(w, "		return x.%s(y).ToInteger().NE(exact)\n", op.method)
+		fmt.Fprintf(w, "	}\n")
+		fmt.Fprintf(w, "	ctx := x.S.Context()\n")
+		fmt.Fprintf(w, "	if ctx == nil { ctx = y.S.Context() }\n")
+		fmt.Fprintf(w, "	cache := getCache(ctx)\n")
+		fmt.Fprintf(w, "	xs, ys := x.sym(cache), y.sym(cache)\n")
+		if signed && op.method == "Mul" {
+			fmt.Fprintf(w, "	wide := xs.SignExtend(%d).Mul(ys.SignExtend(%d))\n", t.Bits, t.Bits)
+		}
+		fmt.Fprintf(w, "	return Bool{S: %s.Not()}\n", check)
+		fmt.Fprintf(w, "}\n\n")
+	}
+}
+
 func genUnOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 	fmt.Fprintf(w, "func (x %s) %s() %s {\n", t.StName, op.Method, t.StName)
 
@@ -302,6 +445,7 @@ func genUnOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 
 func genConv(w io.Writer, from, to ops.Type) {
 	if from.Flags&to.Flags&ops.IsInteger == 0 {
+		genBigConv(w, from, to)
 		return
 	}
 	op := ""
@@ -320,3 +464,38 @@ func genConv(w io.Writer, from, to ops.Type) {
 	fmt.Fprintf(w, "	return %s{S: x.S.%s}\n", to.StName, op)
 	fmt.Fprintf(w, "}\n\n")
 }
+
+// genBigConv generates conversions between fixed-size integers and
+// Integer, and from Integer to Real.
+func genBigConv(w io.Writer, from, to ops.Type) {
+	var con, sym string
+	switch {
+	case from.Flags&ops.IsInteger != 0 && to.Flags&ops.IsBigInt != 0:
+		if from.Flags&ops.IsUnsigned != 0 {
+			con, sym = "new(big.Int).SetUint64(uint64(x.C))", "UToInt()"
+		} else {
+			con, sym = "new(big.Int).SetInt64(int64(x.C))", "SToInt()"
+		}
+		fmt.Fprintf(w, "// To%s returns x as an Integer.\n", to.StName)
+	case from.Flags&ops.IsBigInt != 0 && to.Flags&ops.IsInteger != 0:
+		// Keep the low bits of the two's complement
+		// representation, like a conversion between Go's
+		// fixed-size integers.
+		con = fmt.Sprintf("%s(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())", to.ConType)
+		sym = fmt.Sprintf("ToBV(%d)", to.Bits)
+		fmt.Fprintf(w, "// To%s returns the low bits of x's two's complement\n", to.StName)
+		fmt.Fprintf(w, "// representation, like a conversion between Go's fixed-size\n")
+		fmt.Fprintf(w, "// integer types.\n")
+	case from.Flags&ops.IsBigInt != 0 && to.Flags&ops.IsBigRat != 0:
+		con, sym = "new(big.Rat).SetInt(x.C)", "ToReal()"
+		fmt.Fprintf(w, "// To%s returns x as a Real.\n", to.StName)
+	default:
+		return
+	}
+	fmt.Fprintf(w, "func (x %s) To%s() %s {\n", from.StName, to.StName, to.StName)
+	fmt.Fprintf(w, "	if x.IsConcrete() {\n")
+	fmt.Fprintf(w, "		return %s{C: %s}\n", to.StName, con)
+	fmt.Fprintf(w, "	}\n")
+	fmt.Fprintf(w, "	return %s{S: x.S.%s}\n", to.StName, sym)
+	fmt.Fprintf(w, "}\n\n")
+}
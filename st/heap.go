@@ -0,0 +1,88 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"reflect"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// Heap is a symbolic model of memory for executing code that uses
+// pointers. Pointers are Uintptr addresses, which may be symbolic, so
+// whether two pointers alias is decided by the solver.
+//
+// Memory is divided into typed cells. Each Alloc creates one cell at a
+// new address, and Load and Store access the cell of a given type at
+// an address. Cells of different types are independent, even at the
+// same address. Reading a cell that was never stored to produces an
+// unconstrained value.
+//
+// Unlike the other types in this package, a Heap is mutable.
+type Heap struct {
+	c    *cache
+	mem  map[reflect.Type]z3.Array
+	next uint64
+
+	// allocs are the addresses returned by Alloc.
+	allocs []uint64
+}
+
+// heapBase is the address of the first cell allocated by a Heap.
+// Address 0 is the nil pointer.
+const heapBase = 0x1000
+
+// NewHeap returns an empty Heap.
+func NewHeap(ctx *z3.Context) *Heap {
+	return &Heap{c: getCache(ctx), mem: make(map[reflect.Type]z3.Array), next: heapBase}
+}
+
+// memory returns the memory for cells of type T.
+func memory[T Elem[T]](h *Heap) z3.Array {
+	var zero T
+	typ := reflect.TypeOf(zero)
+	mem, ok := h.mem[typ]
+	if !ok {
+		sort := h.c.z3.ArraySort(h.c.sortUintptr, zero.elemSort(h.c))
+		mem = h.c.z3.FreshConst("heap", sort).(z3.Array)
+		h.mem[typ] = mem
+	}
+	return mem
+}
+
+// Alloc allocates a new cell in h, initializes it to v, and returns its
+// address. This is the equivalent of Go's "p := new(T); *p = v".
+// Every call to Alloc returns a distinct, concrete, non-nil address.
+func Alloc[T Elem[T]](h *Heap, v T) Uintptr {
+	p := Uintptr{C: uintptr(h.next)}
+	h.allocs = append(h.allocs, h.next)
+	h.next++
+	Store(h, p, v)
+	return p
+}
+
+// Load returns the value of the cell of type T at address p, as Go's
+// "*p". Load does not check that p is valid.
+func Load[T Elem[T]](h *Heap, p Uintptr) T {
+	var zero T
+	return zero.elemFrom(memory[T](h).Select(p.sym(h.c)))
+}
+
+// Store sets the cell of type T at address p to v, as Go's "*p = v".
+// Store does not check that p is valid.
+func Store[T Elem[T]](h *Heap, p Uintptr, v T) {
+	var zero T
+	h.mem[reflect.TypeOf(zero)] = memory[T](h).Store(p.sym(h.c), v.elemValue(h.c))
+}
+
+// Valid returns the condition under which p is the address of a cell
+// allocated by Alloc. In particular, the nil pointer is never valid.
+func (h *Heap) Valid(p Uintptr) Bool {
+	valid := Bool{C: false}
+	for _, a := range h.allocs {
+		valid = valid.Or(p.Eq(Uintptr{C: uintptr(a)}))
+	}
+	return valid
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestHeap(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	h := NewHeap(ctx)
+	a := Alloc(h, Int{C: 1})
+	b := Alloc(h, Int{C: 2})
+	if a.C == b.C || a.C == 0 {
+		t.Fatalf("bad addresses %v, %v", a, b)
+	}
+
+	// Store through a symbolic pointer p, then find a p for which
+	// *a changed: p must alias a.
+	p := AnyUintptr(ctx, "p")
+	Store(h, p, Int{C: 42})
+	solver := z3.NewSolver(ctx)
+	solver.Assert(h.Valid(p).S)
+	solver.Assert(Load[Int](h, a).Eq(Int{C: 42}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal("expected SAT", err)
+	}
+	m := solver.Model()
+	if got := p.Eval(m); got != a.C {
+		t.Errorf("p = %#x, want %#x", got, a.C)
+	}
+	if got := Load[Int](h, b).Eval(m); got != 2 {
+		t.Errorf("*b = %d, want 2", got)
+	}
+
+	// If p is valid and *a and *b are unchanged, p cannot alias a
+	// or b.
+	solver.Reset()
+	solver.Assert(h.Valid(p).S)
+	solver.Assert(Load[Int](h, a).Eq(Int{C: 1}).S)
+	solver.Assert(Load[Int](h, b).Eq(Int{C: 2}).S)
+	if sat, _ := solver.Check(); sat {
+		t.Error("valid p aliases neither a nor b")
+	}
+
+	// Cells of different types are independent.
+	Store(h, a, Bool{C: true})
+	solver.Reset()
+	solver.Assert(Load[Int](h, b).NE(Int{C: 2}).S)
+	solver.Assert(p.Eq(a).S)
+	if sat, _ := solver.Check(); sat {
+		t.Error("Bool store changed an Int cell")
+	}
+	if v := h.Valid(Uintptr{C: 0}); !v.IsConcrete() || v.C {
+		t.Errorf("nil pointer is valid: %v", v)
+	}
+}
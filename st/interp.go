@@ -0,0 +1,316 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// A Func is a Go function that can be executed over the values of this
+// package, so it does not have to be rewritten in terms of st types by
+// hand.
+//
+// Func executes the function's SSA form, as built by
+// golang.org/x/tools/go/ssa. Call walks its basic blocks, gives each
+// phi node the value from the edge it arrived by, and decides each
+// conditional jump with Concolic.Branch. This supports functions whose
+// parameters, results, and variables have boolean or integer types,
+// with any control flow that lowers to jumps: if, for, range over an
+// integer, switch, goto, and labeled break and continue. Calls to
+// other functions, closures, defer, panics, and values of other types
+// are not supported.
+type Func struct {
+	fset  *token.FileSet
+	fn    *ssa.Function
+	check CheckFunc
+}
+
+// A CheckFunc receives the side conditions of operations executed by
+// Func.Call. cond is the condition under which the operation at pos
+// fails in the way described by msg, such as "negative shift amount".
+// Checking whether cond is satisfiable, for example with the path
+// condition, finds inputs that trigger the failure.
+type CheckFunc func(pos token.Position, msg string, cond Bool)
+
+// SetCheck sets the function that receives the side conditions of
+// operations executed by f. If check is nil, which is the default,
+// side conditions are not computed.
+//
+// Shifts report the conditions "negative shift amount", which panics
+// in Go, and "shift count too large", under which the shift discards
+// every bit of its operand. Division and remainder report "integer
+// divide by zero", which panics in Go.
+func (f *Func) SetCheck(check CheckFunc) {
+	f.check = check
+}
+
+// ParseFunc parses and type-checks the Go source file src, builds its
+// SSA form, and returns the function called name.
+func ParseFunc(src, name string) (*Func, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "src.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	conf := &types.Config{Importer: importer.Default()}
+	pkg := types.NewPackage(file.Name.Name, file.Name.Name)
+	ssaPkg, _, err := ssautil.BuildPackage(conf, fset, pkg, []*ast.File{file}, 0)
+	if err != nil {
+		return nil, err
+	}
+	fn := ssaPkg.Func(name)
+	if fn == nil {
+		return nil, fmt.Errorf("st: function %s not found", name)
+	}
+	return &Func{fset: fset, fn: fn}, nil
+}
+
+// Call executes f with the given arguments and returns its results.
+// Each argument must be the type from this package that corresponds
+// to the parameter's Go type, such as an Int32 for an int32 parameter.
+//
+// Branches on symbolic conditions are decided by c.Branch, so calling
+// Call from c.Explore executes every path through f.
+//
+// Call panics if f uses an unsupported construct.
+func (f *Func) Call(c *Concolic, args ...interface{}) []interface{} {
+	switch {
+	case len(args) < len(f.fn.Params):
+		panic("st: too few arguments to " + f.fn.Name())
+	case len(args) > len(f.fn.Params):
+		panic("st: too many arguments to " + f.fn.Name())
+	}
+	in := &interp{f: f, c: c, vals: make(map[ssa.Value]interface{})}
+	for i, p := range f.fn.Params {
+		in.vals[p] = args[i]
+	}
+	var prev *ssa.BasicBlock
+	for b := f.fn.Blocks[0]; ; {
+		next, ret := in.block(prev, b)
+		if next == nil {
+			return ret
+		}
+		prev, b = b, next
+	}
+}
+
+type interp struct {
+	f    *Func
+	c    *Concolic
+	vals map[ssa.Value]interface{}
+}
+
+// stTypes maps Go basic types to the corresponding types of this
+// package.
+var stTypes = map[types.BasicKind]reflect.Type{
+	types.Bool:    reflect.TypeOf(Bool{}),
+	types.Int:     reflect.TypeOf(Int{}),
+	types.Int8:    reflect.TypeOf(Int8{}),
+	types.Int16:   reflect.TypeOf(Int16{}),
+	types.Int32:   reflect.TypeOf(Int32{}),
+	types.Int64:   reflect.TypeOf(Int64{}),
+	types.Uint:    reflect.TypeOf(Uint{}),
+	types.Uint8:   reflect.TypeOf(Uint8{}),
+	types.Uint16:  reflect.TypeOf(Uint16{}),
+	types.Uint32:  reflect.TypeOf(Uint32{}),
+	types.Uint64:  reflect.TypeOf(Uint64{}),
+	types.Uintptr: reflect.TypeOf(Uintptr{}),
+}
+
+// binOps maps Go binary operators to the methods that implement them.
+var binOps = map[token.Token]string{
+	token.ADD: "Add", token.SUB: "Sub", token.MUL: "Mul", token.QUO: "Quo", token.REM: "Rem",
+	token.AND: "And", token.OR: "Or", token.XOR: "Xor", token.AND_NOT: "AndNot",
+	token.SHL: "Lsh", token.SHR: "Rsh",
+	token.EQL: "Eq", token.NEQ: "NE", token.LSS: "LT", token.LEQ: "LE", token.GTR: "GT", token.GEQ: "GE",
+}
+
+// position returns the position of pos, or of f if pos is unknown.
+func (in *interp) position(pos token.Pos) token.Position {
+	if !pos.IsValid() {
+		pos = in.f.fn.Pos()
+	}
+	return in.f.fset.Position(pos)
+}
+
+func (in *interp) unsupported(instr ssa.Instruction) {
+	panic(fmt.Sprintf("st: %s: unsupported %s", in.position(instr.Pos()), instr))
+}
+
+// stType returns the type of this package for Go type t.
+func (in *interp) stType(pos token.Pos, t types.Type) reflect.Type {
+	if b, ok := t.Underlying().(*types.Basic); ok {
+		if b.Info()&types.IsUntyped != 0 {
+			b = types.Default(b).(*types.Basic)
+		}
+		if st, ok := stTypes[b.Kind()]; ok {
+			return st
+		}
+	}
+	panic(fmt.Sprintf("st: %s: unsupported type %s", in.position(pos), t))
+}
+
+// concrete returns a concrete value of Go type t. A nil val is the
+// zero value.
+func (in *interp) concrete(pos token.Pos, t types.Type, val constant.Value) interface{} {
+	v := reflect.New(in.stType(pos, t)).Elem()
+	if val == nil {
+		return v.Interface()
+	}
+	switch c := v.Field(0); c.Kind() {
+	case reflect.Bool:
+		c.SetBool(constant.BoolVal(val))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, _ := constant.Int64Val(val)
+		c.SetInt(x)
+	default:
+		x, _ := constant.Uint64Val(val)
+		c.SetUint(x)
+	}
+	return v.Interface()
+}
+
+func call(x interface{}, method string, args ...interface{}) interface{} {
+	return callN(x, method, args...)[0].Interface()
+}
+
+// callCond calls a method of x that returns a value and a condition.
+func callCond(x interface{}, method string, args ...interface{}) (interface{}, Bool) {
+	res := callN(x, method, args...)
+	return res[0].Interface(), res[1].Interface().(Bool)
+}
+
+func callN(x interface{}, method string, args ...interface{}) []reflect.Value {
+	rargs := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		rargs[i] = reflect.ValueOf(arg)
+	}
+	return reflect.ValueOf(x).MethodByName(method).Call(rargs)
+}
+
+// check reports cond as a side condition of the operation at pos,
+// unless cond is known to be false.
+func (in *interp) check(pos token.Pos, msg string, cond Bool) {
+	if in.f.check == nil || cond.IsConcrete() && !cond.C {
+		return
+	}
+	in.f.check(in.position(pos), msg, cond)
+}
+
+// value returns the value of v.
+func (in *interp) value(v ssa.Value) interface{} {
+	if k, ok := v.(*ssa.Const); ok {
+		return in.concrete(k.Pos(), k.Type(), k.Value)
+	}
+	val, ok := in.vals[v]
+	if !ok {
+		panic(fmt.Sprintf("st: %s: unsupported value %s", in.position(v.Pos()), v))
+	}
+	return val
+}
+
+// block executes b, entered from prev, and returns the next block,
+// or nil and the results if b returns.
+func (in *interp) block(prev, b *ssa.BasicBlock) (*ssa.BasicBlock, []interface{}) {
+	// The phi nodes at the start of b take their values at once,
+	// from the edge that led here.
+	edge := -1
+	for i, p := range b.Preds {
+		if p == prev {
+			edge = i
+		}
+	}
+	phis := make(map[ssa.Value]interface{})
+	for _, instr := range b.Instrs {
+		phi, ok := instr.(*ssa.Phi)
+		if !ok {
+			break
+		}
+		phis[phi] = in.value(phi.Edges[edge])
+	}
+	for phi, val := range phis {
+		in.vals[phi] = val
+	}
+
+	for _, instr := range b.Instrs {
+		switch instr := instr.(type) {
+		case *ssa.Phi, *ssa.DebugRef:
+		case *ssa.BinOp:
+			in.vals[instr] = in.binary(instr)
+		case *ssa.UnOp:
+			x := in.value(instr.X)
+			switch instr.Op {
+			case token.SUB:
+				in.vals[instr] = call(x, "Neg")
+			case token.XOR, token.NOT:
+				in.vals[instr] = call(x, "Not")
+			default:
+				in.unsupported(instr)
+			}
+		case *ssa.ChangeType:
+			// Named and unnamed types with the same
+			// underlying type have the same st type.
+			in.vals[instr] = in.value(instr.X)
+		case *ssa.Convert:
+			x := in.value(instr.X)
+			to := in.stType(instr.Pos(), instr.Type())
+			if reflect.TypeOf(x) == to {
+				in.vals[instr] = x
+			} else {
+				in.vals[instr] = call(x, "To"+to.Name())
+			}
+		case *ssa.If:
+			if in.c.Branch(in.value(instr.Cond).(Bool)) {
+				return b.Succs[0], nil
+			}
+			return b.Succs[1], nil
+		case *ssa.Jump:
+			return b.Succs[0], nil
+		case *ssa.Return:
+			res := make([]interface{}, len(instr.Results))
+			for i, r := range instr.Results {
+				res[i] = in.value(r)
+			}
+			return nil, res
+		default:
+			in.unsupported(instr)
+		}
+	}
+	panic(fmt.Sprintf("st: %s: block %d does not end in a jump", in.position(in.f.fn.Pos()), b.Index))
+}
+
+// binary returns the result of op.
+func (in *interp) binary(op *ssa.BinOp) interface{} {
+	method, ok := binOps[op.Op]
+	if !ok {
+		in.unsupported(op)
+	}
+	x, y := in.value(op.X), in.value(op.Y)
+	switch op.Op {
+	case token.SHL, token.SHR:
+		y, neg := callCond(y, "ShiftCount")
+		in.check(op.Pos(), "negative shift amount", neg)
+		z, wide := callCond(x, method+"Checked", y)
+		in.check(op.Pos(), "shift count too large", wide)
+		return z
+	case token.QUO, token.REM:
+		if in.f.check != nil {
+			z, divZero := callCond(x, method+"Checked", y)
+			in.check(op.Pos(), "integer divide by zero", divZero)
+			return z
+		}
+	}
+	return call(x, method, y)
+}
@@ -0,0 +1,382 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+const interpSrc = `package p
+
+func collatzSteps(n uint8, limit int) (steps int) {
+	for n != 1 && steps < limit {
+		if n%2 == 0 {
+			n >>= 1
+		} else {
+			n = 3*n + 1
+		}
+		steps++
+	}
+	return
+}
+
+func classify(x int32, y int16) (int64, bool) {
+	var sum int64 = int64(x) + int64(y)
+	if sum < 0 || x == 0 {
+		return -sum, false
+	}
+	for i := 0; i < 3; i++ {
+		if i == 1 {
+			continue
+		}
+		sum += int64(i)
+	}
+	return sum, true
+}
+
+func second(_ int, _ int, y int8) (_ bool, r int8) {
+	r = y + 1
+	return
+}
+
+func seven(int, bool) int {
+	return 7
+}
+`
+
+func collatzSteps(n uint8, limit int) (steps int) {
+	for n != 1 && steps < limit {
+		if n%2 == 0 {
+			n >>= 1
+		} else {
+			n = 3*n + 1
+		}
+		steps++
+	}
+	return
+}
+
+func TestFuncConcrete(t *testing.T) {
+	f, err := ParseFunc(interpSrc, "collatzSteps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewConcolic(z3.NewContext(nil))
+	for n := 1; n < 256; n += 7 {
+		res := f.Call(c, Uint8{C: uint8(n)}, Int{C: 20})
+		if got, want := res[0].(Int).C, collatzSteps(uint8(n), 20); got != want {
+			t.Errorf("collatzSteps(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestFuncUnnamedParams(t *testing.T) {
+	f, err := ParseFunc(interpSrc, "second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewConcolic(z3.NewContext(nil))
+	res := f.Call(c, Int{C: 1}, Int{C: 2}, Int8{C: 3})
+	if len(res) != 2 || res[0].(Bool).C || res[1].(Int8).C != 4 {
+		t.Errorf("second(1, 2, 3) = %v, want [false 4]", res)
+	}
+	g, err := ParseFunc(interpSrc, "seven")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := g.Call(c, Int{C: 1}, Bool{C: true}); res[0].(Int).C != 7 {
+		t.Errorf("seven(1, true) = %v, want 7", res[0])
+	}
+	for _, args := range [][]interface{}{
+		{Int{C: 1}, Int{C: 2}},
+		{Int{C: 1}, Int{C: 2}, Int8{C: 3}, Int8{C: 4}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Call with %d arguments did not panic", len(args))
+				}
+			}()
+			f.Call(c, args...)
+		}()
+	}
+}
+
+func TestFuncSymbolic(t *testing.T) {
+	f, err := ParseFunc(interpSrc, "classify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := z3.NewContext(nil)
+	x, y := AnyInt32(ctx, "x"), AnyInt16(ctx, "y")
+	c := NewConcolic(ctx)
+	paths := 0
+	c.Explore(100, func(c *Concolic) {
+		paths++
+		res := f.Call(c, x, y)
+		xv, yv := int64(x.Eval(c.Model())), int64(y.Eval(c.Model()))
+		sum, ok := xv+yv, true
+		if sum < 0 || xv == 0 {
+			sum, ok = -sum, false
+		} else {
+			sum += 2
+		}
+		if got := res[0].(Int64).Eval(c.Model()); got != sum || res[1].(Bool).Eval(c.Model()) != ok {
+			t.Errorf("classify(%d, %d) = %d, want %d", xv, yv, got, sum)
+		}
+	})
+	// sum < 0; sum >= 0 && x == 0; sum >= 0 && x != 0.
+	if paths != 3 {
+		t.Errorf("explored %d paths, want 3", paths)
+	}
+
+	if _, err := ParseFunc(interpSrc, "missing"); err == nil {
+		t.Error("expected error for missing function")
+	}
+}
+
+func TestFuncCheck(t *testing.T) {
+	const src = `package p
+
+func shift(x int32, n int) int32 {
+	return x >> n
+}
+`
+	f, err := ParseFunc(src, "shift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := z3.NewContext(nil)
+	x, n := AnyInt32(ctx, "x"), AnyInt(ctx, "n")
+	conds := make(map[string]Bool)
+	f.SetCheck(func(pos token.Position, msg string, cond Bool) {
+		if pos.Line != 4 {
+			t.Errorf("%s: check at wrong line", pos)
+		}
+		conds[msg] = cond
+	})
+	f.Call(NewConcolic(ctx), x, n)
+
+	solver := z3.NewSolver(ctx)
+	for msg, want := range map[string]func(n int64) bool{
+		"negative shift amount": func(n int64) bool { return n < 0 },
+		"shift count too large": func(n int64) bool { return uint64(n) >= 32 },
+	} {
+		cond, ok := conds[msg]
+		if !ok {
+			t.Errorf("no %q check", msg)
+			continue
+		}
+		solver.Reset()
+		solver.Assert(cond.S)
+		if sat, err := solver.Check(); !sat || err != nil {
+			t.Errorf("%q is not satisfiable: %v", msg, err)
+			continue
+		}
+		if nv := n.Eval(solver.Model()); !want(int64(nv)) {
+			t.Errorf("%q satisfied by n = %d", msg, nv)
+		}
+	}
+}
+
+func TestFuncCheckDivide(t *testing.T) {
+	const src = `package p
+
+func avg(sum int64, n uint8) int64 {
+	if n > 10 {
+		return sum / 10
+	}
+	return sum / int64(n)
+}
+`
+	f, err := ParseFunc(src, "avg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := z3.NewContext(nil)
+	sum, n := AnyInt64(ctx, "sum"), AnyUint8(ctx, "n")
+	c := NewConcolic(ctx)
+	var panics []int
+	f.SetCheck(func(pos token.Position, msg string, cond Bool) {
+		if msg != "integer divide by zero" {
+			t.Errorf("%s: unexpected check %q", pos, msg)
+		}
+		solver := z3.NewSolver(ctx)
+		for _, p := range c.Path() {
+			solver.Assert(p.S)
+		}
+		solver.Assert(cond.S)
+		if sat, _ := solver.Check(); sat {
+			if nv := n.Eval(solver.Model()); nv != 0 {
+				t.Errorf("%s: divide by zero with n = %d", pos, nv)
+			}
+			panics = append(panics, pos.Line)
+		}
+	})
+	c.Explore(10, func(c *Concolic) {
+		res := f.Call(c, sum, n)[0].(Int64)
+		s, nv := sum.Eval(c.Model()), n.Eval(c.Model())
+		if nv != 0 && nv <= 10 && res.Eval(c.Model()) != s/int64(nv) {
+			t.Errorf("avg(%d, %d) = %d, want %d", s, nv, res.Eval(c.Model()), s/int64(nv))
+		}
+	})
+	// The constant divisor never panics.
+	if len(panics) != 1 || panics[0] != 7 {
+		t.Errorf("divide by zero reachable at lines %v, want [7]", panics)
+	}
+}
+
+const controlSrc = `package p
+
+type flag bool
+
+type small int8
+
+func convert(b bool, x int32, s small) (bool, int32, int64, small) {
+	f := flag(b)
+	return bool(f), int32(x), int64(int32(x)), small(int8(s))
+}
+
+func grade(score uint8) int {
+	switch {
+	case score >= 90:
+		return 4
+	case score >= 75:
+		return 3
+	}
+	switch score % 3 {
+	case 0:
+		return 1
+	}
+	return 0
+}
+
+func sumTo(n int) (sum int) {
+	for i := range 5 {
+		if i == n {
+			break
+		}
+		sum += i
+	}
+	return
+}
+
+func firstBig(x int16) int16 {
+outer:
+	for i := int16(0); i < 3; i++ {
+		for j := int16(0); j < 3; j++ {
+			if x+i*3+j > 100 {
+				x = i*3 + j
+				break outer
+			}
+		}
+	}
+	if x < 0 {
+		goto neg
+	}
+	return x
+neg:
+	return -x
+}
+`
+
+func grade(score uint8) int {
+	switch {
+	case score >= 90:
+		return 4
+	case score >= 75:
+		return 3
+	}
+	switch score % 3 {
+	case 0:
+		return 1
+	}
+	return 0
+}
+
+func sumTo(n int) (sum int) {
+	for i := range 5 {
+		if i == n {
+			break
+		}
+		sum += i
+	}
+	return
+}
+
+func firstBig(x int16) int16 {
+outer:
+	for i := int16(0); i < 3; i++ {
+		for j := int16(0); j < 3; j++ {
+			if x+i*3+j > 100 {
+				x = i*3 + j
+				break outer
+			}
+		}
+	}
+	if x < 0 {
+		goto neg
+	}
+	return x
+neg:
+	return -x
+}
+
+func parseControl(t *testing.T, name string) *Func {
+	t.Helper()
+	f, err := ParseFunc(controlSrc, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestFuncConvert(t *testing.T) {
+	// Conversions between types with the same st type, including
+	// named types, are the identity.
+	c := NewConcolic(z3.NewContext(nil))
+	res := parseControl(t, "convert").Call(c, Bool{C: true}, Int32{C: -5}, Int8{C: 3})
+	if res[0].(Bool).C != true || res[1].(Int32).C != -5 || res[2].(Int64).C != -5 || res[3].(Int8).C != 3 {
+		t.Errorf("convert(true, -5, 3) = %v", res)
+	}
+}
+
+func TestFuncControlFlow(t *testing.T) {
+	c := NewConcolic(z3.NewContext(nil))
+	f := parseControl(t, "sumTo")
+	for n := -1; n <= 6; n++ {
+		if got, want := f.Call(c, Int{C: n})[0].(Int).C, sumTo(n); got != want {
+			t.Errorf("sumTo(%d) = %d, want %d", n, got, want)
+		}
+	}
+	f = parseControl(t, "firstBig")
+	for _, x := range []int16{-7, 0, 95, 99, 100, 200} {
+		if got, want := f.Call(c, Int16{C: x})[0].(Int16).C, firstBig(x); got != want {
+			t.Errorf("firstBig(%d) = %d, want %d", x, got, want)
+		}
+	}
+}
+
+func TestFuncSwitch(t *testing.T) {
+	f := parseControl(t, "grade")
+	ctx := z3.NewContext(nil)
+	score := AnyUint8(ctx, "score")
+	c := NewConcolic(ctx)
+	paths := 0
+	c.Explore(100, func(c *Concolic) {
+		paths++
+		sv := score.Eval(c.Model())
+		if got, want := f.Call(c, score)[0].(Int).Eval(c.Model()), grade(sv); got != want {
+			t.Errorf("grade(%d) = %d, want %d", sv, got, want)
+		}
+	})
+	// >= 90; 75 to 89; < 75 and divisible by 3; the rest.
+	if paths != 4 {
+		t.Errorf("explored %d paths, want 4", paths)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import "github.com/ralscha/go-z3/z3"
+
+// Map implements symbolic maps from K to V. A Map is modeled by a Z3
+// array of values and a Z3 array recording which keys are present.
+//
+// Like Go maps, loading a key that is not present produces the zero
+// value of V. Unlike Go maps, Maps are values: Store and Delete return
+// a new Map and leave the original unchanged.
+//
+// The zero Map is not valid. Use MakeMap or AnyMap to create a Map.
+type Map[K Elem[K], V Elem[V]] struct {
+	c       *cache
+	vals    z3.Array
+	present z3.Array
+}
+
+// MakeMap returns an empty Map.
+func MakeMap[K Elem[K], V Elem[V]](ctx *z3.Context) Map[K, V] {
+	var k K
+	var v V
+	cache := getCache(ctx)
+	vals := ctx.FreshConst("map", ctx.ArraySort(k.elemSort(cache), v.elemSort(cache))).(z3.Array)
+	present := ctx.ConstArray(k.elemSort(cache), ctx.FromBool(false))
+	return Map[K, V]{cache, vals, present}
+}
+
+// AnyMap returns a Map with an unconstrained set of keys and
+// unconstrained values.
+func AnyMap[K Elem[K], V Elem[V]](ctx *z3.Context, name string) Map[K, V] {
+	var k K
+	var v V
+	cache := getCache(ctx)
+	vals := ctx.FreshConst(name, ctx.ArraySort(k.elemSort(cache), v.elemSort(cache))).(z3.Array)
+	present := ctx.FreshConst(name+".keys", ctx.ArraySort(k.elemSort(cache), cache.sortBool)).(z3.Array)
+	return Map[K, V]{cache, vals, present}
+}
+
+// Contains returns whether key is present in m.
+func (m Map[K, V]) Contains(key K) Bool {
+	return Bool{S: m.present.Select(key.elemValue(m.c)).(z3.Bool)}
+}
+
+// Load returns m[key] and whether key is present in m, as Go's
+// "v, ok := m[key]". If key is not present, the value is the zero
+// value of V.
+func (m Map[K, V]) Load(key K) (V, Bool) {
+	var v V
+	k := key.elemValue(m.c)
+	ok := m.present.Select(k).(z3.Bool)
+	return v.elemFrom(ok.IfThenElse(m.vals.Select(k), v.elemZero(m.c))), Bool{S: ok}
+}
+
+// Store returns a copy of m with m[key] set to val.
+func (m Map[K, V]) Store(key K, val V) Map[K, V] {
+	k := key.elemValue(m.c)
+	m.vals = m.vals.Store(k, val.elemValue(m.c))
+	m.present = m.present.Store(k, m.c.z3.FromBool(true))
+	return m
+}
+
+// Delete returns a copy of m without key.
+func (m Map[K, V]) Delete(key K) Map[K, V] {
+	m.present = m.present.Store(key.elemValue(m.c), m.c.z3.FromBool(false))
+	return m
+}
+
+// String returns m as a string.
+func (m Map[K, V]) String() string {
+	return "map(" + m.vals.String() + ", " + m.present.String() + ")"
+}
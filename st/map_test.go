@@ -0,0 +1,53 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestMap(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	m := MakeMap[Int, Integer](ctx).Store(Int{C: 1}, Integer{C: big.NewInt(10)})
+	m2 := m.Store(Int{C: 2}, Integer{C: big.NewInt(20)}).Delete(Int{C: 1})
+
+	solver := z3.NewSolver(ctx)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal(err)
+	}
+	model := solver.Model()
+	for _, tc := range []struct {
+		m    Map[Int, Integer]
+		key  int
+		want int64
+		ok   bool
+	}{
+		{m, 1, 10, true},
+		{m, 2, 0, false},
+		{m2, 1, 0, false},
+		{m2, 2, 20, true},
+	} {
+		v, ok := tc.m.Load(Int{C: tc.key})
+		if got := v.Eval(model); got.Int64() != tc.want || ok.Eval(model) != tc.ok {
+			t.Errorf("Load(%d) = %v, %v; want %d, %v", tc.key, got, ok.Eval(model), tc.want, tc.ok)
+		}
+		if c := tc.m.Contains(Int{C: tc.key}).Eval(model); c != tc.ok {
+			t.Errorf("Contains(%d) = %v, want %v", tc.key, c, tc.ok)
+		}
+	}
+
+	// A key whose value is nonzero must be present.
+	am := AnyMap[Uint8, Real](ctx, "m")
+	k := AnyUint8(ctx, "k")
+	v, ok := am.Load(k)
+	solver.Reset()
+	solver.Assert(v.NE(Real{C: new(big.Rat)}).And(ok.Not()).S)
+	if sat, _ := solver.Check(); sat {
+		t.Error("missing key loaded a nonzero value")
+	}
+}
@@ -25,7 +25,7 @@
 //	x + y	x.Add(y)
 //	x - y	x.Sub(y)
 //	x * y	x.Mul(y)
-//	x / y	x.Div(y)	(does not panic on symbolic divide by 0)
+//	x / y	x.Quo(y)	(does not panic on symbolic divide by 0)
 //	x % y	x.Rem(y)
 //
 //	x & y	x.And(y)
@@ -50,7 +50,36 @@
 //	!x	x.Not() 	(Bool only)
 //
 // For any pair of types T and U that support conversion in Go, T has
-// a method ToU() that returns a U value.
+// a method ToU() that returns a U value. These follow Go's truncation
+// and sign- or zero-extension rules exactly. In addition, the
+// fixed-size integer types convert to and from Integer, and Integer
+// converts to Real.
+//
+// The shift count of Lsh and Rsh is a Uint64. As in Go, shifting by at
+// least the width of x produces 0, or -1 for Rsh of a negative signed
+// value. To find shifts that misbehave, ShiftCount converts a count
+// along with the condition under which it is negative, and LshChecked
+// and RshChecked also return the condition under which the count is at
+// least the width of x.
+//
+// Quo and Rem truncate toward zero, like Go's / and %, for every type.
+// QuoChecked and RemChecked also return the condition under which the
+// divisor is 0, so code that may panic can be found with the solver.
+//
+// Arithmetic on fixed-size integers wraps around, as in Go. The
+// AddOverflows, SubOverflows, and MulOverflows methods return the
+// condition under which the corresponding operation wraps, to tell
+// intended wraparound apart from overflow bugs. An Env records these
+// side conditions automatically as code executes, along with division
+// by zero and out of bounds slice indexes.
+//
+// Slice[T] implements symbolic slices of any of these types, backed by
+// a Z3 array and a possibly symbolic length. Map[K, V] implements
+// symbolic maps with Go's zero-value semantics for missing keys.
+// Bytes is a Slice[Uint8] that converts to and from z3.String, which
+// models Go strings as sequences of bytes.
+// Cond selects between two values of any of these types based on a
+// Bool.
 //
 // TODO: Float, complex, and string types.
 package st
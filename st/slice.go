@@ -0,0 +1,152 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"reflect"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// Elem is the set of types that can be stored in a Slice or Map. It is
+// satisfied by all of the value types in this package.
+type Elem[T any] interface {
+	IsConcrete() bool
+	String() string
+
+	elemSort(c *cache) z3.Sort
+	elemValue(c *cache) z3.Value
+	elemFrom(v z3.Value) T
+	elemEval(m *z3.Model) T
+	elemZero(c *cache) z3.Value
+}
+
+// Slice implements symbolic slices of T. A Slice has a symbolic
+// backing array, indexed by int, and a possibly symbolic length.
+//
+// Like the other types in this package, Slices are values: Store and
+// Append return a new Slice and leave the original unchanged. Unlike
+// Go slices, Slices never share a backing array.
+//
+// The zero Slice is not valid. Use SliceOf or AnySlice to create a
+// Slice.
+type Slice[T Elem[T]] struct {
+	c   *cache
+	arr z3.Array
+	len Int
+}
+
+// SliceOf returns a Slice of length len(elems) containing elems.
+func SliceOf[T Elem[T]](ctx *z3.Context, elems ...T) Slice[T] {
+	var zero T
+	cache := getCache(ctx)
+	sort := ctx.ArraySort(cache.sortInt, zero.elemSort(cache))
+	s := Slice[T]{cache, cache.z3.FreshConst("slice", sort).(z3.Array), Int{C: 0}}
+	return s.Append(elems...)
+}
+
+// MaxAnySliceLen is the largest length of a Slice returned by
+// AnySlice. It bounds the length so that Eval does not allocate a huge
+// slice for a model that does not constrain it.
+const MaxAnySliceLen = 1<<16 - 1
+
+// AnySlice returns a Slice with unconstrained elements and an
+// unconstrained length between 0 and MaxAnySliceLen.
+func AnySlice[T Elem[T]](ctx *z3.Context, name string) Slice[T] {
+	var zero T
+	cache := getCache(ctx)
+	sort := ctx.ArraySort(cache.sortInt, zero.elemSort(cache))
+	arr := cache.z3.FreshConst(name, sort).(z3.Array)
+	// Build the length from 16 bits so it is never negative or
+	// larger than MaxAnySliceLen.
+	n := cache.z3.FreshConst(name+".len", ctx.BVSort(16)).(z3.BV)
+	s := Slice[T]{cache, arr, Int{S: n.ZeroExtend(48)}}
+	cache.addVar(name, s)
+	return s
+}
+
+// Len returns the length of s.
+func (s Slice[T]) Len() Int {
+	return s.len
+}
+
+// InBounds returns the condition under which i is a valid index of
+// s, that is, 0 <= i < s.Len(). Index and Store do not check this
+// condition; it is the caller's responsibility to assert it or to
+// branch on it, as Go's bounds checks would. In an Env, Index and Store
+// record its negation as an "index out of range" side condition.
+func (s Slice[T]) InBounds(i Int) Bool {
+	return i.GE(Int{C: 0}).And(i.LT(s.len))
+}
+
+// Index returns s[i]. If i is out of bounds, the result is an
+// unconstrained value.
+func (s Slice[T]) Index(i Int) T {
+	s.checkBounds(i)
+	return s.index(i)
+}
+
+func (s Slice[T]) index(i Int) T {
+	var zero T
+	return zero.elemFrom(s.arr.Select(i.sym(s.c)))
+}
+
+// Store returns a copy of s with s[i] set to v. If i is out of
+// bounds, the elements of the result are the same as those of s.
+func (s Slice[T]) Store(i Int, v T) Slice[T] {
+	s.checkBounds(i)
+	return Slice[T]{s.c, s.arr.Store(i.sym(s.c), v.elemValue(s.c)), s.len}
+}
+
+// checkBounds records the bounds check of s[i] in s's Env.
+func (s Slice[T]) checkBounds(i Int) {
+	if s.c.env != nil {
+		s.c.env.record("index out of range", s.InBounds(i).Not())
+	}
+}
+
+// Append returns a copy of s with vs appended, as Go's append(s,
+// vs...).
+func (s Slice[T]) Append(vs ...T) Slice[T] {
+	for _, v := range vs {
+		s.arr = s.arr.Store(s.len.sym(s.c), v.elemValue(s.c))
+		if s.len.IsConcrete() {
+			s.len.C++
+		} else {
+			// Not s.len.Add, which would record a
+			// spurious overflow check in an Env.
+			s.len = Int{S: s.len.S.Add(Int{C: 1}.sym(s.c))}
+		}
+	}
+	return s
+}
+
+// Eval returns the concrete elements of s in model m.
+// This also evaluates s with model completion.
+func (s Slice[T]) Eval(m *z3.Model) []T {
+	res := make([]T, s.len.Eval(m))
+	for i := range res {
+		res[i] = s.index(Int{C: i}).elemEval(m)
+	}
+	return res
+}
+
+// evalAny returns the concrete value of s in model m as a Go slice,
+// such as a []int for a Slice[Int].
+func (s Slice[T]) evalAny(m *z3.Model) interface{} {
+	elems := s.Eval(m)
+	var zero T
+	// The concrete value is in field C.
+	res := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(zero).Field(0).Type), len(elems), len(elems))
+	for i, e := range elems {
+		res.Index(i).Set(reflect.ValueOf(e).Field(0))
+	}
+	return res.Interface()
+}
+
+// String returns s as a string.
+func (s Slice[T]) String() string {
+	return "slice(" + s.arr.String() + ", " + s.len.String() + ")"
+}
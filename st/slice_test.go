@@ -0,0 +1,91 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestSliceConcrete(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	s := SliceOf(ctx, Int{C: 1}, Int{C: 2}).Append(Int{C: 3})
+	s2 := s.Store(Int{C: 0}, Int{C: 10})
+	if !s.Len().IsConcrete() || s.Len().C != 3 {
+		t.Fatalf("Len() = %v, want 3", s.Len())
+	}
+
+	solver := z3.NewSolver(ctx)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal(err)
+	}
+	m := solver.Model()
+	got := []int{}
+	for _, x := range s.Eval(m) {
+		got = append(got, x.C)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("s = %v, want %v", got, want)
+	}
+	if x := s2.Index(Int{C: 0}).Eval(m); x != 10 {
+		t.Errorf("s2[0] = %v, want 10", x)
+	}
+	if x := s.Index(Int{C: 0}).Eval(m); x != 1 {
+		t.Errorf("s[0] = %v after Store, want 1", x)
+	}
+}
+
+func TestSliceSymbolic(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	s := AnySlice[Int](ctx, "s")
+	i := AnyInt(ctx, "i")
+
+	// Find an in-bounds i such that s[i] == 42 and s has length 5
+	// after appending to it.
+	s2 := s.Append(Int{C: 7})
+	solver := z3.NewSolver(ctx)
+	solver.Assert(s2.InBounds(i).S)
+	solver.Assert(s2.Index(i).Eq(Int{C: 42}).S)
+	solver.Assert(s2.Len().Eq(Int{C: 5}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal("expected SAT", err)
+	}
+	m := solver.Model()
+	elems := s2.Eval(m)
+	if len(elems) != 5 || elems[4].C != 7 {
+		t.Fatalf("s2 = %v", elems)
+	}
+	if iv := i.Eval(m); iv < 0 || iv >= 4 || elems[iv].C != 42 {
+		t.Errorf("i = %d, s2 = %v", iv, elems)
+	}
+
+	// A symbolic slice's length is never negative.
+	solver.Reset()
+	solver.Assert(s.Len().LT(Int{C: 0}).S)
+	if sat, _ := solver.Check(); sat {
+		t.Error("length can be negative")
+	}
+	// Nor larger than MaxAnySliceLen, so Eval's result stays small.
+	solver.Reset()
+	solver.Assert(s.Len().GT(Int{C: MaxAnySliceLen}).S)
+	if sat, _ := solver.Check(); sat {
+		t.Error("length can exceed MaxAnySliceLen")
+	}
+	solver.Reset()
+	solver.Assert(s.Len().Eq(Int{C: MaxAnySliceLen}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal("expected SAT", err)
+	}
+	if n := len(s.Eval(solver.Model())); n != MaxAnySliceLen {
+		t.Errorf("len(Eval) = %d, want %d", n, MaxAnySliceLen)
+	}
+	// Bounds checks on concrete slices are concrete.
+	b := SliceOf(ctx, Bool{C: true}, Bool{C: false}, Bool{C: true}).InBounds(Int{C: 3})
+	if !b.IsConcrete() || b.C {
+		t.Errorf("InBounds(3) = %v for slice of length 3", b)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// WriteSMTLIB writes e's assumptions and side conditions to w as an
+// SMT-LIB 2 script, for debugging offline or cross-checking with the
+// z3 command-line tool.
+//
+// The script declares the symbols the conditions use and asserts the
+// assumptions. Each side condition is then named by a fresh Boolean
+// constant, defined to be equal to it, and checked by a
+// check-sat-assuming command under a comment with its message. As in
+// Violations, a check is sat if the side condition can fail.
+func (e *Env) WriteSMTLIB(w io.Writer) error {
+	c := getCache(e.ctx)
+	asserts := e.solver.Assertions()
+	var tail strings.Builder
+	for _, sc := range e.conds {
+		fail := e.ctx.FreshConst("fail", c.sortBool).(z3.Bool)
+		asserts = append(asserts, fail.Eq(sc.Cond.sym(c)))
+		msg := strings.ReplaceAll(sc.Msg, "\n", " ")
+		fmt.Fprintf(&tail, "; %s\n(check-sat-assuming (%s))\n", msg, fail)
+	}
+	return writeSMTLIB(w, e.ctx, asserts, tail.String())
+}
+
+// WriteSMTLIB writes c's path condition to w as an SMT-LIB 2 script
+// that declares the symbols it uses, asserts it, and asks for a model,
+// for debugging offline or cross-checking with the z3 command-line
+// tool.
+func (c *Concolic) WriteSMTLIB(w io.Writer) error {
+	cache := getCache(c.ctx)
+	asserts := make([]z3.Bool, len(c.path))
+	for i, cond := range c.path {
+		asserts[i] = cond.sym(cache)
+	}
+	return writeSMTLIB(w, c.ctx, asserts, "(check-sat)\n(get-model)\n")
+}
+
+// writeSMTLIB writes a script to w that declares the symbols of
+// asserts and asserts them, followed by the commands in tail.
+func writeSMTLIB(w io.Writer, ctx *z3.Context, asserts []z3.Bool, tail string) error {
+	s := z3.NewSolver(ctx)
+	defer s.Close()
+	s.AssertAll(asserts)
+	_, err := io.WriteString(w, s.String()+tail)
+	return err
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestEnvWriteSMTLIB(t *testing.T) {
+	e := NewEnv(nil)
+	ctx := e.Context()
+	x, y := AnyInt32(ctx, "x"), AnyInt32(ctx, "y")
+	e.Assume(x.GT(Int32{C: 0}))
+	x.Quo(y)
+	e.Require(x.NE(Int32{C: 0}), "x is zero")
+
+	var buf strings.Builder
+	if err := e.WriteSMTLIB(&buf); err != nil {
+		t.Fatal(err)
+	}
+	script := buf.String()
+	for _, want := range []string{"(declare-fun x", "(declare-fun y", "; integer divide by zero\n", "; x is zero\n"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script does not contain %q:\n%s", want, script)
+		}
+	}
+
+	// Replay the script in a new context: division by zero is
+	// possible, but x cannot be zero under the assumption.
+	checks := regexp.MustCompile(`\(check-sat-assuming \((.*)\)\)`).FindAllStringSubmatch(script, -1)
+	if len(checks) != 2 {
+		t.Fatalf("got %d checks, want 2:\n%s", len(checks), script)
+	}
+	ctx2 := z3.NewContext(nil)
+	asserts, err := ctx2.ParseSMTLIB2(script, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := z3.NewSolver(ctx2)
+	s.AssertAll(asserts)
+	for i, want := range []bool{true, false} {
+		fail := ctx2.BoolConst(checks[i][1])
+		if sat, err := s.CheckAssumptions(fail); sat != want || err != nil {
+			t.Errorf("check %d: got %v, %v; want %v", i, sat, err, want)
+		}
+	}
+}
+
+func TestConcolicWriteSMTLIB(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x := AnyInt32(ctx, "x")
+	c := NewConcolic(ctx)
+	c.Branch(x.LT(Int32{C: 10}))
+	c.Branch(x.GT(Int32{C: -10}))
+
+	var buf strings.Builder
+	if err := c.WriteSMTLIB(&buf); err != nil {
+		t.Fatal(err)
+	}
+	script := buf.String()
+	if !strings.HasSuffix(script, "(check-sat)\n(get-model)\n") {
+		t.Errorf("script does not end with check-sat and get-model:\n%s", script)
+	}
+	asserts, err := z3.NewContext(nil).ParseSMTLIB2(script, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(asserts) != 2 {
+		t.Errorf("got %d assertions, want 2:\n%s", len(asserts), script)
+	}
+}
@@ -504,3 +504,39 @@ func TestEvalMethods(t *testing.T) {
 		}
 	}
 }
+
+func TestOverflows(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	solver := z3.NewSolver(ctx)
+	x := AnyInt8(ctx, "x")
+	u := AnyUint8(ctx, "u")
+	for _, tc := range []struct {
+		name string
+		cond Bool
+		want func(m *z3.Model) bool
+	}{
+		{"x+1", x.AddOverflows(Int8{C: 1}), func(m *z3.Model) bool { return x.Eval(m) == math.MaxInt8 }},
+		{"x-1", x.SubOverflows(Int8{C: 1}), func(m *z3.Model) bool { return x.Eval(m) == math.MinInt8 }},
+		{"-1*x", Int8{C: -1}.MulOverflows(x), func(m *z3.Model) bool { return x.Eval(m) == math.MinInt8 }},
+		{"u-1", u.SubOverflows(Uint8{C: 1}), func(m *z3.Model) bool { return u.Eval(m) == 0 }},
+		{"u*16", u.MulOverflows(Uint8{C: 16}), func(m *z3.Model) bool { return u.Eval(m) >= 16 }},
+	} {
+		// Every model of the condition must be an overflow.
+		solver.Reset()
+		solver.Assert(tc.cond.S)
+		for i := 0; i < 3; i++ {
+			sat, err := solver.Check()
+			if !sat {
+				if i == 0 {
+					t.Errorf("%s: cannot overflow: %v", tc.name, err)
+				}
+				break
+			}
+			m := solver.Model()
+			if !tc.want(m) {
+				t.Errorf("%s overflows with x = %d, u = %d", tc.name, x.Eval(m), u.Eval(m))
+			}
+			solver.Assert(x.S.NE(m.Eval(x.S, true).(z3.BV)).Or(u.S.NE(m.Eval(u.S, true).(z3.BV))))
+		}
+	}
+}
@@ -58,14 +58,17 @@ func testEquiv(t *testing.T, typ reflect.Type, symMethod interface{}, vals ...in
 		switch m.Name {
 		case "IsConcrete", "Eval", "String":
 			continue
-		case "Lsh", "Rsh":
-			// TODO: Test these
+		case "Lsh", "Rsh", "LshChecked", "RshChecked":
+			t.Run(m.Name, func(t *testing.T) {
+				testShift(t, ctx, typ, symMethod, m, rvals)
+			})
 			continue
 		}
 		t.Run(m.Name, func(t *testing.T) {
 			inputs := genArgs(rvals, m.Type.NumIn())
 			for _, input := range inputs {
-				if m.Name == "Quo" || m.Name == "Rem" {
+				switch m.Name {
+				case "Quo", "Rem", "QuoChecked", "RemChecked":
 					s := fmt.Sprint(input[1].Interface())
 					if s == "0" || s == "0/1" {
 						// Avoid divide by zero
@@ -91,6 +94,27 @@ func testEquiv(t *testing.T, typ reflect.Type, symMethod interface{}, vals ...in
 	}
 }
 
+// testShift checks that shift method m of typ is equivalent for
+// concrete and symbolic operands, including counts at least the width
+// of typ. The count is tested both concrete and symbolic.
+func testShift(t *testing.T, ctx *z3.Context, typ reflect.Type, symMethod interface{}, m reflect.Method, rvals []reflect.Value) {
+	counts := []uint64{0, 1, 7, 8, 15, 16, 31, 32, 63, 64, 65, 1 << 32, math.MaxUint64}
+	for _, x := range rvals {
+		for _, n := range counts {
+			c, s := wrap(ctx, typ, symMethod, []reflect.Value{x})
+			yc, ys := wrap(ctx, reflect.TypeOf(Uint64{}), Uint64.sym, []reflect.Value{reflect.ValueOf(n)})
+			cres := m.Func.Call(append(c, yc...))[0]
+			for _, y := range [][]reflect.Value{yc, ys} {
+				sres := m.Func.Call(append(s, y...))[0]
+				eq := cres.MethodByName("Eq").Call([]reflect.Value{sres})[0]
+				if !toBool(ctx, eq.Interface().(Bool)) {
+					t.Errorf("%s(%v, %v) = %v, want %v", m.Name, x, y[0], sres, cres.FieldByName("C").Interface())
+				}
+			}
+		}
+	}
+}
+
 // genArgs returns the Cartesian product vals^n.
 func genArgs(vals []reflect.Value, n int) [][]reflect.Value {
 	if n == 0 {
@@ -134,6 +158,9 @@ func toBool(ctx *z3.Context, b Bool) bool {
 	// Since everything is literals, the simplifier should have no
 	// trouble getting the answer and is dramatically faster than
 	// the solver.
+	if b.IsConcrete() {
+		return b.C
+	}
 	val, ok := ctx.Simplify(b.S, nil).(z3.Bool).AsBool()
 	if !ok {
 		panic("failed to simplify to a literal")
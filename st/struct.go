@@ -0,0 +1,171 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// Struct is a symbolic value of a Go struct type.
+//
+// Each exported field of the struct type is represented by the
+// corresponding type from this package: a bool field by a Bool, an
+// int32 field by an Int32, a *big.Int field by an Integer, a *big.Rat
+// field by a Real, and so on. Fields of struct type are represented by
+// a Struct. Unexported fields are not represented and are always zero.
+type Struct struct {
+	// Type is the Go struct type.
+	Type reflect.Type
+
+	// Fields are the values of the fields of Type, indexed by field
+	// index. Fields[i] is nil if field i is unexported.
+	Fields []interface{}
+}
+
+var (
+	bigIntType = reflect.TypeOf((*big.Int)(nil))
+	bigRatType = reflect.TypeOf((*big.Rat)(nil))
+)
+
+// AnyStruct returns a Struct of type typ whose fields are all
+// unconstrained symbolic values. The fields' symbolic names are name,
+// a dot, and the field name, such as "req.Port".
+//
+// AnyStruct panics if typ is not a struct type or if an exported field
+// has a type with no equivalent in this package.
+func AnyStruct(ctx *z3.Context, name string, typ reflect.Type) Struct {
+	if typ.Kind() != reflect.Struct {
+		panic("st.AnyStruct: " + typ.String() + " is not a struct type")
+	}
+	s := Struct{typ, make([]interface{}, typ.NumField())}
+	for i := range s.Fields {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		s.Fields[i] = anyField(ctx, name+"."+f.Name, f.Type)
+	}
+	return s
+}
+
+func anyField(ctx *z3.Context, name string, typ reflect.Type) interface{} {
+	switch typ {
+	case bigIntType:
+		return AnyInteger(ctx, name)
+	case bigRatType:
+		return AnyReal(ctx, name)
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return AnyBool(ctx, name)
+	case reflect.Int:
+		return AnyInt(ctx, name)
+	case reflect.Int8:
+		return AnyInt8(ctx, name)
+	case reflect.Int16:
+		return AnyInt16(ctx, name)
+	case reflect.Int32:
+		return AnyInt32(ctx, name)
+	case reflect.Int64:
+		return AnyInt64(ctx, name)
+	case reflect.Uint:
+		return AnyUint(ctx, name)
+	case reflect.Uint8:
+		return AnyUint8(ctx, name)
+	case reflect.Uint16:
+		return AnyUint16(ctx, name)
+	case reflect.Uint32:
+		return AnyUint32(ctx, name)
+	case reflect.Uint64:
+		return AnyUint64(ctx, name)
+	case reflect.Uintptr:
+		return AnyUintptr(ctx, name)
+	case reflect.Struct:
+		return AnyStruct(ctx, name, typ)
+	}
+	panic(fmt.Sprintf("st.AnyStruct: field %s has unsupported type %s", name, typ))
+}
+
+// Field returns the value of the field called name, or nil if there is
+// no such exported field.
+func (s Struct) Field(name string) interface{} {
+	f, ok := s.Type.FieldByName(name)
+	if !ok || len(f.Index) != 1 {
+		return nil
+	}
+	return s.Fields[f.Index[0]]
+}
+
+// Eval returns s's concrete value in model m, as a value of type
+// s.Type. This also evaluates s with model completion.
+func (s Struct) Eval(m *z3.Model) interface{} {
+	v := reflect.New(s.Type).Elem()
+	s.evalInto(m, v)
+	return v.Interface()
+}
+
+func (s Struct) evalInto(m *z3.Model, v reflect.Value) {
+	for i, f := range s.Fields {
+		switch f := f.(type) {
+		case nil:
+			continue
+		case Struct:
+			f.evalInto(m, v.Field(i))
+			continue
+		}
+		c, ok := evalValue(m, f)
+		if !ok {
+			panic(fmt.Sprintf("st.Struct: field %s has unsupported value %T", s.Type.Field(i).Name, f))
+		}
+		v.Field(i).Set(reflect.ValueOf(c).Convert(v.Field(i).Type()))
+	}
+}
+
+// evalAny returns s.Eval(m).
+func (s Struct) evalAny(m *z3.Model) interface{} {
+	return s.Eval(m)
+}
+
+// evalValue returns the concrete value of x, which must be a value
+// from this package, in model m.
+func evalValue(m *z3.Model, x interface{}) (interface{}, bool) {
+	switch x := x.(type) {
+	case Bool:
+		return x.Eval(m), true
+	case Int:
+		return x.Eval(m), true
+	case Int8:
+		return x.Eval(m), true
+	case Int16:
+		return x.Eval(m), true
+	case Int32:
+		return x.Eval(m), true
+	case Int64:
+		return x.Eval(m), true
+	case Uint:
+		return x.Eval(m), true
+	case Uint8:
+		return x.Eval(m), true
+	case Uint16:
+		return x.Eval(m), true
+	case Uint32:
+		return x.Eval(m), true
+	case Uint64:
+		return x.Eval(m), true
+	case Uintptr:
+		return x.Eval(m), true
+	case Integer:
+		return x.Eval(m), true
+	case Real:
+		return x.Eval(m), true
+	case interface{ evalAny(*z3.Model) interface{} }:
+		return x.evalAny(m), true
+	}
+	return nil, false
+}
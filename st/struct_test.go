@@ -0,0 +1,60 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+type testPort uint16
+
+type testAddr struct {
+	Host  uint32
+	Port  testPort
+	local bool
+}
+
+type testRequest struct {
+	Addr    testAddr
+	Retries int8
+	TLS     bool
+	Weight  *big.Rat
+	ID      *big.Int
+}
+
+func TestAnyStruct(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	req := AnyStruct(ctx, "req", reflect.TypeOf(testRequest{}))
+	addr := req.Field("Addr").(Struct)
+	if addr.Field("local") != nil || req.Field("Missing") != nil {
+		t.Error("unexpected field")
+	}
+
+	solver := z3.NewSolver(ctx)
+	solver.Assert(addr.Field("Port").(Uint16).Eq(Uint16{C: 443}).S)
+	solver.Assert(req.Field("Retries").(Int8).LT(Int8{C: -5}).S)
+	solver.Assert(req.Field("TLS").(Bool).S)
+	solver.Assert(req.Field("Weight").(Real).GT(Real{C: big.NewRat(1, 2)}).S)
+	solver.Assert(req.Field("ID").(Integer).Eq(Integer{C: big.NewInt(7)}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal("expected SAT", err)
+	}
+	got := req.Eval(solver.Model()).(testRequest)
+	if got.Addr.Port != 443 || got.Retries >= -5 || !got.TLS ||
+		got.Weight.Cmp(big.NewRat(1, 2)) <= 0 || got.ID.Int64() != 7 {
+		t.Errorf("unexpected value %+v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unsupported field type")
+		}
+	}()
+	AnyStruct(ctx, "bad", reflect.TypeOf(struct{ F float64 }{}))
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// An Input is a named symbolic input, for use with TestCase.
+type Input struct {
+	// Name is the name of the test table field for the input.
+	Name string
+
+	// Value is a value from this package, such as an Int, a
+	// Struct, or a Slice.
+	Value interface{}
+}
+
+// TestCase returns Go source for an entry of a table-driven test that
+// reproduces the values of inputs in model m, such as
+//
+//	{x: 5, req: request{Port: 443, TLS: true}},
+//
+// This turns a model found by the solver, such as a counterexample,
+// into a regression test. Struct types are written with their
+// unqualified names, so the source is meant for a test in the package
+// that defines them.
+func TestCase(m *z3.Model, inputs ...Input) string {
+	var buf strings.Builder
+	buf.WriteString("{")
+	for i, in := range inputs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "%s: %s", in.Name, GoLiteral(m, in.Value))
+	}
+	buf.WriteString("},")
+	return buf.String()
+}
+
+// GoLiteral returns a Go expression for the concrete value of x in
+// model m. x must be a value from this package, such as an Int, a
+// Struct, or a Slice.
+func GoLiteral(m *z3.Model, x interface{}) string {
+	c, ok := evalValue(m, x)
+	if !ok {
+		panic(fmt.Sprintf("st.GoLiteral: unsupported value %T", x))
+	}
+	return goLiteral(reflect.ValueOf(c))
+}
+
+func goLiteral(v reflect.Value) string {
+	switch v.Type() {
+	case bigIntType:
+		x := v.Interface().(*big.Int)
+		if x == nil {
+			return "nil"
+		} else if x.IsInt64() {
+			return fmt.Sprintf("big.NewInt(%d)", x.Int64())
+		}
+		return fmt.Sprintf("func() *big.Int { x, _ := new(big.Int).SetString(%q, 10); return x }()", x.String())
+	case bigRatType:
+		x := v.Interface().(*big.Rat)
+		if x == nil {
+			return "nil"
+		} else if x.Num().IsInt64() && x.Denom().IsInt64() {
+			return fmt.Sprintf("big.NewRat(%d, %d)", x.Num().Int64(), x.Denom().Int64())
+		}
+		return fmt.Sprintf("func() *big.Rat { x, _ := new(big.Rat).SetString(%q); return x }()", x.String())
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Slice:
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elems[i] = goLiteral(v.Index(i))
+		}
+		return v.Type().String() + "{" + strings.Join(elems, ", ") + "}"
+	case reflect.Struct:
+		var fields []string
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Type().Field(i); f.PkgPath == "" {
+				fields = append(fields, f.Name+": "+goLiteral(v.Field(i)))
+			}
+		}
+		return v.Type().Name() + "{" + strings.Join(fields, ", ") + "}"
+	}
+	panic("st.GoLiteral: unsupported type " + v.Type().String())
+}
@@ -0,0 +1,39 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestTestCase(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x := AnyInt8(ctx, "x")
+	req := AnyStruct(ctx, "req", reflect.TypeOf(testAddr{}))
+	s := SliceOf(ctx, Integer{C: big.NewInt(3)}).Append(AnyInteger(ctx, "e"))
+
+	solver := z3.NewSolver(ctx)
+	solver.Assert(x.Eq(Int8{C: -7}).S)
+	solver.Assert(req.Field("Host").(Uint32).Eq(Uint32{C: 1}).S)
+	solver.Assert(req.Field("Port").(Uint16).Eq(Uint16{C: 80}).S)
+	solver.Assert(s.Index(Int{C: 1}).Eq(Integer{C: big.NewInt(-4)}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal("expected SAT", err)
+	}
+	m := solver.Model()
+
+	got := TestCase(m, Input{"x", x}, Input{"req", req}, Input{"s", s})
+	want := `{x: -7, req: testAddr{Host: 1, Port: 80}, s: []*big.Int{big.NewInt(3), big.NewInt(-4)}},`
+	if got != want {
+		t.Errorf("TestCase =\n%s\nwant\n%s", got, want)
+	}
+	if got, want := GoLiteral(m, Real{C: big.NewRat(-1, 3)}), "big.NewRat(-1, 3)"; got != want {
+		t.Errorf("GoLiteral = %s, want %s", got, want)
+	}
+}
@@ -53,7 +53,9 @@ type Bool struct {
 func AnyBool(ctx *z3.Context, name string) Bool {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortBool).(z3.Bool)
-	return Bool{S: sym}
+	x := Bool{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -91,6 +93,16 @@ func (x Bool) sym(c *cache) z3.Bool {
 	return c.z3.FromBool(x.C)
 }
 
+func (Bool) elemSort(c *cache) z3.Sort { return c.sortBool }
+
+func (x Bool) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Bool) elemFrom(v z3.Value) Bool { return Bool{S: v.(z3.Bool)} }
+
+func (x Bool) elemEval(m *z3.Model) Bool { return Bool{C: x.Eval(m)} }
+
+func (Bool) elemZero(c *cache) z3.Value { return c.z3.FromBool(false) }
+
 func (x Bool) And(y Bool) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C && y.C}
@@ -156,7 +168,9 @@ type Int struct {
 func AnyInt(ctx *z3.Context, name string) Int {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortInt).(z3.BV)
-	return Int{S: sym}
+	x := Int{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -194,6 +208,16 @@ func (x Int) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortInt).(z3.BV)
 }
 
+func (Int) elemSort(c *cache) z3.Sort { return c.sortInt }
+
+func (x Int) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int) elemFrom(v z3.Value) Int { return Int{S: v.(z3.BV)} }
+
+func (x Int) elemEval(m *z3.Model) Int { return Int{C: x.Eval(m)} }
+
+func (Int) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortInt) }
+
 func (x Int) Add(y Int) Int {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int{C: x.C + y.C}
@@ -203,6 +227,9 @@ func (x Int) Add(y Int) Int {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Int{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -215,6 +242,9 @@ func (x Int) Sub(y Int) Int {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Int{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -227,6 +257,9 @@ func (x Int) Mul(y Int) Int {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Int{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -239,9 +272,24 @@ func (x Int) Quo(y Int) Int {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int{C: 0}))
+	}
 	return Int{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int) QuoChecked(y Int) (Int, Bool) {
+	zero := Int{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Int) Rem(y Int) Int {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int{C: x.C % y.C}
@@ -251,9 +299,24 @@ func (x Int) Rem(y Int) Int {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int{C: 0}))
+	}
 	return Int{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int) RemChecked(y Int) (Int, Bool) {
+	zero := Int{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Int) And(y Int) Int {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int{C: x.C & y.C}
@@ -321,7 +384,7 @@ func (x Int) Rsh(y Uint64) Int {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 64 {
-			return Int{C: 0}
+			y.C = 63
 		}
 	}
 	rs = y.sym(cache)
@@ -412,6 +475,76 @@ func (x Int) GE(y Int) Bool {
 	return Bool{S: x.sym(cache).SGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Int) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), x.LT(Int{C: 0})
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int) LshChecked(y Uint64) (Int, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 64})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int) RshChecked(y Uint64) (Int, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 64})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of int, so x.Add(y) wraps
+// around.
+func (x Int) AddOverflows(y Int) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, true).And(xs.AddNoUnderflow(ys)).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of int, so x.Sub(y) wraps
+// around.
+func (x Int) SubOverflows(y Int) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, true).And(xs.SubNoOverflow(ys)).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of int, so x.Mul(y) wraps
+// around.
+func (x Int) MulOverflows(y Int) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	wide := xs.SignExtend(64).Mul(ys.SignExtend(64))
+	return Bool{S: wide.Eq(wide.Extract(63, 0).SignExtend(64)).Not()}
+}
+
 func (x Int) Neg() Int {
 	if x.IsConcrete() {
 		return Int{C: -x.C}
@@ -503,6 +636,14 @@ func (x Int) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(0)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Int) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetInt64(int64(x.C))}
+	}
+	return Integer{S: x.S.SToInt()}
+}
+
 // Int8 implements symbolic int8 values.
 type Int8 struct {
 	C int8
@@ -513,7 +654,9 @@ type Int8 struct {
 func AnyInt8(ctx *z3.Context, name string) Int8 {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortInt8).(z3.BV)
-	return Int8{S: sym}
+	x := Int8{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -551,6 +694,16 @@ func (x Int8) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortInt8).(z3.BV)
 }
 
+func (Int8) elemSort(c *cache) z3.Sort { return c.sortInt8 }
+
+func (x Int8) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int8) elemFrom(v z3.Value) Int8 { return Int8{S: v.(z3.BV)} }
+
+func (x Int8) elemEval(m *z3.Model) Int8 { return Int8{C: x.Eval(m)} }
+
+func (Int8) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortInt8) }
+
 func (x Int8) Add(y Int8) Int8 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int8{C: x.C + y.C}
@@ -560,6 +713,9 @@ func (x Int8) Add(y Int8) Int8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Int8{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -572,6 +728,9 @@ func (x Int8) Sub(y Int8) Int8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Int8{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -584,6 +743,9 @@ func (x Int8) Mul(y Int8) Int8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Int8{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -596,9 +758,24 @@ func (x Int8) Quo(y Int8) Int8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int8{C: 0}))
+	}
 	return Int8{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int8) QuoChecked(y Int8) (Int8, Bool) {
+	zero := Int8{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Int8) Rem(y Int8) Int8 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int8{C: x.C % y.C}
@@ -608,9 +785,24 @@ func (x Int8) Rem(y Int8) Int8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int8{C: 0}))
+	}
 	return Int8{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int8) RemChecked(y Int8) (Int8, Bool) {
+	zero := Int8{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Int8) And(y Int8) Int8 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int8{C: x.C & y.C}
@@ -681,7 +873,7 @@ func (x Int8) Rsh(y Uint64) Int8 {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 8 {
-			return Int8{C: 0}
+			y.C = 7
 		}
 		rs = Uint8{C: uint8(y.C)}.sym(cache)
 	} else {
@@ -775,6 +967,76 @@ func (x Int8) GE(y Int8) Bool {
 	return Bool{S: x.sym(cache).SGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Int8) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), x.LT(Int8{C: 0})
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int8) LshChecked(y Uint64) (Int8, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 8})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int8) RshChecked(y Uint64) (Int8, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 8})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of int8, so x.Add(y) wraps
+// around.
+func (x Int8) AddOverflows(y Int8) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, true).And(xs.AddNoUnderflow(ys)).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of int8, so x.Sub(y) wraps
+// around.
+func (x Int8) SubOverflows(y Int8) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, true).And(xs.SubNoOverflow(ys)).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of int8, so x.Mul(y) wraps
+// around.
+func (x Int8) MulOverflows(y Int8) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	wide := xs.SignExtend(8).Mul(ys.SignExtend(8))
+	return Bool{S: wide.Eq(wide.Extract(7, 0).SignExtend(8)).Not()}
+}
+
 func (x Int8) Neg() Int8 {
 	if x.IsConcrete() {
 		return Int8{C: -x.C}
@@ -866,6 +1128,14 @@ func (x Int8) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(56)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Int8) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetInt64(int64(x.C))}
+	}
+	return Integer{S: x.S.SToInt()}
+}
+
 // Int16 implements symbolic int16 values.
 type Int16 struct {
 	C int16
@@ -876,7 +1146,9 @@ type Int16 struct {
 func AnyInt16(ctx *z3.Context, name string) Int16 {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortInt16).(z3.BV)
-	return Int16{S: sym}
+	x := Int16{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -914,6 +1186,16 @@ func (x Int16) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortInt16).(z3.BV)
 }
 
+func (Int16) elemSort(c *cache) z3.Sort { return c.sortInt16 }
+
+func (x Int16) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int16) elemFrom(v z3.Value) Int16 { return Int16{S: v.(z3.BV)} }
+
+func (x Int16) elemEval(m *z3.Model) Int16 { return Int16{C: x.Eval(m)} }
+
+func (Int16) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortInt16) }
+
 func (x Int16) Add(y Int16) Int16 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int16{C: x.C + y.C}
@@ -923,6 +1205,9 @@ func (x Int16) Add(y Int16) Int16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Int16{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -935,6 +1220,9 @@ func (x Int16) Sub(y Int16) Int16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Int16{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -947,6 +1235,9 @@ func (x Int16) Mul(y Int16) Int16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Int16{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -959,9 +1250,24 @@ func (x Int16) Quo(y Int16) Int16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int16{C: 0}))
+	}
 	return Int16{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int16) QuoChecked(y Int16) (Int16, Bool) {
+	zero := Int16{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Int16) Rem(y Int16) Int16 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int16{C: x.C % y.C}
@@ -971,9 +1277,24 @@ func (x Int16) Rem(y Int16) Int16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int16{C: 0}))
+	}
 	return Int16{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int16) RemChecked(y Int16) (Int16, Bool) {
+	zero := Int16{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Int16) And(y Int16) Int16 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int16{C: x.C & y.C}
@@ -1044,7 +1365,7 @@ func (x Int16) Rsh(y Uint64) Int16 {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 16 {
-			return Int16{C: 0}
+			y.C = 15
 		}
 		rs = Uint16{C: uint16(y.C)}.sym(cache)
 	} else {
@@ -1138,6 +1459,76 @@ func (x Int16) GE(y Int16) Bool {
 	return Bool{S: x.sym(cache).SGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Int16) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), x.LT(Int16{C: 0})
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int16) LshChecked(y Uint64) (Int16, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 16})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int16) RshChecked(y Uint64) (Int16, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 16})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of int16, so x.Add(y) wraps
+// around.
+func (x Int16) AddOverflows(y Int16) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, true).And(xs.AddNoUnderflow(ys)).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of int16, so x.Sub(y) wraps
+// around.
+func (x Int16) SubOverflows(y Int16) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, true).And(xs.SubNoOverflow(ys)).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of int16, so x.Mul(y) wraps
+// around.
+func (x Int16) MulOverflows(y Int16) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	wide := xs.SignExtend(16).Mul(ys.SignExtend(16))
+	return Bool{S: wide.Eq(wide.Extract(15, 0).SignExtend(16)).Not()}
+}
+
 func (x Int16) Neg() Int16 {
 	if x.IsConcrete() {
 		return Int16{C: -x.C}
@@ -1229,6 +1620,14 @@ func (x Int16) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(48)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Int16) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetInt64(int64(x.C))}
+	}
+	return Integer{S: x.S.SToInt()}
+}
+
 // Int32 implements symbolic int32 values.
 type Int32 struct {
 	C int32
@@ -1239,7 +1638,9 @@ type Int32 struct {
 func AnyInt32(ctx *z3.Context, name string) Int32 {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortInt32).(z3.BV)
-	return Int32{S: sym}
+	x := Int32{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -1277,6 +1678,16 @@ func (x Int32) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortInt32).(z3.BV)
 }
 
+func (Int32) elemSort(c *cache) z3.Sort { return c.sortInt32 }
+
+func (x Int32) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int32) elemFrom(v z3.Value) Int32 { return Int32{S: v.(z3.BV)} }
+
+func (x Int32) elemEval(m *z3.Model) Int32 { return Int32{C: x.Eval(m)} }
+
+func (Int32) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortInt32) }
+
 func (x Int32) Add(y Int32) Int32 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int32{C: x.C + y.C}
@@ -1286,6 +1697,9 @@ func (x Int32) Add(y Int32) Int32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Int32{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -1298,6 +1712,9 @@ func (x Int32) Sub(y Int32) Int32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Int32{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -1310,6 +1727,9 @@ func (x Int32) Mul(y Int32) Int32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Int32{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -1322,9 +1742,24 @@ func (x Int32) Quo(y Int32) Int32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int32{C: 0}))
+	}
 	return Int32{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int32) QuoChecked(y Int32) (Int32, Bool) {
+	zero := Int32{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Int32) Rem(y Int32) Int32 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int32{C: x.C % y.C}
@@ -1334,9 +1769,24 @@ func (x Int32) Rem(y Int32) Int32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int32{C: 0}))
+	}
 	return Int32{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int32) RemChecked(y Int32) (Int32, Bool) {
+	zero := Int32{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Int32) And(y Int32) Int32 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int32{C: x.C & y.C}
@@ -1407,7 +1857,7 @@ func (x Int32) Rsh(y Uint64) Int32 {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 32 {
-			return Int32{C: 0}
+			y.C = 31
 		}
 		rs = Uint32{C: uint32(y.C)}.sym(cache)
 	} else {
@@ -1501,6 +1951,76 @@ func (x Int32) GE(y Int32) Bool {
 	return Bool{S: x.sym(cache).SGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Int32) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), x.LT(Int32{C: 0})
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int32) LshChecked(y Uint64) (Int32, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 32})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int32) RshChecked(y Uint64) (Int32, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 32})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of int32, so x.Add(y) wraps
+// around.
+func (x Int32) AddOverflows(y Int32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, true).And(xs.AddNoUnderflow(ys)).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of int32, so x.Sub(y) wraps
+// around.
+func (x Int32) SubOverflows(y Int32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, true).And(xs.SubNoOverflow(ys)).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of int32, so x.Mul(y) wraps
+// around.
+func (x Int32) MulOverflows(y Int32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	wide := xs.SignExtend(32).Mul(ys.SignExtend(32))
+	return Bool{S: wide.Eq(wide.Extract(31, 0).SignExtend(32)).Not()}
+}
+
 func (x Int32) Neg() Int32 {
 	if x.IsConcrete() {
 		return Int32{C: -x.C}
@@ -1592,6 +2112,14 @@ func (x Int32) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(32)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Int32) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetInt64(int64(x.C))}
+	}
+	return Integer{S: x.S.SToInt()}
+}
+
 // Int64 implements symbolic int64 values.
 type Int64 struct {
 	C int64
@@ -1602,7 +2130,9 @@ type Int64 struct {
 func AnyInt64(ctx *z3.Context, name string) Int64 {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortInt64).(z3.BV)
-	return Int64{S: sym}
+	x := Int64{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -1640,6 +2170,16 @@ func (x Int64) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortInt64).(z3.BV)
 }
 
+func (Int64) elemSort(c *cache) z3.Sort { return c.sortInt64 }
+
+func (x Int64) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Int64) elemFrom(v z3.Value) Int64 { return Int64{S: v.(z3.BV)} }
+
+func (x Int64) elemEval(m *z3.Model) Int64 { return Int64{C: x.Eval(m)} }
+
+func (Int64) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortInt64) }
+
 func (x Int64) Add(y Int64) Int64 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int64{C: x.C + y.C}
@@ -1649,6 +2189,9 @@ func (x Int64) Add(y Int64) Int64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Int64{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -1661,6 +2204,9 @@ func (x Int64) Sub(y Int64) Int64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Int64{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -1673,6 +2219,9 @@ func (x Int64) Mul(y Int64) Int64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Int64{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -1685,9 +2234,24 @@ func (x Int64) Quo(y Int64) Int64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int64{C: 0}))
+	}
 	return Int64{S: x.sym(cache).SDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int64) QuoChecked(y Int64) (Int64, Bool) {
+	zero := Int64{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Int64) Rem(y Int64) Int64 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int64{C: x.C % y.C}
@@ -1697,9 +2261,24 @@ func (x Int64) Rem(y Int64) Int64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Int64{C: 0}))
+	}
 	return Int64{S: x.sym(cache).SRem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Int64) RemChecked(y Int64) (Int64, Bool) {
+	zero := Int64{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Int64) And(y Int64) Int64 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Int64{C: x.C & y.C}
@@ -1767,7 +2346,7 @@ func (x Int64) Rsh(y Uint64) Int64 {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 64 {
-			return Int64{C: 0}
+			y.C = 63
 		}
 	}
 	rs = y.sym(cache)
@@ -1858,14 +2437,84 @@ func (x Int64) GE(y Int64) Bool {
 	return Bool{S: x.sym(cache).SGE(y.sym(cache))}
 }
 
-func (x Int64) Neg() Int64 {
-	if x.IsConcrete() {
-		return Int64{C: -x.C}
-	}
-	return Int64{S: x.S.Neg()}
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Int64) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), x.LT(Int64{C: 0})
 }
 
-func (x Int64) Not() Int64 {
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int64) LshChecked(y Uint64) (Int64, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 64})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Int64) RshChecked(y Uint64) (Int64, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 64})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of int64, so x.Add(y) wraps
+// around.
+func (x Int64) AddOverflows(y Int64) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, true).And(xs.AddNoUnderflow(ys)).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of int64, so x.Sub(y) wraps
+// around.
+func (x Int64) SubOverflows(y Int64) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, true).And(xs.SubNoOverflow(ys)).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of int64, so x.Mul(y) wraps
+// around.
+func (x Int64) MulOverflows(y Int64) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	wide := xs.SignExtend(64).Mul(ys.SignExtend(64))
+	return Bool{S: wide.Eq(wide.Extract(63, 0).SignExtend(64)).Not()}
+}
+
+func (x Int64) Neg() Int64 {
+	if x.IsConcrete() {
+		return Int64{C: -x.C}
+	}
+	return Int64{S: x.S.Neg()}
+}
+
+func (x Int64) Not() Int64 {
 	if x.IsConcrete() {
 		return Int64{C: ^x.C}
 	}
@@ -1949,6 +2598,14 @@ func (x Int64) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.SignExtend(0)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Int64) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetInt64(int64(x.C))}
+	}
+	return Integer{S: x.S.SToInt()}
+}
+
 // Uint implements symbolic uint values.
 type Uint struct {
 	C uint
@@ -1959,7 +2616,9 @@ type Uint struct {
 func AnyUint(ctx *z3.Context, name string) Uint {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortUint).(z3.BV)
-	return Uint{S: sym}
+	x := Uint{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -1997,6 +2656,16 @@ func (x Uint) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortUint).(z3.BV)
 }
 
+func (Uint) elemSort(c *cache) z3.Sort { return c.sortUint }
+
+func (x Uint) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint) elemFrom(v z3.Value) Uint { return Uint{S: v.(z3.BV)} }
+
+func (x Uint) elemEval(m *z3.Model) Uint { return Uint{C: x.Eval(m)} }
+
+func (Uint) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortUint) }
+
 func (x Uint) Add(y Uint) Uint {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint{C: x.C + y.C}
@@ -2006,6 +2675,9 @@ func (x Uint) Add(y Uint) Uint {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Uint{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -2018,6 +2690,9 @@ func (x Uint) Sub(y Uint) Uint {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Uint{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -2030,6 +2705,9 @@ func (x Uint) Mul(y Uint) Uint {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Uint{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -2042,9 +2720,24 @@ func (x Uint) Quo(y Uint) Uint {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint{C: 0}))
+	}
 	return Uint{S: x.sym(cache).UDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint) QuoChecked(y Uint) (Uint, Bool) {
+	zero := Uint{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Uint) Rem(y Uint) Uint {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint{C: x.C % y.C}
@@ -2054,9 +2747,24 @@ func (x Uint) Rem(y Uint) Uint {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint{C: 0}))
+	}
 	return Uint{S: x.sym(cache).URem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint) RemChecked(y Uint) (Uint, Bool) {
+	zero := Uint{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Uint) And(y Uint) Uint {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint{C: x.C & y.C}
@@ -2215,6 +2923,75 @@ func (x Uint) GE(y Uint) Bool {
 	return Bool{S: x.sym(cache).UGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Uint) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), Bool{C: false}
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint) LshChecked(y Uint64) (Uint, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 64})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint) RshChecked(y Uint64) (Uint, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 64})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of uint, so x.Add(y) wraps
+// around.
+func (x Uint) AddOverflows(y Uint) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, false).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of uint, so x.Sub(y) wraps
+// around.
+func (x Uint) SubOverflows(y Uint) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, false).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of uint, so x.Mul(y) wraps
+// around.
+func (x Uint) MulOverflows(y Uint) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.MulNoOverflow(ys, false).Not()}
+}
+
 func (x Uint) Neg() Uint {
 	if x.IsConcrete() {
 		return Uint{C: -x.C}
@@ -2306,6 +3083,14 @@ func (x Uint) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(0)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Uint) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetUint64(uint64(x.C))}
+	}
+	return Integer{S: x.S.UToInt()}
+}
+
 // Uint8 implements symbolic uint8 values.
 type Uint8 struct {
 	C uint8
@@ -2316,7 +3101,9 @@ type Uint8 struct {
 func AnyUint8(ctx *z3.Context, name string) Uint8 {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortUint8).(z3.BV)
-	return Uint8{S: sym}
+	x := Uint8{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -2354,6 +3141,16 @@ func (x Uint8) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortUint8).(z3.BV)
 }
 
+func (Uint8) elemSort(c *cache) z3.Sort { return c.sortUint8 }
+
+func (x Uint8) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint8) elemFrom(v z3.Value) Uint8 { return Uint8{S: v.(z3.BV)} }
+
+func (x Uint8) elemEval(m *z3.Model) Uint8 { return Uint8{C: x.Eval(m)} }
+
+func (Uint8) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortUint8) }
+
 func (x Uint8) Add(y Uint8) Uint8 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint8{C: x.C + y.C}
@@ -2363,6 +3160,9 @@ func (x Uint8) Add(y Uint8) Uint8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Uint8{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -2375,6 +3175,9 @@ func (x Uint8) Sub(y Uint8) Uint8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Uint8{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -2387,6 +3190,9 @@ func (x Uint8) Mul(y Uint8) Uint8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Uint8{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -2399,9 +3205,24 @@ func (x Uint8) Quo(y Uint8) Uint8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint8{C: 0}))
+	}
 	return Uint8{S: x.sym(cache).UDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint8) QuoChecked(y Uint8) (Uint8, Bool) {
+	zero := Uint8{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Uint8) Rem(y Uint8) Uint8 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint8{C: x.C % y.C}
@@ -2411,9 +3232,24 @@ func (x Uint8) Rem(y Uint8) Uint8 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint8{C: 0}))
+	}
 	return Uint8{S: x.sym(cache).URem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint8) RemChecked(y Uint8) (Uint8, Bool) {
+	zero := Uint8{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Uint8) And(y Uint8) Uint8 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint8{C: x.C & y.C}
@@ -2578,6 +3414,75 @@ func (x Uint8) GE(y Uint8) Bool {
 	return Bool{S: x.sym(cache).UGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Uint8) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), Bool{C: false}
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint8) LshChecked(y Uint64) (Uint8, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 8})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint8) RshChecked(y Uint64) (Uint8, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 8})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of uint8, so x.Add(y) wraps
+// around.
+func (x Uint8) AddOverflows(y Uint8) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, false).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of uint8, so x.Sub(y) wraps
+// around.
+func (x Uint8) SubOverflows(y Uint8) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, false).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of uint8, so x.Mul(y) wraps
+// around.
+func (x Uint8) MulOverflows(y Uint8) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.MulNoOverflow(ys, false).Not()}
+}
+
 func (x Uint8) Neg() Uint8 {
 	if x.IsConcrete() {
 		return Uint8{C: -x.C}
@@ -2669,6 +3574,14 @@ func (x Uint8) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(56)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Uint8) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetUint64(uint64(x.C))}
+	}
+	return Integer{S: x.S.UToInt()}
+}
+
 // Uint16 implements symbolic uint16 values.
 type Uint16 struct {
 	C uint16
@@ -2679,7 +3592,9 @@ type Uint16 struct {
 func AnyUint16(ctx *z3.Context, name string) Uint16 {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortUint16).(z3.BV)
-	return Uint16{S: sym}
+	x := Uint16{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -2717,6 +3632,16 @@ func (x Uint16) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortUint16).(z3.BV)
 }
 
+func (Uint16) elemSort(c *cache) z3.Sort { return c.sortUint16 }
+
+func (x Uint16) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint16) elemFrom(v z3.Value) Uint16 { return Uint16{S: v.(z3.BV)} }
+
+func (x Uint16) elemEval(m *z3.Model) Uint16 { return Uint16{C: x.Eval(m)} }
+
+func (Uint16) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortUint16) }
+
 func (x Uint16) Add(y Uint16) Uint16 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint16{C: x.C + y.C}
@@ -2726,6 +3651,9 @@ func (x Uint16) Add(y Uint16) Uint16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Uint16{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -2738,6 +3666,9 @@ func (x Uint16) Sub(y Uint16) Uint16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Uint16{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -2750,6 +3681,9 @@ func (x Uint16) Mul(y Uint16) Uint16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Uint16{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -2762,9 +3696,24 @@ func (x Uint16) Quo(y Uint16) Uint16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint16{C: 0}))
+	}
 	return Uint16{S: x.sym(cache).UDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint16) QuoChecked(y Uint16) (Uint16, Bool) {
+	zero := Uint16{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Uint16) Rem(y Uint16) Uint16 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint16{C: x.C % y.C}
@@ -2774,9 +3723,24 @@ func (x Uint16) Rem(y Uint16) Uint16 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint16{C: 0}))
+	}
 	return Uint16{S: x.sym(cache).URem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint16) RemChecked(y Uint16) (Uint16, Bool) {
+	zero := Uint16{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Uint16) And(y Uint16) Uint16 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint16{C: x.C & y.C}
@@ -2941,6 +3905,75 @@ func (x Uint16) GE(y Uint16) Bool {
 	return Bool{S: x.sym(cache).UGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Uint16) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), Bool{C: false}
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint16) LshChecked(y Uint64) (Uint16, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 16})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint16) RshChecked(y Uint64) (Uint16, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 16})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of uint16, so x.Add(y) wraps
+// around.
+func (x Uint16) AddOverflows(y Uint16) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, false).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of uint16, so x.Sub(y) wraps
+// around.
+func (x Uint16) SubOverflows(y Uint16) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, false).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of uint16, so x.Mul(y) wraps
+// around.
+func (x Uint16) MulOverflows(y Uint16) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.MulNoOverflow(ys, false).Not()}
+}
+
 func (x Uint16) Neg() Uint16 {
 	if x.IsConcrete() {
 		return Uint16{C: -x.C}
@@ -3032,6 +4065,14 @@ func (x Uint16) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(48)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Uint16) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetUint64(uint64(x.C))}
+	}
+	return Integer{S: x.S.UToInt()}
+}
+
 // Uint32 implements symbolic uint32 values.
 type Uint32 struct {
 	C uint32
@@ -3042,7 +4083,9 @@ type Uint32 struct {
 func AnyUint32(ctx *z3.Context, name string) Uint32 {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortUint32).(z3.BV)
-	return Uint32{S: sym}
+	x := Uint32{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -3080,6 +4123,16 @@ func (x Uint32) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortUint32).(z3.BV)
 }
 
+func (Uint32) elemSort(c *cache) z3.Sort { return c.sortUint32 }
+
+func (x Uint32) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint32) elemFrom(v z3.Value) Uint32 { return Uint32{S: v.(z3.BV)} }
+
+func (x Uint32) elemEval(m *z3.Model) Uint32 { return Uint32{C: x.Eval(m)} }
+
+func (Uint32) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortUint32) }
+
 func (x Uint32) Add(y Uint32) Uint32 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint32{C: x.C + y.C}
@@ -3089,6 +4142,9 @@ func (x Uint32) Add(y Uint32) Uint32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Uint32{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -3101,6 +4157,9 @@ func (x Uint32) Sub(y Uint32) Uint32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Uint32{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -3113,6 +4172,9 @@ func (x Uint32) Mul(y Uint32) Uint32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Uint32{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -3125,9 +4187,24 @@ func (x Uint32) Quo(y Uint32) Uint32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint32{C: 0}))
+	}
 	return Uint32{S: x.sym(cache).UDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint32) QuoChecked(y Uint32) (Uint32, Bool) {
+	zero := Uint32{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Uint32) Rem(y Uint32) Uint32 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint32{C: x.C % y.C}
@@ -3137,9 +4214,24 @@ func (x Uint32) Rem(y Uint32) Uint32 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint32{C: 0}))
+	}
 	return Uint32{S: x.sym(cache).URem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint32) RemChecked(y Uint32) (Uint32, Bool) {
+	zero := Uint32{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Uint32) And(y Uint32) Uint32 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint32{C: x.C & y.C}
@@ -3304,6 +4396,75 @@ func (x Uint32) GE(y Uint32) Bool {
 	return Bool{S: x.sym(cache).UGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Uint32) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), Bool{C: false}
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint32) LshChecked(y Uint64) (Uint32, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 32})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint32) RshChecked(y Uint64) (Uint32, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 32})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of uint32, so x.Add(y) wraps
+// around.
+func (x Uint32) AddOverflows(y Uint32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, false).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of uint32, so x.Sub(y) wraps
+// around.
+func (x Uint32) SubOverflows(y Uint32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, false).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of uint32, so x.Mul(y) wraps
+// around.
+func (x Uint32) MulOverflows(y Uint32) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.MulNoOverflow(ys, false).Not()}
+}
+
 func (x Uint32) Neg() Uint32 {
 	if x.IsConcrete() {
 		return Uint32{C: -x.C}
@@ -3395,6 +4556,14 @@ func (x Uint32) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(32)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Uint32) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetUint64(uint64(x.C))}
+	}
+	return Integer{S: x.S.UToInt()}
+}
+
 // Uint64 implements symbolic uint64 values.
 type Uint64 struct {
 	C uint64
@@ -3405,7 +4574,9 @@ type Uint64 struct {
 func AnyUint64(ctx *z3.Context, name string) Uint64 {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortUint64).(z3.BV)
-	return Uint64{S: sym}
+	x := Uint64{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -3443,6 +4614,16 @@ func (x Uint64) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortUint64).(z3.BV)
 }
 
+func (Uint64) elemSort(c *cache) z3.Sort { return c.sortUint64 }
+
+func (x Uint64) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uint64) elemFrom(v z3.Value) Uint64 { return Uint64{S: v.(z3.BV)} }
+
+func (x Uint64) elemEval(m *z3.Model) Uint64 { return Uint64{C: x.Eval(m)} }
+
+func (Uint64) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortUint64) }
+
 func (x Uint64) Add(y Uint64) Uint64 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint64{C: x.C + y.C}
@@ -3452,6 +4633,9 @@ func (x Uint64) Add(y Uint64) Uint64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Uint64{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -3464,6 +4648,9 @@ func (x Uint64) Sub(y Uint64) Uint64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Uint64{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -3476,6 +4663,9 @@ func (x Uint64) Mul(y Uint64) Uint64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Uint64{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -3488,9 +4678,24 @@ func (x Uint64) Quo(y Uint64) Uint64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint64{C: 0}))
+	}
 	return Uint64{S: x.sym(cache).UDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint64) QuoChecked(y Uint64) (Uint64, Bool) {
+	zero := Uint64{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Uint64) Rem(y Uint64) Uint64 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint64{C: x.C % y.C}
@@ -3500,9 +4705,24 @@ func (x Uint64) Rem(y Uint64) Uint64 {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uint64{C: 0}))
+	}
 	return Uint64{S: x.sym(cache).URem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uint64) RemChecked(y Uint64) (Uint64, Bool) {
+	zero := Uint64{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Uint64) And(y Uint64) Uint64 {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uint64{C: x.C & y.C}
@@ -3661,6 +4881,75 @@ func (x Uint64) GE(y Uint64) Bool {
 	return Bool{S: x.sym(cache).UGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Uint64) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), Bool{C: false}
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint64) LshChecked(y Uint64) (Uint64, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 64})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uint64) RshChecked(y Uint64) (Uint64, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 64})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of uint64, so x.Add(y) wraps
+// around.
+func (x Uint64) AddOverflows(y Uint64) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, false).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of uint64, so x.Sub(y) wraps
+// around.
+func (x Uint64) SubOverflows(y Uint64) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, false).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of uint64, so x.Mul(y) wraps
+// around.
+func (x Uint64) MulOverflows(y Uint64) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.MulNoOverflow(ys, false).Not()}
+}
+
 func (x Uint64) Neg() Uint64 {
 	if x.IsConcrete() {
 		return Uint64{C: -x.C}
@@ -3752,6 +5041,14 @@ func (x Uint64) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(0)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Uint64) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetUint64(uint64(x.C))}
+	}
+	return Integer{S: x.S.UToInt()}
+}
+
 // Uintptr implements symbolic uintptr values.
 type Uintptr struct {
 	C uintptr
@@ -3762,7 +5059,9 @@ type Uintptr struct {
 func AnyUintptr(ctx *z3.Context, name string) Uintptr {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortUintptr).(z3.BV)
-	return Uintptr{S: sym}
+	x := Uintptr{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -3800,6 +5099,16 @@ func (x Uintptr) sym(c *cache) z3.BV {
 	return c.z3.FromInt(int64(x.C), c.sortUintptr).(z3.BV)
 }
 
+func (Uintptr) elemSort(c *cache) z3.Sort { return c.sortUintptr }
+
+func (x Uintptr) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Uintptr) elemFrom(v z3.Value) Uintptr { return Uintptr{S: v.(z3.BV)} }
+
+func (x Uintptr) elemEval(m *z3.Model) Uintptr { return Uintptr{C: x.Eval(m)} }
+
+func (Uintptr) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortUintptr) }
+
 func (x Uintptr) Add(y Uintptr) Uintptr {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uintptr{C: x.C + y.C}
@@ -3809,6 +5118,9 @@ func (x Uintptr) Add(y Uintptr) Uintptr {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.AddOverflows(y))
+	}
 	return Uintptr{S: x.sym(cache).Add(y.sym(cache))}
 }
 
@@ -3821,6 +5133,9 @@ func (x Uintptr) Sub(y Uintptr) Uintptr {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.SubOverflows(y))
+	}
 	return Uintptr{S: x.sym(cache).Sub(y.sym(cache))}
 }
 
@@ -3833,6 +5148,9 @@ func (x Uintptr) Mul(y Uintptr) Uintptr {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil && cache.env.overflow {
+		cache.env.record("integer overflow", x.MulOverflows(y))
+	}
 	return Uintptr{S: x.sym(cache).Mul(y.sym(cache))}
 }
 
@@ -3845,9 +5163,24 @@ func (x Uintptr) Quo(y Uintptr) Uintptr {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uintptr{C: 0}))
+	}
 	return Uintptr{S: x.sym(cache).UDiv(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uintptr) QuoChecked(y Uintptr) (Uintptr, Bool) {
+	zero := Uintptr{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Uintptr) Rem(y Uintptr) Uintptr {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uintptr{C: x.C % y.C}
@@ -3857,9 +5190,24 @@ func (x Uintptr) Rem(y Uintptr) Uintptr {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Uintptr{C: 0}))
+	}
 	return Uintptr{S: x.sym(cache).URem(y.sym(cache))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Uintptr) RemChecked(y Uintptr) (Uintptr, Bool) {
+	zero := Uintptr{C: 0}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Uintptr) And(y Uintptr) Uintptr {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Uintptr{C: x.C & y.C}
@@ -4018,6 +5366,75 @@ func (x Uintptr) GE(y Uintptr) Bool {
 	return Bool{S: x.sym(cache).UGE(y.sym(cache))}
 }
 
+// ShiftCount returns x as a shift count, along with the condition
+// under which x is negative. A Go shift by a negative count panics.
+func (x Uintptr) ShiftCount() (Uint64, Bool) {
+	return x.ToUint64(), Bool{C: false}
+}
+
+// LshChecked returns x.Lsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uintptr) LshChecked(y Uint64) (Uintptr, Bool) {
+	return x.Lsh(y), y.GE(Uint64{C: 64})
+}
+
+// RshChecked returns x.Rsh(y), along with the condition under which
+// y is at least the width of x.
+func (x Uintptr) RshChecked(y Uint64) (Uintptr, Bool) {
+	return x.Rsh(y), y.GE(Uint64{C: 64})
+}
+
+// AddOverflows returns the condition under which x + y overflows:
+// the exact result is outside the range of uintptr, so x.Add(y) wraps
+// around.
+func (x Uintptr) AddOverflows(y Uintptr) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Add(y.ToInteger())
+		return x.Add(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.AddNoOverflow(ys, false).Not()}
+}
+
+// SubOverflows returns the condition under which x - y overflows:
+// the exact result is outside the range of uintptr, so x.Sub(y) wraps
+// around.
+func (x Uintptr) SubOverflows(y Uintptr) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Sub(y.ToInteger())
+		return x.Sub(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.SubNoUnderflow(ys, false).Not()}
+}
+
+// MulOverflows returns the condition under which x * y overflows:
+// the exact result is outside the range of uintptr, so x.Mul(y) wraps
+// around.
+func (x Uintptr) MulOverflows(y Uintptr) Bool {
+	if x.IsConcrete() && y.IsConcrete() {
+		exact := x.ToInteger().Mul(y.ToInteger())
+		return x.Mul(y).ToInteger().NE(exact)
+	}
+	ctx := x.S.Context()
+	if ctx == nil {
+		ctx = y.S.Context()
+	}
+	cache := getCache(ctx)
+	xs, ys := x.sym(cache), y.sym(cache)
+	return Bool{S: xs.MulNoOverflow(ys, false).Not()}
+}
+
 func (x Uintptr) Neg() Uintptr {
 	if x.IsConcrete() {
 		return Uintptr{C: -x.C}
@@ -4109,6 +5526,14 @@ func (x Uintptr) ToUintptr() Uintptr {
 	return Uintptr{S: x.S.ZeroExtend(0)}
 }
 
+// ToInteger returns x as an Integer.
+func (x Uintptr) ToInteger() Integer {
+	if x.IsConcrete() {
+		return Integer{C: new(big.Int).SetUint64(uint64(x.C))}
+	}
+	return Integer{S: x.S.UToInt()}
+}
+
 // Integer implements symbolic *big.Int values.
 type Integer struct {
 	C *big.Int
@@ -4119,7 +5544,9 @@ type Integer struct {
 func AnyInteger(ctx *z3.Context, name string) Integer {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortInteger).(z3.Int)
-	return Integer{S: sym}
+	x := Integer{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -4157,6 +5584,16 @@ func (x Integer) sym(c *cache) z3.Int {
 	return c.z3.FromBigInt(x.C, c.sortInteger).(z3.Int)
 }
 
+func (Integer) elemSort(c *cache) z3.Sort { return c.sortInteger }
+
+func (x Integer) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Integer) elemFrom(v z3.Value) Integer { return Integer{S: v.(z3.Int)} }
+
+func (x Integer) elemEval(m *z3.Model) Integer { return Integer{C: x.Eval(m)} }
+
+func (Integer) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortInteger) }
+
 func (x Integer) Add(y Integer) Integer {
 	if x.IsConcrete() && y.IsConcrete() {
 		z := Integer{C: new(big.Int)}
@@ -4210,12 +5647,27 @@ func (x Integer) Quo(y Integer) Integer {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Integer{C: new(big.Int)}))
+	}
 	xs, ys := x.sym(cache), y.sym(cache)
 	zero := cache.z3.FromInt(0, cache.sortInteger).(z3.Int)
 	one := cache.z3.FromInt(1, cache.sortInteger).(z3.Int)
 	return Integer{S: xs.Div(ys).Add(xs.Mod(ys).Eq(zero).Or(xs.GE(zero)).IfThenElse(zero, ys.GE(zero).IfThenElse(one, one.Neg())).(z3.Int))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Integer) QuoChecked(y Integer) (Integer, Bool) {
+	zero := Integer{C: new(big.Int)}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Integer) Rem(y Integer) Integer {
 	if x.IsConcrete() && y.IsConcrete() {
 		z := Integer{C: new(big.Int)}
@@ -4227,12 +5679,27 @@ func (x Integer) Rem(y Integer) Integer {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("integer divide by zero", y.Eq(Integer{C: new(big.Int)}))
+	}
 	xs, ys := x.sym(cache), y.sym(cache)
 	zero := cache.z3.FromInt(0, cache.sortInteger).(z3.Int)
 	one := cache.z3.FromInt(1, cache.sortInteger).(z3.Int)
 	return Integer{S: xs.Sub(xs.Div(ys).Add(xs.Mod(ys).Eq(zero).Or(xs.GE(zero)).IfThenElse(zero, ys.GE(zero).IfThenElse(one, one.Neg())).(z3.Int)).Mul(ys))}
 }
 
+// RemChecked returns x.Rem(y), along with the condition under which
+// y is 0, in which case Go's % panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Integer) RemChecked(y Integer) (Integer, Bool) {
+	zero := Integer{C: new(big.Int)}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Rem(y), divZero
+}
+
 func (x Integer) Eq(y Integer) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C.Cmp(y.C) == 0}
@@ -4314,6 +5781,124 @@ func (x Integer) Neg() Integer {
 	return Integer{S: x.S.Neg()}
 }
 
+// ToInt returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToInt() Int {
+	if x.IsConcrete() {
+		return Int{C: int(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Int{S: x.S.ToBV(64)}
+}
+
+// ToInt8 returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToInt8() Int8 {
+	if x.IsConcrete() {
+		return Int8{C: int8(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Int8{S: x.S.ToBV(8)}
+}
+
+// ToInt16 returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToInt16() Int16 {
+	if x.IsConcrete() {
+		return Int16{C: int16(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Int16{S: x.S.ToBV(16)}
+}
+
+// ToInt32 returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToInt32() Int32 {
+	if x.IsConcrete() {
+		return Int32{C: int32(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Int32{S: x.S.ToBV(32)}
+}
+
+// ToInt64 returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToInt64() Int64 {
+	if x.IsConcrete() {
+		return Int64{C: int64(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Int64{S: x.S.ToBV(64)}
+}
+
+// ToUint returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToUint() Uint {
+	if x.IsConcrete() {
+		return Uint{C: uint(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Uint{S: x.S.ToBV(64)}
+}
+
+// ToUint8 returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToUint8() Uint8 {
+	if x.IsConcrete() {
+		return Uint8{C: uint8(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Uint8{S: x.S.ToBV(8)}
+}
+
+// ToUint16 returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToUint16() Uint16 {
+	if x.IsConcrete() {
+		return Uint16{C: uint16(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Uint16{S: x.S.ToBV(16)}
+}
+
+// ToUint32 returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToUint32() Uint32 {
+	if x.IsConcrete() {
+		return Uint32{C: uint32(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Uint32{S: x.S.ToBV(32)}
+}
+
+// ToUint64 returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToUint64() Uint64 {
+	if x.IsConcrete() {
+		return Uint64{C: uint64(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Uint64{S: x.S.ToBV(64)}
+}
+
+// ToUintptr returns the low bits of x's two's complement
+// representation, like a conversion between Go's fixed-size
+// integer types.
+func (x Integer) ToUintptr() Uintptr {
+	if x.IsConcrete() {
+		return Uintptr{C: uintptr(new(big.Int).And(x.C, new(big.Int).SetUint64(^uint64(0))).Uint64())}
+	}
+	return Uintptr{S: x.S.ToBV(64)}
+}
+
+// ToReal returns x as a Real.
+func (x Integer) ToReal() Real {
+	if x.IsConcrete() {
+		return Real{C: new(big.Rat).SetInt(x.C)}
+	}
+	return Real{S: x.S.ToReal()}
+}
+
 // Real implements symbolic *big.Rat values.
 type Real struct {
 	C *big.Rat
@@ -4324,7 +5909,9 @@ type Real struct {
 func AnyReal(ctx *z3.Context, name string) Real {
 	cache := getCache(ctx)
 	sym := cache.z3.FreshConst(name, cache.sortReal).(z3.Real)
-	return Real{S: sym}
+	x := Real{S: sym}
+	cache.addVar(name, x)
+	return x
 }
 
 // String returns x as a string.
@@ -4365,6 +5952,16 @@ func (x Real) sym(c *cache) z3.Real {
 	return c.z3.FromBigRat(x.C)
 }
 
+func (Real) elemSort(c *cache) z3.Sort { return c.sortReal }
+
+func (x Real) elemValue(c *cache) z3.Value { return x.sym(c) }
+
+func (Real) elemFrom(v z3.Value) Real { return Real{S: v.(z3.Real)} }
+
+func (x Real) elemEval(m *z3.Model) Real { return Real{C: x.Eval(m)} }
+
+func (Real) elemZero(c *cache) z3.Value { return c.z3.FromInt(0, c.sortReal) }
+
 func (x Real) Add(y Real) Real {
 	if x.IsConcrete() && y.IsConcrete() {
 		z := Real{C: new(big.Rat)}
@@ -4418,9 +6015,24 @@ func (x Real) Quo(y Real) Real {
 		ctx = y.S.Context()
 	}
 	cache := getCache(ctx)
+	if cache.env != nil {
+		cache.env.record("division by zero", y.Eq(Real{C: new(big.Rat)}))
+	}
 	return Real{S: x.sym(cache).Div(y.sym(cache))}
 }
 
+// QuoChecked returns x.Quo(y), along with the condition under which
+// y is 0, in which case Go's / panics. If y is concretely 0, the
+// result is 0 instead of panicking.
+func (x Real) QuoChecked(y Real) (Real, Bool) {
+	zero := Real{C: new(big.Rat)}
+	divZero := y.Eq(zero)
+	if divZero.IsConcrete() && divZero.C {
+		return zero, divZero
+	}
+	return x.Quo(y), divZero
+}
+
 func (x Real) Eq(y Real) Bool {
 	if x.IsConcrete() && y.IsConcrete() {
 		return Bool{C: x.C.Cmp(y.C) == 0}
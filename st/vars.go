@@ -0,0 +1,32 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import "github.com/ralscha/go-z3/z3"
+
+// Vars returns the symbolic variables created for ctx by the Any
+// functions of this package, such as AnyInt and AnySlice, in the order
+// they were created. The fields of a Struct from AnyStruct are
+// separate variables, named as in AnyStruct. Maps from AnyMap are not
+// included.
+//
+// The result can be passed to TestCase.
+func Vars(ctx *z3.Context) []Input {
+	return append([]Input(nil), getCache(ctx).vars...)
+}
+
+// Snapshot returns the concrete value in model m of every variable in
+// Vars(ctx), keyed by name. If several variables have the same name,
+// the snapshot has the value of the last one created.
+//
+// This is typically used after a satisfiable check to see the inputs
+// that the solver found.
+func Snapshot(ctx *z3.Context, m *z3.Model) map[string]interface{} {
+	snap := make(map[string]interface{})
+	for _, v := range getCache(ctx).vars {
+		snap[v.Name], _ = evalValue(m, v.Value)
+	}
+	return snap
+}
@@ -0,0 +1,53 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package st
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestSnapshot(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	x := AnyInt32(ctx, "x")
+	ok := AnyBool(ctx, "ok")
+	req := AnyStruct(ctx, "req", reflect.TypeOf(testRequest{}))
+	s := AnySlice[Uint8](ctx, "s")
+
+	var names []string
+	for _, v := range Vars(ctx) {
+		names = append(names, v.Name)
+	}
+	want := []string{"x", "ok", "req.Addr.Host", "req.Addr.Port", "req.Retries", "req.TLS", "req.Weight", "req.ID", "s"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("Vars = %v, want %v", names, want)
+	}
+
+	solver := z3.NewSolver(ctx)
+	solver.Assert(x.Eq(Int32{C: -7}).S)
+	solver.Assert(ok.S)
+	solver.Assert(req.Field("Retries").(Int8).Eq(Int8{C: 3}).S)
+	solver.Assert(s.Len().Eq(Int{C: 2}).S)
+	solver.Assert(s.Index(Int{C: 1}).Eq(Uint8{C: 9}).S)
+	if sat, err := solver.Check(); !sat {
+		t.Fatal("unsat:", err)
+	}
+	snap := Snapshot(ctx, solver.Model())
+	if len(snap) != len(want) {
+		t.Errorf("snapshot has %d values, want %d", len(snap), len(want))
+	}
+	for name, want := range map[string]interface{}{
+		"x": int32(-7), "ok": true, "req.Retries": int8(3),
+	} {
+		if got := snap[name]; got != want {
+			t.Errorf("snapshot[%q] = %#v, want %#v", name, got, want)
+		}
+	}
+	if got := snap["s"].([]uint8); len(got) != 2 || got[1] != 9 {
+		t.Errorf("snapshot[s] = %v, want [_ 9]", got)
+	}
+}
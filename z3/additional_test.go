@@ -4,7 +4,10 @@
 
 package z3
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestArrayNE(t *testing.T) {
 	ctx := NewContext(nil)
@@ -127,6 +130,36 @@ func TestSolverReset(t *testing.T) {
 	}
 }
 
+func TestAssertAll(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	var vals []Bool
+	for i := 0; i < 100; i++ {
+		vals = append(vals, x.GT(ctx.Int(i)))
+	}
+
+	solver := NewSolver(ctx)
+	solver.AssertAll(nil)
+	solver.AssertAll(vals)
+	if n := solver.NumAssertions(); n != 100 {
+		t.Errorf("got %d assertions, want 100", n)
+	}
+	solver.AssertAll([]Bool{x.LT(ctx.Int(100))})
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT")
+	}
+
+	opt := NewOptimize(ctx)
+	opt.AssertAll(vals)
+	opt.Minimize(x)
+	if sat, _ := opt.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	if got := opt.Model().Eval(x, true).String(); got != "100" {
+		t.Errorf("min x = %s, want 100", got)
+	}
+}
+
 func TestUninterpNE(t *testing.T) {
 	ctx := NewContext(nil)
 	sort := ctx.UninterpretedSort("T")
@@ -169,6 +202,48 @@ func TestFiniteDomainNE(t *testing.T) {
 	}
 }
 
+func TestFiniteDomainValue(t *testing.T) {
+	ctx := NewContext(nil)
+	sort := ctx.FiniteDomainSort("FD", 10)
+	if n := sort.FiniteDomainSize(); n != 10 {
+		t.Errorf("FiniteDomainSize() = %d, want 10", n)
+	}
+
+	v := ctx.FiniteDomainValue(sort, 7)
+	if got, ok := v.AsUint64(); got != 7 || !ok {
+		t.Errorf("AsUint64() = %d, %v; want 7, true", got, ok)
+	}
+	x := ctx.Const("x", sort).(FiniteDomain)
+	if _, ok := x.AsUint64(); ok {
+		t.Errorf("AsUint64() of constant is a literal")
+	}
+
+	solver := NewSolver(ctx)
+	solver.Assert(x.NE(ctx.FiniteDomainValue(sort, 0)))
+	solver.Assert(x.Eq(v))
+	if sat, err := solver.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	if got, lit, ok := solver.Model().EvalAsUint64(x, true); got != 7 || !lit || !ok {
+		t.Errorf("EvalAsUint64(x) = %d, %v, %v; want 7, true, true", got, lit, ok)
+	}
+
+	for name, f := range map[string]func(){
+		"out of range":       func() { ctx.FiniteDomainValue(sort, 10) },
+		"other Context sort": func() { NewContext(nil).FiniteDomainValue(sort, 0) },
+		"zero Sort":          func() { ctx.FiniteDomainValue(Sort{}, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("FiniteDomainValue with %s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
 func TestASTContext(t *testing.T) {
 	ctx := NewContext(nil)
 	x := ctx.BoolConst("x")
@@ -228,6 +303,33 @@ func TestSortDomainAndRange(t *testing.T) {
 	}
 }
 
+func TestSortEqual(t *testing.T) {
+	ctx := NewContext(nil)
+	intSort := ctx.IntSort()
+	arrSort := ctx.ArraySort(intSort, ctx.BoolSort())
+	domain, _ := arrSort.DomainAndRange()
+
+	if !domain.Equal(intSort) || domain.ID() != intSort.ID() {
+		t.Errorf("array domain %v not equal to %v", domain, intSort)
+	}
+	if intSort.Equal(ctx.RealSort()) || intSort.ID() == ctx.RealSort().ID() {
+		t.Errorf("Int equal to Real")
+	}
+	if !ctx.BVSort(8).Equal(ctx.BVSort(8)) || ctx.BVSort(8).Equal(ctx.BVSort(16)) {
+		t.Errorf("bit-vector sorts compare wrong")
+	}
+	u1, u2 := ctx.UninterpretedSort("u"), ctx.UninterpretedSort("u")
+	if !u1.Equal(u2) || u1.Equal(ctx.UninterpretedSort("v")) {
+		t.Errorf("uninterpreted sorts compare wrong")
+	}
+	if intSort.Equal(NewContext(nil).IntSort()) {
+		t.Errorf("Int equal to Int from another Context")
+	}
+	if intSort.Equal(Sort{}) || (Sort{}).Equal(intSort) || (Sort{}).Equal(Sort{}) {
+		t.Errorf("zero Sort equal to a sort")
+	}
+}
+
 func TestFuncDeclContext(t *testing.T) {
 	ctx := NewContext(nil)
 	intSort := ctx.IntSort()
@@ -766,6 +868,31 @@ func TestSimplifyConfig(t *testing.T) {
 	}
 }
 
+func TestSimplifyParams(t *testing.T) {
+	ctx := NewContext(nil)
+	cfg := NewSimplifyConfig(ctx)
+	types := make(map[string]string)
+	for _, p := range cfg.Params() {
+		types[p.Name] = p.Type
+	}
+	for _, name := range []string{"arith_lhs", "elim_sign_ext", "pull_cheap_ite"} {
+		if types[name] != "bool" {
+			t.Errorf("parameter %s has type %q, want bool", name, types[name])
+		}
+	}
+	if help := ctx.SimplifyHelp(); !strings.Contains(help, "arith_lhs") {
+		t.Errorf("SimplifyHelp does not mention arith_lhs:\n%s", help)
+	}
+
+	x := ctx.IntConst("x")
+	e := x.Add(ctx.Int(1)).Mul(x.Add(ctx.Int(2)))
+	def := ctx.Simplify(e, nil).String()
+	som := ctx.Simplify(e, cfg.SetBool("som", true)).String()
+	if def == som || strings.Contains(som, "(+ 1 x)") || strings.Contains(som, "(+ 2 x)") {
+		t.Errorf("som: %s simplified to %s, want sum of monomials", def, som)
+	}
+}
+
 func TestConfigSetBool(t *testing.T) {
 	cfg := NewContextConfig()
 	cfg.SetBool("proof", true)
@@ -792,3 +919,53 @@ func TestConfigSetFloat(t *testing.T) {
 		t.Error("expected non-nil context")
 	}
 }
+
+func TestConfigValidation(t *testing.T) {
+	cfg := NewContextConfig().SetUint("timeout", 1000)
+	if err := cfg.Err(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	cfg.SetUint("timout", 10).SetBool("timeout", true)
+	if err := cfg.Err(); err == nil || !strings.Contains(err.Error(), "timout") {
+		t.Errorf("want unknown parameter error, got %v", err)
+	}
+	if err := NewContextConfig().SetBool("timeout", true).Err(); err == nil {
+		t.Error("want type error for timeout")
+	}
+	// Validation is advisory: rejected settings are still applied.
+	if v, ok := cfg.m["timout"]; !ok || v != uint(10) {
+		t.Errorf("rejected setting not applied: got %v, %v", v, ok)
+	}
+	for _, name := range []string{"type_check", "well_sorted_check"} {
+		if err := NewContextConfig().SetBool(name, true).Err(); err != nil {
+			t.Errorf("%s rejected: %v", name, err)
+		}
+	}
+	if err := NewContextConfig().SetString("encoding", "ascii").Err(); err != nil {
+		t.Errorf("encoding rejected: %v", err)
+	}
+	if err := NewContextConfig().SetFloat("pp.decimal_precision", 10).Err(); err != nil {
+		t.Errorf("module parameter rejected: %v", err)
+	}
+
+	ctx := NewContext(nil)
+	if err := ctx.Config().SetBool("no_such_param", true).Err(); err == nil {
+		t.Error("want error from Context.Config")
+	}
+	if err := NewSimplifyConfig(ctx).SetUint("som", 1).Err(); err == nil {
+		t.Error("want type error for som")
+	}
+
+	found := false
+	for _, p := range NewContextConfig().Params() {
+		if p.Name == "timeout" && p.Type == "uint" && p.Doc != "" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("timeout missing from context parameters")
+	}
+	if help := NewContextConfig().Help(); !strings.Contains(help, "unsat_core (bool)") {
+		t.Errorf("unexpected help:\n%s", help)
+	}
+}
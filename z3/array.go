@@ -43,6 +43,30 @@ func (ctx *Context) ArraySort(domain, range_ Sort) Sort {
 	return sort
 }
 
+// ArraySortN returns a sort for arrays that are indexed by tuples of
+// values of the given domain sorts and have values from range. For
+// example, a matrix of Reals indexed by (row, col) has sort
+// ArraySortN([]Sort{ctx.IntSort(), ctx.IntSort()}, ctx.RealSort()).
+//
+// Arrays of these sorts are accessed using SelectN and StoreN.
+// domain must not be empty.
+func (ctx *Context) ArraySortN(domain []Sort, range_ Sort) Sort {
+	if len(domain) == 0 {
+		panic("z3: ArraySortN: empty domain")
+	}
+	cdomain := make([]C.Z3_sort, len(domain))
+	for i, s := range domain {
+		cdomain[i] = s.c
+	}
+	var sort Sort
+	ctx.do(func() {
+		sort = wrapSort(ctx, C.Z3_mk_array_sort_n(ctx.c, C.uint(len(cdomain)), &cdomain[0], range_.c), KindArray)
+	})
+	runtime.KeepAlive(domain)
+	runtime.KeepAlive(range_)
+	return sort
+}
+
 // ConstArray returns an Array value where every index maps to value.
 func (ctx *Context) ConstArray(domain Sort, value Value) Array {
 	res := Array(wrapValue(ctx, func() C.Z3_ast {
@@ -53,7 +77,47 @@ func (ctx *Context) ConstArray(domain Sort, value Value) Array {
 	return res
 }
 
-//go:generate go run genwrap.go -t Array $GOFILE
+// Lambda returns the array that maps each combination of values of
+// vars to body evaluated at those values. vars must be constants,
+// such as those created by Const or FreshConst; they are bound by the
+// lambda and may appear free in body. vars must not be empty.
+//
+// The result has sort ArraySortN of the sorts of vars and the sort of
+// body.
+func (ctx *Context) Lambda(vars []Value, body Value) Array {
+	if len(vars) == 0 {
+		panic("z3: Lambda: no bound variables")
+	}
+	cvars := make([]C.Z3_app, len(vars))
+	ctx.do(func() {
+		for i, v := range vars {
+			cvars[i] = C.Z3_to_app(ctx.c, v.impl().c)
+		}
+	})
+	res := Array(wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_lambda_const(ctx.c, C.uint(len(cvars)), &cvars[0], body.impl().c)
+	}))
+	runtime.KeepAlive(vars)
+	runtime.KeepAlive(body)
+	return res
+}
+
+// ArrayFromFunc returns the array over domain whose value at each
+// index i is f(i). f is called once with a fresh symbolic index of
+// sort domain and must return a Value built from it, which becomes
+// the body of a lambda.
+//
+// For example, the array of squares is
+//
+//	ctx.ArrayFromFunc(ctx.IntSort(), func(i Value) Value {
+//		return i.(Int).Mul(i.(Int))
+//	})
+func (ctx *Context) ArrayFromFunc(domain Sort, f func(Value) Value) Array {
+	x := ctx.FreshConst("x", domain)
+	return ctx.Lambda([]Value{x}, f(x))
+}
+
+//go:generate go run genwrap.go -t Array -e $GOFILE
 
 // Select returns the value of array x at index i.
 //
@@ -82,6 +146,87 @@ func (ctx *Context) ConstArray(domain Sort, value Value) Array {
 //
 //wrap:expr Ext:Value x y:Array : Z3_mk_array_ext x y
 
+// StoreAll returns an array y that's identical to x except that
+// y.Select(idxs[i]) == vals[i] for each i. If an index appears more
+// than once, the last value wins.
+//
+// This is equivalent to chaining calls to Store, but builds the
+// whole chain at once, which is much faster when initializing large
+// arrays. StoreAll panics if idxs and vals have different lengths.
+func (x Array) StoreAll(idxs, vals []Value) Array {
+	if len(idxs) != len(vals) {
+		panic("z3: StoreAll: length of idxs and vals differ")
+	}
+	ctx := x.ctx
+	var val value
+	ctx.do(func() {
+		cur := x.c
+		for i := range idxs {
+			next := C.Z3_mk_store(ctx.c, cur, idxs[i].impl().c, vals[i].impl().c)
+			// Hold each intermediate array only until the
+			// next store references it.
+			C.Z3_inc_ref(ctx.c, next)
+			if i > 0 {
+				C.Z3_dec_ref(ctx.c, cur)
+			}
+			cur = next
+		}
+		val = value{(*valueImpl)(wrapAST(ctx, cur).astImpl), noEq{}}
+		if len(idxs) > 0 {
+			C.Z3_dec_ref(ctx.c, cur)
+		}
+	})
+	runtime.KeepAlive(x)
+	runtime.KeepAlive(idxs)
+	runtime.KeepAlive(vals)
+	return Array(val)
+}
+
+// SelectN returns the value of multi-dimensional array x at the index
+// tuple idxs.
+//
+// The sorts of idxs must match x's domain sorts. The result has the
+// sort of x's range.
+func (x Array) SelectN(idxs ...Value) Value {
+	if len(idxs) == 0 {
+		panic("z3: SelectN: no indexes")
+	}
+	ctx := x.ctx
+	cidxs := make([]C.Z3_ast, len(idxs))
+	for i, idx := range idxs {
+		cidxs[i] = idx.impl().c
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_select_n(ctx.c, x.c, C.uint(len(cidxs)), &cidxs[0])
+	})
+	runtime.KeepAlive(x)
+	runtime.KeepAlive(idxs)
+	return val.lift(KindUnknown)
+}
+
+// StoreN returns an array y that's identical to multi-dimensional
+// array x except that y.SelectN(idxs...) == v.
+//
+// The sorts of idxs must match x's domain sorts and v's sort must
+// match x's range. The result has the same sort as x.
+func (x Array) StoreN(idxs []Value, v Value) Array {
+	if len(idxs) == 0 {
+		panic("z3: StoreN: no indexes")
+	}
+	ctx := x.ctx
+	cidxs := make([]C.Z3_ast, len(idxs))
+	for i, idx := range idxs {
+		cidxs[i] = idx.impl().c
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_store_n(ctx.c, x.c, C.uint(len(cidxs)), &cidxs[0], v.impl().c)
+	})
+	runtime.KeepAlive(x)
+	runtime.KeepAlive(idxs)
+	runtime.KeepAlive(v)
+	return Array(val)
+}
+
 // Map applies function f element-wise to the given arrays.
 // All arrays must have the same domain sort.
 // f must take len(arrays) arguments of the range sorts of the arrays
@@ -16,12 +16,22 @@ func (l Array) Eq(r Array) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_eq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// EqE is like Eq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Array) EqE(r Array) (res Bool, err error) {
+	chk := argChecker{method: "Array.Eq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Eq(r) })
+	return
+}
+
 // NE returns a Value that is true if l and r are not equal.
 func (l Array) NE(r Array) Bool {
 	return l.ctx.Distinct(l, r)
@@ -32,56 +42,96 @@ func (l Array) NE(r Array) Bool {
 // i's sort must match x's domain. The result has the sort of x's
 // range.
 func (x Array) Select(i Value) Value {
-	// Generated from array.go:63.
+	// Generated from array.go:127.
 	ctx := x.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_select(ctx.c, x.c, i.impl().c)
-	})
+	}, x.c, i.impl().c)
 	runtime.KeepAlive(x)
 	runtime.KeepAlive(i)
 	return val.lift(KindUnknown)
 }
 
+// SelectE is like Select, but returns an error instead of
+// panicking if the arguments are invalid.
+func (x Array) SelectE(i Value) (res Value, err error) {
+	chk := argChecker{method: "Array.Select"}
+	chk.value("x", x)
+	chk.value("i", i)
+	err = chk.do(func() { res = x.Select(i) })
+	return
+}
+
 // Store returns an array y that's identical to x except that
 // y.Select(i) == v.
 //
 // i's sort must match x's domain and v's sort must match x's range.
 // The result has the same sort as x.
 func (x Array) Store(i Value, v Value) Array {
-	// Generated from array.go:71.
+	// Generated from array.go:135.
 	ctx := x.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_store(ctx.c, x.c, i.impl().c, v.impl().c)
-	})
+	}, x.c, i.impl().c, v.impl().c)
 	runtime.KeepAlive(x)
 	runtime.KeepAlive(i)
 	runtime.KeepAlive(v)
 	return Array(val)
 }
 
+// StoreE is like Store, but returns an error instead of
+// panicking if the arguments are invalid.
+func (x Array) StoreE(i Value, v Value) (res Array, err error) {
+	chk := argChecker{method: "Array.Store"}
+	chk.value("x", x)
+	chk.value("i", i)
+	chk.value("v", v)
+	err = chk.do(func() { res = x.Store(i, v) })
+	return
+}
+
 // Default returns the default value of an array, for arrays that can
 // be represented as finite maps plus a default value.
 //
 // This is useful for extracting array values interpreted by models.
 func (x Array) Default() Value {
-	// Generated from array.go:78.
+	// Generated from array.go:142.
 	ctx := x.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_array_default(ctx.c, x.c)
-	})
+	}, x.c)
 	runtime.KeepAlive(x)
 	return val.lift(KindUnknown)
 }
 
+// DefaultE is like Default, but returns an error instead of
+// panicking if the arguments are invalid.
+func (x Array) DefaultE() (res Value, err error) {
+	chk := argChecker{method: "Array.Default"}
+	chk.value("x", x)
+	err = chk.do(func() { res = x.Default() })
+	return
+}
+
 // Ext returns an index at which arrays x and y differ.
 // If x and y are equal, the result is unconstrained.
 func (x Array) Ext(y Array) Value {
-	// Generated from array.go:83.
+	// Generated from array.go:147.
 	ctx := x.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_array_ext(ctx.c, x.c, y.c)
-	})
+	}, x.c, y.c)
 	runtime.KeepAlive(x)
 	runtime.KeepAlive(y)
 	return val.lift(KindUnknown)
 }
+
+// ExtE is like Ext, but returns an error instead of
+// panicking if the arguments are invalid.
+func (x Array) ExtE(y Array) (res Value, err error) {
+	chk := argChecker{method: "Array.Ext"}
+	chk.value("x", x)
+	chk.value("y", y)
+	err = chk.do(func() { res = x.Ext(y) })
+	return
+}
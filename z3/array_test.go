@@ -0,0 +1,129 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestArrayN(t *testing.T) {
+	ctx := NewContext(nil)
+	intSort := ctx.IntSort()
+	matSort := ctx.ArraySortN([]Sort{intSort, intSort}, ctx.BoolSort())
+	m := ctx.Const("m", matSort).(Array)
+
+	m2 := m.StoreN([]Value{ctx.Int(1), ctx.Int(2)}, ctx.FromBool(true))
+	solver := NewSolver(ctx)
+	solver.Assert(m2.SelectN(ctx.Int(1), ctx.Int(2)).(Bool).Not())
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT for m2[1,2] != true")
+	}
+
+	// Other entries are unchanged.
+	solver = NewSolver(ctx)
+	solver.Assert(m2.SelectN(ctx.Int(2), ctx.Int(1)).(Bool).Eq(m.SelectN(ctx.Int(2), ctx.Int(1)).(Bool)).Not())
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT for m2[2,1] != m[2,1]")
+	}
+}
+
+func TestArrayNEmpty(t *testing.T) {
+	ctx := NewContext(nil)
+	m := ctx.Const("m", ctx.ArraySortN([]Sort{ctx.IntSort()}, ctx.IntSort())).(Array)
+	wantPanic(t, "ArraySortN: empty domain", func() {
+		ctx.ArraySortN(nil, ctx.IntSort())
+	})
+	wantPanic(t, "SelectN: no indexes", func() {
+		m.SelectN()
+	})
+	wantPanic(t, "StoreN: no indexes", func() {
+		m.StoreN(nil, ctx.Int(1))
+	})
+}
+
+func TestArrayFromFunc(t *testing.T) {
+	ctx := NewContext(nil)
+	squares := ctx.ArrayFromFunc(ctx.IntSort(), func(i Value) Value {
+		return i.(Int).Mul(i.(Int))
+	})
+	x := ctx.IntConst("x")
+
+	solver := NewSolver(ctx)
+	solver.Assert(squares.Select(x).(Int).Eq(ctx.Int(49)))
+	solver.Assert(x.GT(ctx.Int(0)))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	if v, _, _ := solver.Model().EvalAsInt64(x, true); v != 7 {
+		t.Errorf("expected 7, got %d", v)
+	}
+}
+
+func TestLambda(t *testing.T) {
+	ctx := NewContext(nil)
+	i, j := ctx.IntConst("i"), ctx.IntConst("j")
+	sum := ctx.Lambda([]Value{i, j}, i.Add(j))
+
+	solver := NewSolver(ctx)
+	solver.Assert(sum.SelectN(ctx.Int(2), ctx.Int(3)).(Int).NE(ctx.Int(5)))
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT for sum[2,3] != 5")
+	}
+}
+
+func TestLambdaEmpty(t *testing.T) {
+	ctx := NewContext(nil)
+	wantPanic(t, "Lambda: no bound variables", func() {
+		ctx.Lambda(nil, ctx.Int(1))
+	})
+}
+
+func TestArrayStoreAll(t *testing.T) {
+	ctx := NewContext(nil)
+	bvSort := ctx.BVSort(8)
+	mem := ctx.Const("mem", ctx.ArraySort(ctx.BVSort(16), bvSort)).(Array)
+
+	var idxs, vals []Value
+	for i := 0; i < 256; i++ {
+		idxs = append(idxs, ctx.FromInt(int64(i), ctx.BVSort(16)))
+		vals = append(vals, ctx.FromInt(int64(255-i), bvSort))
+	}
+	idxs = append(idxs, ctx.FromInt(7, ctx.BVSort(16)))
+	vals = append(vals, ctx.FromInt(42, bvSort))
+	mem2 := mem.StoreAll(idxs, vals)
+
+	for _, test := range []struct{ idx, want int64 }{{0, 255}, {100, 155}, {7, 42}} {
+		solver := NewSolver(ctx)
+		got := mem2.Select(ctx.FromInt(test.idx, ctx.BVSort(16))).(BV)
+		solver.Assert(got.NE(ctx.FromInt(test.want, bvSort).(BV)))
+		if sat, _ := solver.Check(); sat {
+			t.Errorf("mem2[%d] != %d", test.idx, test.want)
+		}
+	}
+
+	if !mem.StoreAll(nil, nil).AsAST().Equal(mem.AsAST()) {
+		t.Error("StoreAll with no entries should return x")
+	}
+	wantPanic(t, "length", func() { mem.StoreAll(idxs, vals[1:]) })
+}
+
+func TestArrayArity(t *testing.T) {
+	ctx := NewContext(nil)
+	intSort, boolSort := ctx.IntSort(), ctx.BoolSort()
+	if n := ctx.ArraySort(intSort, boolSort).ArrayArity(); n != 1 {
+		t.Errorf("expected arity 1, got %d", n)
+	}
+
+	s := ctx.ArraySortN([]Sort{intSort, boolSort, ctx.BVSort(8)}, intSort)
+	if n := s.ArrayArity(); n != 3 {
+		t.Fatalf("expected arity 3, got %d", n)
+	}
+	for i, want := range []Kind{KindInt, KindBool, KindBV} {
+		if k := s.ArrayDomainN(i).Kind(); k != want {
+			t.Errorf("domain %d: expected %v, got %v", i, want, k)
+		}
+	}
+	if _, rng := s.DomainAndRange(); rng.Kind() != KindInt {
+		t.Errorf("expected KindInt range, got %v", rng.Kind())
+	}
+}
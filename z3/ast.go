@@ -42,14 +42,21 @@ func wrapAST(ctx *Context, c C.Z3_ast) AST {
 	// If we allocate two objects without incrementing the
 	// refcount on the first, Z3 will reclaim the first object!
 	C.Z3_inc_ref(ctx.c, c)
-	runtime.SetFinalizer(impl, func(impl *astImpl) {
-		impl.ctx.do(func() {
-			C.Z3_dec_ref(impl.ctx.c, impl.c)
-		})
-	})
+	if ctx.scope != nil {
+		// The active Scope releases impl in bulk.
+		ctx.scope.asts = append(ctx.scope.asts, impl)
+	} else {
+		runtime.SetFinalizer(impl, finalizeAST)
+	}
 	return AST{impl, noEq{}}
 }
 
+func finalizeAST(impl *astImpl) {
+	impl.ctx.release(func() {
+		C.Z3_dec_ref(impl.ctx.c, impl.c)
+	})
+}
+
 // Context returns the Context that created ast.
 func (ast AST) Context() *Context {
 	if ast.astImpl == nil {
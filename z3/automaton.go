@@ -0,0 +1,628 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Automaton is a minimal deterministic finite automaton over
+// characters.
+//
+// States are numbered 0 through NumStates-1, and state 0 is the
+// initial state. A character with no outgoing transition from the
+// current state leads to an implicit rejecting state. Every state
+// other than the initial state can reach an accepting state.
+type Automaton struct {
+	NumStates   int
+	Accept      []bool
+	Transitions []Transition
+}
+
+// Transition is a transition of an Automaton from state From to state
+// To on any character in the range [Lo, Hi].
+type Transition struct {
+	From, To int
+	Lo, Hi   rune
+}
+
+// Automaton converts re to an equivalent deterministic finite
+// automaton. This is intended for inspecting complex regular
+// expressions, such as intersections and complements, before solving
+// with them.
+//
+// re must be a concrete regular expression over strings: it must be
+// built from string literals, character ranges and RE operators. If
+// re contains uninterpreted constants or other terms, Automaton
+// returns an error.
+func (re RE) Automaton() (*Automaton, error) {
+	var b automatonBuilder
+	f, err := b.build(re.impl())
+	if err != nil {
+		return nil, err
+	}
+	return b.determinize(f).trim().minimize(), nil
+}
+
+// Accepts returns true if a accepts string s.
+func (a *Automaton) Accepts(s string) bool {
+	state := 0
+	for _, r := range s {
+		next := -1
+		for _, t := range a.Transitions {
+			if t.From == state && t.Lo <= r && r <= t.Hi {
+				next = t.To
+				break
+			}
+		}
+		if next < 0 {
+			return false
+		}
+		state = next
+	}
+	return a.Accept[state]
+}
+
+// DOT returns a rendering of a in the Graphviz DOT language.
+//
+// Accepting states are drawn as double circles. Transitions between
+// the same pair of states are combined into a single edge labeled
+// with a comma-separated list of character ranges.
+func (a *Automaton) DOT() string {
+	type edge struct{ from, to int }
+	var order []edge
+	labels := make(map[edge][]string)
+	for _, t := range a.Transitions {
+		e := edge{t.From, t.To}
+		if _, ok := labels[e]; !ok {
+			order = append(order, e)
+		}
+		labels[e] = append(labels[e], dotRange(t.Lo, t.Hi))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("digraph automaton {\n")
+	buf.WriteString("\trankdir=LR;\n")
+	buf.WriteString("\tstart [shape=point];\n")
+	buf.WriteString("\tstart -> 0;\n")
+	for i := 0; i < a.NumStates; i++ {
+		shape := "circle"
+		if a.Accept[i] {
+			shape = "doublecircle"
+		}
+		fmt.Fprintf(&buf, "\t%d [shape=%s];\n", i, shape)
+	}
+	for _, e := range order {
+		fmt.Fprintf(&buf, "\t%d -> %d [label=%q];\n", e.from, e.to, strings.Join(labels[e], ","))
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+func dotRange(lo, hi rune) string {
+	switch {
+	case lo == 0 && hi == maxChar:
+		return "."
+	case lo == hi:
+		return dotRune(lo)
+	}
+	return dotRune(lo) + "-" + dotRune(hi)
+}
+
+func dotRune(r rune) string {
+	if r > ' ' && r < 0x7f && !strings.ContainsRune(`\-,."`, r) {
+		return string(r)
+	}
+	return fmt.Sprintf(`\u{%x}`, r)
+}
+
+// nfaEdge is a transition of an NFA on the characters [lo, hi].
+type nfaEdge struct {
+	lo, hi rune
+	to     int
+}
+
+// fragment is a piece of an NFA with a single start and a single
+// accepting state.
+type fragment struct {
+	start, end int
+}
+
+// automatonBuilder constructs an NFA from a regular expression term
+// using Thompson's construction. Intersections and complements are
+// computed on determinized sub-automata, which are then embedded back
+// into the NFA.
+type automatonBuilder struct {
+	edges [][]nfaEdge
+	eps   [][]int
+}
+
+func (b *automatonBuilder) state() int {
+	b.edges = append(b.edges, nil)
+	b.eps = append(b.eps, nil)
+	return len(b.edges) - 1
+}
+
+func (b *automatonBuilder) fragment() fragment {
+	return fragment{b.state(), b.state()}
+}
+
+func (b *automatonBuilder) build(re *valueImpl) (fragment, error) {
+	kind, args, ok := re.appArgs()
+	if !ok {
+		return fragment{}, fmt.Errorf("z3: cannot convert %v to an automaton", re)
+	}
+	switch kind {
+	case C.Z3_OP_SEQ_TO_RE:
+		s, ok := args[0].(String)
+		if !ok {
+			return fragment{}, fmt.Errorf("z3: cannot convert %v to an automaton: not a string regular expression", re)
+		}
+		rs, ok := s.AsRunes()
+		if !ok {
+			return fragment{}, fmt.Errorf("z3: cannot convert %v to an automaton: %v is not a literal", re, s)
+		}
+		f := b.fragment()
+		cur := f.start
+		for _, r := range rs {
+			next := b.state()
+			b.edges[cur] = append(b.edges[cur], nfaEdge{r, r, next})
+			cur = next
+		}
+		b.eps[cur] = append(b.eps[cur], f.end)
+		return f, nil
+
+	case C.Z3_OP_RE_EMPTY_SET:
+		return b.fragment(), nil
+
+	case C.Z3_OP_RE_FULL_SET:
+		f := b.fragment()
+		b.edges[f.start] = append(b.edges[f.start], nfaEdge{0, maxChar, f.start})
+		b.eps[f.start] = append(b.eps[f.start], f.end)
+		return f, nil
+
+	case C.Z3_OP_RE_FULL_CHAR_SET:
+		f := b.fragment()
+		b.edges[f.start] = append(b.edges[f.start], nfaEdge{0, maxChar, f.end})
+		return f, nil
+
+	case C.Z3_OP_RE_RANGE:
+		var bounds [2][]rune
+		for i := range bounds {
+			s, ok := args[i].(String)
+			if ok {
+				bounds[i], ok = s.AsRunes()
+			}
+			if !ok {
+				return fragment{}, fmt.Errorf("z3: cannot convert %v to an automaton: %v is not a literal", re, args[i])
+			}
+		}
+		f := b.fragment()
+		// Z3 treats ranges whose bounds are not single
+		// characters as empty.
+		if len(bounds[0]) == 1 && len(bounds[1]) == 1 && bounds[0][0] <= bounds[1][0] {
+			b.edges[f.start] = append(b.edges[f.start], nfaEdge{bounds[0][0], bounds[1][0], f.end})
+		}
+		return f, nil
+
+	case C.Z3_OP_RE_CONCAT:
+		f := b.fragment()
+		cur := f.start
+		for _, arg := range args {
+			sub, err := b.build(arg.impl())
+			if err != nil {
+				return fragment{}, err
+			}
+			b.eps[cur] = append(b.eps[cur], sub.start)
+			cur = sub.end
+		}
+		b.eps[cur] = append(b.eps[cur], f.end)
+		return f, nil
+
+	case C.Z3_OP_RE_UNION:
+		f := b.fragment()
+		for _, arg := range args {
+			sub, err := b.build(arg.impl())
+			if err != nil {
+				return fragment{}, err
+			}
+			b.eps[f.start] = append(b.eps[f.start], sub.start)
+			b.eps[sub.end] = append(b.eps[sub.end], f.end)
+		}
+		return f, nil
+
+	case C.Z3_OP_RE_STAR:
+		return b.repeat(args[0].impl(), 0, -1)
+
+	case C.Z3_OP_RE_PLUS:
+		return b.repeat(args[0].impl(), 1, -1)
+
+	case C.Z3_OP_RE_OPTION:
+		return b.repeat(args[0].impl(), 0, 1)
+
+	case C.Z3_OP_RE_LOOP:
+		params := re.declIntParams()
+		switch len(params) {
+		case 1:
+			return b.repeat(args[0].impl(), params[0], -1)
+		case 2:
+			if params[0] > params[1] {
+				return b.fragment(), nil
+			}
+			return b.repeat(args[0].impl(), params[0], params[1])
+		}
+
+	case C.Z3_OP_RE_POWER:
+		if params := re.declIntParams(); len(params) == 1 {
+			return b.repeat(args[0].impl(), params[0], params[0])
+		}
+
+	case C.Z3_OP_RE_INTERSECT, C.Z3_OP_RE_DIFF:
+		d, err := subDFA(args[0].impl())
+		if err != nil {
+			return fragment{}, err
+		}
+		for _, arg := range args[1:] {
+			other, err := subDFA(arg.impl())
+			if err != nil {
+				return fragment{}, err
+			}
+			if kind == C.Z3_OP_RE_DIFF {
+				other = other.complement()
+			}
+			d = d.intersect(other)
+		}
+		return b.embed(d), nil
+
+	case C.Z3_OP_RE_COMPLEMENT:
+		d, err := subDFA(args[0].impl())
+		if err != nil {
+			return fragment{}, err
+		}
+		return b.embed(d.complement()), nil
+	}
+	return fragment{}, fmt.Errorf("z3: cannot convert %v to an automaton", re)
+}
+
+// repeat builds a fragment matching between min and max occurrences
+// of re. If max < 0, the number of occurrences is unbounded.
+func (b *automatonBuilder) repeat(re *valueImpl, min, max int) (fragment, error) {
+	f := b.fragment()
+	cur := f.start
+	for i := 0; i < min; i++ {
+		sub, err := b.build(re)
+		if err != nil {
+			return fragment{}, err
+		}
+		b.eps[cur] = append(b.eps[cur], sub.start)
+		cur = sub.end
+	}
+	if max < 0 {
+		sub, err := b.build(re)
+		if err != nil {
+			return fragment{}, err
+		}
+		b.eps[cur] = append(b.eps[cur], sub.start)
+		b.eps[sub.end] = append(b.eps[sub.end], cur)
+	}
+	for i := min; i < max; i++ {
+		sub, err := b.build(re)
+		if err != nil {
+			return fragment{}, err
+		}
+		b.eps[cur] = append(b.eps[cur], sub.start, f.end)
+		cur = sub.end
+	}
+	b.eps[cur] = append(b.eps[cur], f.end)
+	return f, nil
+}
+
+// embed adds a copy of d to b and returns the resulting fragment.
+func (b *automatonBuilder) embed(d *dfa) fragment {
+	base := len(b.edges)
+	for range d.edges {
+		b.state()
+	}
+	end := b.state()
+	for s, edges := range d.edges {
+		for _, e := range edges {
+			b.edges[base+s] = append(b.edges[base+s], nfaEdge{e.lo, e.hi, base + e.to})
+		}
+		if d.accept[s] {
+			b.eps[base+s] = append(b.eps[base+s], end)
+		}
+	}
+	return fragment{base, end}
+}
+
+// closure adds to set all states reachable from it by epsilon
+// transitions.
+func (b *automatonBuilder) closure(set map[int]bool) {
+	var work []int
+	for s := range set {
+		work = append(work, s)
+	}
+	for len(work) > 0 {
+		s := work[len(work)-1]
+		work = work[:len(work)-1]
+		for _, t := range b.eps[s] {
+			if !set[t] {
+				set[t] = true
+				work = append(work, t)
+			}
+		}
+	}
+}
+
+// determinize converts fragment f of b into a DFA using the subset
+// construction.
+func (b *automatonBuilder) determinize(f fragment) *dfa {
+	d := &dfa{}
+	index := make(map[string]int)
+	var sets [][]int
+	add := func(set map[int]bool) int {
+		b.closure(set)
+		states := make([]int, 0, len(set))
+		for s := range set {
+			states = append(states, s)
+		}
+		sort.Ints(states)
+		key := fmt.Sprint(states)
+		if i, ok := index[key]; ok {
+			return i
+		}
+		index[key] = len(sets)
+		sets = append(sets, states)
+		d.edges = append(d.edges, nil)
+		d.accept = append(d.accept, set[f.end])
+		return len(sets) - 1
+	}
+	add(map[int]bool{f.start: true})
+	for i := 0; i < len(sets); i++ {
+		// Partition the characters at the boundaries of all
+		// outgoing edges so that each edge either covers an
+		// interval completely or not at all.
+		var edges []nfaEdge
+		var points []rune
+		for _, s := range sets[i] {
+			for _, e := range b.edges[s] {
+				edges = append(edges, e)
+				points = append(points, e.lo, e.hi+1)
+			}
+		}
+		sort.Slice(points, func(i, j int) bool { return points[i] < points[j] })
+		for j := 0; j+1 < len(points); j++ {
+			lo, hi := points[j], points[j+1]-1
+			if lo > hi {
+				continue
+			}
+			target := make(map[int]bool)
+			for _, e := range edges {
+				if e.lo <= lo && hi <= e.hi {
+					target[e.to] = true
+				}
+			}
+			if len(target) == 0 {
+				continue
+			}
+			to := add(target)
+			out := d.edges[i]
+			if n := len(out); n > 0 && out[n-1].to == to && out[n-1].hi+1 == lo {
+				out[n-1].hi = hi
+				continue
+			}
+			d.edges[i] = append(out, nfaEdge{lo, hi, to})
+		}
+	}
+	return d
+}
+
+// subDFA returns a DFA for re built in a separate automatonBuilder.
+func subDFA(re *valueImpl) (*dfa, error) {
+	var b automatonBuilder
+	f, err := b.build(re)
+	if err != nil {
+		return nil, err
+	}
+	return b.determinize(f), nil
+}
+
+// dfa is a deterministic finite automaton with initial state 0. The
+// edges out of each state have disjoint ranges.
+type dfa struct {
+	edges  [][]nfaEdge
+	accept []bool
+}
+
+// complement returns a DFA accepting exactly the strings d rejects.
+func (d *dfa) complement() *dfa {
+	n := len(d.edges)
+	out := &dfa{edges: make([][]nfaEdge, n+1), accept: make([]bool, n+1)}
+	dead := n
+	for s, edges := range d.edges {
+		edges = append([]nfaEdge(nil), edges...)
+		sort.Slice(edges, func(i, j int) bool { return edges[i].lo < edges[j].lo })
+		next := rune(0)
+		for _, e := range edges {
+			if next < e.lo {
+				out.edges[s] = append(out.edges[s], nfaEdge{next, e.lo - 1, dead})
+			}
+			out.edges[s] = append(out.edges[s], e)
+			next = e.hi + 1
+		}
+		if next <= maxChar {
+			out.edges[s] = append(out.edges[s], nfaEdge{next, maxChar, dead})
+		}
+		out.accept[s] = !d.accept[s]
+	}
+	out.edges[dead] = []nfaEdge{{0, maxChar, dead}}
+	out.accept[dead] = true
+	return out
+}
+
+// intersect returns the product of d and other, which accepts the
+// strings accepted by both.
+func (d *dfa) intersect(other *dfa) *dfa {
+	out := &dfa{}
+	type pair struct{ a, b int }
+	index := make(map[pair]int)
+	var pairs []pair
+	add := func(p pair) int {
+		if i, ok := index[p]; ok {
+			return i
+		}
+		index[p] = len(pairs)
+		pairs = append(pairs, p)
+		out.edges = append(out.edges, nil)
+		out.accept = append(out.accept, d.accept[p.a] && other.accept[p.b])
+		return len(pairs) - 1
+	}
+	add(pair{0, 0})
+	for i := 0; i < len(pairs); i++ {
+		p := pairs[i]
+		for _, ea := range d.edges[p.a] {
+			for _, eb := range other.edges[p.b] {
+				lo, hi := ea.lo, ea.hi
+				if eb.lo > lo {
+					lo = eb.lo
+				}
+				if eb.hi < hi {
+					hi = eb.hi
+				}
+				if lo <= hi {
+					to := add(pair{ea.to, eb.to})
+					out.edges[i] = append(out.edges[i], nfaEdge{lo, hi, to})
+				}
+			}
+		}
+	}
+	return out
+}
+
+// trim removes the states of d that cannot reach an accepting state,
+// except for the initial state, and returns the result as an
+// Automaton.
+func (d *dfa) trim() *Automaton {
+	n := len(d.edges)
+	rev := make([][]int, n)
+	for s, edges := range d.edges {
+		for _, e := range edges {
+			rev[e.to] = append(rev[e.to], s)
+		}
+	}
+	live := make([]bool, n)
+	var work []int
+	for s := 0; s < n; s++ {
+		if d.accept[s] {
+			live[s] = true
+			work = append(work, s)
+		}
+	}
+	for len(work) > 0 {
+		s := work[len(work)-1]
+		work = work[:len(work)-1]
+		for _, t := range rev[s] {
+			if !live[t] {
+				live[t] = true
+				work = append(work, t)
+			}
+		}
+	}
+	live[0] = true
+
+	renum := make([]int, n)
+	a := &Automaton{}
+	for s := 0; s < n; s++ {
+		if live[s] {
+			renum[s] = a.NumStates
+			a.NumStates++
+			a.Accept = append(a.Accept, d.accept[s])
+		}
+	}
+	for s, edges := range d.edges {
+		if !live[s] {
+			continue
+		}
+		for _, e := range edges {
+			if live[e.to] {
+				a.Transitions = append(a.Transitions, Transition{renum[s], renum[e.to], e.lo, e.hi})
+			}
+		}
+	}
+	return a
+}
+
+// minimize merges equivalent states of a using Moore's partition
+// refinement algorithm.
+func (a *Automaton) minimize() *Automaton {
+	out := make([][]Transition, a.NumStates)
+	for _, t := range a.Transitions {
+		out[t.From] = append(out[t.From], t)
+	}
+	for _, ts := range out {
+		sort.Slice(ts, func(i, j int) bool { return ts[i].Lo < ts[j].Lo })
+	}
+
+	// edges returns the transitions of state s with targets mapped
+	// to their classes and adjacent ranges merged.
+	edges := func(s int, class []int) []Transition {
+		var res []Transition
+		for _, t := range out[s] {
+			t.From, t.To = class[s], class[t.To]
+			if n := len(res); n > 0 && res[n-1].To == t.To && res[n-1].Hi+1 == t.Lo {
+				res[n-1].Hi = t.Hi
+				continue
+			}
+			res = append(res, t)
+		}
+		return res
+	}
+
+	class := make([]int, a.NumStates)
+	for n := 0; ; {
+		index := make(map[string]int)
+		next := make([]int, a.NumStates)
+		for s := range next {
+			var key strings.Builder
+			fmt.Fprint(&key, class[s], a.Accept[s])
+			for _, t := range edges(s, class) {
+				fmt.Fprint(&key, ";", t.Lo, t.Hi, t.To)
+			}
+			i, ok := index[key.String()]
+			if !ok {
+				i = len(index)
+				index[key.String()] = i
+			}
+			next[s] = i
+		}
+		class = next
+		if len(index) == n {
+			break
+		}
+		n = len(index)
+	}
+
+	m := &Automaton{}
+	seen := make(map[int]bool)
+	for s := 0; s < a.NumStates; s++ {
+		if seen[class[s]] {
+			continue
+		}
+		seen[class[s]] = true
+		m.NumStates++
+		m.Accept = append(m.Accept, a.Accept[s])
+		m.Transitions = append(m.Transitions, edges(s, class)...)
+	}
+	return m
+}
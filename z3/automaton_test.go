@@ -0,0 +1,81 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestREAutomaton(t *testing.T) {
+	ctx := NewContext(nil)
+	sort := ctx.RESort(ctx.StringSort())
+	lower := ctx.RERange(ctx.FromString("a"), ctx.FromString("z"))
+	ab := ctx.FromString("ab").ToRE()
+
+	tests := []struct {
+		name    string
+		re      RE
+		match   []string
+		noMatch []string
+	}{
+		{"literal", ab, []string{"ab"}, []string{"", "a", "abc"}},
+		{"star", ab.Star(), []string{"", "ab", "abab"}, []string{"aba"}},
+		{"loop", lower.Loop(2, 3), []string{"xy", "xyz"}, []string{"x", "wxyz"}},
+		{"power", lower.Power(2), []string{"ab"}, []string{"a", "abc"}},
+		{"union", ab.Union(lower.Plus()), []string{"ab", "q"}, []string{"", "A"}},
+		{"intersect", lower.Plus().Intersect(ctx.REFull(sort).Concat(ab, ctx.REFull(sort))),
+			[]string{"ab", "xaby"}, []string{"a", "AB", "xa"}},
+		{"complement", ab.Complement(), []string{"", "a", "abc"}, []string{"ab"}},
+		{"diff", lower.Star().Diff(ab), []string{"", "abc", "xy"}, []string{"ab", "A"}},
+		{"allchar", ctx.REAllChar(sort).Option(), []string{"", "\u00e9", "\U0002ffff"}, []string{"ab"}},
+		{"empty", ctx.REEmpty(sort), nil, []string{"", "a"}},
+	}
+	for _, test := range tests {
+		a, err := test.re.Automaton()
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		for _, s := range test.match {
+			if !a.Accepts(s) {
+				t.Errorf("%s: %q should be accepted", test.name, s)
+			}
+			if !checkMatch(t, ctx, test.re, s) {
+				t.Errorf("%s: %q should match", test.name, s)
+			}
+		}
+		for _, s := range test.noMatch {
+			if a.Accepts(s) {
+				t.Errorf("%s: %q should not be accepted", test.name, s)
+			}
+		}
+	}
+}
+
+func TestREAutomatonDOT(t *testing.T) {
+	ctx := NewContext(nil)
+	a, err := ctx.FromString("a").ToRE().Plus().Automaton()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.NumStates != 2 || a.Accept[0] || !a.Accept[1] {
+		t.Errorf("unexpected automaton %+v", a)
+	}
+	dot := a.DOT()
+	for _, want := range []string{"digraph", "1 [shape=doublecircle]", `0 -> 1 [label="a"]`, `1 -> 1 [label="a"]`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, dot)
+		}
+	}
+}
+
+func TestREAutomatonNotConcrete(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.StringConst("x")
+	if _, err := x.ToRE().Star().Automaton(); err == nil {
+		t.Error("expected error for non-literal regular expression")
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// This file provides axioms for common algebraic properties of
+// uninterpreted functions. Each axiom is a universally quantified
+// formula with patterns (triggers) chosen so that Z3's E-matching
+// instantiates it only for terms that already appear in the problem,
+// which keeps the axioms from causing instantiation loops.
+
+// InjectiveAxiom returns an axiom stating that f is injective in each
+// of its arguments: f(x1, ..., xn) = f(y1, ..., yn) implies xi = yi
+// for every i.
+//
+// Rather than the quadratic statement above, the axiom introduces a
+// fresh inverse function gi for each argument and states
+// gi(f(x1, ..., xn)) = xi, triggered on f(x1, ..., xn).
+func (f FuncDecl) InjectiveAxiom() Bool {
+	domain := f.Domain()
+	if len(domain) == 0 {
+		panic("z3: InjectiveAxiom: " + f.Name().String() + " has no arguments")
+	}
+	ctx, range_ := f.ctx, f.Range()
+	xs := boundVars(ctx, domain)
+	app := f.Apply(xs...)
+	conj := make([]Bool, len(xs))
+	for i, x := range xs {
+		inv := ctx.FreshFuncDecl("inv", []Sort{range_}, domain[i])
+		conj[i] = valueEq(inv.Apply(app), x)
+	}
+	body := conj[0].And(conj[1:]...)
+	return forall(xs, [][]Value{{app}}, body)
+}
+
+// CommutativeAxiom returns an axiom stating that the binary function f
+// is commutative: f(x, y) = f(y, x). Both arguments of f must have the
+// same sort.
+func (f FuncDecl) CommutativeAxiom() Bool {
+	x, y := f.binaryVars("CommutativeAxiom", false)
+	fxy := f.Apply(x, y)
+	return forall([]Value{x, y}, [][]Value{{fxy}}, valueEq(fxy, f.Apply(y, x)))
+}
+
+// AssociativeAxiom returns an axiom stating that the binary function f
+// is associative: f(f(x, y), z) = f(x, f(y, z)). Both arguments and
+// the result of f must have the same sort.
+func (f FuncDecl) AssociativeAxiom() Bool {
+	x, y := f.binaryVars("AssociativeAxiom", true)
+	z := f.ctx.FreshConst("z", f.Range())
+	lhs := f.Apply(f.Apply(x, y), z)
+	rhs := f.Apply(x, f.Apply(y, z))
+	return forall([]Value{x, y, z}, [][]Value{{lhs}, {rhs}}, valueEq(lhs, rhs))
+}
+
+// IdempotentAxiom returns an axiom stating that f is idempotent.
+//
+// For a binary function, whose arguments and result must have the
+// same sort, this is f(x, x) = x, as for min, max, and set union. For
+// a unary function, whose argument and result must have the same
+// sort, this is f(f(x)) = f(x), as for absolute value and closure
+// operators.
+func (f FuncDecl) IdempotentAxiom() Bool {
+	if len(f.Domain()) == 2 {
+		x, _ := f.binaryVars("IdempotentAxiom", true)
+		fxx := f.Apply(x, x)
+		return forall([]Value{x}, [][]Value{{fxx}}, valueEq(fxx, x))
+	}
+	x := f.unaryVar("IdempotentAxiom", true)
+	ffx := f.Apply(f.Apply(x))
+	return forall([]Value{x}, [][]Value{{ffx}}, valueEq(ffx, f.Apply(x)))
+}
+
+// MonotoneAxiom returns an axiom stating that the unary function f is
+// monotone: x <= y implies f(x) <= f(y), or f(x) >= f(y) if
+// decreasing is true. The argument and result of f may be Bools,
+// ordered false before true, Ints, Reals, or BVs, compared as
+// unsigned; they need not have the same sort.
+//
+// The axiom is triggered by each pair of applications of f, so it
+// adds instances quadratic in the number of such applications.
+func (f FuncDecl) MonotoneAxiom(decreasing bool) Bool {
+	x := f.unaryVar("MonotoneAxiom", false)
+	y := f.ctx.FreshConst("y", x.Sort())
+	fx, fy := f.Apply(x), f.Apply(y)
+	xle, _ := lexCompare(x, y)
+	var fle Bool
+	if decreasing {
+		fle, _ = lexCompare(fy, fx)
+	} else {
+		fle, _ = lexCompare(fx, fy)
+	}
+	return forall([]Value{x, y}, [][]Value{{fx, fy}}, xle.Implies(fle))
+}
+
+// unaryVar checks that f is unary and, if closed is set, that its
+// argument and result have the same sort, and returns a bound
+// variable for its argument.
+func (f FuncDecl) unaryVar(op string, closed bool) Value {
+	domain := f.Domain()
+	if len(domain) != 1 {
+		panic("z3: " + op + ": " + f.Name().String() + " is not unary")
+	}
+	if closed && !domain[0].Equal(f.Range()) {
+		panic("z3: " + op + ": " + f.Name().String() + " has different domain and range sorts")
+	}
+	return f.ctx.FreshConst("x", domain[0])
+}
+
+// binaryVars checks that f is binary with both arguments of the same
+// sort and, if closed is set, that its result also has that sort, and
+// returns bound variables for its arguments.
+func (f FuncDecl) binaryVars(op string, closed bool) (x, y Value) {
+	domain := f.Domain()
+	if len(domain) != 2 {
+		panic("z3: " + op + ": " + f.Name().String() + " is not binary")
+	}
+	if !domain[0].Equal(domain[1]) {
+		panic("z3: " + op + ": " + f.Name().String() + " has arguments of different sorts")
+	}
+	if closed && !domain[0].Equal(f.Range()) {
+		panic("z3: " + op + ": " + f.Name().String() + " has different domain and range sorts")
+	}
+	return f.ctx.FreshConst("x", domain[0]), f.ctx.FreshConst("y", domain[1])
+}
+
+// boundVars returns fresh constants of the given sorts, to be bound
+// by forall.
+func boundVars(ctx *Context, sorts []Sort) []Value {
+	xs := make([]Value, len(sorts))
+	for i, s := range sorts {
+		xs[i] = ctx.FreshConst("x", s)
+	}
+	return xs
+}
+
+// forall returns the formula "for all bound, body", where bound are
+// constants that body abstracts over. Each element of patterns is a
+// multi-pattern: a set of terms that together mention every bound
+// constant, and whose instances in the problem trigger instantiation.
+// If patterns is empty, Z3 chooses its own, or relies on model-based
+// instantiation if there are none.
+func forall(bound []Value, patterns [][]Value, body Bool) Bool {
+	ctx := body.ctx
+	cbound := make([]C.Z3_app, len(bound))
+	val := wrapValue(ctx, func() C.Z3_ast {
+		for i, x := range bound {
+			cbound[i] = C.Z3_to_app(ctx.c, x.impl().c)
+		}
+		cpats := make([]C.Z3_pattern, len(patterns))
+		for i, pat := range patterns {
+			terms := make([]C.Z3_ast, len(pat))
+			for j, t := range pat {
+				terms[j] = t.impl().c
+			}
+			cpats[i] = C.Z3_mk_pattern(ctx.c, C.uint(len(terms)), &terms[0])
+		}
+		var cpat *C.Z3_pattern
+		if len(cpats) > 0 {
+			cpat = &cpats[0]
+		}
+		return C.Z3_mk_forall_const(ctx.c, 0, C.uint(len(cbound)), &cbound[0], C.uint(len(cpats)), cpat, body.c)
+	})
+	runtime.KeepAlive(bound)
+	runtime.KeepAlive(patterns)
+	runtime.KeepAlive(body)
+	return Bool(val)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestFuncDeclAxioms(t *testing.T) {
+	ctx := NewContext(nil)
+	is := ctx.IntSort()
+	a, b, c := ctx.IntConst("a"), ctx.IntConst("b"), ctx.IntConst("c")
+	f1 := ctx.FuncDecl("f1", []Sort{is}, is)
+	f2 := ctx.FuncDecl("f2", []Sort{is, is}, is)
+	app1 := func(x Int) Int { return f1.Apply(x).(Int) }
+	app2 := func(x, y Int) Int { return f2.Apply(x, y).(Int) }
+
+	tests := []struct {
+		name  string
+		axiom Bool
+		goal  Bool
+	}{
+		{"injective", f1.InjectiveAxiom(),
+			app1(a).Eq(app1(b)).Implies(a.Eq(b))},
+		{"injective2", f2.InjectiveAxiom(),
+			app2(a, b).Eq(app2(c, b)).Implies(a.Eq(c))},
+		{"commutative", f2.CommutativeAxiom(),
+			app2(a, b).Eq(app2(b, a))},
+		{"associative", f2.AssociativeAxiom(),
+			app2(app2(a, b), c).Eq(app2(a, app2(b, c)))},
+		{"idempotent2", f2.IdempotentAxiom(),
+			app2(a, a).Eq(a)},
+		{"idempotent1", f1.IdempotentAxiom(),
+			app1(app1(app1(a))).Eq(app1(a))},
+		{"monotone", f1.MonotoneAxiom(false),
+			a.LE(b).Implies(app1(a).LE(app1(b)))},
+		{"decreasing", f1.MonotoneAxiom(true),
+			a.LE(b).Implies(app1(a).GE(app1(b)))},
+	}
+	for _, tt := range tests {
+		s := NewSolver(ctx)
+		s.Assert(tt.goal.Not())
+		if sat, err := s.Check(); !sat || err != nil {
+			t.Errorf("%s: goal holds without axiom: %v, %v", tt.name, sat, err)
+		}
+		s.Assert(tt.axiom)
+		if sat, err := s.Check(); sat || err != nil {
+			t.Errorf("%s: got %v, %v; want unsat", tt.name, sat, err)
+		}
+	}
+
+	// The axioms are consistent with functions that have the
+	// property. Over a finite sort, Z3 can find such a model.
+	bs := ctx.BoolSort()
+	or := ctx.FuncDecl("or", []Sort{bs, bs}, bs)
+	p, q := ctx.BoolConst("p"), ctx.BoolConst("q")
+	s := NewSolver(ctx)
+	s.Assert(or.CommutativeAxiom())
+	s.Assert(or.AssociativeAxiom())
+	s.Assert(or.IdempotentAxiom())
+	s.Assert(or.Apply(p, q).(Bool).Xor(p))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Errorf("commutative, associative, idempotent: got %v, %v; want sat", sat, err)
+	}
+}
+
+func TestFuncDeclAxiomsPanic(t *testing.T) {
+	ctx := NewContext(nil)
+	g := ctx.FuncDecl("g", []Sort{ctx.IntSort(), ctx.BoolSort()}, ctx.IntSort())
+	defer func() {
+		if recover() == nil {
+			t.Errorf("CommutativeAxiom of mixed-sort function did not panic")
+		}
+	}()
+	g.CommutativeAxiom()
+}
@@ -0,0 +1,417 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A Binding associates the fields of a Go struct with constants, so
+// that constraints can be written over the constants and a Model can
+// be decoded back into the struct.
+//
+// Bind binds each struct field that has a "z3" tag. The tag has the
+// form
+//
+//	z3:"sort,option,..."
+//
+// where sort is int, real, bool, string, or bv, and may be omitted to
+// use the sort that corresponds to the field's Go type. The options
+// are:
+//
+//	name=N    name the constant N instead of the field name
+//	min=N     constrain the value to be at least N
+//	max=N     constrain the value to be at most N
+//	bits=N    use N-bit bit-vectors (default: the Go type's size)
+//	distinct  constrain the elements of an array or slice to differ
+//
+// Fields may be booleans, integers, floats (bound as reals), strings,
+// *big.Int, *big.Rat, or arrays or slices of these. Each element of an
+// array or slice gets its own constant, named like "name[i]". Slices
+// must already have their final length when the struct is bound.
+// Bit-vectors and min and max bounds on them are signed if the Go type
+// is a signed integer and unsigned otherwise.
+//
+// For example,
+//
+//	var p struct {
+//		Digits [4]int `z3:"int,min=0,max=9,distinct"`
+//		Odd    bool   `z3:""`
+//	}
+//	b, err := ctx.Bind(&p)
+//	...
+//	solver.Assert(b.Constraints())
+//	solver.Assert(b.Var("Odd").(z3.Bool).Eq(...))
+//	if sat, _ := solver.Check(); sat {
+//		err = b.Decode(solver.Model())
+//	}
+type Binding struct {
+	ctx    *Context
+	v      reflect.Value // The bound struct
+	fields []*boundField
+	byName map[string]*boundField
+	conds  []Bool
+}
+
+type boundField struct {
+	name   string // Go field name
+	index  int
+	kind   Kind
+	signed bool // For KindBV
+	vals   []Value
+	multi  bool // Array or slice field
+}
+
+var (
+	bigIntType = reflect.TypeOf((*big.Int)(nil))
+	bigRatType = reflect.TypeOf((*big.Rat)(nil))
+)
+
+// Bind declares constants for the tagged fields of the struct that
+// ptr points to. See Binding for the format of the tags.
+func (ctx *Context) Bind(ptr interface{}) (*Binding, error) {
+	pv := reflect.ValueOf(ptr)
+	if pv.Kind() != reflect.Ptr || pv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("z3: Bind of %T, not a pointer to a struct", ptr)
+	}
+	b := &Binding{ctx: ctx, v: pv.Elem(), byName: make(map[string]*boundField)}
+	t := b.v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("z3")
+		if !ok || tag == "-" {
+			continue
+		}
+		if err := b.bindField(sf, i, tag); err != nil {
+			return nil, fmt.Errorf("z3: Bind field %s: %v", sf.Name, err)
+		}
+	}
+	return b, nil
+}
+
+func (b *Binding) bindField(sf reflect.StructField, index int, tag string) error {
+	if sf.PkgPath != "" {
+		return fmt.Errorf("field is not exported")
+	}
+	f := &boundField{name: sf.Name, index: index}
+	fv := b.v.Field(index)
+	n := 1
+	et := sf.Type
+	switch et.Kind() {
+	case reflect.Array, reflect.Slice:
+		f.multi = true
+		n = fv.Len()
+		et = et.Elem()
+	}
+
+	parts := strings.Split(tag, ",")
+	sortName, opts := parts[0], parts[1:]
+	if sortName == "" {
+		sortName = defaultSortName(et)
+		if sortName == "" {
+			return fmt.Errorf("unsupported type %s", et)
+		}
+	}
+	bits := int(et.Size() * 8)
+	f.signed = isSigned(et)
+	switch sortName {
+	case "int":
+		f.kind = KindInt
+	case "real":
+		f.kind = KindReal
+	case "bool":
+		f.kind = KindBool
+	case "string":
+		f.kind = KindSeq
+	case "bv":
+		f.kind = KindBV
+	default:
+		return fmt.Errorf("unknown sort %q", sortName)
+	}
+	if !compatible(f.kind, et) {
+		return fmt.Errorf("cannot bind %s to sort %s", et, sortName)
+	}
+
+	name := sf.Name
+	var min, max string
+	distinct := false
+	for _, opt := range opts {
+		key, val, _ := strings.Cut(opt, "=")
+		switch key {
+		case "name":
+			name = val
+		case "min":
+			min = val
+		case "max":
+			max = val
+		case "bits":
+			var err error
+			if bits, err = strconv.Atoi(val); err != nil || bits <= 0 {
+				return fmt.Errorf("bad bits %q", val)
+			}
+		case "distinct":
+			if !f.multi {
+				return fmt.Errorf("distinct on a field that is not an array or slice")
+			}
+			distinct = true
+		default:
+			return fmt.Errorf("unknown option %q", opt)
+		}
+	}
+
+	var sort Sort
+	switch f.kind {
+	case KindInt:
+		sort = b.ctx.IntSort()
+	case KindReal:
+		sort = b.ctx.RealSort()
+	case KindBool:
+		sort = b.ctx.BoolSort()
+	case KindSeq:
+		sort = b.ctx.StringSort()
+	case KindBV:
+		sort = b.ctx.BVSort(bits)
+	}
+	for i := 0; i < n; i++ {
+		cname := name
+		if f.multi {
+			cname = fmt.Sprintf("%s[%d]", name, i)
+		}
+		f.vals = append(f.vals, b.ctx.Const(cname, sort))
+	}
+
+	for _, bound := range []struct {
+		s     string
+		upper bool
+	}{{min, false}, {max, true}} {
+		if bound.s == "" {
+			continue
+		}
+		if err := b.addBound(f, sort, bound.s, bound.upper); err != nil {
+			return err
+		}
+	}
+	if distinct && n > 1 {
+		b.conds = append(b.conds, b.ctx.Distinct(f.vals...))
+	}
+
+	if _, ok := b.byName[f.name]; ok {
+		return fmt.Errorf("field bound twice")
+	}
+	b.fields = append(b.fields, f)
+	b.byName[f.name] = f
+	return nil
+}
+
+// addBound adds the constraint that the values of f are at least or,
+// if upper, at most the literal s.
+func (b *Binding) addBound(f *boundField, sort Sort, s string, upper bool) error {
+	var lit Value
+	switch f.kind {
+	case KindInt, KindBV:
+		x, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return fmt.Errorf("bad bound %q", s)
+		}
+		lit = b.ctx.FromBigInt(x, sort)
+	case KindReal:
+		x, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return fmt.Errorf("bad bound %q", s)
+		}
+		lit = b.ctx.FromBigRat(x)
+	default:
+		return fmt.Errorf("min and max require a numeric sort")
+	}
+	for _, v := range f.vals {
+		var c Bool
+		switch v := v.(type) {
+		case Int:
+			if upper {
+				c = v.LE(lit.(Int))
+			} else {
+				c = v.GE(lit.(Int))
+			}
+		case Real:
+			if upper {
+				c = v.LE(lit.(Real))
+			} else {
+				c = v.GE(lit.(Real))
+			}
+		case BV:
+			switch {
+			case upper && f.signed:
+				c = v.SLE(lit.(BV))
+			case upper:
+				c = v.ULE(lit.(BV))
+			case f.signed:
+				c = v.SGE(lit.(BV))
+			default:
+				c = v.UGE(lit.(BV))
+			}
+		}
+		b.conds = append(b.conds, c)
+	}
+	return nil
+}
+
+func defaultSortName(t reflect.Type) string {
+	switch {
+	case t == bigIntType:
+		return "int"
+	case t == bigRatType:
+		return "real"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "real"
+	case reflect.String:
+		return "string"
+	}
+	return ""
+}
+
+func isSigned(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// compatible reports whether values of kind k can be decoded into Go
+// type t.
+func compatible(k Kind, t reflect.Type) bool {
+	switch k {
+	case KindInt:
+		return t == bigIntType || defaultSortName(t) == "int"
+	case KindBV:
+		return t != bigIntType && defaultSortName(t) == "int"
+	case KindReal:
+		return t == bigRatType || t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+	case KindBool:
+		return t.Kind() == reflect.Bool
+	case KindSeq:
+		return t.Kind() == reflect.String
+	}
+	return false
+}
+
+// Var returns the constant bound to the named struct field. If the
+// field is an array or slice, Var returns its first element; use Vars
+// to get all of them. Var panics if field is not bound.
+func (b *Binding) Var(field string) Value {
+	return b.Vars(field)[0]
+}
+
+// Vars returns the constants bound to the elements of the named array
+// or slice field. For other fields, it returns a single constant.
+// Vars panics if field is not bound.
+func (b *Binding) Vars(field string) []Value {
+	f, ok := b.byName[field]
+	if !ok {
+		panic("z3: field " + field + " is not bound")
+	}
+	return f.vals
+}
+
+// Constraints returns the conjunction of the constraints given by the
+// min, max, and distinct options of the struct tags.
+func (b *Binding) Constraints() Bool {
+	return b.ctx.FromBool(true).And(b.conds...)
+}
+
+// Decode sets the bound fields of the struct to their values in m.
+// Constants that m does not constrain get arbitrary values. Decode
+// returns an error if a value does not fit in its field.
+func (b *Binding) Decode(m *Model) error {
+	for _, f := range b.fields {
+		fv := b.v.Field(f.index)
+		for i, val := range f.vals {
+			dst := fv
+			if f.multi {
+				dst = fv.Index(i)
+			}
+			if err := decodeValue(m.Eval(val, true), f.signed, dst); err != nil {
+				return fmt.Errorf("z3: decoding %s: %v", val, err)
+			}
+		}
+	}
+	return nil
+}
+
+func decodeValue(val Value, signed bool, dst reflect.Value) error {
+	var x *big.Int
+	switch val := val.(type) {
+	case Bool:
+		v, ok := val.AsBool()
+		if !ok {
+			return fmt.Errorf("%s is not a literal", val)
+		}
+		dst.SetBool(v)
+		return nil
+	case String:
+		v, ok := val.AsString()
+		if !ok {
+			return fmt.Errorf("%s is not a literal", val)
+		}
+		dst.SetString(v)
+		return nil
+	case Real:
+		r, ok := val.AsBigRat()
+		if !ok {
+			return fmt.Errorf("%s is not a rational literal", val)
+		}
+		if dst.Type() == bigRatType {
+			dst.Set(reflect.ValueOf(r))
+		} else {
+			f, _ := r.Float64()
+			dst.SetFloat(f)
+		}
+		return nil
+	case Int:
+		var ok bool
+		if x, ok = val.AsBigInt(); !ok {
+			return fmt.Errorf("%s is not a literal", val)
+		}
+	case BV:
+		var ok bool
+		if signed {
+			x, ok = val.AsBigSigned()
+		} else {
+			x, ok = val.AsBigUnsigned()
+		}
+		if !ok {
+			return fmt.Errorf("%s is not a literal", val)
+		}
+	default:
+		return fmt.Errorf("unexpected value %s", val)
+	}
+
+	switch {
+	case dst.Type() == bigIntType:
+		dst.Set(reflect.ValueOf(x))
+	case isSigned(dst.Type()):
+		if !x.IsInt64() || dst.OverflowInt(x.Int64()) {
+			return fmt.Errorf("%s overflows %s", x, dst.Type())
+		}
+		dst.SetInt(x.Int64())
+	default:
+		if !x.IsUint64() || dst.OverflowUint(x.Uint64()) {
+			return fmt.Errorf("%s overflows %s", x, dst.Type())
+		}
+		dst.SetUint(x.Uint64())
+	}
+	return nil
+}
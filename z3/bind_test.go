@@ -0,0 +1,130 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	ctx := NewContext(nil)
+	var p struct {
+		Digits [4]int   `z3:"int,min=1,max=4,distinct"`
+		Flag   bool     `z3:""`
+		Name   string   `z3:"string,name=n"`
+		Ratio  float64  `z3:",min=0"`
+		Byte   uint8    `z3:"bv"`
+		Small  int8     `z3:"bv,min=-3,max=-1"`
+		Big    *big.Int `z3:""`
+		Rat    *big.Rat `z3:""`
+		Other  int
+	}
+	p.Other = 42
+	b, err := ctx.Bind(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.Var("Name").String(); got != "n" {
+		t.Errorf("Name bound to %s, want n", got)
+	}
+	if got := b.Vars("Digits")[2].String(); got != "|Digits[2]|" {
+		t.Errorf("Digits[2] bound to %s", got)
+	}
+
+	s := NewSolver(ctx)
+	s.Assert(b.Constraints())
+	ds := b.Vars("Digits")
+	s.Assert(ds[0].(Int).GT(ds[3].(Int)))
+	s.Assert(ds[1].(Int).Eq(ctx.Int(3)))
+	s.Assert(b.Var("Flag").(Bool))
+	s.Assert(b.Var("Name").(String).Eq(ctx.FromString("go")))
+	s.Assert(b.Var("Ratio").(Real).Mul(ctx.FromBigRat(big.NewRat(4, 1))).Eq(ctx.FromBigRat(big.NewRat(1, 1))))
+	s.Assert(b.Var("Byte").(BV).Eq(ctx.FromInt(-1, ctx.BVSort(8)).(BV)))
+	s.Assert(b.Var("Small").(BV).SGT(ctx.FromInt(-2, ctx.BVSort(8)).(BV)))
+	s.Assert(b.Var("Big").(Int).Eq(ctx.FromBigInt(new(big.Int).Lsh(big.NewInt(1), 70), ctx.IntSort()).(Int)))
+	s.Assert(b.Var("Rat").(Real).Eq(ctx.FromBigRat(big.NewRat(-1, 3))))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v", sat, err)
+	}
+	if err := b.Decode(s.Model()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Digits is a permutation of 1..4 with d[1] == 3 and d[0] > d[3].
+	seen := 0
+	for _, d := range p.Digits {
+		if d < 1 || d > 4 {
+			t.Errorf("digit %d out of range", d)
+		}
+		seen |= 1 << d
+	}
+	if seen != 0x1e || p.Digits[1] != 3 || p.Digits[0] <= p.Digits[3] {
+		t.Errorf("Digits = %v", p.Digits)
+	}
+	if !p.Flag || p.Name != "go" || p.Ratio != 0.25 || p.Byte != 255 || p.Small != -1 {
+		t.Errorf("decoded %+v", p)
+	}
+	if p.Big.BitLen() != 71 || p.Rat.Cmp(big.NewRat(-1, 3)) != 0 {
+		t.Errorf("Big = %v, Rat = %v", p.Big, p.Rat)
+	}
+	if p.Other != 42 {
+		t.Errorf("untagged field changed to %d", p.Other)
+	}
+}
+
+func TestBindOverflow(t *testing.T) {
+	ctx := NewContext(nil)
+	var p struct {
+		X int8 `z3:""`
+	}
+	b, err := ctx.Bind(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSolver(ctx)
+	s.Assert(b.Var("X").(Int).Eq(ctx.Int(1000)))
+	s.Check()
+	if err := b.Decode(s.Model()); err == nil || !strings.Contains(err.Error(), "overflows int8") {
+		t.Errorf("Decode of 1000 into int8: got %v", err)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, test := range []struct {
+		v   interface{}
+		err string
+	}{
+		{struct{}{}, "not a pointer to a struct"},
+		{&struct {
+			X int `z3:"float"`
+		}{}, `unknown sort "float"`},
+		{&struct {
+			X string `z3:"int"`
+		}{}, "cannot bind string"},
+		{&struct {
+			X int `z3:"int,distinct"`
+		}{}, "not an array or slice"},
+		{&struct {
+			X bool `z3:",min=1"`
+		}{}, "numeric sort"},
+		{&struct {
+			X int `z3:",foo"`
+		}{}, `unknown option "foo"`},
+		{&struct {
+			x int `z3:""`
+		}{}, "not exported"},
+		{&struct {
+			X []chan int `z3:""`
+		}{}, "unsupported type"},
+	} {
+		_, err := ctx.Bind(test.v)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Bind(%T): got %v, want %q", test.v, err, test.err)
+		}
+	}
+}
@@ -111,7 +111,7 @@ func (lit BV) AsUint64() (val uint64, isLiteral, ok bool) {
 	return lit.asUint64()
 }
 
-//go:generate go run genwrap.go -t BV $GOFILE
+//go:generate go run genwrap.go -t BV -e $GOFILE
 
 // Not returns the bit-wise negation of l.
 //
@@ -346,6 +346,17 @@ func (lit BV) AsUint64() (val uint64, isLiteral, ok bool) {
 //
 //wrap:expr UToInt:Int l : Z3_mk_bv2int l "false"
 
+// SToString returns the decimal representation of l, interpreting l
+// as a two's complement signed number. Negative values have a leading
+// "-".
+//
+//wrap:expr SToString:String Z3_mk_sbv_to_str l
+
+// UToString returns the decimal representation of l, interpreting l
+// as unsigned.
+//
+//wrap:expr UToString:String Z3_mk_ubv_to_str l
+
 // IEEEToFloat converts l into a floating-point number, interpreting l
 // in IEEE 754-2008 format.
 //
@@ -416,6 +427,40 @@ func (l BV) MulNoOverflow(r BV, isSigned bool) Bool {
 	return Bool(val)
 }
 
+// UDivTotal returns the floor of l / r, treating l and r as unsigned,
+// together with a predicate that is true if r is 0. This encodes Go
+// division, where the predicate is the condition for a run-time
+// panic, in one call.
+//
+// If def is given, the quotient is def[0] when r is 0. Otherwise, it
+// is the same as UDiv.
+//
+// l and r must have the same size.
+func (l BV) UDivTotal(r BV, def ...BV) (quo BV, divByZero Bool) {
+	return l.divTotal(r, l.UDiv(r), def)
+}
+
+// SDivTotal returns l / r rounded toward 0, treating l and r as two's
+// complement signed numbers, together with a predicate that is true if
+// r is 0. Like Go's signed division, dividing the most negative value
+// by -1 wraps around to the most negative value.
+//
+// If def is given, the quotient is def[0] when r is 0. Otherwise, it
+// is the same as SDiv.
+//
+// l and r must have the same size.
+func (l BV) SDivTotal(r BV, def ...BV) (quo BV, divByZero Bool) {
+	return l.divTotal(r, l.SDiv(r), def)
+}
+
+func (l BV) divTotal(r, quo BV, def []BV) (BV, Bool) {
+	divByZero := r.Eq(l.ctx.FromInt(0, r.Sort()).(BV))
+	if len(def) > 0 {
+		quo = divByZero.IfThenElse(def[0], quo).(BV)
+	}
+	return quo, divByZero
+}
+
 // MulNoUnderflow returns a predicate that is true if the signed
 // multiplication of l and r does not underflow.
 //
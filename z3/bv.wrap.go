@@ -16,12 +16,22 @@ func (l BV) Eq(r BV) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_eq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// EqE is like Eq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) EqE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.Eq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Eq(r) })
+	return
+}
+
 // NE returns a Value that is true if l and r are not equal.
 func (l BV) NE(r BV) Bool {
 	return l.ctx.Distinct(l, r)
@@ -29,192 +39,328 @@ func (l BV) NE(r BV) Bool {
 
 // Not returns the bit-wise negation of l.
 func (l BV) Not() BV {
-	// Generated from bv.go:117.
+	// Generated from bv.go:118.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvnot(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// NotE is like Not, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) NotE() (res BV, err error) {
+	chk := argChecker{method: "BV.Not"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Not() })
+	return
+}
+
 // AllBits returns a 1-bit bit-vector that is the bit-wise "and" of
 // all bits.
 func (l BV) AllBits() BV {
-	// Generated from bv.go:122.
+	// Generated from bv.go:123.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvredand(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// AllBitsE is like AllBits, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) AllBitsE() (res BV, err error) {
+	chk := argChecker{method: "BV.AllBits"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.AllBits() })
+	return
+}
+
 // AnyBits returns a 1-bit bit-vector that is the bit-wise "or" of all
 // bits.
 func (l BV) AnyBits() BV {
-	// Generated from bv.go:127.
+	// Generated from bv.go:128.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvredor(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// AnyBitsE is like AnyBits, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) AnyBitsE() (res BV, err error) {
+	chk := argChecker{method: "BV.AnyBits"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.AnyBits() })
+	return
+}
+
 // And returns the bit-wise and of l and r.
 //
 // l and r must have the same size.
 func (l BV) And(r BV) BV {
-	// Generated from bv.go:133.
+	// Generated from bv.go:134.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvand(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// AndE is like And, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) AndE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.And"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.And(r) })
+	return
+}
+
 // Or returns the bit-wise or of l and r.
 //
 // l and r must have the same size.
 func (l BV) Or(r BV) BV {
-	// Generated from bv.go:139.
+	// Generated from bv.go:140.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvor(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// OrE is like Or, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) OrE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Or"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Or(r) })
+	return
+}
+
 // Xor returns the bit-wise xor of l and r.
 //
 // l and r must have the same size.
 func (l BV) Xor(r BV) BV {
-	// Generated from bv.go:145.
+	// Generated from bv.go:146.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvxor(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// XorE is like Xor, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) XorE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Xor"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Xor(r) })
+	return
+}
+
 // Nand returns the bit-wise nand of l and r.
 //
 // l and r must have the same size.
 func (l BV) Nand(r BV) BV {
-	// Generated from bv.go:151.
+	// Generated from bv.go:152.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvnand(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// NandE is like Nand, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) NandE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Nand"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Nand(r) })
+	return
+}
+
 // Nor returns the bit-wise nor of l and r.
 //
 // l and r must have the same size.
 func (l BV) Nor(r BV) BV {
-	// Generated from bv.go:157.
+	// Generated from bv.go:158.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvnor(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// NorE is like Nor, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) NorE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Nor"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Nor(r) })
+	return
+}
+
 // Xnor returns the bit-wise xnor of l and r.
 //
 // l and r must have the same size.
 func (l BV) Xnor(r BV) BV {
-	// Generated from bv.go:163.
+	// Generated from bv.go:164.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvxnor(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// XnorE is like Xnor, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) XnorE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Xnor"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Xnor(r) })
+	return
+}
+
 // Neg returns the two's complement negation of l.
 func (l BV) Neg() BV {
-	// Generated from bv.go:167.
+	// Generated from bv.go:168.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvneg(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// NegE is like Neg, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) NegE() (res BV, err error) {
+	chk := argChecker{method: "BV.Neg"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Neg() })
+	return
+}
+
 // Add returns the two's complement sum of l and r.
 //
 // l and r must have the same size.
 func (l BV) Add(r BV) BV {
-	// Generated from bv.go:173.
+	// Generated from bv.go:174.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvadd(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// AddE is like Add, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) AddE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Add"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Add(r) })
+	return
+}
+
 // Sub returns the two's complement subtraction l minus r.
 //
 // l and r must have the same size.
 func (l BV) Sub(r BV) BV {
-	// Generated from bv.go:179.
+	// Generated from bv.go:180.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvsub(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// SubE is like Sub, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SubE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Sub"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Sub(r) })
+	return
+}
+
 // Mul returns the two's complement product of l and r.
 //
 // l and r must have the same size.
 func (l BV) Mul(r BV) BV {
-	// Generated from bv.go:185.
+	// Generated from bv.go:186.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvmul(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// MulE is like Mul, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) MulE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Mul"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Mul(r) })
+	return
+}
+
 // UDiv returns the floor of l / r, treating l and r as unsigned.
 //
 // If r is 0, the result is unconstrained.
 //
 // l and r must have the same size.
 func (l BV) UDiv(r BV) BV {
-	// Generated from bv.go:193.
+	// Generated from bv.go:194.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvudiv(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// UDivE is like UDiv, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) UDivE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.UDiv"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.UDiv(r) })
+	return
+}
+
 // SDiv returns l / r rounded toward 0, treating l and r as two's
 // complement signed numbers.
 //
@@ -222,452 +368,817 @@ func (l BV) UDiv(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) SDiv(r BV) BV {
-	// Generated from bv.go:202.
+	// Generated from bv.go:203.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvsdiv(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// SDivE is like SDiv, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SDivE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.SDiv"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.SDiv(r) })
+	return
+}
+
 // URem returns the unsigned remainder of l divided by r.
 //
 // l and r must have the same size.
 func (l BV) URem(r BV) BV {
-	// Generated from bv.go:208.
+	// Generated from bv.go:209.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvurem(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// URemE is like URem, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) URemE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.URem"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.URem(r) })
+	return
+}
+
 // SRem returns the two's complement signed remainder of l divided by r.
 //
 // The sign of the result follows the sign of l.
 //
 // l and r must have the same size.
 func (l BV) SRem(r BV) BV {
-	// Generated from bv.go:216.
+	// Generated from bv.go:217.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvsrem(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// SRemE is like SRem, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SRemE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.SRem"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.SRem(r) })
+	return
+}
+
 // SMod returns the two's complement signed modulus of l divided by r.
 //
 // The sign of the result follows the sign of r.
 //
 // l and r must have the same size.
 func (l BV) SMod(r BV) BV {
-	// Generated from bv.go:224.
+	// Generated from bv.go:225.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvsmod(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// SModE is like SMod, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SModE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.SMod"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.SMod(r) })
+	return
+}
+
 // ULT returns the l < r, where l and r are unsigned.
 //
 // l and r must have the same size.
 func (l BV) ULT(r BV) Bool {
-	// Generated from bv.go:230.
+	// Generated from bv.go:231.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvult(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// ULTE is like ULT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) ULTE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.ULT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.ULT(r) })
+	return
+}
+
 // SLT returns the l < r, where l and r are signed.
 //
 // l and r must have the same size.
 func (l BV) SLT(r BV) Bool {
-	// Generated from bv.go:236.
+	// Generated from bv.go:237.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvslt(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// SLTE is like SLT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SLTE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.SLT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.SLT(r) })
+	return
+}
+
 // ULE returns the l <= r, where l and r are unsigned.
 //
 // l and r must have the same size.
 func (l BV) ULE(r BV) Bool {
-	// Generated from bv.go:242.
+	// Generated from bv.go:243.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvule(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// ULEE is like ULE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) ULEE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.ULE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.ULE(r) })
+	return
+}
+
 // SLE returns the l <= r, where l and r are signed.
 //
 // l and r must have the same size.
 func (l BV) SLE(r BV) Bool {
-	// Generated from bv.go:248.
+	// Generated from bv.go:249.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvsle(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// SLEE is like SLE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SLEE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.SLE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.SLE(r) })
+	return
+}
+
 // UGE returns the l >= r, where l and r are unsigned.
 //
 // l and r must have the same size.
 func (l BV) UGE(r BV) Bool {
-	// Generated from bv.go:254.
+	// Generated from bv.go:255.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvuge(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// UGEE is like UGE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) UGEE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.UGE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.UGE(r) })
+	return
+}
+
 // SGE returns the l >= r, where l and r are signed.
 //
 // l and r must have the same size.
 func (l BV) SGE(r BV) Bool {
-	// Generated from bv.go:260.
+	// Generated from bv.go:261.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvsge(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// SGEE is like SGE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SGEE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.SGE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.SGE(r) })
+	return
+}
+
 // UGT returns the l > r, where l and r are unsigned.
 //
 // l and r must have the same size.
 func (l BV) UGT(r BV) Bool {
-	// Generated from bv.go:266.
+	// Generated from bv.go:267.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvugt(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// UGTE is like UGT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) UGTE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.UGT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.UGT(r) })
+	return
+}
+
 // SGT returns the l > r, where l and r are signed.
 //
 // l and r must have the same size.
 func (l BV) SGT(r BV) Bool {
-	// Generated from bv.go:272.
+	// Generated from bv.go:273.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvsgt(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// SGTE is like SGT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SGTE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.SGT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.SGT(r) })
+	return
+}
+
 // Concat returns concatenation of l and r.
 //
 // The result is a bit-vector whose length is the sum of the lengths
 // of l and r.
 func (l BV) Concat(r BV) BV {
-	// Generated from bv.go:279.
+	// Generated from bv.go:280.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_concat(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return BV(val)
 }
 
+// ConcatE is like Concat, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) ConcatE(r BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Concat"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Concat(r) })
+	return
+}
+
 // Extract returns bits [high, low] (inclusive) of l, where bit 0 is
 // the least significant bit.
 func (l BV) Extract(high int, low int) BV {
-	// Generated from bv.go:284.
+	// Generated from bv.go:285.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_extract(ctx.c, C.unsigned(high), C.unsigned(low), l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// ExtractE is like Extract, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) ExtractE(high int, low int) (res BV, err error) {
+	chk := argChecker{method: "BV.Extract"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Extract(high, low) })
+	return
+}
+
 // SignExtend returns l sign-extended to a bit-vector of length m+i,
 // where m is the length of l.
 func (l BV) SignExtend(i int) BV {
-	// Generated from bv.go:289.
+	// Generated from bv.go:290.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_sign_ext(ctx.c, C.unsigned(i), l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// SignExtendE is like SignExtend, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SignExtendE(i int) (res BV, err error) {
+	chk := argChecker{method: "BV.SignExtend"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.SignExtend(i) })
+	return
+}
+
 // ZeroExtend returns l zero-extended to a bit-vector of length m+i,
 // where m is the length of l.
 func (l BV) ZeroExtend(i int) BV {
-	// Generated from bv.go:294.
+	// Generated from bv.go:295.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_zero_ext(ctx.c, C.unsigned(i), l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// ZeroExtendE is like ZeroExtend, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) ZeroExtendE(i int) (res BV, err error) {
+	chk := argChecker{method: "BV.ZeroExtend"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.ZeroExtend(i) })
+	return
+}
+
 // Repeat returns l repeated up to length i.
 func (l BV) Repeat(i int) BV {
-	// Generated from bv.go:298.
+	// Generated from bv.go:299.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_repeat(ctx.c, C.unsigned(i), l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// RepeatE is like Repeat, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) RepeatE(i int) (res BV, err error) {
+	chk := argChecker{method: "BV.Repeat"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Repeat(i) })
+	return
+}
+
 // Bit2Bool extracts the bit at position i of l and yields a boolean.
 func (l BV) Bit2Bool(i int) Bool {
-	// Generated from bv.go:302.
+	// Generated from bv.go:303.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bit2bool(ctx.c, C.unsigned(i), l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// Bit2BoolE is like Bit2Bool, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) Bit2BoolE(i int) (res Bool, err error) {
+	chk := argChecker{method: "BV.Bit2Bool"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Bit2Bool(i) })
+	return
+}
+
 // Lsh returns l shifted left by i bits.
 //
 // This is equivalent to l * 2^i.
 //
 // l and i must have the same size. The result has the same sort.
 func (l BV) Lsh(i BV) BV {
-	// Generated from bv.go:310.
+	// Generated from bv.go:311.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvshl(ctx.c, l.c, i.c)
-	})
+	}, l.c, i.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
 	return BV(val)
 }
 
+// LshE is like Lsh, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) LshE(i BV) (res BV, err error) {
+	chk := argChecker{method: "BV.Lsh"}
+	chk.value("l", l)
+	chk.value("i", i)
+	err = chk.do(func() { res = l.Lsh(i) })
+	return
+}
+
 // URsh returns l logically shifted right by i bits.
 //
 // This is equivalent to l / 2^i, where l and i are unsigned.
 //
 // l and i must have the same size. The result has the same sort.
 func (l BV) URsh(i BV) BV {
-	// Generated from bv.go:318.
+	// Generated from bv.go:319.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvlshr(ctx.c, l.c, i.c)
-	})
+	}, l.c, i.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
 	return BV(val)
 }
 
+// URshE is like URsh, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) URshE(i BV) (res BV, err error) {
+	chk := argChecker{method: "BV.URsh"}
+	chk.value("l", l)
+	chk.value("i", i)
+	err = chk.do(func() { res = l.URsh(i) })
+	return
+}
+
 // SRsh returns l arithmetically shifted right by i bits.
 //
 // This is like URsh, but the sign of the result is the sign of l.
 //
 // l and i must have the same size. The result has the same sort.
 func (l BV) SRsh(i BV) BV {
-	// Generated from bv.go:326.
+	// Generated from bv.go:327.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvashr(ctx.c, l.c, i.c)
-	})
+	}, l.c, i.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
 	return BV(val)
 }
 
+// SRshE is like SRsh, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SRshE(i BV) (res BV, err error) {
+	chk := argChecker{method: "BV.SRsh"}
+	chk.value("l", l)
+	chk.value("i", i)
+	err = chk.do(func() { res = l.SRsh(i) })
+	return
+}
+
 // RotateLeft returns l rotated left by i bits.
 //
 // l and i must have the same size.
 func (l BV) RotateLeft(i BV) BV {
-	// Generated from bv.go:332.
+	// Generated from bv.go:333.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_ext_rotate_left(ctx.c, l.c, i.c)
-	})
+	}, l.c, i.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
 	return BV(val)
 }
 
+// RotateLeftE is like RotateLeft, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) RotateLeftE(i BV) (res BV, err error) {
+	chk := argChecker{method: "BV.RotateLeft"}
+	chk.value("l", l)
+	chk.value("i", i)
+	err = chk.do(func() { res = l.RotateLeft(i) })
+	return
+}
+
 // RotateRight returns l rotated right by i bits.
 //
 // l and i must have the same size.
 func (l BV) RotateRight(i BV) BV {
-	// Generated from bv.go:338.
+	// Generated from bv.go:339.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_ext_rotate_right(ctx.c, l.c, i.c)
-	})
+	}, l.c, i.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
 	return BV(val)
 }
 
+// RotateRightE is like RotateRight, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) RotateRightE(i BV) (res BV, err error) {
+	chk := argChecker{method: "BV.RotateRight"}
+	chk.value("l", l)
+	chk.value("i", i)
+	err = chk.do(func() { res = l.RotateRight(i) })
+	return
+}
+
 // SToInt converts signed bit-vector l to an integer.
 func (l BV) SToInt() Int {
-	// Generated from bv.go:342.
+	// Generated from bv.go:343.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bv2int(ctx.c, l.c, true)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Int(val)
 }
 
+// SToIntE is like SToInt, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SToIntE() (res Int, err error) {
+	chk := argChecker{method: "BV.SToInt"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.SToInt() })
+	return
+}
+
 // UToInt converts unsigned bit-vector l to an integer.
 func (l BV) UToInt() Int {
-	// Generated from bv.go:346.
+	// Generated from bv.go:347.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bv2int(ctx.c, l.c, false)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Int(val)
 }
 
+// UToIntE is like UToInt, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) UToIntE() (res Int, err error) {
+	chk := argChecker{method: "BV.UToInt"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.UToInt() })
+	return
+}
+
+// SToString returns the decimal representation of l, interpreting l
+// as a two's complement signed number. Negative values have a leading
+// "-".
+func (l BV) SToString() String {
+	// Generated from bv.go:353.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_sbv_to_str(ctx.c, l.c)
+	}, l.c)
+	runtime.KeepAlive(l)
+	return String(val)
+}
+
+// SToStringE is like SToString, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SToStringE() (res String, err error) {
+	chk := argChecker{method: "BV.SToString"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.SToString() })
+	return
+}
+
+// UToString returns the decimal representation of l, interpreting l
+// as unsigned.
+func (l BV) UToString() String {
+	// Generated from bv.go:358.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_ubv_to_str(ctx.c, l.c)
+	}, l.c)
+	runtime.KeepAlive(l)
+	return String(val)
+}
+
+// UToStringE is like UToString, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) UToStringE() (res String, err error) {
+	chk := argChecker{method: "BV.UToString"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.UToString() })
+	return
+}
+
 // IEEEToFloat converts l into a floating-point number, interpreting l
 // in IEEE 754-2008 format.
 //
 // The size of l must equal ebits+sbits of s.
 func (l BV) IEEEToFloat(s Sort) Float {
-	// Generated from bv.go:353.
+	// Generated from bv.go:365.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_fp_bv(ctx.c, l.c, s.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
 	return Float(val)
 }
 
+// IEEEToFloatE is like IEEEToFloat, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) IEEEToFloatE(s Sort) (res Float, err error) {
+	chk := argChecker{method: "BV.IEEEToFloat"}
+	chk.value("l", l)
+	chk.sort("s", s)
+	err = chk.do(func() { res = l.IEEEToFloat(s) })
+	return
+}
+
 // SToFloat converts signed bit-vector l into a floating-point number.
 //
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l BV) SToFloat(s Sort) Float {
-	// Generated from bv.go:360.
+	// Generated from bv.go:372.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_fp_signed(ctx.c, rm.c, l.c, s.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
 	return Float(val)
 }
 
+// SToFloatE is like SToFloat, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SToFloatE(s Sort) (res Float, err error) {
+	chk := argChecker{method: "BV.SToFloat"}
+	chk.value("l", l)
+	chk.sort("s", s)
+	err = chk.do(func() { res = l.SToFloat(s) })
+	return
+}
+
 // UToFloat converts unsigned bit-vector l into a floating-point number.
 //
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l BV) UToFloat(s Sort) Float {
-	// Generated from bv.go:367.
+	// Generated from bv.go:379.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_fp_unsigned(ctx.c, rm.c, l.c, s.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
 	return Float(val)
 }
 
+// UToFloatE is like UToFloat, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) UToFloatE(s Sort) (res Float, err error) {
+	chk := argChecker{method: "BV.UToFloat"}
+	chk.value("l", l)
+	chk.sort("s", s)
+	err = chk.do(func() { res = l.UToFloat(s) })
+	return
+}
+
 // AddNoUnderflow returns a predicate that is true if the signed
 // addition of l and r does not underflow.
 func (l BV) AddNoUnderflow(r BV) Bool {
-	// Generated from bv.go:385.
+	// Generated from bv.go:397.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvadd_no_underflow(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// AddNoUnderflowE is like AddNoUnderflow, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) AddNoUnderflowE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.AddNoUnderflow"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.AddNoUnderflow(r) })
+	return
+}
+
 // SubNoOverflow returns a predicate that is true if the signed
 // subtraction of l and r does not overflow.
 func (l BV) SubNoOverflow(r BV) Bool {
-	// Generated from bv.go:390.
+	// Generated from bv.go:402.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvsub_no_overflow(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// SubNoOverflowE is like SubNoOverflow, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SubNoOverflowE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.SubNoOverflow"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.SubNoOverflow(r) })
+	return
+}
+
 // MulNoUnderflow returns a predicate that is true if the signed
 // multiplication of l and r does not underflow.
 func (l BV) MulNoUnderflow(r BV) Bool {
-	// Generated from bv.go:421.
+	// Generated from bv.go:467.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvmul_no_underflow(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// MulNoUnderflowE is like MulNoUnderflow, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) MulNoUnderflowE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.MulNoUnderflow"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.MulNoUnderflow(r) })
+	return
+}
+
 // SDivNoOverflow returns a predicate that is true if the signed
 // division of l and r does not overflow.
 func (l BV) SDivNoOverflow(r BV) Bool {
-	// Generated from bv.go:426.
+	// Generated from bv.go:472.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvsdiv_no_overflow(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// SDivNoOverflowE is like SDivNoOverflow, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) SDivNoOverflowE(r BV) (res Bool, err error) {
+	chk := argChecker{method: "BV.SDivNoOverflow"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.SDivNoOverflow(r) })
+	return
+}
+
 // NegNoOverflow returns a predicate that is true if the negation
 // of l does not overflow (when l is interpreted as signed).
 func (l BV) NegNoOverflow() Bool {
-	// Generated from bv.go:431.
+	// Generated from bv.go:477.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_bvneg_no_overflow(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
+
+// NegNoOverflowE is like NegNoOverflow, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l BV) NegNoOverflowE() (res Bool, err error) {
+	chk := argChecker{method: "BV.NegNoOverflow"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.NegNoOverflow() })
+	return
+}
@@ -510,3 +510,33 @@ func TestSubNoUnderflow(t *testing.T) {
 		t.Error("expected SAT for sub underflow case")
 	}
 }
+
+func TestBVDivTotal(t *testing.T) {
+	ctx := NewContext(nil)
+	bv := func(x int64) BV { return ctx.FromInt(x, ctx.BVSort(8)).(BV) }
+	check := func(name string, q BV, dz Bool, want int64, wantDZ bool) {
+		t.Helper()
+		got, _, _ := ctx.Simplify(q, nil).(BV).AsInt64()
+		gotDZ, _ := ctx.Simplify(dz, nil).(Bool).AsBool()
+		if got != want || gotDZ != wantDZ {
+			t.Errorf("%s = %d, %v; want %d, %v", name, got, gotDZ, want, wantDZ)
+		}
+	}
+	q, dz := bv(200).UDivTotal(bv(7))
+	check("200/7", q, dz, 28, false)
+	q, dz = bv(-56).SDivTotal(bv(7))
+	check("-56/7", q, dz, -8, false)
+	q, dz = bv(-128).SDivTotal(bv(-1))
+	check("-128/-1", q, dz, -128, false)
+	q, dz = bv(5).UDivTotal(bv(0), bv(42))
+	check("5/0 with default", q, dz, 42, true)
+	q, dz = bv(-5).SDivTotal(bv(0), bv(-1))
+	check("-5/0 with default", q, dz, -1, true)
+
+	// Without a default, the quotient is that of UDiv.
+	x := ctx.BVConst("x", 8)
+	q, dz = x.UDivTotal(bv(0))
+	if proved, _, err := ctx.Prove(dz.And(q.Eq(x.UDiv(bv(0))))); !proved || err != nil {
+		t.Errorf("x/0 without default differs from UDiv: %v", err)
+	}
+}
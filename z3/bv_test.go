@@ -86,3 +86,34 @@ func TestBVAsInt64(t *testing.T) {
 		t.Errorf("-1:128 as int: expected %v, %v, %v; got %v, %v, %v", -1, true, true, vs, isConst, ok)
 	}
 }
+
+func TestBVToString(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.FromInt(-5, ctx.BVSort(8)).(BV)
+	tests := []struct {
+		s    String
+		want string
+	}{
+		{x.SToString(), "-5"},
+		{x.UToString(), "251"},
+		{ctx.FromInt(0, ctx.BVSort(4)).(BV).SToString(), "0"},
+	}
+	for _, test := range tests {
+		solver := NewSolver(ctx)
+		solver.Assert(test.s.NE(ctx.FromString(test.want)))
+		if sat, _ := solver.Check(); sat {
+			t.Errorf("%s: expected %q", test.s, test.want)
+		}
+	}
+
+	// Find an 8-bit value whose unsigned decimal form is "42".
+	y := ctx.BVConst("y", 8)
+	solver := NewSolver(ctx)
+	solver.Assert(y.UToString().Eq(ctx.FromString("42")))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	if v, _, _ := solver.Model().Eval(y, true).(BV).AsUint64(); v != 42 {
+		t.Errorf("expected 42, got %d", v)
+	}
+}
@@ -47,6 +47,30 @@ func (ctx *Context) CharConst(name string) Char {
 	return ctx.Const(name, ctx.CharSort()).(Char)
 }
 
+// CharFromRune returns a character literal for code point r. r must
+// be in the range supported by Z3's string encoding (by default,
+// 0 through 0x2FFFF).
+func (ctx *Context) CharFromRune(r rune) Char {
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_char(ctx.c, C.unsigned(r))
+	})
+	return Char(val)
+}
+
+// AsRune returns the code point of lit. If lit is not a character
+// literal, it returns 0, false.
+func (lit Char) AsRune() (val rune, isLiteral bool) {
+	kind, _, ok := lit.appArgs()
+	if !ok || kind != C.Z3_OP_CHAR_CONST {
+		return 0, false
+	}
+	params := lit.declIntParams()
+	if len(params) != 1 {
+		return 0, false
+	}
+	return rune(params[0]), true
+}
+
 // Eq returns a Value that is true if l and r are equal.
 func (l Char) Eq(r Char) Bool {
 	ctx := l.ctx
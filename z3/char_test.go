@@ -114,3 +114,32 @@ func TestStringFromCode(t *testing.T) {
 		t.Error("expected SAT for StringFromCode(65) = 'A'")
 	}
 }
+
+func TestCharFromRune(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, r := range []rune{'a', 'Z', 'é', 0x2FFFF} {
+		lit := ctx.CharFromRune(r)
+		if got, ok := lit.AsRune(); !ok || got != r {
+			t.Errorf("CharFromRune(%q).AsRune() = %q, %v", r, got, ok)
+		}
+	}
+	if _, ok := ctx.CharConst("c").AsRune(); ok {
+		t.Error("expected non-literal")
+	}
+}
+
+func TestModelEvalAsRune(t *testing.T) {
+	ctx := NewContext(nil)
+	c := ctx.CharConst("c")
+
+	solver := NewSolver(ctx)
+	solver.Assert(ctx.CharFromRune('a').LE(c))
+	solver.Assert(c.LE(ctx.CharFromRune('z')))
+	solver.Assert(c.ToInt().Eq(ctx.Int('q')))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	if r, ok := solver.Model().EvalAsRune(c, true); !ok || r != 'q' {
+		t.Errorf("expected 'q', got %q, %v", r, ok)
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// checkpointHeader starts every checkpoint written by Save.
+const checkpointHeader = "; z3 solver checkpoint"
+
+// namedInfo is the set-info keyword that gives the name of the
+// following assertion in a checkpoint.
+const namedInfo = "(set-info :go-z3-named "
+
+// Save writes a checkpoint of s's assertion stack to w, so that a long
+// incremental session can be resumed later, possibly in another
+// process, with Restore.
+//
+// The checkpoint is an SMT-LIB 2 script that declares the symbols used
+// by the assertions, asserts the assertions of the outermost scope,
+// and then has a (push 1) command followed by the assertions of each
+// scope opened by Push. Parameters set by SetParams are saved as
+// set-option commands, and the name of each assertion added by
+// AssertNamed as a set-info command before it. Models and Z3's
+// learned lemmas are not saved; they are recomputed by the next Check.
+func (s *Solver) Save(w io.Writer) error {
+	asserts := s.Assertions()
+
+	// Let Z3 print the declarations and assertions, then split
+	// them into top-level commands to insert the pushes.
+	tmp := NewSolver(s.ctx)
+	defer tmp.Close()
+	tmp.AssertAll(asserts)
+	cmds, err := splitSExprs(tmp.String())
+	if err != nil {
+		return fmt.Errorf("z3: Save: %v", err)
+	}
+
+	// Copy the solver's tracking state under the lock.
+	var marks []uint
+	var names []string
+	params := make(map[string]interface{})
+	named := make(map[uint]string)
+	s.ctx.do(func() {
+		marks = append(marks, s.marks...)
+		for name, v := range s.params {
+			names = append(names, name)
+			params[name] = v
+		}
+		for _, n := range s.named {
+			named[n.index] = n.name
+		}
+	})
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s: %d scopes\n", checkpointHeader, len(marks))
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&buf, "(set-option :%s %s)\n", name, formatOption(params[name]))
+	}
+	n := uint(0)
+	for _, cmd := range cmds {
+		if isAssert(cmd) {
+			for len(marks) > 0 && marks[0] == n {
+				buf.WriteString("(push 1)\n")
+				marks = marks[1:]
+			}
+			if name, ok := named[n]; ok {
+				fmt.Fprintf(&buf, "%s%s)\n", namedInfo, quoteSMTLIB(name))
+			}
+			n++
+		}
+		buf.WriteString(cmd)
+		buf.WriteString("\n")
+	}
+	if n != uint(len(asserts)) {
+		return fmt.Errorf("z3: Save: printed %d of %d assertions", n, len(asserts))
+	}
+	for range marks {
+		buf.WriteString("(push 1)\n")
+	}
+	_, err = io.WriteString(w, buf.String())
+	return err
+}
+
+// Restore replaces s's assertion stack with the one saved in a
+// checkpoint written by Save. The symbols declared by the checkpoint
+// are created in s's Context, so restored constants are equal to
+// constants of the same name and sort made with Context.Const.
+// Restored named assertions keep their names for UnsatCoreNames, and
+// the saved parameters are set as if by SetParams.
+//
+// If the checkpoint is invalid, Restore returns an error and s is
+// left empty.
+func (s *Solver) Restore(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.Reset()
+	src := string(data)
+	if !strings.HasPrefix(src, checkpointHeader) {
+		return errors.New("z3: Restore: not a solver checkpoint")
+	}
+	cmds, err := splitSExprs(src)
+	if err != nil {
+		return fmt.Errorf("z3: Restore: %v", err)
+	}
+
+	// Each scope is parsed separately, after all declarations seen
+	// so far. names holds the name of each assertion of the scope,
+	// or "" if it is not named.
+	var decls, scope strings.Builder
+	var names []string
+	name, hasNames := "", false
+	params := newConfig(nil)
+	flush := func() error {
+		if scope.Len() == 0 {
+			return nil
+		}
+		vals := s.ctx.parseSMTLIB2(decls.String()+scope.String(), nil, nil)
+		scope.Reset()
+		if !hasNames {
+			s.AssertAll(vals)
+			names = names[:0]
+			return nil
+		}
+		if len(vals) != len(names) {
+			return fmt.Errorf("parsed %d of %d assertions", len(vals), len(names))
+		}
+		for i, val := range vals {
+			if names[i] == "" {
+				s.Assert(val)
+				continue
+			}
+			// Save printed the assertion as Z3 tracks it:
+			// (=> lit val).
+			kind, args, ok := val.appArgs()
+			if !ok || kind != C.Z3_OP_IMPLIES || len(args) != 2 {
+				return fmt.Errorf("named assertion %q is not an implication", names[i])
+			}
+			lit, ok := args[0].(Bool)
+			if !ok {
+				return fmt.Errorf("named assertion %q is not tracked by a Bool", names[i])
+			}
+			s.assertTracked(names[i], lit, args[1].(Bool))
+		}
+		names, hasNames = names[:0], false
+		return nil
+	}
+	var perr error
+	err = s.ctx.Catch(func() {
+		for _, cmd := range cmds {
+			switch {
+			case cmd == "(push 1)":
+				if perr = flush(); perr != nil {
+					return
+				}
+				s.Push()
+			case isAssert(cmd):
+				scope.WriteString(cmd)
+				scope.WriteString("\n")
+				names = append(names, name)
+				name = ""
+			case strings.HasPrefix(cmd, namedInfo):
+				if name, perr = unquoteSMTLIB(strings.TrimSuffix(cmd[len(namedInfo):], ")")); perr != nil {
+					return
+				}
+				hasNames = true
+			case strings.HasPrefix(cmd, "(set-option :"):
+				var val interface{}
+				key, arg, _ := strings.Cut(strings.TrimSuffix(cmd[len("(set-option :"):], ")"), " ")
+				if val, perr = parseOption(arg); perr != nil {
+					return
+				}
+				params.m[key] = val
+			default:
+				decls.WriteString(cmd)
+				decls.WriteString("\n")
+			}
+		}
+		if perr = flush(); perr != nil {
+			return
+		}
+		if len(params.m) > 0 {
+			s.SetParams(params)
+		}
+	})
+	if err == nil {
+		err = perr
+	}
+	if err != nil {
+		s.Reset()
+		return fmt.Errorf("z3: Restore: %v", err)
+	}
+	return nil
+}
+
+// formatOption returns the SMT-LIB form of a parameter value of a
+// Config.
+func formatOption(v interface{}) string {
+	switch v := v.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case float64:
+		// Keep a decimal point so parseOption reads a double.
+		s := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s
+	case string:
+		return quoteSMTLIB(v)
+	}
+	panic(fmt.Sprintf("z3: unexpected parameter value %#v", v))
+}
+
+// parseOption parses a parameter value written by formatOption.
+func parseOption(s string) (interface{}, error) {
+	switch {
+	case s == "true" || s == "false":
+		return s == "true", nil
+	case strings.HasPrefix(s, `"`):
+		return unquoteSMTLIB(s)
+	case strings.Contains(s, "."):
+		return strconv.ParseFloat(s, 64)
+	}
+	v, err := strconv.ParseUint(s, 10, 0)
+	return uint(v), err
+}
+
+// quoteSMTLIB returns s as an SMT-LIB string literal.
+func quoteSMTLIB(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// unquoteSMTLIB parses an SMT-LIB string literal written by
+// quoteSMTLIB.
+func unquoteSMTLIB(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("bad string literal %s", s)
+	}
+	return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`), nil
+}
+
+func isAssert(cmd string) bool {
+	return strings.HasPrefix(cmd, "(assert ") || strings.HasPrefix(cmd, "(assert\n")
+}
+
+// splitSExprs splits src into its top-level S-expressions, dropping
+// comments and the whitespace between them.
+func splitSExprs(src string) ([]string, error) {
+	var out []string
+	depth, start := 0, -1
+	for i := 0; i < len(src); i++ {
+		switch c := src[i]; c {
+		case ';':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case '"', '|':
+			// Strings escape " by doubling it, which this loop
+			// handles as two adjacent strings.
+			j := strings.IndexByte(src[i+1:], c)
+			if j < 0 {
+				return nil, fmt.Errorf("unterminated %c at offset %d", c, i)
+			}
+			if start < 0 {
+				start = i
+			}
+			i += j + 1
+		case '(':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("unbalanced ) at offset %d", i)
+			}
+			depth--
+		case ' ', '\t', '\r', '\n':
+			if depth == 0 && start >= 0 {
+				out = append(out, src[start:i])
+				start = -1
+			}
+			continue
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+		if depth == 0 && start >= 0 && src[i] == ')' {
+			out = append(out, src[start:i+1])
+			start = -1
+		}
+	}
+	if depth != 0 {
+		return nil, errors.New("unbalanced (")
+	}
+	if start >= 0 {
+		out = append(out, src[start:])
+	}
+	return out, nil
+}
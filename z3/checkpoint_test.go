@@ -0,0 +1,141 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSolverSaveRestore(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	s := ctx.StringConst("s")
+	f := ctx.FuncDecl("f", []Sort{ctx.IntSort()}, ctx.IntSort())
+
+	solver := NewSolver(ctx)
+	solver.Assert(x.GT(ctx.Int(0)))
+	solver.Assert(s.Contains(ctx.FromString(`a "(quoted)" ;string`)))
+	solver.Push()
+	solver.Push()
+	solver.Assert(f.Apply(x).(Int).Eq(y))
+	solver.Assert(y.LT(ctx.Int(0)))
+	solver.Push()
+	solver.Assert(x.LT(ctx.Int(0)))
+
+	var buf bytes.Buffer
+	if err := solver.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(buf.String(), "(push 1)"); got != 3 {
+		t.Fatalf("checkpoint has %d pushes, want 3:\n%s", got, buf.String())
+	}
+
+	// Restore into a fresh Context, as after a restart.
+	ctx2 := NewContext(nil)
+	solver2 := NewSolver(ctx2)
+	solver2.Assert(ctx2.FromBool(false))
+	if err := solver2.Restore(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("%v in\n%s", err, buf.String())
+	}
+	if solver2.NumScopes() != 3 || solver2.NumAssertions() != 5 {
+		t.Fatalf("restored %d scopes, %d assertions", solver2.NumScopes(), solver2.NumAssertions())
+	}
+	for _, want := range []bool{false, true, true, true} {
+		if sat, err := solver2.Check(); sat != want || err != nil {
+			t.Fatalf("at %d scopes: Check() = %v, %v, want %v", solver2.NumScopes(), sat, err, want)
+		}
+		if want {
+			m := solver2.Model()
+			if str, _ := m.Eval(ctx2.StringConst("s"), true).(String).AsString(); !strings.Contains(str, `a "(quoted)" ;string`) {
+				t.Errorf("s = %q", str)
+			}
+		}
+		if solver2.NumScopes() > 0 {
+			solver2.Pop()
+		}
+	}
+	if solver2.NumAssertions() != 2 {
+		t.Errorf("%d assertions after popping all scopes, want 2", solver2.NumAssertions())
+	}
+
+	// Saving the restored solver gives the same checkpoint.
+	solver.Pop()
+	solver.Pop()
+	solver.Pop()
+	var buf2 bytes.Buffer
+	if err := solver2.Save(&buf2); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	solver.Save(&buf)
+	if buf.String() != buf2.String() {
+		t.Errorf("re-saved checkpoint differs:\n%s\nvs\n%s", buf.String(), buf2.String())
+	}
+}
+
+func TestSolverRestoreErrors(t *testing.T) {
+	ctx := NewContext(nil)
+	solver := NewSolver(ctx)
+	for _, src := range []string{
+		"(assert true)",
+		checkpointHeader + "\n(assert (> x 0))",
+		checkpointHeader + "\n(assert (> 1 0)",
+	} {
+		solver.Assert(ctx.FromBool(true))
+		if err := solver.Restore(strings.NewReader(src)); err == nil {
+			t.Errorf("Restore(%q) succeeded", src)
+		}
+		if solver.NumAssertions() != 0 {
+			t.Errorf("Restore(%q) left %d assertions", src, solver.NumAssertions())
+		}
+	}
+}
+
+func TestSolverSaveRestoreNamed(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	solver := NewSolver(ctx)
+	solver.SetParams(NewSolverConfig(ctx).SetUint("random_seed", 7).SetFloat("random_freq", 0.5).SetString("phase", "caching").SetBool("core.minimize", true))
+	solver.AssertNamed(`x "positive"`, x.GT(ctx.Int(0)))
+	solver.Assert(x.LT(ctx.Int(10)))
+	solver.Push()
+	solver.AssertNamed("x negative", x.LT(ctx.Int(0)))
+
+	var buf bytes.Buffer
+	if err := solver.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx2 := NewContext(nil)
+	solver2 := NewSolver(ctx2)
+	if err := solver2.Restore(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("%v in\n%s", err, buf.String())
+	}
+	if sat, err := solver2.Check(); sat || err != nil {
+		t.Fatalf("Check() = %v, %v, want false", sat, err)
+	}
+	core := solver2.UnsatCoreNames()
+	sort.Strings(core)
+	if want := []string{`x "positive"`, "x negative"}; !reflect.DeepEqual(core, want) {
+		t.Errorf("UnsatCoreNames() = %q, want %q", core, want)
+	}
+	if want := map[string]interface{}{"random_seed": uint(7), "random_freq": 0.5, "phase": "caching", "core.minimize": true}; !reflect.DeepEqual(solver2.params, want) {
+		t.Errorf("restored params %v, want %v", solver2.params, want)
+	}
+
+	// The named assertion of the popped scope is forgotten.
+	solver2.Pop()
+	var buf2 bytes.Buffer
+	if err := solver2.Save(&buf2); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf2.String(), namedInfo); n != 1 {
+		t.Errorf("checkpoint after Pop has %d named assertions, want 1:\n%s", n, buf2.String())
+	}
+}
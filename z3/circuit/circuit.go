@@ -0,0 +1,170 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package circuit builds Boolean circuits, such as adders and sorting
+// networks, out of z3.Bool formulas.
+//
+// These are building blocks for custom encodings of arithmetic and
+// cardinality constraints, which on some problems outperform Z3's
+// built-in bit-vector and pseudo-Boolean reasoning.
+//
+// Multi-bit values are slices of z3.Bool in little-endian order:
+// element 0 is the least significant bit.
+package circuit
+
+import "github.com/ralscha/go-z3/z3"
+
+// A Sum is the output of an adder.
+type Sum struct {
+	Sum, Carry z3.Bool
+}
+
+// HalfAdder returns the sum and carry of adding bits a and b.
+func HalfAdder(a, b z3.Bool) Sum {
+	return Sum{a.Xor(b), a.And(b)}
+}
+
+// FullAdder returns the sum and carry of adding bits a, b, and carry
+// in bit c.
+func FullAdder(a, b, c z3.Bool) Sum {
+	ab := a.Xor(b)
+	return Sum{ab.Xor(c), a.And(b).Or(ab.And(c))}
+}
+
+// Add returns the sum of a and b, which must have the same length,
+// using a ripple-carry adder. The result has one more bit than a and
+// b, so it never overflows.
+func Add(a, b []z3.Bool) []z3.Bool {
+	if len(a) != len(b) || len(a) == 0 {
+		panic("circuit: Add of bit slices with different or zero lengths")
+	}
+	out := make([]z3.Bool, len(a)+1)
+	s := HalfAdder(a[0], b[0])
+	out[0] = s.Sum
+	for i := 1; i < len(a); i++ {
+		s = FullAdder(a[i], b[i], s.Carry)
+		out[i] = s.Sum
+	}
+	out[len(a)] = s.Carry
+	return out
+}
+
+// A Comparison is the output of a comparator.
+type Comparison struct {
+	Less, Equal z3.Bool
+}
+
+// Compare compares a and b, which must have the same length, as
+// unsigned integers.
+func Compare(a, b []z3.Bool) Comparison {
+	if len(a) != len(b) || len(a) == 0 {
+		panic("circuit: Compare of bit slices with different or zero lengths")
+	}
+	// Work up from the least significant bit: a < b if the higher
+	// bits are less, or they're equal and the lower bits are less.
+	c := Comparison{a[0].Not().And(b[0]), a[0].Eq(b[0])}
+	for i := 1; i < len(a); i++ {
+		eq := a[i].Eq(b[i])
+		c.Less = a[i].Not().And(b[i]).Or(eq.And(c.Less))
+		c.Equal = eq.And(c.Equal)
+	}
+	return c
+}
+
+// Mux returns in[sel], where sel is an unsigned integer. in must have
+// 1<<len(sel) elements.
+func Mux(sel []z3.Bool, in []z3.Bool) z3.Bool {
+	if len(in) != 1<<len(sel) {
+		panic("circuit: Mux inputs do not match selector width")
+	}
+	// Select on the most significant bit last, halving the inputs at
+	// each level.
+	for _, s := range sel {
+		next := make([]z3.Bool, len(in)/2)
+		for i := range next {
+			next[i] = s.IfThenElse(in[2*i+1], in[2*i]).(z3.Bool)
+		}
+		in = next
+	}
+	return in[0]
+}
+
+// Sort returns xs sorted so that all true values come before all false
+// values, using Batcher's odd-even merge sorting network. Element k of
+// the result is true if and only if more than k elements of xs are
+// true.
+func Sort(xs []z3.Bool) []z3.Bool {
+	if len(xs) == 0 {
+		return nil
+	}
+	// Pad to a power of two with false, which sorts to the end.
+	n := 1
+	for n < len(xs) {
+		n *= 2
+	}
+	ys := make([]z3.Bool, n)
+	copy(ys, xs)
+	f := xs[0].Context().FromBool(false)
+	for i := len(xs); i < n; i++ {
+		ys[i] = f
+	}
+	oddEvenSort(ys, 0, n)
+	return ys[:len(xs)]
+}
+
+// oddEvenSort sorts xs[lo:hi], whose length is a power of two, in
+// place.
+func oddEvenSort(xs []z3.Bool, lo, hi int) {
+	if hi-lo <= 1 {
+		return
+	}
+	mid := lo + (hi-lo)/2
+	oddEvenSort(xs, lo, mid)
+	oddEvenSort(xs, mid, hi)
+	oddEvenMerge(xs, lo, hi, 1)
+}
+
+// oddEvenMerge merges the two sorted halves of the elements of
+// xs[lo:hi] at stride r.
+func oddEvenMerge(xs []z3.Bool, lo, hi, r int) {
+	step := 2 * r
+	if step >= hi-lo-1 {
+		compareSwap(xs, lo, lo+r)
+		return
+	}
+	oddEvenMerge(xs, lo, hi, step)
+	oddEvenMerge(xs, lo+r, hi, step)
+	for i := lo + r; i+r < hi-1; i += step {
+		compareSwap(xs, i, i+r)
+	}
+}
+
+// compareSwap orders xs[i] and xs[j] so the true value comes first.
+func compareSwap(xs []z3.Bool, i, j int) {
+	xs[i], xs[j] = xs[i].Or(xs[j]), xs[i].And(xs[j])
+}
+
+// AtLeast returns a constraint that at least k of xs, which must not
+// be empty, are true, using a sorting network.
+func AtLeast(xs []z3.Bool, k int) z3.Bool {
+	switch {
+	case k <= 0:
+		return xs[0].Context().FromBool(true)
+	case k > len(xs):
+		return xs[0].Context().FromBool(false)
+	}
+	return Sort(xs)[k-1]
+}
+
+// AtMost returns a constraint that at most k of xs, which must not be
+// empty, are true, using a sorting network.
+func AtMost(xs []z3.Bool, k int) z3.Bool {
+	switch {
+	case k < 0:
+		return xs[0].Context().FromBool(false)
+	case k >= len(xs):
+		return xs[0].Context().FromBool(true)
+	}
+	return Sort(xs)[k].Not()
+}
@@ -0,0 +1,133 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package circuit
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// bitsOf returns the low n bits of x as constant Bools.
+func bitsOf(ctx *z3.Context, x, n int) []z3.Bool {
+	bs := make([]z3.Bool, n)
+	for i := range bs {
+		bs[i] = ctx.FromBool(x>>i&1 != 0)
+	}
+	return bs
+}
+
+func eval(t *testing.T, x z3.Bool) bool {
+	t.Helper()
+	y := x.Context().Simplify(x, nil).(z3.Bool)
+	b, ok := y.AsBool()
+	if !ok {
+		t.Fatalf("Simplify(%s) = %s, want bool literal", x, y)
+	}
+	return b
+}
+
+func evalInt(t *testing.T, bs []z3.Bool) int {
+	t.Helper()
+	x := 0
+	for i, b := range bs {
+		if eval(t, b) {
+			x |= 1 << i
+		}
+	}
+	return x
+}
+
+func TestAdders(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	for x := 0; x < 8; x++ {
+		in := bitsOf(ctx, x, 3)
+		fa := FullAdder(in[0], in[1], in[2])
+		if got, want := evalInt(t, []z3.Bool{fa.Sum, fa.Carry}), bits.OnesCount(uint(x)); got != want {
+			t.Errorf("FullAdder(%03b) = %d, want %d", x, got, want)
+		}
+		if x < 4 {
+			ha := HalfAdder(in[0], in[1])
+			if got, want := evalInt(t, []z3.Bool{ha.Sum, ha.Carry}), bits.OnesCount(uint(x)); got != want {
+				t.Errorf("HalfAdder(%02b) = %d, want %d", x, got, want)
+			}
+		}
+	}
+	for a := 0; a < 8; a++ {
+		for b := 0; b < 8; b++ {
+			if got := evalInt(t, Add(bitsOf(ctx, a, 3), bitsOf(ctx, b, 3))); got != a+b {
+				t.Errorf("Add(%d, %d) = %d", a, b, got)
+			}
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	for a := 0; a < 8; a++ {
+		for b := 0; b < 8; b++ {
+			c := Compare(bitsOf(ctx, a, 3), bitsOf(ctx, b, 3))
+			if eval(t, c.Less) != (a < b) || eval(t, c.Equal) != (a == b) {
+				t.Errorf("Compare(%d, %d): Less %s, Equal %s", a, b, c.Less, c.Equal)
+			}
+		}
+	}
+}
+
+func TestMux(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	in := bitsOf(ctx, 0xb4, 8)
+	for sel := 0; sel < 8; sel++ {
+		if got, want := eval(t, Mux(bitsOf(ctx, sel, 3), in)), 0xb4>>sel&1 != 0; got != want {
+			t.Errorf("Mux(%d) = %v, want %v", sel, got, want)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	for n := 1; n <= 6; n++ {
+		for x := 0; x < 1<<n; x++ {
+			out := Sort(bitsOf(ctx, x, n))
+			ones := bits.OnesCount(uint(x))
+			for k, b := range out {
+				if eval(t, b) != (k < ones) {
+					t.Errorf("Sort(%0*b)[%d] = %v", n, x, k, !(k < ones))
+				}
+			}
+		}
+	}
+}
+
+func TestCardinality(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	var xs []z3.Bool
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		xs = append(xs, ctx.BoolConst(name))
+	}
+	for k := -1; k <= 6; k++ {
+		// The network encodings must agree with the built-in ones.
+		s := z3.NewSolver(ctx)
+		want := ctx.FromBool(k <= 0)
+		if k > 0 {
+			want = ctx.AtLeast(xs, uint(k))
+		}
+		s.Assert(AtLeast(xs, k).Eq(want).Not())
+		if sat, err := s.Check(); sat || err != nil {
+			t.Errorf("AtLeast(%d) differs from Context.AtLeast", k)
+		}
+
+		s = z3.NewSolver(ctx)
+		want = ctx.FromBool(k >= 0)
+		if k >= 0 {
+			want = ctx.AtMost(xs, uint(k))
+		}
+		s.Assert(AtMost(xs, k).Eq(want).Not())
+		if sat, err := s.Check(); sat || err != nil {
+			t.Errorf("AtMost(%d) differs from Context.AtMost", k)
+		}
+	}
+}
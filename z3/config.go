@@ -9,59 +9,137 @@ package z3
 #include <z3.h>
 */
 import "C"
+import (
+	"fmt"
+	"strings"
+)
 
 // Config stores a set of configuration parameters. Configs are used
 // to configure many different objects in Z3.
 type Config struct {
 	m   map[string]interface{}
-	set func(name string, value interface{})
+	set func(name string, value interface{}) error
+
+	// desc describes the parameters p accepts. If desc is nil,
+	// parameter names are not checked.
+	desc []ParamDescr
+
+	// err is the first error from a Set method.
+	err error
+}
+
+// A ParamDescr describes a configuration parameter.
+type ParamDescr struct {
+	// Name is the name of the parameter.
+	Name string
+
+	// Type is the type of the parameter: "bool", "uint",
+	// "double", "symbol", "string", or "other" for kinds that
+	// cannot be set through the API. Use SetFloat for "double"
+	// parameters and SetString for "symbol" and "string"
+	// parameters.
+	Type string
+
+	// Doc is a short description of the parameter.
+	Doc string
 }
 
-type param struct {
-	name, typ, description string
+func newConfig(desc []ParamDescr) *Config {
+	return &Config{m: make(map[string]interface{}), desc: desc}
 }
 
-func newConfig(desc []param) *Config {
-	// TODO: API to access the parameter descriptions.
-	return &Config{m: make(map[string]interface{})}
+// Params returns descriptions of the parameters accepted by p.
+func (p *Config) Params() []ParamDescr {
+	return append([]ParamDescr(nil), p.desc...)
 }
 
+// SetBool sets the bool parameter name to value.
 func (p *Config) SetBool(name string, value bool) *Config {
-	if p.set != nil {
-		p.set(name, value)
-	} else {
-		p.m[name] = value
-	}
-	return p
+	return p.setParam(name, "bool", value)
 }
 
+// SetString sets the string or symbol parameter name to value.
 func (p *Config) SetString(name, value string) *Config {
-	if p.set != nil {
-		p.set(name, value)
-	} else {
-		p.m[name] = value
-	}
-	return p
+	return p.setParam(name, "string", value)
 }
 
+// SetUint sets the uint parameter name to value.
 func (p *Config) SetUint(name string, value uint) *Config {
-	if p.set != nil {
-		p.set(name, value)
-	} else {
-		p.m[name] = value
-	}
-	return p
+	return p.setParam(name, "uint", value)
 }
 
+// SetFloat sets the double parameter name to value.
 func (p *Config) SetFloat(name string, value float64) *Config {
+	return p.setParam(name, "double", value)
+}
+
+// Err returns the first error encountered by a Set method, such as an
+// unknown parameter name or a value of the wrong type. Validation is
+// advisory: a setting that fails it is still passed to Z3, which may
+// accept it (for example, if the parameter list is out of date for
+// the installed Z3), ignore it with a warning, or report an error when
+// the Config is used. For the Config returned by Context.Config, which
+// applies settings immediately, Err also reports errors from Z3.
+//
+// Parameter names are checked against Params. Names of the form
+// "module.param", such as "pp.decimal_precision", refer to Z3's
+// module parameters and are not checked.
+func (p *Config) Err() error {
+	return p.err
+}
+
+func (p *Config) setParam(name, typ string, value interface{}) *Config {
+	err := p.check(name, typ)
 	if p.set != nil {
-		p.set(name, value)
+		// The setting takes effect immediately, so Z3's own
+		// error, if any, is reported now rather than on use.
+		if serr := p.set(name, value); err == nil {
+			err = serr
+		}
 	} else {
 		p.m[name] = value
 	}
+	if err != nil && p.err == nil {
+		p.err = err
+	}
 	return p
 }
 
+// check returns an error if p does not accept a parameter name of
+// type typ.
+func (p *Config) check(name, typ string) error {
+	if p.desc == nil || strings.Contains(name, ".") {
+		return nil
+	}
+	for _, d := range p.desc {
+		if d.Name != name {
+			continue
+		}
+		if d.Type == typ || typ == "string" && d.Type == "symbol" {
+			return nil
+		}
+		return fmt.Errorf("z3: parameter %s has type %s, not %s", name, d.Type, typ)
+	}
+	return fmt.Errorf("z3: unknown parameter %q", name)
+}
+
+// Help returns a description of the parameters accepted by p, one per
+// line.
+func (p *Config) Help() string {
+	var buf strings.Builder
+	for _, d := range p.desc {
+		fmt.Fprintf(&buf, "%s (%s) %s\n", d.Name, d.Type, d.Doc)
+	}
+	return buf.String()
+}
+
+// SetMemoryMaxSize sets the memory_max_size parameter, which is a
+// hard limit on Z3's memory use in megabytes. This is process-wide;
+// see the package-level SetMemoryMaxSize.
+func (p *Config) SetMemoryMaxSize(mb uint) *Config {
+	return p.SetUint("memory_max_size", mb)
+}
+
 func (p *Config) toC(ctx *Context) C.Z3_params {
 	var c C.Z3_params
 	ctx.do(func() {
@@ -101,3 +179,31 @@ func (p *Config) toC(ctx *Context) C.Z3_params {
 	ok = true
 	return c
 }
+
+// paramDescrs returns the descriptions in d. This must be called with
+// the ctx.lock held.
+func paramDescrs(ctx *Context, d C.Z3_param_descrs) []ParamDescr {
+	C.Z3_param_descrs_inc_ref(ctx.c, d)
+	defer C.Z3_param_descrs_dec_ref(ctx.c, d)
+	res := make([]ParamDescr, C.Z3_param_descrs_size(ctx.c, d))
+	for i := range res {
+		sym := C.Z3_param_descrs_get_name(ctx.c, d, C.uint(i))
+		res[i].Name = C.GoString(C.Z3_get_symbol_string(ctx.c, sym))
+		switch C.Z3_param_descrs_get_kind(ctx.c, d, sym) {
+		case C.Z3_PK_UINT:
+			res[i].Type = "uint"
+		case C.Z3_PK_BOOL:
+			res[i].Type = "bool"
+		case C.Z3_PK_DOUBLE:
+			res[i].Type = "double"
+		case C.Z3_PK_SYMBOL:
+			res[i].Type = "symbol"
+		case C.Z3_PK_STRING:
+			res[i].Type = "string"
+		default:
+			res[i].Type = "other"
+		}
+		res[i].Doc = C.GoString(C.Z3_param_descrs_get_documentation(ctx.c, d, sym))
+	}
+	return res
+}
@@ -0,0 +1,178 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package constraints provides global constraints, as found in
+// constraint programming, encoded as Z3 formulas.
+//
+// Each function returns a z3.Bool to assert in a Solver or Optimize,
+// or a term to use in other constraints. Some encodings introduce
+// fresh auxiliary constants, which appear in models but can be
+// ignored.
+//
+// Integer variables are represented as z3.Int. Constraints that
+// involve indexes, such as Element and Circuit, number positions from
+// 0.
+//
+// Scheduling problems are modeled with Intervals, which the NoOverlap
+// and Cumulative constraints assign to shared resources.
+package constraints
+
+import (
+	"fmt"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// eq returns x == y for Values of any sort.
+func eq(ctx *z3.Context, x, y z3.Value) z3.Bool {
+	return ctx.Distinct(x, y).Not()
+}
+
+// inRange returns 0 <= x < n.
+func inRange(ctx *z3.Context, x z3.Int, n int) z3.Bool {
+	return x.GE(ctx.Int(0)).And(x.LT(ctx.Int(n)))
+}
+
+// Element returns xs[index] as a term, along with a constraint that
+// index is in range. If index is out of range, the value of the term
+// is unspecified, so callers typically assert valid.
+//
+// Element panics if xs is empty.
+func Element[T z3.Value](index z3.Int, xs []T) (v T, valid z3.Bool) {
+	if len(xs) == 0 {
+		panic("constraints: Element of empty slice")
+	}
+	ctx := index.Context()
+	// Build an if-then-else chain, which Z3 handles better than a
+	// disjunction of cases.
+	v = xs[len(xs)-1]
+	for i := len(xs) - 2; i >= 0; i-- {
+		v = index.Eq(ctx.Int(i)).IfThenElse(xs[i], v).(T)
+	}
+	return v, inRange(ctx, index, len(xs))
+}
+
+// Table returns a constraint that vars takes the values of one of the
+// rows of tuples. Each row must have len(vars) elements.
+func Table(vars []z3.Int, tuples [][]int64) z3.Bool {
+	if len(vars) == 0 {
+		panic("constraints: Table of no variables")
+	}
+	ctx := vars[0].Context()
+	rows := make([]z3.Bool, len(tuples))
+	for i, t := range tuples {
+		if len(t) != len(vars) {
+			panic(fmt.Sprintf("constraints: Table row %d has %d values, want %d", i, len(t), len(vars)))
+		}
+		cols := make([]z3.Bool, len(t))
+		for j, x := range t {
+			cols[j] = vars[j].Eq(ctx.Int64(x))
+		}
+		rows[i] = ctx.FromBool(true).And(cols...)
+	}
+	return ctx.FromBool(false).Or(rows...)
+}
+
+// Circuit returns a constraint that next describes a single cycle
+// through all of the nodes 0, ..., len(next)-1, where next[i] is the
+// node that follows node i.
+//
+// Circuit rules out sub-cycles by numbering the nodes in the order
+// the cycle visits them from node 0, using fresh constants.
+func Circuit(next []z3.Int) z3.Bool {
+	if len(next) == 0 {
+		panic("constraints: Circuit of no nodes")
+	}
+	ctx := next[0].Context()
+	n := len(next)
+	var conds []z3.Bool
+	vals := make([]z3.Value, n)
+	for i, x := range next {
+		conds = append(conds, inRange(ctx, x, n))
+		vals[i] = x
+	}
+	if n == 1 {
+		return conds[0]
+	}
+	conds = append(conds, ctx.Distinct(vals...))
+
+	// order[i] is the position of node i in the cycle.
+	order := make([]z3.Int, n)
+	for i := range order {
+		order[i] = ctx.FreshConst("order", ctx.IntSort()).(z3.Int)
+	}
+	conds = append(conds, order[0].Eq(ctx.Int(0)))
+	for i := range next {
+		conds = append(conds, next[i].NE(ctx.Int(i)))
+		for j := 1; j < n; j++ {
+			conds = append(conds, next[i].Eq(ctx.Int(j)).Implies(order[j].Eq(order[i].Add(ctx.Int(1)))))
+		}
+	}
+	return ctx.FromBool(true).And(conds...)
+}
+
+// Inverse returns a constraint that f and g are inverse permutations:
+// f[i] == j if and only if g[j] == i. f and g must have the same
+// length.
+func Inverse(f, g []z3.Int) z3.Bool {
+	if len(f) != len(g) {
+		panic("constraints: Inverse of slices with different lengths")
+	}
+	if len(f) == 0 {
+		panic("constraints: Inverse of empty slices")
+	}
+	ctx := f[0].Context()
+	n := len(f)
+	var conds []z3.Bool
+	for i := 0; i < n; i++ {
+		conds = append(conds, inRange(ctx, f[i], n), inRange(ctx, g[i], n))
+		for j := 0; j < n; j++ {
+			conds = append(conds, f[i].Eq(ctx.Int(j)).Eq(g[j].Eq(ctx.Int(i))))
+		}
+	}
+	return ctx.FromBool(true).And(conds...)
+}
+
+// LexLess returns a constraint that xs is lexicographically less than
+// ys. As with Go strings, a proper prefix is less than the longer
+// slice.
+func LexLess[T z3.Numeric](n z3.Num[T], xs, ys []T) z3.Bool {
+	return lex(n, xs, ys, true)
+}
+
+// LexLessEq returns a constraint that xs is lexicographically less
+// than or equal to ys.
+func LexLessEq[T z3.Numeric](n z3.Num[T], xs, ys []T) z3.Bool {
+	return lex(n, xs, ys, false)
+}
+
+func lex[T z3.Numeric](n z3.Num[T], xs, ys []T, strict bool) z3.Bool {
+	var ctx *z3.Context
+	if len(xs) > 0 {
+		ctx = any(xs[0]).(z3.Value).Context()
+	} else if len(ys) > 0 {
+		ctx = any(ys[0]).(z3.Value).Context()
+	} else {
+		panic("constraints: lexicographic comparison of empty slices")
+	}
+	m := min(len(xs), len(ys))
+	// Work backwards from the comparison of the remaining
+	// lengths.
+	res := ctx.FromBool(len(xs) < len(ys) || !strict && len(xs) == len(ys))
+	for i := m - 1; i >= 0; i-- {
+		res = n.LT(xs[i], ys[i]).Or(n.Eq(xs[i], ys[i]).And(res))
+	}
+	return res
+}
+
+// Count returns the number of elements of xs equal to v.
+func Count[T z3.Value](xs []T, v T) z3.Int {
+	ctx := v.Context()
+	zero, one := ctx.Int(0), ctx.Int(1)
+	terms := make([]z3.Int, len(xs))
+	for i, x := range xs {
+		terms[i] = eq(ctx, x, v).IfThenElse(one, zero).(z3.Int)
+	}
+	return zero.Add(terms...)
+}
@@ -0,0 +1,192 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package constraints
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func ints(ctx *z3.Context, prefix string, n int) []z3.Int {
+	xs := make([]z3.Int, n)
+	for i := range xs {
+		xs[i] = ctx.IntConst(fmt.Sprintf("%s%d", prefix, i))
+	}
+	return xs
+}
+
+// solutions returns all assignments of vars that satisfy cond.
+func solutions(t *testing.T, cond z3.Bool, vars []z3.Int) [][]int64 {
+	t.Helper()
+	ctx := cond.Context()
+	s := z3.NewSolver(ctx)
+	s.Assert(cond)
+	var sols [][]int64
+	for {
+		sat, err := s.Check()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sat {
+			return sols
+		}
+		m := s.Model()
+		sol := make([]int64, len(vars))
+		var block []z3.Bool
+		for i, v := range vars {
+			x, _, _ := m.EvalAsInt64(v, true)
+			sol[i] = x
+			block = append(block, v.NE(ctx.Int64(x)))
+		}
+		sols = append(sols, sol)
+		s.Assert(ctx.FromBool(false).Or(block...))
+		if len(sols) > 1000 {
+			t.Fatal("too many solutions")
+		}
+	}
+}
+
+func TestElement(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	xs := []z3.Int{ctx.Int(10), ctx.Int(20), ctx.Int(30)}
+	i := ctx.IntConst("i")
+	v, valid := Element(i, xs)
+	sols := solutions(t, valid.And(v.GE(ctx.Int(20))), []z3.Int{i})
+	if len(sols) != 2 {
+		t.Errorf("got solutions %v, want i=1 and i=2", sols)
+	}
+	sols = solutions(t, valid.And(v.Eq(ctx.Int(30))), []z3.Int{i})
+	if len(sols) != 1 || sols[0][0] != 2 {
+		t.Errorf("got solutions %v, want i=2", sols)
+	}
+}
+
+func TestTable(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	vars := ints(ctx, "x", 2)
+	tuples := [][]int64{{1, 2}, {3, 4}, {5, 6}}
+	sols := solutions(t, Table(vars, tuples), vars)
+	if len(sols) != len(tuples) {
+		t.Errorf("got solutions %v, want %v", sols, tuples)
+	}
+	sols = solutions(t, Table(vars, tuples).And(vars[0].GT(ctx.Int(2)), vars[1].LT(ctx.Int(6))), vars)
+	if len(sols) != 1 || sols[0][0] != 3 || sols[0][1] != 4 {
+		t.Errorf("got solutions %v, want [[3 4]]", sols)
+	}
+}
+
+func TestCircuit(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	for n, want := range []int{1: 1, 2: 1, 3: 2, 4: 6} {
+		if n == 0 {
+			continue
+		}
+		next := ints(ctx, fmt.Sprintf("next%d_", n), n)
+		sols := solutions(t, Circuit(next), next)
+		// There are (n-1)! Hamiltonian cycles.
+		if len(sols) != want {
+			t.Errorf("n=%d: got %d circuits, want %d: %v", n, len(sols), want, sols)
+		}
+		for _, sol := range sols {
+			// Follow the cycle from 0.
+			seen, node := 0, int64(0)
+			for {
+				node = sol[node]
+				seen++
+				if node == 0 {
+					break
+				}
+			}
+			if seen != n {
+				t.Errorf("n=%d: %v is not a single cycle", n, sol)
+			}
+		}
+	}
+}
+
+func TestInverse(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	f, g := ints(ctx, "f", 3), ints(ctx, "g", 3)
+	sols := solutions(t, Inverse(f, g), append(f, g...))
+	if len(sols) != 6 {
+		t.Errorf("got %d solutions, want 6 permutations", len(sols))
+	}
+	for _, sol := range sols {
+		for i := 0; i < 3; i++ {
+			if sol[3+sol[i]] != int64(i) {
+				t.Errorf("%v: g[f[%d]] != %d", sol, i, i)
+			}
+		}
+	}
+}
+
+func TestLex(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	n := z3.Num[z3.Int]{}
+	vec := func(xs ...int) []z3.Int {
+		var v []z3.Int
+		for _, x := range xs {
+			v = append(v, ctx.Int(x))
+		}
+		return v
+	}
+	for _, test := range []struct {
+		xs, ys    []int
+		less, leq bool
+	}{
+		{[]int{1, 2, 3}, []int{1, 2, 4}, true, true},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, false, true},
+		{[]int{1, 3}, []int{1, 2, 9}, false, false},
+		{[]int{1, 2}, []int{1, 2, 0}, true, true},
+		{[]int{1, 2, 0}, []int{1, 2}, false, false},
+		{[]int{}, []int{0}, true, true},
+	} {
+		xs, ys := vec(test.xs...), vec(test.ys...)
+		if got := simplify(t, LexLess(n, xs, ys)); got != test.less {
+			t.Errorf("LexLess(%v, %v) = %v", test.xs, test.ys, got)
+		}
+		if got := simplify(t, LexLessEq(n, xs, ys)); got != test.leq {
+			t.Errorf("LexLessEq(%v, %v) = %v", test.xs, test.ys, got)
+		}
+	}
+
+	// Symmetry breaking: x0 x1 <lex y0 y1 with all in {0, 1}.
+	x, y := ints(ctx, "x", 2), ints(ctx, "y", 2)
+	all := append(append([]z3.Int{}, x...), y...)
+	cond := LexLess(n, x, y)
+	for _, v := range all {
+		cond = cond.And(v.GE(ctx.Int(0)), v.LE(ctx.Int(1)))
+	}
+	if sols := solutions(t, cond, all); len(sols) != 6 {
+		t.Errorf("got %d solutions, want 6", len(sols))
+	}
+}
+
+func simplify(t *testing.T, x z3.Bool) bool {
+	t.Helper()
+	y := x.Context().Simplify(x, nil).(z3.Bool)
+	b, ok := y.AsBool()
+	if !ok {
+		t.Fatalf("Simplify(%s) = %s, want bool literal", x, y)
+	}
+	return b
+}
+
+func TestCount(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	xs := ints(ctx, "x", 3)
+	cond := Count(xs, ctx.Int(7)).Eq(ctx.Int(2))
+	for _, x := range xs {
+		cond = cond.And(x.GE(ctx.Int(6)), x.LE(ctx.Int(7)))
+	}
+	if sols := solutions(t, cond, xs); len(sols) != 3 {
+		t.Errorf("got %d solutions, want 3", len(sols))
+	}
+	if !simplify(t, Count([]z3.Int{}, ctx.Int(1)).Eq(ctx.Int(0))) {
+		t.Errorf("Count of nothing != 0")
+	}
+}
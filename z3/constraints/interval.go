@@ -0,0 +1,111 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package constraints
+
+import "github.com/ralscha/go-z3/z3"
+
+// An Interval is a task in a schedule, occupying the time from Start
+// up to but not including End = Start + Duration.
+//
+// An optional interval may be left out of the schedule. Scheduling
+// constraints only apply to intervals that are Present.
+type Interval struct {
+	Start, Duration, End z3.Int
+
+	// Present is true if the interval is part of the schedule. It
+	// is the constant true for intervals that are not optional.
+	Present z3.Bool
+}
+
+// NewInterval returns an interval whose start is a new constant named
+// name and which lasts for duration. duration may be a constant or
+// any other Int term, but it must not be negative.
+func NewInterval(name string, duration z3.Int) Interval {
+	ctx := duration.Context()
+	start := ctx.IntConst(name)
+	return Interval{
+		Start:    start,
+		Duration: duration,
+		End:      start.Add(duration),
+		Present:  ctx.FromBool(true),
+	}
+}
+
+// NewOptionalInterval is like NewInterval, but the interval is
+// present only if a new Bool constant named name + ".present" is true.
+func NewOptionalInterval(name string, duration z3.Int) Interval {
+	iv := NewInterval(name, duration)
+	iv.Present = duration.Context().BoolConst(name + ".present")
+	return iv
+}
+
+// Within returns a constraint that iv, if present, lies within the
+// time from lo up to hi.
+func (iv Interval) Within(lo, hi z3.Int) z3.Bool {
+	return iv.Present.Implies(iv.Start.GE(lo).And(iv.End.LE(hi)))
+}
+
+// Before returns a constraint that iv ends before next starts, if
+// they are both present.
+func (iv Interval) Before(next Interval) z3.Bool {
+	return iv.Present.And(next.Present).Implies(iv.End.LE(next.Start))
+}
+
+// NoOverlap returns a constraint that no two of the present intervals
+// in ivs overlap, as when they all need the same machine or person.
+//
+// Each pair of intervals gets a single disjunction, "a ends before b
+// starts or b ends before a starts", guarded by their presence when
+// either is optional.
+func NoOverlap(ivs []Interval) z3.Bool {
+	if len(ivs) == 0 {
+		panic("constraints: NoOverlap of no intervals")
+	}
+	ctx := ivs[0].Start.Context()
+	var conds []z3.Bool
+	for i := range ivs {
+		for j := i + 1; j < len(ivs); j++ {
+			a, b := ivs[i], ivs[j]
+			sep := a.End.LE(b.Start).Or(b.End.LE(a.Start))
+			if both := a.Present.And(b.Present); !isTrue(ctx, both) {
+				sep = both.Implies(sep)
+			}
+			conds = append(conds, sep)
+		}
+	}
+	return ctx.FromBool(true).And(conds...)
+}
+
+// Cumulative returns a constraint that at any time, the total demand
+// of the present intervals in ivs that are running is at most
+// capacity. demands[i] is the demand of ivs[i] and must not be
+// negative.
+//
+// Since the total demand can only increase when an interval starts,
+// Cumulative only checks the demand at each start time.
+func Cumulative(ivs []Interval, demands []z3.Int, capacity z3.Int) z3.Bool {
+	if len(ivs) != len(demands) {
+		panic("constraints: Cumulative with different numbers of intervals and demands")
+	}
+	ctx := capacity.Context()
+	zero := ctx.Int(0)
+	var conds []z3.Bool
+	for _, at := range ivs {
+		// Demand of all intervals running at at.Start.
+		use := make([]z3.Int, len(ivs))
+		for i, iv := range ivs {
+			running := iv.Present.And(iv.Start.LE(at.Start), at.Start.LT(iv.End))
+			use[i] = running.IfThenElse(demands[i], zero).(z3.Int)
+		}
+		conds = append(conds, at.Present.Implies(zero.Add(use...).LE(capacity)))
+	}
+	return ctx.FromBool(true).And(conds...)
+}
+
+// isTrue reports whether x simplifies to true.
+func isTrue(ctx *z3.Context, x z3.Bool) bool {
+	b, ok := ctx.Simplify(x, nil).(z3.Bool).AsBool()
+	return ok && b
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package constraints
+
+import (
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestNoOverlap(t *testing.T) {
+	// The "organize your day" puzzle.
+	ctx := z3.NewContext(nil)
+	work := NewInterval("work", ctx.Int(4))
+	mail := NewInterval("mail", ctx.Int(1))
+	bank := NewInterval("bank", ctx.Int(2))
+	shopping := NewInterval("shopping", ctx.Int(1))
+	ivs := []Interval{work, mail, bank, shopping}
+
+	s := z3.NewSolver(ctx)
+	for _, iv := range ivs {
+		s.Assert(iv.Within(ctx.Int(9), ctx.Int(17)))
+	}
+	s.Assert(NoOverlap(ivs))
+	s.Assert(work.Start.GE(ctx.Int(11)))
+	s.Assert(mail.Before(work))
+	s.Assert(bank.Before(shopping))
+	sat, err := s.Check()
+	if !sat || err != nil {
+		t.Fatalf("Check() = %v, %v", sat, err)
+	}
+	m := s.Model()
+	type span struct{ start, end int64 }
+	var spans []span
+	for _, iv := range ivs {
+		start, _, _ := m.EvalAsInt64(iv.Start, true)
+		end, _, _ := m.EvalAsInt64(iv.End, true)
+		spans = append(spans, span{start, end})
+	}
+	for i, a := range spans {
+		for _, b := range spans[i+1:] {
+			if a.start < b.end && b.start < a.end {
+				t.Errorf("intervals %v and %v overlap", a, b)
+			}
+		}
+	}
+
+	// Five hours of tasks don't fit in four.
+	s.Assert(ctx.FromBool(true).And(work.Within(ctx.Int(11), ctx.Int(15)), mail.Within(ctx.Int(11), ctx.Int(15))))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("Check() = %v, %v; want unsat", sat, err)
+	}
+}
+
+func TestNoOverlapOptional(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	var ivs []Interval
+	var present []z3.Bool
+	for _, name := range []string{"a", "b", "c"} {
+		iv := NewOptionalInterval(name, ctx.Int(2))
+		ivs = append(ivs, iv)
+		present = append(present, iv.Present)
+	}
+	s := z3.NewSolver(ctx)
+	for _, iv := range ivs {
+		s.Assert(iv.Within(ctx.Int(0), ctx.Int(4)))
+	}
+	s.Assert(NoOverlap(ivs))
+
+	// At most two of the intervals fit.
+	s.Assert(ctx.AtLeast(present, 2))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Errorf("two present: Check() = %v, %v; want sat", sat, err)
+	}
+	s.Assert(ctx.AtLeast(present, 3))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("three present: Check() = %v, %v; want unsat", sat, err)
+	}
+}
+
+func TestCumulative(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	var ivs []Interval
+	var demands []z3.Int
+	for _, name := range []string{"a", "b", "c"} {
+		ivs = append(ivs, NewInterval(name, ctx.Int(2)))
+		demands = append(demands, ctx.Int(1))
+	}
+	for _, test := range []struct {
+		capacity, horizon int
+		sat               bool
+	}{
+		{3, 2, true},
+		{2, 2, false},
+		{2, 4, true},
+		{1, 4, false},
+		{1, 6, true},
+	} {
+		s := z3.NewSolver(ctx)
+		for _, iv := range ivs {
+			s.Assert(iv.Within(ctx.Int(0), ctx.Int(test.horizon)))
+		}
+		s.Assert(Cumulative(ivs, demands, ctx.Int(test.capacity)))
+		sat, err := s.Check()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sat != test.sat {
+			t.Errorf("capacity %d, horizon %d: sat = %v, want %v", test.capacity, test.horizon, sat, test.sat)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
@@ -53,6 +55,31 @@ type Context struct {
 	// error. Use Context.do to acquire this around a Z3 operation
 	// and panic if the operation has an error status.
 	lock sync.Mutex
+
+	// closed is set once Close has deleted the Z3 context. It is
+	// protected by lock.
+	closed bool
+
+	// scope is the innermost active Scope, or nil. It is
+	// protected by lock.
+	scope *Scope
+
+	// freshNamer, if non-nil, names fresh constants and
+	// functions. It is protected by lock.
+	freshNamer FreshNamer
+
+	// trace, if non-nil, is called after each call into Z3. It
+	// is protected by lock.
+	trace TraceFunc
+	// interruptLock serializes Interrupt, which must not wait for
+	// lock, with the deletion of the Z3 context. deleted is set
+	// under it by Close and read by Interrupt.
+	interruptLock sync.Mutex
+	deleted       atomic.Bool
+
+	// checks is the number of Checks run by Solvers and Optimizes
+	// of this context, for ContextPool. It is protected by lock.
+	checks int
 }
 
 type contextImpl struct {
@@ -76,10 +103,7 @@ func goZ3ErrorHandler(ctx C.Z3_context, e C.Z3_error_code) {
 	// Z3_get_error_msg.
 
 	msg := C.Z3_get_error_msg(ctx, e)
-	// TODO: Lift the Z3 errors to better Go errors. At least wrap
-	// the string in a type and consider using the error code to
-	// determine which of different error types to use.
-	panic(C.GoString(msg))
+	panic(&Error{ErrorCode(e), C.GoString(msg)})
 }
 
 // NewContext returns a new Z3 context with the given configuration.
@@ -92,6 +116,10 @@ func NewContext(config *Config) *Context {
 	defer C.Z3_del_config(cfg)
 	if config != nil {
 		for key, val := range config.m {
+			if globalParams[key] {
+				setGlobalParam(key, val)
+				continue
+			}
 			ckey, cval := C.CString(key), C.CString(fmt.Sprint(val))
 			defer C.free(unsafe.Pointer(ckey))
 			defer C.free(unsafe.Pointer(cval))
@@ -110,8 +138,16 @@ func NewContext(config *Config) *Context {
 		value{},
 		nil,
 		sync.Mutex{},
+		false,
+		nil,
+		nil,
+		nil,
+		sync.Mutex{},
+		atomic.Bool{},
+		0,
 	}
-	// Install an error handler that turns errors into Go panics.
+	// Install an error handler that turns errors into Go panics
+	// with an *Error, which Catch can recover.
 	// This error handler is equivalent to a longjmp on the C++
 	// side, but Z3 is actually designed to handle that, which is
 	// nice because it saves us the trouble of checking the
@@ -132,13 +168,24 @@ func NewContext(config *Config) *Context {
 //
 // Most of these can be changed after a Context is created using
 // Context.Config().
+//
+// Config.Params and Config.Help list all context parameters. Setting
+// a parameter that is not in this list, or with the wrong type, is
+// reported by Config.Err.
+//
+// The memory limits memory_max_size and memory_high_watermark_mb can
+// also be set with a Config (see Config.SetMemoryMaxSize), but they
+// apply to the whole process, not just the new Context. When a Check
+// exceeds them, it returns an *ErrResourceExhausted instead of
+// aborting.
 func NewContextConfig() *Config {
 	// Based on context_params.cpp:collect_param_descrs.
 	// Unfortunately, there's no way to access this from the API.
-	return newConfig([]param{
+	return newConfig([]ParamDescr{
 		{"timeout", "uint", "Timeout in milliseconds used for solvers"},
 		{"rlimit", "uint", "Resource limit used for solvers"},
 		{"well_sorted_check", "bool", "Type checker"},
+		{"type_check", "bool", "Type checker (alias for well_sorted_check)"},
 		{"auto_config", "bool", "Use heuristics to automatically select solver and configure it"},
 		{"model_validate", "bool", "Validate models produced by solvers"},
 		{"dump_models", "bool", "Dump models whenever check-sat returns sat"},
@@ -146,10 +193,16 @@ func NewContextConfig() *Config {
 		{"trace_file_name", "string", "Trace out file for VCC traces"},
 		{"debug_ref_count", "bool", "Debug support for AST reference counting"},
 		{"smtlib2_compliant", "bool", "Enable SMT-LIB 2.0 compliance"},
+		{"encoding", "symbol", "String encoding used internally: unicode, bmp, or ascii"},
 		// Solver parameters.
 		{"proof", "bool", "Enable proof generation"},
 		{"model", "bool", "Enable model generation for solvers"},
 		{"unsat_core", "bool", "Enable unsat-core generation for solvers"},
+		// Global parameters. These affect all Contexts.
+		{"memory_max_size", "uint", "Hard memory limit in megabytes (process-wide)"},
+		{"memory_high_watermark_mb", "uint", "Soft memory limit in megabytes (process-wide)"},
+		{"parallel.enable", "bool", "Enable parallel solving (process-wide)"},
+		{"parallel.threads.max", "uint", "Maximum number of threads for parallel solving (process-wide)"},
 	})
 }
 
@@ -161,19 +214,34 @@ func (ctx *Context) Config() *Config {
 	return cfg
 }
 
-func (ctx *Context) setParam(name string, val interface{}) {
+func (ctx *Context) setParam(name string, val interface{}) error {
+	if globalParams[name] {
+		setGlobalParam(name, val)
+		return nil
+	}
 	cname, cval := C.CString(name), C.CString(fmt.Sprint(val))
 	defer C.free(unsafe.Pointer(cname))
 	defer C.free(unsafe.Pointer(cval))
-	ctx.do(func() {
-		C.Z3_update_param_value(ctx.c, cname, cval)
+	return ctx.Catch(func() {
+		ctx.do(func() {
+			C.Z3_update_param_value(ctx.c, cname, cval)
+		})
 	})
 }
 
 // Interrupt stops the current solver, simplifier, or tactic being
-// executed by ctx.
+// executed by ctx. It may be called from any goroutine, even while
+// another one is using ctx. After Close, Interrupt does nothing, so a
+// watchdog goroutine need not coordinate with the owner of ctx.
 func (ctx *Context) Interrupt() {
-	C.Z3_interrupt(ctx.c)
+	if ctx.deleted.Load() {
+		return
+	}
+	ctx.interruptLock.Lock()
+	defer ctx.interruptLock.Unlock()
+	if !ctx.deleted.Load() {
+		C.Z3_interrupt(ctx.c)
+	}
 	runtime.KeepAlive(ctx)
 }
 
@@ -210,11 +278,80 @@ func (ctx *Context) SetExtra(key, value interface{}) {
 // means we need to synchronize both reference counts and the
 // per-context last error state.
 func (ctx *Context) do(f func()) {
+	ctx.doArgs(nil, f)
+}
+
+// doArgs is like do, but also passes args, the operands of the call
+// into Z3 made by f, to ctx's TraceFunc.
+func (ctx *Context) doArgs(args []C.Z3_ast, f func()) {
 	ctx.lock.Lock()
+	if trace := ctx.trace; trace != nil {
+		// This runs after the deferred Unlock below.
+		op, start := traceOp(), time.Now()
+		var asts []AST
+		defer func() { trace(op, asts, time.Since(start)) }()
+		call := f
+		f = func() {
+			// Wrap the operands with the lock held, once the
+			// Context is known to be open.
+			for _, arg := range args {
+				asts = append(asts, wrapAST(ctx, arg))
+			}
+			start = time.Now()
+			call()
+		}
+	}
 	defer ctx.lock.Unlock()
+	if ctx.closed {
+		panic("z3: use of closed Context")
+	}
 	f()
 }
 
+// numChecks returns the number of Checks run in ctx.
+func (ctx *Context) numChecks() int {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	return ctx.checks
+}
+
+// LogID returns the identifier of ctx in Z3's interaction log. This
+// can be passed to z3log.Log.Context to select the calls made on ctx.
+func (ctx *Context) LogID() string {
+	return fmt.Sprintf("%p", unsafe.Pointer(ctx.c))
+}
+
+// release calls f with the per-context lock held, unless ctx has been
+// closed. This is used to release references to Z3 objects, which is
+// unnecessary once the whole context has been deleted.
+func (ctx *Context) release(f func()) {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	if !ctx.closed {
+		f()
+	}
+}
+
+// Close deletes ctx and frees all Z3 resources associated with it,
+// without waiting for the garbage collector to finalize ctx.
+//
+// All objects created from ctx, including Values, Sorts, Solvers and
+// Models, become invalid, and using them or ctx after Close panics.
+// Close is idempotent.
+func (ctx *Context) Close() {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	if ctx.closed {
+		return
+	}
+	ctx.closed = true
+	runtime.SetFinalizer(ctx.contextImpl, nil)
+	ctx.interruptLock.Lock()
+	defer ctx.interruptLock.Unlock()
+	ctx.deleted.Store(true)
+	C.Z3_del_context(ctx.c)
+}
+
 // symbol interns name as a Z3 symbol.
 func (ctx *Context) symbol(name string) C.Z3_symbol {
 	if sym, ok := ctx.syms[name]; ok {
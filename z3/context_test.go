@@ -5,8 +5,11 @@
 package z3
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -32,3 +35,112 @@ func TestErrorHandling(t *testing.T) {
 	y := ctx.BVConst("y", 2)
 	expectPanic(t, "are incompatible", func() { x.Eq(y) })
 }
+
+func TestCatch(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BVConst("x", 1)
+	y := ctx.BVConst("y", 2)
+	err := ctx.Catch(func() { x.Eq(y) })
+	z3err, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if !strings.Contains(z3err.Message, "are incompatible") {
+		t.Errorf("unexpected message %q", z3err.Message)
+	}
+	if s := z3err.Code.String(); strings.HasPrefix(s, "ErrorCode(") {
+		t.Errorf("unexpected error code %s", s)
+	}
+
+	// The context remains usable after an error.
+	if err := ctx.Catch(func() { x.Eq(x) }); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	expectPanic(t, "^other$", func() { ctx.Catch(func() { panic("other") }) })
+}
+
+func TestMethodE(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BVConst("x", 8)
+	y := ctx.BVConst("y", 16)
+
+	if z, err := x.AddE(x); err != nil || z.String() != "(bvadd x x)" {
+		t.Errorf("AddE = %v, %v", z, err)
+	}
+
+	// Z3 reports the sort mismatch.
+	_, err := x.AddE(y)
+	if _, ok := err.(*Error); !ok {
+		t.Errorf("AddE with mismatched sorts: got %v, want *Error", err)
+	}
+
+	// Invalid arguments are caught before calling Z3.
+	other := NewContext(nil)
+	_, err = x.AddE(other.BVConst("x", 8))
+	if !errors.Is(err, ErrContextMismatch) || err.Error() != "z3: BV.Add: argument r: value from a different Context" {
+		t.Errorf("AddE with other context: got %v", err)
+	}
+	_, err = x.ConcatE(BV{})
+	var argErr *ArgError
+	if !errors.As(err, &argErr) || argErr.Arg != "r" || !errors.Is(err, ErrZeroValue) {
+		t.Errorf("ConcatE with zero value: got %v", err)
+	}
+	_, err = ctx.DistinctE(x, x, nil)
+	if !errors.As(err, &argErr) || argErr.Arg != "vals[2]" {
+		t.Errorf("DistinctE with nil value: got %v", err)
+	}
+	if _, err := ctx.IntConst("i").AddE(ctx.Int(1), ctx.Int(2)); err != nil {
+		t.Errorf("variadic AddE: %v", err)
+	}
+}
+
+func TestInterruptClose(t *testing.T) {
+	// Interrupt from a watchdog races with Close.
+	for i := 0; i < 20; i++ {
+		ctx := NewContext(nil)
+		done := make(chan bool)
+		go func() {
+			for j := 0; j < 100; j++ {
+				ctx.Interrupt()
+			}
+			close(done)
+		}()
+		ctx.Close()
+		<-done
+	}
+}
+
+func TestClose(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+
+	solver := NewSolver(ctx)
+	solver.Assert(x.GT(ctx.Int(1)))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	m := solver.Model()
+	v := m.Eval(x, true)
+	m.Close()
+	m.Close()
+	solver.Close()
+	solver.Close()
+	if n, _, _ := v.(Int).AsInt64(); n <= 1 {
+		t.Errorf("expected x > 1, got %d", n)
+	}
+
+	opt := NewOptimize(ctx)
+	opt.Close()
+
+	ctx.Close()
+	ctx.Close()
+	expectPanic(t, "closed Context", func() { ctx.IntConst("y") })
+	// Interrupt is a no-op after Close, so watchdogs may call it.
+	ctx.Interrupt()
+
+	// Finalizers of objects created from ctx must not touch the
+	// deleted context.
+	x, v = Int{}, nil
+	runtime.GC()
+	runtime.GC()
+}
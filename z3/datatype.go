@@ -0,0 +1,206 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// Datatype is a symbolic value of an algebraic datatype, such as a
+// record, an enumeration, or a list. Datatype sorts are declared with
+// DatatypeSort or DatatypeSorts.
+//
+// Datatype values are built, tested, and taken apart by applying the
+// constructors, recognizers, and accessors of their sort.
+//
+// Datatype implements Value.
+type Datatype value
+
+func init() {
+	kindWrappers[KindDatatype] = func(x value) Value {
+		return Datatype(x)
+	}
+}
+
+// A DatatypeDecl declares a datatype for DatatypeSorts.
+type DatatypeDecl struct {
+	Name         string
+	Constructors []ConstructorDecl
+}
+
+// A ConstructorDecl declares a constructor of a datatype.
+type ConstructorDecl struct {
+	// Name is the name of the constructor function.
+	Name string
+
+	// Recognizer is the name of the predicate that tests whether a
+	// value was built by this constructor. If Recognizer is "", it
+	// is "is-" followed by Name. Recent versions of Z3 ignore this
+	// and write every recognizer as "(_ is Name)".
+	Recognizer string
+
+	// Fields are the fields of the constructor, in order.
+	Fields []FieldDecl
+}
+
+// A FieldDecl declares a field of a constructor.
+type FieldDecl struct {
+	// Name is the name of the accessor function for the field.
+	Name string
+
+	// Sort is the sort of the field. To refer to a datatype that is
+	// being declared, as recursive datatypes do, leave Sort as the
+	// zero Sort and set Ref to the name of the datatype.
+	Sort Sort
+	Ref  string
+}
+
+// DatatypeSort returns a new datatype sort named name with the given
+// constructors. Fields that refer to the datatype itself have Ref set
+// to name.
+func (ctx *Context) DatatypeSort(name string, cons ...ConstructorDecl) Sort {
+	return ctx.DatatypeSorts(DatatypeDecl{name, cons})[0]
+}
+
+// DatatypeSorts returns new datatype sorts for decls, which may refer
+// to each other. The result has one sort for each element of decls.
+//
+// DatatypeSorts panics if the Ref of a field is not the name of one of
+// decls.
+func (ctx *Context) DatatypeSorts(decls ...DatatypeDecl) []Sort {
+	index := make(map[string]int)
+	names := make([]C.Z3_symbol, len(decls))
+	for i, d := range decls {
+		index[d.Name] = i
+		names[i] = ctx.symbol(d.Name)
+	}
+
+	// Resolve names and references before taking the lock.
+	type field struct {
+		name C.Z3_symbol
+		sort Sort
+		ref  int
+	}
+	type constructor struct {
+		name, recognizer C.Z3_symbol
+		fields           []field
+	}
+	cons := make([][]constructor, len(decls))
+	for i, d := range decls {
+		for _, cd := range d.Constructors {
+			rec := cd.Recognizer
+			if rec == "" {
+				rec = "is-" + cd.Name
+			}
+			c := constructor{name: ctx.symbol(cd.Name), recognizer: ctx.symbol(rec)}
+			for _, fd := range cd.Fields {
+				f := field{name: ctx.symbol(fd.Name), sort: fd.Sort}
+				if fd.Sort.sortImpl == nil {
+					ref, ok := index[fd.Ref]
+					if !ok {
+						panic("z3: field " + fd.Name + " refers to unknown datatype " + fd.Ref)
+					}
+					f.ref = ref
+				}
+				c.fields = append(c.fields, f)
+			}
+			cons[i] = append(cons[i], c)
+		}
+	}
+
+	sorts := make([]Sort, len(decls))
+	ctx.do(func() {
+		var ccons []C.Z3_constructor
+		clists := make([]C.Z3_constructor_list, len(decls))
+		defer func() {
+			for _, c := range ccons {
+				C.Z3_del_constructor(ctx.c, c)
+			}
+			for _, l := range clists {
+				if l != nil {
+					C.Z3_del_constructor_list(ctx.c, l)
+				}
+			}
+		}()
+		for i := range decls {
+			// Allocate one extra element so &x[0] is valid
+			// even for empty lists.
+			list := make([]C.Z3_constructor, len(cons[i])+1)
+			for j, c := range cons[i] {
+				n := len(c.fields)
+				fnames := make([]C.Z3_symbol, n+1)
+				fsorts := make([]C.Z3_sort, n+1)
+				frefs := make([]C.uint, n+1)
+				for k, f := range c.fields {
+					fnames[k] = f.name
+					if f.sort.sortImpl != nil {
+						fsorts[k] = f.sort.c
+					}
+					frefs[k] = C.uint(f.ref)
+				}
+				list[j] = C.Z3_mk_constructor(ctx.c, c.name, c.recognizer, C.uint(n), &fnames[0], &fsorts[0], &frefs[0])
+				ccons = append(ccons, list[j])
+			}
+			clists[i] = C.Z3_mk_constructor_list(ctx.c, C.uint(len(cons[i])), &list[0])
+		}
+		csorts := make([]C.Z3_sort, len(decls))
+		C.Z3_mk_datatypes(ctx.c, C.uint(len(decls)), &names[0], &csorts[0], &clists[0])
+		for i, cs := range csorts {
+			sorts[i] = wrapSort(ctx, cs, KindDatatype)
+		}
+	})
+	runtime.KeepAlive(decls)
+	return sorts
+}
+
+// DatatypeConstructors returns the constructors of datatype sort s, in
+// the order they were declared.
+func (s Sort) DatatypeConstructors() []FuncDecl {
+	var decls []FuncDecl
+	s.ctx.do(func() {
+		n := int(C.Z3_get_datatype_sort_num_constructors(s.ctx.c, s.c))
+		for i := 0; i < n; i++ {
+			decls = append(decls, wrapFuncDecl(s.ctx, C.Z3_get_datatype_sort_constructor(s.ctx.c, s.c, C.uint(i))))
+		}
+	})
+	runtime.KeepAlive(s)
+	return decls
+}
+
+// DatatypeRecognizers returns the recognizers of datatype sort s, in
+// the same order as its constructors. Recognizer i is a predicate
+// that is true of values built by constructor i.
+func (s Sort) DatatypeRecognizers() []FuncDecl {
+	var decls []FuncDecl
+	s.ctx.do(func() {
+		n := int(C.Z3_get_datatype_sort_num_constructors(s.ctx.c, s.c))
+		for i := 0; i < n; i++ {
+			decls = append(decls, wrapFuncDecl(s.ctx, C.Z3_get_datatype_sort_recognizer(s.ctx.c, s.c, C.uint(i))))
+		}
+	})
+	runtime.KeepAlive(s)
+	return decls
+}
+
+// DatatypeAccessors returns the accessors of the fields of constructor
+// i of datatype sort s, in the order the fields were declared.
+func (s Sort) DatatypeAccessors(i int) []FuncDecl {
+	var decls []FuncDecl
+	s.ctx.do(func() {
+		con := C.Z3_get_datatype_sort_constructor(s.ctx.c, s.c, C.uint(i))
+		n := int(C.Z3_get_domain_size(s.ctx.c, con))
+		for j := 0; j < n; j++ {
+			decls = append(decls, wrapFuncDecl(s.ctx, C.Z3_get_datatype_sort_constructor_accessor(s.ctx.c, s.c, C.uint(i), C.uint(j))))
+		}
+	})
+	runtime.KeepAlive(s)
+	return decls
+}
+
+//go:generate go run genwrap.go -t Datatype -e $GOFILE
@@ -0,0 +1,38 @@
+// Generated by genwrap.go. DO NOT EDIT
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// Eq returns a Value that is true if l and r are equal.
+func (l Datatype) Eq(r Datatype) Bool {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+	}, l.c, r.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// EqE is like Eq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Datatype) EqE(r Datatype) (res Bool, err error) {
+	chk := argChecker{method: "Datatype.Eq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Eq(r) })
+	return
+}
+
+// NE returns a Value that is true if l and r are not equal.
+func (l Datatype) NE(r Datatype) Bool {
+	return l.ctx.Distinct(l, r)
+}
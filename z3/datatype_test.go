@@ -0,0 +1,82 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestDatatype(t *testing.T) {
+	ctx := NewContext(nil)
+	list := ctx.DatatypeSort("List",
+		ConstructorDecl{Name: "nil"},
+		ConstructorDecl{Name: "cons", Fields: []FieldDecl{
+			{Name: "head", Sort: ctx.IntSort()},
+			{Name: "tail", Ref: "List"},
+		}},
+	)
+	if list.Kind() != KindDatatype || list.String() != "List" {
+		t.Fatalf("sort is %v of kind %v", list, list.Kind())
+	}
+	cons := list.DatatypeConstructors()
+	recs := list.DatatypeRecognizers()
+	if len(cons) != 2 || len(recs) != 2 {
+		t.Fatalf("got %d constructors and %d recognizers, want 2", len(cons), len(recs))
+	}
+	if got := cons[1].Name().String(); got != "cons" {
+		t.Errorf("constructor 1 is %s, want cons", got)
+	}
+	if got := ctx.Simplify(recs[1].Apply(cons[0].Apply()), nil).String(); got != "false" {
+		t.Errorf("recognizer 1 of nil is %s, want false", got)
+	}
+	if n := len(list.DatatypeAccessors(0)); n != 0 {
+		t.Errorf("nil has %d accessors", n)
+	}
+	acc := list.DatatypeAccessors(1)
+	if len(acc) != 2 || acc[0].Name().String() != "head" || acc[1].Name().String() != "tail" {
+		t.Fatalf("cons accessors are %v", acc)
+	}
+
+	// Find a two-element list whose elements sum to 10 and whose
+	// head is 3.
+	nilv := cons[0].Apply()
+	l := ctx.Const("l", list).(Datatype)
+	tail := acc[1].Apply(l).(Datatype)
+	solver := NewSolver(ctx)
+	solver.Assert(recs[1].Apply(l).(Bool))
+	solver.Assert(recs[1].Apply(tail).(Bool))
+	solver.Assert(acc[1].Apply(tail).(Datatype).Eq(nilv.(Datatype)))
+	solver.Assert(acc[0].Apply(l).(Int).Add(acc[0].Apply(tail).(Int)).Eq(ctx.FromInt(10, ctx.IntSort()).(Int)))
+	solver.Assert(acc[0].Apply(l).(Int).Eq(ctx.FromInt(3, ctx.IntSort()).(Int)))
+	if sat, err := solver.Check(); !sat {
+		t.Fatal("unsat:", err)
+	}
+	if got, want := solver.Model().Eval(l, true).String(), "(cons 3 (cons 7 nil))"; got != want {
+		t.Errorf("l = %s, want %s", got, want)
+	}
+}
+
+func TestDatatypeSorts(t *testing.T) {
+	ctx := NewContext(nil)
+	sorts := ctx.DatatypeSorts(
+		DatatypeDecl{"Tree", []ConstructorDecl{
+			{Name: "leaf", Fields: []FieldDecl{{Name: "value", Sort: ctx.BoolSort()}}},
+			{Name: "node", Fields: []FieldDecl{{Name: "children", Ref: "Forest"}}},
+		}},
+		DatatypeDecl{"Forest", []ConstructorDecl{
+			{Name: "empty"},
+			{Name: "grow", Fields: []FieldDecl{{Name: "first", Ref: "Tree"}, {Name: "rest", Ref: "Forest"}}},
+		}},
+	)
+	if len(sorts) != 2 || sorts[0].String() != "Tree" || sorts[1].String() != "Forest" {
+		t.Fatalf("sorts are %v", sorts)
+	}
+	first := sorts[1].DatatypeAccessors(1)[0]
+	if got := first.Apply(ctx.Const("f", sorts[1])).Sort().String(); got != "Tree" {
+		t.Errorf("first returns %v, want Tree", got)
+	}
+
+	expectPanic(t, "unknown datatype Missing", func() {
+		ctx.DatatypeSort("Bad", ConstructorDecl{Name: "bad", Fields: []FieldDecl{{Name: "f", Ref: "Missing"}}})
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// DecimalFormat describes the decimal integer syntax accepted by
+// String.IsDecimal. The zero DecimalFormat accepts only non-negative
+// numbers without a sign or leading zeros, as produced by IntToString.
+type DecimalFormat struct {
+	// Minus allows negative numbers, written with a leading '-'.
+	// "-0" then also represents 0.
+	Minus bool
+
+	// Plus allows an optional leading '+' on non-negative numbers.
+	Plus bool
+
+	// LeadingZeros allows zeros before the first significant
+	// digit, as in "007".
+	LeadingZeros bool
+}
+
+// IsDecimal returns a constraint that l is the decimal representation
+// of n in the given format: an optional sign as permitted by format,
+// followed by one or more ASCII digits.
+//
+// Unlike l.ToInt().Eq(n), which cannot express negative numbers and
+// is also true of non-numeric strings when n is -1, IsDecimal holds
+// only of well-formed strings. It introduces no auxiliary constants,
+// so its negation means exactly that l does not represent n.
+func (l String) IsDecimal(n Int, format DecimalFormat) Bool {
+	ctx := l.ctx
+	minus := ctx.FromBool(false)
+	if format.Minus {
+		minus = ctx.FromString("-").PrefixOf(l)
+	}
+	sign := minus
+	if format.Plus {
+		sign = sign.Or(ctx.FromString("+").PrefixOf(l))
+	}
+	one := ctx.Int(1)
+	digits := sign.IfThenElse(l.Extract(one, l.Length().Sub(one)), l).(String)
+	value := digits.ToInt()
+	res := digits.InRE(ctx.REDigit().Plus()).
+		And(minus.IfThenElse(value.Neg(), value).(Int).Eq(n))
+	if !format.LeadingZeros {
+		zero := ctx.FromString("0")
+		res = res.And(digits.Eq(zero).Or(zero.PrefixOf(digits).Not()))
+	}
+	return res
+}
+
+// IntToDecimal returns the decimal representation of n, with a leading
+// '-' if n is negative. IntToString, in contrast, returns the empty
+// string for negative numbers.
+func (ctx *Context) IntToDecimal(n Int) String {
+	neg := ctx.IntToString(n.Neg())
+	return n.LT(ctx.Int(0)).IfThenElse(ctx.FromString("-").Concat(neg), ctx.IntToString(n)).(String)
+}
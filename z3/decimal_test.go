@@ -0,0 +1,82 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestStringIsDecimal(t *testing.T) {
+	ctx := NewContext(nil)
+	all := DecimalFormat{Minus: true, Plus: true, LeadingZeros: true}
+	tests := []struct {
+		s      string
+		n      int
+		format DecimalFormat
+		want   bool
+	}{
+		{"42", 42, DecimalFormat{}, true},
+		{"0", 0, DecimalFormat{}, true},
+		{"42", 41, DecimalFormat{}, false},
+		{"", 0, all, false},
+		{"x", -1, all, false},
+		{"-1", -1, DecimalFormat{}, false},
+		{"-17", -17, DecimalFormat{Minus: true}, true},
+		{"-0", 0, DecimalFormat{Minus: true}, true},
+		{"-", 0, all, false},
+		{"+5", 5, DecimalFormat{}, false},
+		{"+5", 5, DecimalFormat{Plus: true}, true},
+		{"+-5", -5, all, false},
+		{"007", 7, DecimalFormat{}, false},
+		{"007", 7, DecimalFormat{LeadingZeros: true}, true},
+		{"-007", -7, all, true},
+		{"00", 0, DecimalFormat{}, false},
+	}
+	for _, tt := range tests {
+		s := NewSolver(ctx)
+		s.Assert(ctx.FromString(tt.s).IsDecimal(ctx.Int(tt.n), tt.format))
+		if sat, err := s.Check(); sat != tt.want || err != nil {
+			t.Errorf("%q.IsDecimal(%d, %+v): got %v, %v; want %v", tt.s, tt.n, tt.format, sat, err, tt.want)
+		}
+	}
+
+	// Parse a string: n is determined uniquely.
+	n := ctx.IntConst("n")
+	s := NewSolver(ctx)
+	s.Assert(ctx.FromString("-0012").IsDecimal(n, all))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	if got := s.Model().Eval(n, true).String(); got != ctx.Int(-12).String() {
+		t.Errorf("parsed %s, want -12", got)
+	}
+	s.Assert(n.NE(ctx.Int(-12)))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("second value: got %v, %v; want unsat", sat, err)
+	}
+
+	// Format a number: the canonical representation of -12.
+	str := ctx.StringConst("s")
+	s = NewSolver(ctx)
+	s.Assert(str.IsDecimal(ctx.Int(-12), DecimalFormat{Minus: true}))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	if got, _ := s.Model().Eval(str, true).(String).AsString(); got != "-12" {
+		t.Errorf("got %q, want %q", got, "-12")
+	}
+}
+
+func TestIntToDecimal(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, n := range []int{0, 7, -7, 1234} {
+		got, _ := ctx.Simplify(ctx.IntToDecimal(ctx.Int(n)), nil).(String).AsString()
+		if want := ctx.Int(n).String(); n < 0 {
+			if want = "-" + ctx.Int(-n).String(); got != want {
+				t.Errorf("IntToDecimal(%d) = %q, want %q", n, got, want)
+			}
+		} else if got != want {
+			t.Errorf("IntToDecimal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -24,3 +24,91 @@ func TestDeMorgan(t *testing.T) {
 		t.Errorf("disproved De Morgan's law")
 	}
 }
+
+func TestProve(t *testing.T) {
+	ctx := NewContext(nil)
+
+	x, y := ctx.BoolConst("x"), ctx.BoolConst("y")
+	proved, cex, err := ctx.Prove(x.And(y).Not().Iff(x.Not().Or(y.Not())))
+	if err != nil || !proved || cex != nil {
+		t.Errorf("De Morgan: Prove = %v, %v, %v", proved, cex, err)
+	}
+
+	// a > 0 implies a+b > b, but a*2 > a needs a > 0.
+	a, b := ctx.IntConst("a"), ctx.IntConst("b")
+	zero := ctx.Int(0)
+	proved, cex, err = ctx.Prove(a.Add(b).GT(b), a.GT(zero))
+	if err != nil || !proved {
+		t.Errorf("a > 0 => a+b > b: Prove = %v, %v, %v", proved, cex, err)
+	}
+	proved, cex, err = ctx.Prove(a.Mul(ctx.Int(2)).GT(a))
+	if err != nil || proved || cex == nil {
+		t.Fatalf("a*2 > a: Prove = %v, %v, %v", proved, cex, err)
+	}
+	if av, _, _ := cex.EvalAsInt64(a, true); av > 0 {
+		t.Errorf("counterexample has a = %d > 0", av)
+	}
+}
+
+func TestProveEquivalent(t *testing.T) {
+	ctx := NewContext(nil)
+	bv := ctx.BVSort(8)
+	x := ctx.BVConst("x", 8)
+	two := ctx.FromInt(2, bv).(BV)
+
+	// x*2 == x<<1, but x*2 != x+x+1.
+	if equiv, m, err := ctx.ProveEquivalent(x.Mul(two), x.Lsh(ctx.FromInt(1, bv).(BV))); !equiv || err != nil {
+		t.Errorf("x*2 != x<<1: %v, %v", m, err)
+	}
+	if equiv, m, err := ctx.ProveEquivalent(x.Mul(two), x.Add(x).Add(ctx.FromInt(1, bv).(BV))); equiv || m == nil || err != nil {
+		t.Errorf("x*2 == x+x+1: %v, %v", equiv, err)
+	}
+
+	// Unsigned x < 4 implies x < 8, but not the reverse.
+	lt := func(n int64) Bool { return x.ULT(ctx.FromInt(n, bv).(BV)) }
+	if ok, _, err := ctx.ProveImplies(lt(4), lt(8)); !ok || err != nil {
+		t.Errorf("x < 4 does not imply x < 8")
+	}
+	ok, m, err := ctx.ProveImplies(lt(8), lt(4))
+	if ok || err != nil {
+		t.Fatalf("x < 8 implies x < 4")
+	}
+	if v, _, _ := m.Eval(x, true).(BV).AsUint64(); v < 4 || v >= 8 {
+		t.Errorf("counterexample x = %d", v)
+	}
+
+	// Two versions of a constraint generator: the second has an
+	// off-by-one error when a == 0.
+	abs1 := func(args ...Value) Value {
+		a := args[0].(Int)
+		return a.LT(ctx.Int(0)).IfThenElse(a.Neg(), a)
+	}
+	abs2 := func(args ...Value) Value {
+		a := args[0].(Int)
+		return a.GT(ctx.Int(0)).IfThenElse(a, a.Neg().Add(ctx.Int(1)))
+	}
+	domain := []Sort{ctx.IntSort()}
+	if equiv, cex, err := ctx.ProveEquivalentFuncs(domain, abs1, abs1); !equiv || cex != nil || err != nil {
+		t.Errorf("abs1 not equivalent to itself: %v, %v", cex, err)
+	}
+	equiv, cex, err := ctx.ProveEquivalentFuncs(domain, abs1, abs2)
+	if equiv || err != nil || len(cex) != 1 {
+		t.Fatalf("abs1 equivalent to abs2: %v, %v", cex, err)
+	}
+	if a, _, _ := cex[0].(Int).AsInt64(); a > 0 {
+		t.Errorf("counterexample a = %d, want a <= 0", a)
+	}
+
+	// Uninterpreted functions are only equivalent to themselves.
+	f := ctx.FuncDecl("f", []Sort{bv, bv}, bv)
+	g := ctx.FuncDecl("g", []Sort{bv, bv}, bv)
+	if equiv, _, err := ctx.ProveEquivalentDecls(f, f); !equiv || err != nil {
+		t.Errorf("f not equivalent to itself")
+	}
+	if equiv, cex, _ := ctx.ProveEquivalentDecls(f, g); equiv || len(cex) != 2 {
+		t.Errorf("f equivalent to g: %v", cex)
+	}
+	if d := f.Domain(); len(d) != 2 || d[0].BVSize() != 8 || f.Range().Kind() != KindBV {
+		t.Errorf("f has domain %v, range %v", d, f.Range())
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// A DepGraph is the bipartite graph of a set of assertions and the
+// uninterpreted constants and functions they mention. Assertions that
+// share no symbols, directly or through other assertions, are
+// independent subproblems that can be solved separately.
+type DepGraph struct {
+	// Assertions are the assertions the graph was built from.
+	Assertions []Bool
+
+	// Symbols are the uninterpreted constants and functions that
+	// appear in Assertions, in order of first appearance.
+	Symbols []FuncDecl
+
+	// Uses[i] lists the indexes in Symbols of the symbols that
+	// appear in Assertions[i], in increasing order.
+	Uses [][]int
+}
+
+// NewDepGraph returns the dependency graph of asserts.
+func NewDepGraph(asserts []Bool) *DepGraph {
+	g := &DepGraph{Assertions: asserts, Uses: make([][]int, len(asserts))}
+	if len(asserts) == 0 {
+		return g
+	}
+	ctx := asserts[0].ctx
+	index := make(map[C.uint]int) // Symbol index by decl AST ID
+	ctx.do(func() {
+		c := ctx.c
+		for i, a := range asserts {
+			used := make(map[int]bool)
+			uninterpretedApps(c, a.c, func(_ C.Z3_ast, decl C.Z3_func_decl) {
+				id := C.Z3_get_ast_id(c, C.Z3_func_decl_to_ast(c, decl))
+				j, ok := index[id]
+				if !ok {
+					j = len(g.Symbols)
+					index[id] = j
+					g.Symbols = append(g.Symbols, wrapFuncDecl(ctx, decl))
+				}
+				if !used[j] {
+					used[j] = true
+					g.Uses[i] = append(g.Uses[i], j)
+				}
+			})
+		}
+	})
+	runtime.KeepAlive(asserts)
+	for _, uses := range g.Uses {
+		sort.Ints(uses)
+	}
+	return g
+}
+
+// Components partitions the assertions into independent subproblems:
+// groups connected by shared symbols. Each component lists indexes
+// into g.Assertions in increasing order, and the components are
+// ordered by their first assertion. An assertion without symbols is a
+// component by itself.
+func (g *DepGraph) Components() [][]int {
+	// Union-find over assertions, joining each assertion to the
+	// first assertion seen using each of its symbols.
+	parent := make([]int, len(g.Assertions))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	owner := make(map[int]int)
+	for i, uses := range g.Uses {
+		for _, sym := range uses {
+			if j, ok := owner[sym]; ok {
+				ri, rj := find(i), find(j)
+				if ri < rj {
+					parent[rj] = ri
+				} else {
+					parent[ri] = rj
+				}
+			} else {
+				owner[sym] = i
+			}
+		}
+	}
+	var comps [][]int
+	index := make(map[int]int) // Component index by root
+	for i := range g.Assertions {
+		r := find(i)
+		k, ok := index[r]
+		if !ok {
+			k = len(comps)
+			index[r] = k
+			comps = append(comps, nil)
+		}
+		comps[k] = append(comps[k], i)
+	}
+	return comps
+}
+
+// dotLabelMax is the longest assertion label WriteDOT writes.
+const dotLabelMax = 40
+
+// WriteDOT writes g to w in the Graphviz DOT language. Assertions are
+// boxes labeled with their infix form, abbreviated if long, and
+// symbols are ellipses labeled with their names. Each component is
+// drawn as a cluster, so decoupled subproblems stand out.
+func (g *DepGraph) WriteDOT(w io.Writer) error {
+	var buf strings.Builder
+	buf.WriteString("graph deps {\n")
+	for k, comp := range g.Components() {
+		fmt.Fprintf(&buf, "\tsubgraph cluster_%d {\n", k)
+		syms := make(map[int]bool)
+		for _, i := range comp {
+			label := FormatInfix(g.Assertions[i])
+			if len(label) > dotLabelMax {
+				label = label[:dotLabelMax-3] + "..."
+			}
+			fmt.Fprintf(&buf, "\t\ta%d [shape=box, label=%s];\n", i, strconv.Quote(label))
+			for _, j := range g.Uses[i] {
+				if !syms[j] {
+					syms[j] = true
+					fmt.Fprintf(&buf, "\t\ts%d [label=%s];\n", j, strconv.Quote(g.Symbols[j].Name().String()))
+				}
+			}
+		}
+		buf.WriteString("\t}\n")
+	}
+	for i, uses := range g.Uses {
+		for _, j := range uses {
+			fmt.Fprintf(&buf, "\ta%d -- s%d;\n", i, j)
+		}
+	}
+	buf.WriteString("}\n")
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
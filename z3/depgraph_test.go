@@ -0,0 +1,56 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDepGraph(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y, z, w := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z"), ctx.IntConst("w")
+	f := ctx.FuncDecl("f", []Sort{ctx.IntSort()}, ctx.IntSort())
+	asserts := []Bool{
+		x.GT(y),
+		z.Eq(f.Apply(w).(Int)),
+		y.LT(ctx.Int(10)),
+		ctx.FromBool(true),
+		f.Apply(ctx.Int(0)).(Int).Eq(ctx.Int(1)),
+	}
+	g := NewDepGraph(asserts)
+
+	var syms []string
+	for _, s := range g.Symbols {
+		syms = append(syms, s.Name().String())
+	}
+	if got, want := fmt.Sprint(syms), "[x y z f w]"; got != want {
+		t.Errorf("Symbols = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprint(g.Uses), "[[0 1] [2 3 4] [1] [] [3]]"; got != want {
+		t.Errorf("Uses = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprint(g.Components()), "[[0 2] [1 4] [3]]"; got != want {
+		t.Errorf("Components = %s, want %s", got, want)
+	}
+
+	var buf strings.Builder
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	dot := buf.String()
+	for _, want := range []string{
+		"graph deps {",
+		"subgraph cluster_2 {",
+		`a0 [shape=box, label="x > y"];`,
+		`s3 [label="f"];`,
+		"a4 -- s3;",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, dot)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// EnumerateParallel calls f with each distinct assignment to vars
+// that satisfies asserts, using several worker goroutines, each with
+// its own Context.
+//
+// The search space is split into cubes: constraints such that each
+// solution satisfies exactly one of them. Workers take cubes one at a
+// time and enumerate the solutions within them, so there should be
+// several cubes per worker to balance the load. For each solution to
+// be reported once, cubes must mention only vars. SplitBools,
+// SplitInt, and SplitBV build such cubes. If cubes is empty, the
+// whole space is a single cube.
+//
+// If workers is 0, EnumerateParallel uses one worker per CPU.
+//
+// f is called from the calling goroutine, one solution at a time, with
+// the values of vars translated into ctx. Solutions arrive in no
+// particular order. If f returns false, enumeration stops.
+// EnumerateParallel returns the number of solutions passed to f. If a
+// worker's Check fails, it stops the enumeration and returns the
+// error.
+func (ctx *Context) EnumerateParallel(asserts []Bool, vars []Value, cubes []Bool, workers int, f func(values []Value) bool) (n int, err error) {
+	if len(cubes) == 0 {
+		cubes = []Bool{ctx.FromBool(true)}
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(cubes) {
+		workers = len(cubes)
+	}
+
+	// Translate everything up front, since ctx may not be used
+	// concurrently.
+	type worker struct {
+		ctx   *Context
+		s     *Solver
+		vars  []Value
+		cubes []Bool
+	}
+	ws := make([]*worker, workers)
+	for i := range ws {
+		w := &worker{ctx: NewContext(nil)}
+		w.s = NewSolver(w.ctx)
+		for _, a := range asserts {
+			w.s.Assert(a.AsAST().Translate(w.ctx).AsValue().(Bool))
+		}
+		for _, v := range vars {
+			w.vars = append(w.vars, v.AsAST().Translate(w.ctx).AsValue())
+		}
+		for _, c := range cubes {
+			w.cubes = append(w.cubes, c.AsAST().Translate(w.ctx).AsValue().(Bool))
+		}
+		ws[i] = w
+	}
+	defer func() {
+		for _, w := range ws {
+			w.ctx.Close()
+		}
+	}()
+
+	type result struct {
+		values []Value
+		err    error
+	}
+	todo := make(chan int, len(cubes))
+	for i := range cubes {
+		todo <- i
+	}
+	close(todo)
+	results := make(chan result)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, w := range ws {
+		wg.Add(1)
+		go func(w *worker) {
+			defer wg.Done()
+			send := func(r result) bool {
+				select {
+				case results <- r:
+					return true
+				case <-done:
+					return false
+				}
+			}
+			for i := range todo {
+				w.s.Push()
+				w.s.Assert(w.cubes[i])
+				for {
+					sat, err := w.s.Check()
+					if err != nil {
+						send(result{err: err})
+						return
+					}
+					if !sat {
+						break
+					}
+					// stop may interrupt the worker after Check
+					// has returned.
+					vals := make([]Value, len(w.vars))
+					var diff []Bool
+					err = w.ctx.Catch(func() {
+						m := w.s.Model()
+						defer m.Close()
+						for j, v := range w.vars {
+							x := m.Eval(v, true)
+							vals[j] = x.AsAST().Translate(ctx).AsValue()
+							diff = append(diff, w.ctx.Distinct(v, x))
+						}
+					})
+					if err != nil {
+						send(result{err: err})
+						return
+					}
+					if !send(result{values: vals}) {
+						return
+					}
+					if len(diff) == 0 {
+						break
+					}
+					w.s.Assert(diff[0].Or(diff[1:]...))
+				}
+				w.s.Pop()
+			}
+		}(w)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// An Interrupt before a worker starts its Check is lost, so keep
+	// interrupting the workers until they stop.
+	stop := func() {
+		close(done)
+		tick := time.NewTicker(10 * time.Millisecond)
+		defer tick.Stop()
+		for {
+			for _, w := range ws {
+				w.ctx.Interrupt()
+			}
+			select {
+			case _, ok := <-results:
+				if !ok {
+					return
+				}
+			case <-tick.C:
+			}
+		}
+	}
+	for r := range results {
+		if r.err != nil {
+			stop()
+			return n, r.err
+		}
+		n++
+		if !f(r.values) {
+			stop()
+			return n, nil
+		}
+	}
+	return n, nil
+}
+
+// SplitBools returns the cubes for EnumerateParallel that assign each
+// combination of true and false to bs. There are 2^len(bs) cubes.
+func SplitBools(bs ...Bool) []Bool {
+	if len(bs) == 0 {
+		return nil
+	}
+	cubes := []Bool{bs[0], bs[0].Not()}
+	for _, b := range bs[1:] {
+		next := make([]Bool, 0, 2*len(cubes))
+		for _, c := range cubes {
+			next = append(next, c.And(b), c.And(b.Not()))
+		}
+		cubes = next
+	}
+	return cubes
+}
+
+// SplitInt returns the cubes for EnumerateParallel that divide the
+// range [lo, hi] of x into n ranges of nearly equal size, plus the
+// cubes x < lo and x > hi.
+func SplitInt(x Int, lo, hi int64, n int) []Bool {
+	ctx := x.Context()
+	if n < 1 {
+		n = 1
+	}
+	size := (hi - lo + 1) / int64(n)
+	if size < 1 {
+		size = 1
+	}
+	cubes := []Bool{x.LT(ctx.Int64(lo)), x.GT(ctx.Int64(hi))}
+	for start := lo; start <= hi; start += size {
+		end := start + size - 1
+		if end > hi || hi-end < size {
+			// Fold the remainder into the last range.
+			end = hi
+		}
+		cubes = append(cubes, x.GE(ctx.Int64(start)).And(x.LE(ctx.Int64(end))))
+		if end == hi {
+			break
+		}
+	}
+	return cubes
+}
+
+// SplitBV returns the cubes for EnumerateParallel that fix the top
+// bits bits of x to each of their 2^bits values.
+func SplitBV(x BV, bits int) []Bool {
+	size := x.Sort().BVSize()
+	if bits > size {
+		bits = size
+	}
+	if bits <= 0 {
+		return []Bool{x.Context().FromBool(true)}
+	}
+	top := x.Extract(size-1, size-bits)
+	sort := top.Sort()
+	cubes := make([]Bool, 1<<uint(bits))
+	for i := range cubes {
+		cubes[i] = top.Eq(x.Context().FromInt(int64(i), sort).(BV))
+	}
+	return cubes
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestEnumerateParallel(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.BVConst("x", 8), ctx.BVConst("y", 8)
+	asserts := []Bool{x.Add(y).Eq(ctx.FromInt(200, ctx.BVSort(8)).(BV))}
+	seen := make(map[string]bool)
+	n, err := ctx.EnumerateParallel(asserts, []Value{x, y}, SplitBV(x, 3), 4, func(vals []Value) bool {
+		key := vals[0].String() + " " + vals[1].String()
+		if seen[key] {
+			t.Errorf("duplicate solution %s", key)
+		}
+		seen[key] = true
+		if vals[0].Context() != ctx {
+			t.Errorf("solution is not in ctx")
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 256 || len(seen) != 256 {
+		t.Errorf("got %d solutions, want 256", n)
+	}
+
+	// Stop early.
+	n, err = ctx.EnumerateParallel(asserts, []Value{x, y}, SplitBV(x, 3), 0, func([]Value) bool { return false })
+	if n != 1 || err != nil {
+		t.Errorf("stopping early: got %d solutions, err %v; want 1", n, err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	ctx := NewContext(nil)
+	i, j := ctx.IntConst("i"), ctx.IntConst("j")
+	ints := []Bool{i.Add(j).Eq(ctx.Int(20)), i.GE(ctx.Int(0)), j.GE(ctx.Int(0))}
+	a, b, c := ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c")
+	bools := []Bool{a.Or(b)}
+
+	for _, test := range []struct {
+		name    string
+		asserts []Bool
+		vars    []Value
+		cubes   []Bool
+		want    int
+	}{
+		{"SplitInt", ints, []Value{i, j}, SplitInt(i, 0, 20, 4), 21},
+		{"SplitInt small", ints, []Value{i, j}, SplitInt(i, 5, 6, 10), 21},
+		{"SplitBools", bools, []Value{a, b, c}, SplitBools(a, b), 6},
+		{"no cubes", bools, []Value{a, b, c}, nil, 6},
+	} {
+		n, err := ctx.EnumerateParallel(test.asserts, test.vars, test.cubes, 2, func([]Value) bool { return true })
+		if n != test.want || err != nil {
+			t.Errorf("%s: got %d solutions, err %v; want %d", test.name, n, err, test.want)
+		}
+	}
+	if got := len(SplitBools(a, b, c)); got != 8 {
+		t.Errorf("SplitBools made %d cubes, want 8", got)
+	}
+}
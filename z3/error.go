@@ -0,0 +1,199 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// Error is an error reported by Z3, such as an argument with the
+// wrong sort.
+//
+// Z3 errors are reported by panicking with an *Error. Use
+// Context.Catch or the E variants of methods, such as BV.AddE, to turn
+// them into returned errors.
+type Error struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether e matches target. An *Error with code
+// ErrorMemout matches ErrMemoryExceeded.
+func (e *Error) Is(target error) bool {
+	return target == ErrMemoryExceeded && e.Code == ErrorMemout
+}
+
+// ErrorCode is the category of an Error.
+type ErrorCode int
+
+const (
+	ErrorSort           = ErrorCode(C.Z3_SORT_ERROR)
+	ErrorIndexOOB       = ErrorCode(C.Z3_IOB)
+	ErrorInvalidArg     = ErrorCode(C.Z3_INVALID_ARG)
+	ErrorParser         = ErrorCode(C.Z3_PARSER_ERROR)
+	ErrorNoParser       = ErrorCode(C.Z3_NO_PARSER)
+	ErrorInvalidPattern = ErrorCode(C.Z3_INVALID_PATTERN)
+	ErrorMemout         = ErrorCode(C.Z3_MEMOUT_FAIL)
+	ErrorFileAccess     = ErrorCode(C.Z3_FILE_ACCESS_ERROR)
+	ErrorInternalFatal  = ErrorCode(C.Z3_INTERNAL_FATAL)
+	ErrorInvalidUsage   = ErrorCode(C.Z3_INVALID_USAGE)
+	ErrorDecRef         = ErrorCode(C.Z3_DEC_REF_ERROR)
+	ErrorException      = ErrorCode(C.Z3_EXCEPTION)
+)
+
+// String returns c as a string like "ErrorSort".
+func (c ErrorCode) String() string {
+	switch c {
+	case ErrorSort:
+		return "ErrorSort"
+	case ErrorIndexOOB:
+		return "ErrorIndexOOB"
+	case ErrorInvalidArg:
+		return "ErrorInvalidArg"
+	case ErrorParser:
+		return "ErrorParser"
+	case ErrorNoParser:
+		return "ErrorNoParser"
+	case ErrorInvalidPattern:
+		return "ErrorInvalidPattern"
+	case ErrorMemout:
+		return "ErrorMemout"
+	case ErrorFileAccess:
+		return "ErrorFileAccess"
+	case ErrorInternalFatal:
+		return "ErrorInternalFatal"
+	case ErrorInvalidUsage:
+		return "ErrorInvalidUsage"
+	case ErrorDecRef:
+		return "ErrorDecRef"
+	case ErrorException:
+		return "ErrorException"
+	}
+	return "ErrorCode(" + strconv.Itoa(int(c)) + ")"
+}
+
+// Catch calls f and returns the Z3 error that f panicked with, or nil
+// if f returned normally. Panics with values other than *Error are
+// propagated.
+//
+// Catch makes it possible to use ctx in long-running programs that
+// must not crash on invalid input, for example, when building terms
+// from user-provided data:
+//
+//	err := ctx.Catch(func() {
+//		solver.Assert(x.Eq(y))
+//	})
+//
+// Objects created by f before the error remain valid.
+func (ctx *Context) Catch(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+	f()
+	return nil
+}
+
+// Errors reported by the E variants of methods, such as BV.AddE, for
+// arguments that Z3 cannot be given at all.
+var (
+	// ErrZeroValue means an argument is the zero value of its
+	// type, rather than a value created by a Context.
+	ErrZeroValue = errors.New("z3: zero value")
+
+	// ErrContextMismatch means an argument belongs to a different
+	// Context than the receiver.
+	ErrContextMismatch = errors.New("z3: value from a different Context")
+
+	// ErrArgCount means a function was applied to the wrong number
+	// of arguments.
+	ErrArgCount = errors.New("z3: wrong number of arguments")
+
+	// ErrSortMismatch means an argument does not have the sort
+	// the function expects.
+	ErrSortMismatch = errors.New("z3: sort mismatch")
+)
+
+// An ArgError is an invalid argument to an E variant of a method.
+//
+// Each method that builds an expression, such as BV.Add, has an E
+// variant, such as BV.AddE, that returns an error instead of
+// panicking. The E variant checks its arguments in Go and reports
+// problems with an *ArgError. Errors that Z3 itself reports, such as a
+// sort mismatch, are returned as an *Error.
+type ArgError struct {
+	Method string // The method, such as "BV.Add"
+	Arg    string // The argument, such as "r" or "vals[2]"
+	Err    error  // Wraps ErrZeroValue, ErrContextMismatch, etc.
+}
+
+func (e *ArgError) Error() string {
+	return "z3: " + e.Method + ": argument " + e.Arg + ": " + strings.TrimPrefix(e.Err.Error(), "z3: ")
+}
+
+func (e *ArgError) Unwrap() error {
+	return e.Err
+}
+
+// argChecker checks the arguments of an E method. The first argument
+// with a Context determines the expected Context.
+type argChecker struct {
+	method string
+	ctx    *Context
+	err    error
+}
+
+func (c *argChecker) check(name string, ctx *Context) {
+	switch {
+	case c.err != nil:
+	case ctx == nil:
+		c.err = &ArgError{c.method, name, ErrZeroValue}
+	case c.ctx == nil:
+		c.ctx = ctx
+	case ctx != c.ctx:
+		c.err = &ArgError{c.method, name, ErrContextMismatch}
+	}
+}
+
+func (c *argChecker) value(name string, v Value) {
+	if v == nil {
+		c.check(name, nil)
+		return
+	}
+	c.check(name, v.impl().Context())
+}
+
+func (c *argChecker) elem(name string, i int, v Value) {
+	c.value(name+"["+strconv.Itoa(i)+"]", v)
+}
+
+func (c *argChecker) sort(name string, s Sort) {
+	c.check(name, s.Context())
+}
+
+// do calls f with c's Context, converting a Z3 error into a returned
+// error, unless an argument was invalid.
+func (c *argChecker) do(f func()) error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.ctx.Catch(f)
+}
@@ -69,9 +69,11 @@ type value struct {
 
 type valueImpl astImpl
 
-func wrapValue(ctx *Context, ctor func() C.Z3_ast) value {
+// wrapValue calls ctor with ctx's lock held and wraps the AST it
+// returns. args are the operands of the call, for ctx's TraceFunc.
+func wrapValue(ctx *Context, ctor func() C.Z3_ast, args ...C.Z3_ast) value {
 	var val value
-	ctx.do(func() {
+	ctx.doArgs(args, func() {
 		cast := ctor()
 		val = value{(*valueImpl)(wrapAST(ctx, cast).astImpl), noEq{}}
 	})
@@ -95,17 +97,16 @@ func (x value) lift(kind Kind) Value {
 // constant will be same as all other constants created with this
 // name.
 func (ctx *Context) Const(name string, sort Sort) Value {
-	sym := ctx.symbol(name)
-	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_const(ctx.c, sym, sort.c)
-	})
-	runtime.KeepAlive(sort)
-	return val.lift(sort.Kind())
+	return ctx.ConstSym(ctx.StringSymbol(name), sort)
 }
 
 // FreshConst returns a constant that is distinct from all other
-// constants. The name will begin with "prefix".
+// constants. The name will begin with "prefix". If ctx has a
+// FreshNamer, it chooses the name; see SetFreshNamer.
 func (ctx *Context) FreshConst(prefix string, sort Sort) Value {
+	if name, ok := ctx.freshName(prefix); ok {
+		return ctx.Const(name, sort)
+	}
 	cprefix := C.CString(prefix)
 	defer C.free(unsafe.Pointer(cprefix))
 	val := wrapValue(ctx, func() C.Z3_ast {
@@ -237,7 +238,7 @@ func (expr *valueImpl) asUint64() (val uint64, isLiteral, ok bool) {
 	switch expr.Sort().Kind() {
 	default:
 		panic("sort " + expr.Sort().String() + " cannot be represented as an int64")
-	case KindInt, KindBV:
+	case KindInt, KindBV, KindFiniteDomain:
 	}
 	if expr.astKind() != C.Z3_NUMERAL_AST {
 		return 0, false, false
@@ -257,3 +258,46 @@ func (expr *valueImpl) isAppOf(k C.Z3_decl_kind) bool {
 	runtime.KeepAlive(expr)
 	return res
 }
+
+// appArgs returns the declaration kind and arguments of expr. If expr
+// is not an application, it returns ok == false.
+func (expr *valueImpl) appArgs() (kind C.Z3_decl_kind, args []Value, ok bool) {
+	var cargs []C.Z3_ast
+	expr.ctx.do(func() {
+		if !z3ToBool(C.Z3_is_app(expr.ctx.c, expr.c)) {
+			return
+		}
+		ok = true
+		app := C.Z3_to_app(expr.ctx.c, expr.c)
+		kind = C.Z3_get_decl_kind(expr.ctx.c, C.Z3_get_app_decl(expr.ctx.c, app))
+		n := C.Z3_get_app_num_args(expr.ctx.c, app)
+		cargs = make([]C.Z3_ast, n)
+		for i := C.uint(0); i < n; i++ {
+			cargs[i] = C.Z3_get_app_arg(expr.ctx.c, app, i)
+		}
+	})
+	args = make([]Value, len(cargs))
+	for i, carg := range cargs {
+		a := carg // capture for closure
+		args[i] = wrapValue(expr.ctx, func() C.Z3_ast { return a }).lift(KindUnknown)
+	}
+	runtime.KeepAlive(expr)
+	return kind, args, ok
+}
+
+// declIntParams returns the integer parameters of the declaration of
+// application expr, such as the bounds of a regular expression loop.
+func (expr *valueImpl) declIntParams() []int {
+	var params []int
+	expr.ctx.do(func() {
+		decl := C.Z3_get_app_decl(expr.ctx.c, C.Z3_to_app(expr.ctx.c, expr.c))
+		n := C.Z3_get_decl_num_parameters(expr.ctx.c, decl)
+		for i := C.uint(0); i < n; i++ {
+			if C.Z3_get_decl_parameter_kind(expr.ctx.c, decl, i) == C.Z3_PARAMETER_INT {
+				params = append(params, int(C.Z3_get_decl_int_parameter(expr.ctx.c, decl, i)))
+			}
+		}
+	})
+	runtime.KeepAlive(expr)
+	return params
+}
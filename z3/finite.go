@@ -9,14 +9,19 @@ package z3
 #include <z3.h>
 */
 import "C"
+import (
+	"fmt"
+	"runtime"
+)
 
 // FiniteDomain is a symbolic value from a finite domain of size n,
 // where n depends on the value's sort.
 //
 // Finite domain values are uninterpreted (see Uninterpreted), but
 // represented as numerals. Hence, to construct a specific
-// finite-domain value, use methods like Context.FromInt with a value
-// in [0, n).
+// finite-domain value, use Context.FiniteDomainValue with a value in
+// [0, n), and to read one back, for example from a Model, use
+// AsUint64.
 //
 // FiniteDomain implements Value.
 type FiniteDomain value
@@ -41,4 +46,43 @@ func (ctx *Context) FiniteDomainSort(name string, n uint64) Sort {
 	return sort
 }
 
-//go:generate go run genwrap.go -t FiniteDomain $GOFILE
+// FiniteDomainSize returns the number of values of finite-domain sort
+// s.
+func (s Sort) FiniteDomainSize() uint64 {
+	if s.Kind() != KindFiniteDomain {
+		panic("z3: FiniteDomainSize: sort " + s.String() + " is not a finite-domain sort")
+	}
+	var size C.uint64_t
+	s.ctx.do(func() {
+		C.Z3_get_finite_domain_sort_size(s.ctx.c, s.c, &size)
+	})
+	runtime.KeepAlive(s)
+	return uint64(size)
+}
+
+// FiniteDomainValue returns the val'th value of finite-domain sort s.
+// s must belong to ctx, and val must be less than
+// s.FiniteDomainSize().
+func (ctx *Context) FiniteDomainValue(s Sort, val uint64) FiniteDomain {
+	if s.Context() != ctx {
+		panic("z3: FiniteDomainValue: sort is the zero Sort or from a different Context")
+	}
+	if n := s.FiniteDomainSize(); val >= n {
+		panic(fmt.Sprintf("z3: FiniteDomainValue: value %d out of range for sort %s of size %d", val, s, n))
+	}
+	v := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_unsigned_int64(ctx.c, C.uint64_t(val), s.c)
+	})
+	runtime.KeepAlive(s)
+	return FiniteDomain(v)
+}
+
+// AsUint64 returns the value of lit as a uint64. If lit is not a
+// literal, it returns 0, false. Every finite-domain literal fits in a
+// uint64.
+func (lit FiniteDomain) AsUint64() (val uint64, isLiteral bool) {
+	val, isLiteral, _ = lit.asUint64()
+	return val, isLiteral
+}
+
+//go:generate go run genwrap.go -t FiniteDomain -e $GOFILE
@@ -16,12 +16,22 @@ func (l FiniteDomain) Eq(r FiniteDomain) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_eq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// EqE is like Eq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l FiniteDomain) EqE(r FiniteDomain) (res Bool, err error) {
+	chk := argChecker{method: "FiniteDomain.Eq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Eq(r) })
+	return
+}
+
 // NE returns a Value that is true if l and r are not equal.
 func (l FiniteDomain) NE(r FiniteDomain) Bool {
 	return l.ctx.Distinct(l, r)
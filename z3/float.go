@@ -108,20 +108,23 @@ func (rm RoundingMode) ast(ctx *Context) value {
 	cache, _ := ctx.Extra(roundingModeKey).([]value)
 	if cache == nil {
 		cache = make([]value, roundingModesNum)
-		cache[RoundToNearestEven] = wrapValue(ctx, func() C.Z3_ast {
-			return C.Z3_mk_fpa_rne(ctx.c)
-		})
-		cache[RoundToNearestAway] = wrapValue(ctx, func() C.Z3_ast {
-			return C.Z3_mk_fpa_rna(ctx.c)
-		})
-		cache[RoundToPositive] = wrapValue(ctx, func() C.Z3_ast {
-			return C.Z3_mk_fpa_rtp(ctx.c)
-		})
-		cache[RoundToNegative] = wrapValue(ctx, func() C.Z3_ast {
-			return C.Z3_mk_fpa_rtn(ctx.c)
-		})
-		cache[RoundToZero] = wrapValue(ctx, func() C.Z3_ast {
-			return C.Z3_mk_fpa_rtz(ctx.c)
+		// The cache outlives any active Scope.
+		ctx.withoutScope(func() {
+			cache[RoundToNearestEven] = wrapValue(ctx, func() C.Z3_ast {
+				return C.Z3_mk_fpa_rne(ctx.c)
+			})
+			cache[RoundToNearestAway] = wrapValue(ctx, func() C.Z3_ast {
+				return C.Z3_mk_fpa_rna(ctx.c)
+			})
+			cache[RoundToPositive] = wrapValue(ctx, func() C.Z3_ast {
+				return C.Z3_mk_fpa_rtp(ctx.c)
+			})
+			cache[RoundToNegative] = wrapValue(ctx, func() C.Z3_ast {
+				return C.Z3_mk_fpa_rtn(ctx.c)
+			})
+			cache[RoundToZero] = wrapValue(ctx, func() C.Z3_ast {
+				return C.Z3_mk_fpa_rtz(ctx.c)
+			})
 		})
 		ctx.SetExtra(roundingModeKey, cache)
 	}
@@ -512,7 +515,7 @@ func (lit Float) AsBigFloat() (val *big.Float, isLiteral bool) {
 	return &out, true
 }
 
-//go:generate go run genwrap.go -t Float $GOFILE
+//go:generate go run genwrap.go -t Float -e $GOFILE
 
 // Abs returns the absolute value of l.
 //
@@ -16,12 +16,22 @@ func (l Float) Eq(r Float) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_eq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// EqE is like Eq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) EqE(r Float) (res Bool, err error) {
+	chk := argChecker{method: "Float.Eq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Eq(r) })
+	return
+}
+
 // NE returns a Value that is true if l and r are not equal.
 func (l Float) NE(r Float) Bool {
 	return l.ctx.Distinct(l, r)
@@ -29,380 +39,656 @@ func (l Float) NE(r Float) Bool {
 
 // Abs returns the absolute value of l.
 func (l Float) Abs() Float {
-	// Generated from float.go:491.
+	// Generated from float.go:522.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_abs(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Float(val)
 }
 
+// AbsE is like Abs, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) AbsE() (res Float, err error) {
+	chk := argChecker{method: "Float.Abs"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Abs() })
+	return
+}
+
 // Neg returns -l.
 func (l Float) Neg() Float {
-	// Generated from float.go:495.
+	// Generated from float.go:526.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_neg(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Float(val)
 }
 
+// NegE is like Neg, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) NegE() (res Float, err error) {
+	chk := argChecker{method: "Float.Neg"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Neg() })
+	return
+}
+
 // Add returns l+r.
 //
 // Add uses the current rounding mode.
 func (l Float) Add(r Float) Float {
-	// Generated from float.go:501.
+	// Generated from float.go:532.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_add(ctx.c, rm.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Float(val)
 }
 
+// AddE is like Add, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) AddE(r Float) (res Float, err error) {
+	chk := argChecker{method: "Float.Add"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Add(r) })
+	return
+}
+
 // Sub returns l-r.
 //
 // Sub uses the current rounding mode.
 func (l Float) Sub(r Float) Float {
-	// Generated from float.go:507.
+	// Generated from float.go:538.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_sub(ctx.c, rm.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Float(val)
 }
 
+// SubE is like Sub, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) SubE(r Float) (res Float, err error) {
+	chk := argChecker{method: "Float.Sub"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Sub(r) })
+	return
+}
+
 // Mul returns l*r.
 //
 // Mul uses the current rounding mode.
 func (l Float) Mul(r Float) Float {
-	// Generated from float.go:513.
+	// Generated from float.go:544.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_mul(ctx.c, rm.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Float(val)
 }
 
+// MulE is like Mul, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) MulE(r Float) (res Float, err error) {
+	chk := argChecker{method: "Float.Mul"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Mul(r) })
+	return
+}
+
 // Div returns l/r.
 //
 // Div uses the current rounding mode.
 func (l Float) Div(r Float) Float {
-	// Generated from float.go:519.
+	// Generated from float.go:550.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_div(ctx.c, rm.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Float(val)
 }
 
+// DivE is like Div, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) DivE(r Float) (res Float, err error) {
+	chk := argChecker{method: "Float.Div"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Div(r) })
+	return
+}
+
 // MulAdd returns l*r+a (fused multiply and add).
 //
 // MulAdd uses the current rounding mode on the result of the whole
 // operation.
 func (l Float) MulAdd(r Float, a Float) Float {
-	// Generated from float.go:526.
+	// Generated from float.go:557.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_fma(ctx.c, rm.c, l.c, r.c, a.c)
-	})
+	}, l.c, r.c, a.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	runtime.KeepAlive(a)
 	return Float(val)
 }
 
+// MulAddE is like MulAdd, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) MulAddE(r Float, a Float) (res Float, err error) {
+	chk := argChecker{method: "Float.MulAdd"}
+	chk.value("l", l)
+	chk.value("r", r)
+	chk.value("a", a)
+	err = chk.do(func() { res = l.MulAdd(r, a) })
+	return
+}
+
 // Sqrt returns the square root of l.
 //
 // Sqrt uses the current rounding mode.
 func (l Float) Sqrt() Float {
-	// Generated from float.go:532.
+	// Generated from float.go:563.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_sqrt(ctx.c, rm.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Float(val)
 }
 
+// SqrtE is like Sqrt, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) SqrtE() (res Float, err error) {
+	chk := argChecker{method: "Float.Sqrt"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Sqrt() })
+	return
+}
+
 // Rem returns the remainder of l/r.
 func (l Float) Rem(r Float) Float {
-	// Generated from float.go:536.
+	// Generated from float.go:567.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_rem(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Float(val)
 }
 
+// RemE is like Rem, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) RemE(r Float) (res Float, err error) {
+	chk := argChecker{method: "Float.Rem"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Rem(r) })
+	return
+}
+
 // Round rounds l to an integral floating-point value according to
 // rounding mode rm.
 func (l Float) Round(rm RoundingMode) Float {
-	// Generated from float.go:541.
+	// Generated from float.go:572.
 	ctx := l.ctx
 	rmc := rm.ast(ctx)
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_round_to_integral(ctx.c, rmc.c, l.c)
-	})
+	}, rmc.c, l.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(rm)
 	return Float(val)
 }
 
+// RoundE is like Round, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) RoundE(rm RoundingMode) (res Float, err error) {
+	chk := argChecker{method: "Float.Round"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Round(rm) })
+	return
+}
+
 // Min returns the minimum of l and r.
 func (l Float) Min(r Float) Float {
-	// Generated from float.go:545.
+	// Generated from float.go:576.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_min(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Float(val)
 }
 
+// MinE is like Min, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) MinE(r Float) (res Float, err error) {
+	chk := argChecker{method: "Float.Min"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Min(r) })
+	return
+}
+
 // Max returns the maximum of l and r.
 func (l Float) Max(r Float) Float {
-	// Generated from float.go:549.
+	// Generated from float.go:580.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_max(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Float(val)
 }
 
+// MaxE is like Max, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) MaxE(r Float) (res Float, err error) {
+	chk := argChecker{method: "Float.Max"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Max(r) })
+	return
+}
+
 // IEEEEq returns l == r according to IEEE 754 equality.
 //
 // This differs from Eq, which is true if l and r are identical. In
 // contrast, under IEEE equality, ±0 == ±0, while NaN != NaN and ±inf
 // != ±inf.
 func (l Float) IEEEEq(r Float) Bool {
-	// Generated from float.go:557.
+	// Generated from float.go:588.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_eq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// IEEEEqE is like IEEEEq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) IEEEEqE(r Float) (res Bool, err error) {
+	chk := argChecker{method: "Float.IEEEEq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.IEEEEq(r) })
+	return
+}
+
 // LT returns l < r.
 func (l Float) LT(r Float) Bool {
-	// Generated from float.go:561.
+	// Generated from float.go:592.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_lt(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// LTE is like LT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) LTE(r Float) (res Bool, err error) {
+	chk := argChecker{method: "Float.LT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.LT(r) })
+	return
+}
+
 // LE returns l <= r.
 func (l Float) LE(r Float) Bool {
-	// Generated from float.go:565.
+	// Generated from float.go:596.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_leq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// LEE is like LE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) LEE(r Float) (res Bool, err error) {
+	chk := argChecker{method: "Float.LE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.LE(r) })
+	return
+}
+
 // GT returns l > r.
 func (l Float) GT(r Float) Bool {
-	// Generated from float.go:569.
+	// Generated from float.go:600.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_gt(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// GTE is like GT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) GTE(r Float) (res Bool, err error) {
+	chk := argChecker{method: "Float.GT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.GT(r) })
+	return
+}
+
 // GE returns l >= r.
 func (l Float) GE(r Float) Bool {
-	// Generated from float.go:573.
+	// Generated from float.go:604.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_geq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// GEE is like GE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) GEE(r Float) (res Bool, err error) {
+	chk := argChecker{method: "Float.GE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.GE(r) })
+	return
+}
+
 // IsNormal returns true if l is a normal floating-point number.
 func (l Float) IsNormal() Bool {
-	// Generated from float.go:577.
+	// Generated from float.go:608.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_normal(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// IsNormalE is like IsNormal, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) IsNormalE() (res Bool, err error) {
+	chk := argChecker{method: "Float.IsNormal"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.IsNormal() })
+	return
+}
+
 // IsSubnormal returns true if l is a subnormal floating-point number.
 func (l Float) IsSubnormal() Bool {
-	// Generated from float.go:581.
+	// Generated from float.go:612.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_subnormal(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// IsSubnormalE is like IsSubnormal, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) IsSubnormalE() (res Bool, err error) {
+	chk := argChecker{method: "Float.IsSubnormal"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.IsSubnormal() })
+	return
+}
+
 // IsZero returns true if l is ±0.
 func (l Float) IsZero() Bool {
-	// Generated from float.go:585.
+	// Generated from float.go:616.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_zero(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// IsZeroE is like IsZero, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) IsZeroE() (res Bool, err error) {
+	chk := argChecker{method: "Float.IsZero"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.IsZero() })
+	return
+}
+
 // IsInfinite returns true if l is ±∞.
 func (l Float) IsInfinite() Bool {
-	// Generated from float.go:589.
+	// Generated from float.go:620.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_infinite(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// IsInfiniteE is like IsInfinite, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) IsInfiniteE() (res Bool, err error) {
+	chk := argChecker{method: "Float.IsInfinite"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.IsInfinite() })
+	return
+}
+
 // IsNaN returns true if l is NaN.
 func (l Float) IsNaN() Bool {
-	// Generated from float.go:593.
+	// Generated from float.go:624.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_nan(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// IsNaNE is like IsNaN, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) IsNaNE() (res Bool, err error) {
+	chk := argChecker{method: "Float.IsNaN"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.IsNaN() })
+	return
+}
+
 // IsNegative returns true if l is negative.
 func (l Float) IsNegative() Bool {
-	// Generated from float.go:597.
+	// Generated from float.go:628.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_negative(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// IsNegativeE is like IsNegative, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) IsNegativeE() (res Bool, err error) {
+	chk := argChecker{method: "Float.IsNegative"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.IsNegative() })
+	return
+}
+
 // IsPositive returns true if l is positive.
 func (l Float) IsPositive() Bool {
-	// Generated from float.go:601.
+	// Generated from float.go:632.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_is_positive(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// IsPositiveE is like IsPositive, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) IsPositiveE() (res Bool, err error) {
+	chk := argChecker{method: "Float.IsPositive"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.IsPositive() })
+	return
+}
+
 // ToFloat converts l into a floating-point number of a different
 // floating-point sort.
 //
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l Float) ToFloat(s Sort) Float {
-	// Generated from float.go:609.
+	// Generated from float.go:640.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_fp_float(ctx.c, rm.c, l.c, s.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
 	return Float(val)
 }
 
+// ToFloatE is like ToFloat, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) ToFloatE(s Sort) (res Float, err error) {
+	chk := argChecker{method: "Float.ToFloat"}
+	chk.value("l", l)
+	chk.sort("s", s)
+	err = chk.do(func() { res = l.ToFloat(s) })
+	return
+}
+
 // ToUBV converts l.Round() into an unsigned bit-vector of size 'bits'.
 //
 // l is first rounded to an integer using the current rounding mode.
 // If the result is not in the range [0, 2^bits-1], the result is
 // unspecified.
 func (l Float) ToUBV(bits int) BV {
-	// Generated from float.go:617.
+	// Generated from float.go:648.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_ubv(ctx.c, rm.c, l.c, C.unsigned(bits))
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// ToUBVE is like ToUBV, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) ToUBVE(bits int) (res BV, err error) {
+	chk := argChecker{method: "Float.ToUBV"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.ToUBV(bits) })
+	return
+}
+
 // ToSBV converts l.Round() into a signed bit-vector of size 'bits'.
 //
 // l is first rounded to an integer using the current rounding mode.
 // If the result is not in the range [-2^(bits-1), 2^(bits-1)-1], the
 // result is unspecified.
 func (l Float) ToSBV(bits int) BV {
-	// Generated from float.go:625.
+	// Generated from float.go:656.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_sbv(ctx.c, rm.c, l.c, C.unsigned(bits))
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// ToSBVE is like ToSBV, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) ToSBVE(bits int) (res BV, err error) {
+	chk := argChecker{method: "Float.ToSBV"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.ToSBV(bits) })
+	return
+}
+
 // ToReal converts l into a real number.
 //
 // If l is ±inf, or NaN, the result is unspecified.
 func (l Float) ToReal() Real {
-	// Generated from float.go:631.
+	// Generated from float.go:662.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_real(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Real(val)
 }
 
+// ToRealE is like ToReal, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) ToRealE() (res Real, err error) {
+	chk := argChecker{method: "Float.ToReal"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.ToReal() })
+	return
+}
+
 // ToIEEEBV converts l to a bit-vector in IEEE 754-2008 format.
 //
 // Note that NaN has many possible representations. This conversion
 // always uses the same representation.
 func (l Float) ToIEEEBV() BV {
-	// Generated from float.go:638.
+	// Generated from float.go:669.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_ieee_bv(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
+
+// ToIEEEBVE is like ToIEEEBV, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Float) ToIEEEBVE() (res BV, err error) {
+	chk := argChecker{method: "Float.ToIEEEBV"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.ToIEEEBV() })
+	return
+}
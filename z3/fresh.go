@@ -0,0 +1,92 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"strconv"
+	"sync"
+)
+
+// A FreshNamer chooses the names of constants and functions created
+// by FreshConst and FreshFuncDecl. FreshName is called with the prefix
+// passed to those methods and should return a name that has not been
+// used for any other symbol in the Context. If it returns a name that
+// the Context has already used, for example for a constant declared
+// with Const, FreshName is called again until it returns an unused
+// one, so it must not return the same name forever.
+type FreshNamer interface {
+	FreshName(prefix string) string
+}
+
+// SetFreshNamer sets the FreshNamer used by FreshConst and
+// FreshFuncDecl.
+//
+// By default, fresh names are chosen by Z3 from a counter that is
+// shared with Z3's internal fresh symbols, so the names depend on
+// everything else the Context has done. Installing a FreshNamer, such
+// as one returned by NewCounterNamer, makes the names depend only on
+// the sequence of FreshConst and FreshFuncDecl calls, which makes
+// String output and SMT-LIB dumps reproducible across runs.
+//
+// If namer is nil, ctx reverts to Z3's fresh names.
+func (ctx *Context) SetFreshNamer(namer FreshNamer) {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	ctx.freshNamer = namer
+}
+
+// freshName returns the name to use for a fresh symbol with the given
+// prefix, or "", false if Z3 should choose the name.
+func (ctx *Context) freshName(prefix string) (string, bool) {
+	ctx.lock.Lock()
+	namer := ctx.freshNamer
+	ctx.lock.Unlock()
+	if namer == nil {
+		return "", false
+	}
+	for {
+		name := namer.FreshName(prefix)
+		ctx.lock.Lock()
+		_, used := ctx.syms[name]
+		ctx.lock.Unlock()
+		if !used {
+			return name, true
+		}
+	}
+}
+
+// A CounterNamer is a FreshNamer that names the n'th fresh symbol with
+// a given prefix "prefix!n", counting from 0 separately for each
+// prefix. This matches the form of Z3's own fresh names. A
+// CounterNamer is safe for concurrent use.
+type CounterNamer struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewCounterNamer returns a new CounterNamer with all counters at 0.
+func NewCounterNamer() *CounterNamer {
+	return &CounterNamer{counts: make(map[string]int)}
+}
+
+// FreshName returns the next name for prefix.
+func (n *CounterNamer) FreshName(prefix string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	i := n.counts[prefix]
+	n.counts[prefix] = i + 1
+	return prefix + "!" + strconv.Itoa(i)
+}
+
+// Reset sets all counters back to 0. This makes the names of
+// subsequent fresh symbols independent of earlier ones, for example
+// when the CounterNamer is shared by several Contexts used in turn. In
+// a Context that has already used a name, the name is skipped, so
+// fresh symbols remain distinct from all earlier ones.
+func (n *CounterNamer) Reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.counts = make(map[string]int)
+}
@@ -0,0 +1,53 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestFreshNamer(t *testing.T) {
+	names := func() []string {
+		ctx := NewContext(nil)
+		ctx.SetFreshNamer(NewCounterNamer())
+		x0 := ctx.FreshConst("x", ctx.IntSort())
+		x1 := ctx.FreshConst("x", ctx.BoolSort())
+		f := ctx.FreshFuncDecl("f", []Sort{ctx.IntSort()}, ctx.IntSort())
+		return []string{x0.String(), x1.String(), f.Apply(ctx.Int(1)).String()}
+	}
+	want := []string{"x!0", "x!1", "(f!0 1)"}
+	for run := 0; run < 2; run++ {
+		got := names()
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("run %d: name %d = %q, want %q", run, i, got[i], want[i])
+			}
+		}
+	}
+
+	ctx := NewContext(nil)
+	ctx.SetFreshNamer(NewCounterNamer())
+	a, b := ctx.FreshConst("a", ctx.IntSort()).(Int), ctx.FreshConst("a", ctx.IntSort()).(Int)
+	solver := NewSolver(ctx)
+	solver.Assert(a.NE(b))
+	if sat, err := solver.Check(); !sat || err != nil {
+		t.Errorf("fresh constants should be distinct: sat=%v err=%v", sat, err)
+	}
+
+	// Names already used, by earlier fresh constants before a
+	// Reset or by ordinary constants, are skipped.
+	namer := NewCounterNamer()
+	ctx.SetFreshNamer(namer)
+	c0 := ctx.FreshConst("c", ctx.IntSort()).(Int)
+	namer.Reset()
+	user := ctx.IntConst("c!1")
+	c2 := ctx.FreshConst("c", ctx.IntSort()).(Int)
+	if got := c2.String(); got != "c!2" {
+		t.Errorf("fresh constant after Reset is %q, want c!2", got)
+	}
+	solver.Reset()
+	solver.Assert(c0.NE(c2).And(user.NE(c2)))
+	if sat, err := solver.Check(); !sat || err != nil {
+		t.Errorf("fresh constants after Reset should be distinct: sat=%v err=%v", sat, err)
+	}
+}
@@ -5,7 +5,9 @@
 package z3
 
 import (
+	"fmt"
 	"runtime"
+	"strconv"
 	"unsafe"
 )
 
@@ -39,7 +41,7 @@ func wrapFuncDecl(ctx *Context, c C.Z3_func_decl) FuncDecl {
 	impl := &funcDeclImpl{ctx, c}
 	C.Z3_inc_ref(ctx.c, C.Z3_func_decl_to_ast(ctx.c, c))
 	runtime.SetFinalizer(impl, func(impl *funcDeclImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_dec_ref(impl.ctx.c, C.Z3_func_decl_to_ast(impl.ctx.c, impl.c))
 		})
 	})
@@ -53,7 +55,18 @@ func wrapFuncDecl(ctx *Context, c C.Z3_func_decl) FuncDecl {
 // function is only assigned an interpretation in a particular model,
 // and different models may assign different interpretations.
 func (ctx *Context) FuncDecl(name string, domain []Sort, range_ Sort) FuncDecl {
-	sym := ctx.symbol(name)
+	return ctx.FuncDeclSym(ctx.StringSymbol(name), domain, range_)
+}
+
+// FreshFuncDecl creates a fresh uninterpreted function distinct from
+// all other functions. If ctx has a FreshNamer, it chooses the name;
+// see SetFreshNamer.
+func (ctx *Context) FreshFuncDecl(prefix string, domain []Sort, range_ Sort) FuncDecl {
+	if name, ok := ctx.freshName(prefix); ok {
+		return ctx.FuncDecl(name, domain, range_)
+	}
+	cprefix := C.CString(prefix)
+	defer C.free(unsafe.Pointer(cprefix))
 	cdomain := make([]C.Z3_sort, len(domain))
 	for i, sort := range domain {
 		cdomain[i] = sort.c
@@ -64,18 +77,23 @@ func (ctx *Context) FuncDecl(name string, domain []Sort, range_ Sort) FuncDecl {
 		if len(cdomain) > 0 {
 			cdp = &cdomain[0]
 		}
-		funcdecl = wrapFuncDecl(ctx, C.Z3_mk_func_decl(ctx.c, sym, C.uint(len(cdomain)), cdp, range_.c))
+		funcdecl = wrapFuncDecl(ctx, C.Z3_mk_fresh_func_decl(ctx.c, cprefix, C.uint(len(cdomain)), cdp, range_.c))
 	})
 	runtime.KeepAlive(domain)
 	runtime.KeepAlive(range_)
 	return funcdecl
 }
 
-// FreshFuncDecl creates a fresh uninterpreted function distinct from
-// all other functions.
-func (ctx *Context) FreshFuncDecl(prefix string, domain []Sort, range_ Sort) FuncDecl {
-	cprefix := C.CString(prefix)
-	defer C.free(unsafe.Pointer(cprefix))
+// RecFuncDecl declares a recursive function named "name". Its
+// definition must be given by AddRecDef before f is used in a
+// Solver.
+//
+// Unlike an uninterpreted function with a quantified axiom defining
+// it, a recursive function is unfolded by the solver as far as
+// needed, which makes it suitable for definitions over sequences and
+// ranges of integers.
+func (ctx *Context) RecFuncDecl(name string, domain []Sort, range_ Sort) FuncDecl {
+	sym := ctx.StringSymbol(name)
 	cdomain := make([]C.Z3_sort, len(domain))
 	for i, sort := range domain {
 		cdomain[i] = sort.c
@@ -86,13 +104,34 @@ func (ctx *Context) FreshFuncDecl(prefix string, domain []Sort, range_ Sort) Fun
 		if len(cdomain) > 0 {
 			cdp = &cdomain[0]
 		}
-		funcdecl = wrapFuncDecl(ctx, C.Z3_mk_fresh_func_decl(ctx.c, cprefix, C.uint(len(cdomain)), cdp, range_.c))
+		funcdecl = wrapFuncDecl(ctx, C.Z3_mk_rec_func_decl(ctx.c, sym.c, C.uint(len(cdomain)), cdp, range_.c))
 	})
 	runtime.KeepAlive(domain)
 	runtime.KeepAlive(range_)
 	return funcdecl
 }
 
+// AddRecDef defines the recursive function f, which must have been
+// created by RecFuncDecl, as body. args are constants, such as those
+// created by FreshConst, that stand for f's arguments in body. body
+// may apply f.
+func (f FuncDecl) AddRecDef(args []Value, body Value) {
+	cargs := make([]C.Z3_ast, len(args))
+	for i, arg := range args {
+		cargs[i] = arg.impl().c
+	}
+	f.ctx.do(func() {
+		var cap *C.Z3_ast
+		if len(cargs) > 0 {
+			cap = &cargs[0]
+		}
+		C.Z3_add_rec_def(f.ctx.c, f.c, C.uint(len(cargs)), cap, body.impl().c)
+	})
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(args)
+	runtime.KeepAlive(body)
+}
+
 // Context returns the Context that created f.
 func (f FuncDecl) Context() *Context {
 	if f.funcDeclImpl == nil {
@@ -121,6 +160,39 @@ func (f FuncDecl) AsAST() AST {
 	return ast
 }
 
+// Name returns the name of f.
+func (f FuncDecl) Name() Symbol {
+	var sym Symbol
+	f.ctx.do(func() {
+		sym = Symbol{f.ctx, C.Z3_get_decl_name(f.ctx.c, f.c)}
+	})
+	runtime.KeepAlive(f)
+	return sym
+}
+
+// Domain returns the sorts of f's arguments.
+func (f FuncDecl) Domain() []Sort {
+	var sorts []Sort
+	f.ctx.do(func() {
+		n := int(C.Z3_get_domain_size(f.ctx.c, f.c))
+		for i := 0; i < n; i++ {
+			sorts = append(sorts, wrapSort(f.ctx, C.Z3_get_domain(f.ctx.c, f.c, C.uint(i)), KindUnknown))
+		}
+	})
+	runtime.KeepAlive(f)
+	return sorts
+}
+
+// Range returns the sort of f's result.
+func (f FuncDecl) Range() Sort {
+	var sort Sort
+	f.ctx.do(func() {
+		sort = wrapSort(f.ctx, C.Z3_get_range(f.ctx.c, f.c), KindUnknown)
+	})
+	runtime.KeepAlive(f)
+	return sort
+}
+
 // Apply creates a Value representing the result of applying f to
 // args.
 //
@@ -143,6 +215,34 @@ func (f FuncDecl) Apply(args ...Value) Value {
 	return val.lift(KindUnknown)
 }
 
+// ApplyE is like Apply, but returns an error instead of panicking if
+// the arguments are invalid. Before calling Z3, it checks that args
+// has as many elements as f's domain and that each has the sort of
+// the corresponding domain element, and reports the first mismatch
+// as an *ArgError naming the argument's position, such as args[1].
+// This is useful when argument lists are built dynamically.
+func (f FuncDecl) ApplyE(args ...Value) (res Value, err error) {
+	chk := argChecker{method: "FuncDecl.Apply"}
+	chk.check("f", f.Context())
+	for i, arg := range args {
+		chk.elem("args", i, arg)
+	}
+	if chk.err != nil {
+		return nil, chk.err
+	}
+	domain := f.Domain()
+	if len(args) != len(domain) {
+		return nil, &ArgError{chk.method, "args", fmt.Errorf("%w: got %d, want %d", ErrArgCount, len(args), len(domain))}
+	}
+	for i, arg := range args {
+		if s := arg.Sort(); !s.Equal(domain[i]) {
+			return nil, &ArgError{chk.method, "args[" + strconv.Itoa(i) + "]", fmt.Errorf("%w: got %s, want %s", ErrSortMismatch, s, domain[i])}
+		}
+	}
+	err = chk.do(func() { res = f.Apply(args...) })
+	return
+}
+
 // Map applies f to each value in each of the args array.
 //
 // Given that f has sort range_1, ..., range_n -> range, args[i] must
@@ -4,7 +4,10 @@
 
 package z3
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestFuncDecl(t *testing.T) {
 	ctx := NewContext(nil)
@@ -22,3 +25,99 @@ func TestFuncDecl(t *testing.T) {
 		t.Errorf("%s satisfiable: %s", s, err)
 	}
 }
+
+func TestSymbols(t *testing.T) {
+	ctx := NewContext(nil)
+	i3, s := ctx.IntSymbol(3), ctx.StringSymbol("x")
+	if v, ok := i3.AsInt(); !ok || v != 3 || !i3.IsInt() {
+		t.Errorf("IntSymbol(3).AsInt() = %v, %v", v, ok)
+	}
+	if _, ok := s.AsInt(); ok || s.IsInt() || s.String() != "x" {
+		t.Errorf("StringSymbol(\"x\") = %v", s)
+	}
+
+	// Constants with the same symbol are the same.
+	a := ctx.ConstSym(ctx.IntSymbol(3), ctx.BoolSort()).(Bool)
+	b := ctx.ConstSym(i3, ctx.BoolSort()).(Bool)
+	c := ctx.ConstSym(s, ctx.BoolSort()).(Bool)
+	solver := NewSolver(ctx)
+	solver.Assert(a.Xor(b))
+	if sat, _ := solver.Check(); sat {
+		t.Errorf("%v and %v should be the same constant", a, b)
+	}
+	solver.Reset()
+	solver.Assert(a.Xor(c))
+	if sat, _ := solver.Check(); !sat {
+		t.Errorf("%v and %v should be different constants", a, c)
+	}
+
+	f := ctx.FuncDeclSym(ctx.IntSymbol(7), []Sort{ctx.IntSort()}, ctx.IntSort())
+	if v, ok := f.Name().AsInt(); !ok || v != 7 {
+		t.Errorf("f.Name() = %v", f.Name())
+	}
+	if got := ctx.FuncDecl("g", nil, ctx.IntSort()).Name().String(); got != "g" {
+		t.Errorf("g.Name() = %q", got)
+	}
+}
+
+func TestRecFuncDecl(t *testing.T) {
+	ctx := NewContext(nil)
+	is := ctx.IntSort()
+	fact := ctx.RecFuncDecl("fact", []Sort{is}, is)
+	n := ctx.FreshConst("n", is).(Int)
+	fact.AddRecDef([]Value{n}, n.LE(ctx.Int(0)).IfThenElse(ctx.Int(1),
+		n.Mul(fact.Apply(n.Sub(ctx.Int(1))).(Int))))
+
+	s := NewSolver(ctx)
+	s.Assert(fact.Apply(ctx.Int(5)).(Int).NE(ctx.Int(120)))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("fact(5) != 120: got %v, %v; want unsat", sat, err)
+	}
+	x := ctx.IntConst("x")
+	s.Reset()
+	s.Assert(fact.Apply(x).(Int).Eq(ctx.Int(24)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("fact(x) = 24: got %v, %v; want sat", sat, err)
+	}
+	if got := s.Model().Eval(x, true).String(); got != "4" {
+		t.Errorf("x = %s, want 4", got)
+	}
+}
+
+func TestFuncDeclApplyE(t *testing.T) {
+	ctx := NewContext(nil)
+	f := ctx.FuncDecl("f", []Sort{ctx.IntSort(), ctx.BoolSort()}, ctx.IntSort())
+	x, p := ctx.IntConst("x"), ctx.BoolConst("p")
+
+	v, err := f.ApplyE(x, p)
+	if err != nil {
+		t.Fatalf("ApplyE: %v", err)
+	}
+	if _, ok := v.(Int); !ok {
+		t.Errorf("ApplyE returned %T, want Int", v)
+	}
+
+	_, err = f.ApplyE(x)
+	if !errors.Is(err, ErrArgCount) || err.Error() != "z3: FuncDecl.Apply: argument args: wrong number of arguments: got 1, want 2" {
+		t.Errorf("ApplyE with one argument: got %v", err)
+	}
+	_, err = f.ApplyE(x, x)
+	var argErr *ArgError
+	if !errors.As(err, &argErr) || argErr.Arg != "args[1]" || !errors.Is(err, ErrSortMismatch) {
+		t.Errorf("ApplyE with wrong sort: got %v", err)
+	}
+	if want := "z3: FuncDecl.Apply: argument args[1]: sort mismatch: got Int, want Bool"; err == nil || err.Error() != want {
+		t.Errorf("ApplyE with wrong sort: got %v, want %s", err, want)
+	}
+	_, err = f.ApplyE(x, nil)
+	if !errors.Is(err, ErrZeroValue) {
+		t.Errorf("ApplyE with nil: got %v", err)
+	}
+	_, err = f.ApplyE(NewContext(nil).IntConst("x"), p)
+	if !errors.Is(err, ErrContextMismatch) {
+		t.Errorf("ApplyE with other context: got %v", err)
+	}
+	if _, err := (FuncDecl{}).ApplyE(x, p); !errors.Is(err, ErrZeroValue) {
+		t.Errorf("ApplyE of zero FuncDecl: got %v", err)
+	}
+}
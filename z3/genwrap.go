@@ -5,6 +5,25 @@
 //go:build ignore
 // +build ignore
 
+// Genwrap generates Go methods that wrap Z3 C functions.
+//
+// It reads directives of the form
+//
+//	//wrap:expr Method[:ResultType] args... [: CFunc cargs...]
+//
+// from the comment lines of its input files, and emits a method for
+// each, documented by the comment lines directly above the directive.
+// See the existing uses in this package for the argument syntax.
+//
+// The inputs need not be Go files. A downstream fork can wrap Z3
+// functions that this package does not yet cover by putting directives
+// in a spec file of its own and generating a separate output from it,
+// without editing any existing file:
+//
+//	//go:generate go run genwrap.go -t BV -common=false -o bv_extra.wrap.go bv_extra.wrapspec
+//
+// A spec file may change the default type for the directives after it
+// with a "//wrap:type Type" line.
 package main
 
 import (
@@ -17,11 +36,17 @@ import (
 	"strings"
 )
 
-var flagType = flag.String("t", "", "default arguments and results to `type`")
+var (
+	flagType = flag.String("t", "", "default arguments and results to `type`")
+	flagE    = flag.Bool("e", false, "also generate error-returning E variants of methods")
+	flagOut  = flag.String("o", "", "write output to `file` (default: first input file with a .wrap.go suffix)")
+
+	flagCommon = flag.Bool("common", true, "generate the Eq and NE methods of the -t type")
+)
 
 func main() {
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s -t type file.go [file2.go...]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s -t type [-e] [-o out.go] file [file2...]\n", os.Args[0])
 		flag.PrintDefaults()
 	}
 	flag.Parse()
@@ -30,11 +55,14 @@ func main() {
 		os.Exit(2)
 	}
 
-	if !strings.HasSuffix(flag.Arg(0), ".go") {
-		fmt.Fprintf(os.Stderr, "not a .go file: %s\n", flag.Arg(0))
-		os.Exit(1)
+	nfilename := *flagOut
+	if nfilename == "" {
+		if !strings.HasSuffix(flag.Arg(0), ".go") {
+			fmt.Fprintf(os.Stderr, "not a .go file: %s\n", flag.Arg(0))
+			os.Exit(1)
+		}
+		nfilename = flag.Arg(0)[:len(flag.Arg(0))-3] + ".wrap.go"
 	}
-	nfilename := flag.Arg(0)[:len(flag.Arg(0))-3] + ".wrap.go"
 
 	// Emit prologue.
 	var out bytes.Buffer
@@ -54,9 +82,15 @@ import "C"
 `)
 
 	// Emit common methods.
-	genCommon(&out)
+	if *flagCommon {
+		genCommon(&out)
+	}
 
+	defType := *flagType
 	for _, filename := range flag.Args() {
+		// //wrap:type applies to the rest of its file.
+		*flagType = defType
+
 		code, err := ioutil.ReadFile(filename)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err)
@@ -96,6 +130,9 @@ func genCommon(w *bytes.Buffer) {
 	fmt.Fprintln(w, "// Eq returns a Value that is true if l and r are equal.")
 	dir := parseDirective(strings.Fields("//wrap:expr Eq:Bool Z3_mk_eq l r"))
 	genMethod(w, dir, "")
+	if *flagE {
+		genMethodE(w, dir)
+	}
 
 	fmt.Fprintf(w, `// NE returns a Value that is true if l and r are not equal.
 func (l %s) NE(r %s) Bool {
@@ -115,6 +152,10 @@ type directive struct {
 
 type arg struct {
 	name, goTyp, cExpr, cCode, setup string
+
+	// isAST is set if the C argument is a Z3_ast, which is passed
+	// on to the Context's TraceFunc.
+	isAST bool
 }
 
 func (a arg) c(varName string) string {
@@ -171,7 +212,7 @@ func parseDirective(parts []string) *directive {
 	argMap := make(map[string]*arg)
 	for _, goArg := range goArgs {
 		name, goTyp := split(goArg, defType)
-		argMap[name] = &arg{name, goTyp, "", "", ""}
+		argMap[name] = &arg{name: name, goTyp: goTyp}
 		dir.goArgs = append(dir.goArgs, argMap[name])
 	}
 	for _, cArg := range cArgs {
@@ -195,11 +236,14 @@ func parseDirective(parts []string) *directive {
 		}
 		if cTyp == "" && arg.goTyp == "Value" {
 			arg.cExpr = "%s.impl().c" // Value interface
+			arg.isAST = true
 		} else if cTyp == "" && arg.goTyp == "RoundingMode" {
 			arg.setup = "rmc := " + arg.name + ".ast(ctx)"
 			arg.cCode = "rmc.c"
+			arg.isAST = true
 		} else if cTyp == "" {
 			arg.cExpr = "%s.c" // expr wrapper
+			arg.isAST = arg.goTyp != "Sort"
 		} else {
 			arg.cExpr = "C." + cTyp + "(%s)" // basic type
 		}
@@ -214,10 +258,14 @@ func parseDirective(parts []string) *directive {
 }
 
 func process(w *bytes.Buffer, line []byte, doc [][]byte, label string) {
-	if !bytes.Contains(line, []byte("//wrap:expr")) {
+	if !bytes.Contains(line, []byte("//wrap:")) {
 		return
 	}
 	parts := strings.Fields(string(line))
+	if parts[0] == "//wrap:type" && len(parts) == 2 {
+		*flagType = parts[1]
+		return
+	}
 	if parts[0] != "//wrap:expr" {
 		return
 	}
@@ -234,6 +282,9 @@ func process(w *bytes.Buffer, line []byte, doc [][]byte, label string) {
 	}
 
 	genMethod(w, dir, label)
+	if *flagE {
+		genMethodE(w, dir)
+	}
 }
 
 func genMethod(w *bytes.Buffer, dir *directive, label string) {
@@ -290,7 +341,18 @@ func genMethod(w *bytes.Buffer, dir *directive, label string) {
 		fmt.Fprintf(w, ", C.uint(len(cargs)), &cargs[0]")
 	}
 	fmt.Fprintf(w, ")\n")
-	fmt.Fprintf(w, " })\n")
+	// Pass the operands on for tracing.
+	fmt.Fprintf(w, " }")
+	if !dir.isDDD {
+		for _, a := range dir.cArgs {
+			if a.isAST {
+				fmt.Fprintf(w, ", %s", a.c(a.name))
+			}
+		}
+	} else {
+		fmt.Fprintf(w, ", cargs...")
+	}
+	fmt.Fprintf(w, ")\n")
 
 	// Keep arguments alive.
 	if !dir.isDDD {
@@ -313,3 +375,44 @@ func genMethod(w *bytes.Buffer, dir *directive, label string) {
 	fmt.Fprintf(w, "}\n")
 	fmt.Fprintf(w, "\n")
 }
+
+// genMethodE generates the E variant of the method for dir, which
+// checks its arguments and returns an error instead of panicking.
+func genMethodE(w *bytes.Buffer, dir *directive) {
+	recv := dir.goArgs[0]
+	typeName := strings.TrimPrefix(recv.goTyp, "*")
+
+	fmt.Fprintf(w, "// %sE is like %s, but returns an error instead of\n", dir.goFn, dir.goFn)
+	fmt.Fprintf(w, "// panicking if the arguments are invalid.\n")
+	fmt.Fprintf(w, "func (%s %s) %sE(", recv.name, recv.goTyp, dir.goFn)
+	var params, callArgs []string
+	for _, a := range dir.goArgs[1:] {
+		// A "..." suffix on a name makes a variadic parameter
+		// or call argument.
+		params = append(params, fmt.Sprintf("%s %s", a.name, a.goTyp))
+		callArgs = append(callArgs, a.name)
+	}
+	fmt.Fprintf(w, "%s) (res %s, err error) {\n", strings.Join(params, ", "), dir.resType)
+
+	if recv.goTyp == "*Context" {
+		fmt.Fprintf(w, " chk := argChecker{method: %q, ctx: %s}\n", typeName+"."+dir.goFn, recv.name)
+	} else {
+		fmt.Fprintf(w, " chk := argChecker{method: %q}\n", typeName+"."+dir.goFn)
+	}
+	for _, a := range dir.goArgs {
+		switch {
+		case a.goTyp == "*Context", a.goTyp == "int", a.goTyp == "RoundingMode":
+			// Nothing to check.
+		case strings.HasSuffix(a.name, "..."):
+			name := strings.TrimSuffix(a.name, "...")
+			fmt.Fprintf(w, " for i, v := range %s { chk.elem(%q, i, v) }\n", name, name)
+		case a.goTyp == "Sort":
+			fmt.Fprintf(w, " chk.sort(%q, %s)\n", a.name, a.name)
+		default:
+			fmt.Fprintf(w, " chk.value(%q, %s)\n", a.name, a.name)
+		}
+	}
+	fmt.Fprintf(w, " err = chk.do(func() { res = %s.%s(%s) })\n", recv.name, dir.goFn, strings.Join(callArgs, ", "))
+	fmt.Fprintf(w, " return\n")
+	fmt.Fprintf(w, "}\n\n")
+}
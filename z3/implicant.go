@@ -0,0 +1,75 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"runtime"
+)
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// PrimeImplicant shrinks m to a prime implicant of f: a minimal set of
+// the assignments m makes to vars that still implies f, whatever
+// values the other variables take. Each assignment is returned as a
+// literal, v or !v for a Bool variable and v == value otherwise, in
+// the order of vars.
+//
+// Dropping the variables that don't matter gives a compact
+// explanation of why f holds, or a test input that exercises f with
+// the fewest fixed values. The result is minimal, not minimum: no
+// literal can be removed from it, but a smaller prime implicant may
+// exist.
+//
+// vars must include every constant in f that the result may need to
+// fix. If the assignments of m to vars don't imply f, for example
+// because f is false in m, PrimeImplicant returns an error.
+func (m *Model) PrimeImplicant(f Bool, vars []Value) ([]Bool, error) {
+	ctx := m.ctx
+	lits := make([]Bool, len(vars))
+	for i, v := range vars {
+		x := m.Eval(v, true)
+		if b, ok := v.(Bool); ok {
+			if val, _ := x.(Bool).AsBool(); val {
+				lits[i] = b
+			} else {
+				lits[i] = b.Not()
+			}
+			continue
+		}
+		lits[i] = Bool(wrapValue(ctx, func() C.Z3_ast {
+			return C.Z3_mk_eq(ctx.c, v.impl().c, x.impl().c)
+		}))
+		runtime.KeepAlive(v)
+		runtime.KeepAlive(x)
+	}
+
+	// The literals imply f exactly when they are inconsistent with
+	// its negation.
+	s := NewSolver(ctx)
+	defer s.Close()
+	s.Assert(f.Not())
+	ss := newSubsetSolver(s, lits)
+	defer ss.close()
+	subset := make([]bool, len(lits))
+	for i := range subset {
+		subset[i] = true
+	}
+	sat, err := ss.check(subset)
+	if err != nil {
+		return nil, err
+	}
+	if sat {
+		return nil, errors.New("z3: PrimeImplicant: assignment does not imply formula")
+	}
+	ss.core(subset)
+	if err := ss.shrink(subset); err != nil {
+		return nil, err
+	}
+	return ss.selected(subset, true), nil
+}
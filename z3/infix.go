@@ -0,0 +1,316 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// An InfixFormatter renders Values in a Go-like infix syntax, such as
+//
+//	x + 2*y <= 7 && (b || z != 0)
+//
+// which is easier to read for people who don't know SMT-LIB than the
+// S-expressions of Value.String.
+//
+// Arithmetic, comparison, Boolean, and unsigned bit-vector operations
+// use Go's operators, with == for equality and ==> for implication.
+// Other operations, including the signed bit-vector operations, are
+// written as function calls named after their Z3 declaration, such as
+// bvsle(x, y) and ite(c, x, y). Quantifiers are written in SMT-LIB
+// syntax.
+//
+// The zero InfixFormatter uses Go's operator precedence and does not
+// wrap lines.
+type InfixFormatter struct {
+	// Width is the maximum line width. An expression that does not
+	// fit is broken before the operators of its outermost infix
+	// operation, with each operand indented on its own line, and
+	// function calls are broken after each argument. If Width is 0,
+	// the output is a single line.
+	Width int
+
+	// Precedence overrides the precedence of operators, keyed by
+	// operator, such as "&&". Higher numbers bind more tightly.
+	// The defaults are those of Go: 5 for * / % << >> &, 4 for + -
+	// | ^, 3 for comparisons, 2 for &&, 1 for ||, and 0 for ==>.
+	// Unary operators bind more tightly than all binary operators.
+	Precedence map[string]int
+}
+
+// FormatInfix renders x with the zero InfixFormatter.
+func FormatInfix(x Value) string {
+	var f InfixFormatter
+	return f.Format(x)
+}
+
+// Format renders x in infix syntax.
+func (f *InfixFormatter) Format(x Value) string {
+	ctx := x.impl().ctx
+	var n *infixNode
+	ctx.do(func() {
+		n = infixTree(ctx.c, x.impl().c)
+	})
+	runtime.KeepAlive(x)
+	return f.render(n, "")
+}
+
+// An infixNode is an expression prepared for rendering.
+type infixNode struct {
+	text string // Leaf text, or operator or function name
+	op   opClass
+	args []*infixNode
+}
+
+type opClass int
+
+const (
+	opLeaf   opClass = iota
+	opCall           // text(args...)
+	opUnary          // text arg
+	opInfix          // arg text arg text arg...
+	opInfixA         // An associative opInfix
+)
+
+// infixOps maps Z3 declaration kinds to Go-like operators. Operators
+// marked true are associative.
+var infixOps = map[C.Z3_decl_kind]struct {
+	op    string
+	assoc bool
+}{
+	C.Z3_OP_ADD: {"+", true}, C.Z3_OP_SUB: {"-", false}, C.Z3_OP_MUL: {"*", true},
+	C.Z3_OP_DIV: {"/", false}, C.Z3_OP_IDIV: {"/", false}, C.Z3_OP_MOD: {"%", false},
+	C.Z3_OP_LE: {"<=", false}, C.Z3_OP_LT: {"<", false},
+	C.Z3_OP_GE: {">=", false}, C.Z3_OP_GT: {">", false},
+	C.Z3_OP_EQ: {"==", false}, C.Z3_OP_IFF: {"==", false},
+	C.Z3_OP_AND: {"&&", true}, C.Z3_OP_OR: {"||", true},
+	C.Z3_OP_IMPLIES: {"==>", false}, C.Z3_OP_XOR: {"!=", false},
+	C.Z3_OP_BADD: {"+", true}, C.Z3_OP_BSUB: {"-", false}, C.Z3_OP_BMUL: {"*", true},
+	C.Z3_OP_BUDIV: {"/", false}, C.Z3_OP_BUREM: {"%", false},
+	C.Z3_OP_BAND: {"&", true}, C.Z3_OP_BOR: {"|", true}, C.Z3_OP_BXOR: {"^", true},
+	C.Z3_OP_BSHL: {"<<", false}, C.Z3_OP_BLSHR: {">>", false},
+	C.Z3_OP_ULEQ: {"<=", false}, C.Z3_OP_ULT: {"<", false},
+	C.Z3_OP_UGEQ: {">=", false}, C.Z3_OP_UGT: {">", false},
+	C.Z3_OP_SEQ_CONCAT: {"+", true},
+}
+
+var unaryOps = map[C.Z3_decl_kind]string{
+	C.Z3_OP_NOT:    "!",
+	C.Z3_OP_UMINUS: "-",
+	C.Z3_OP_BNOT:   "^",
+	C.Z3_OP_BNEG:   "-",
+}
+
+var defaultPrecedence = map[string]int{
+	"*": 5, "/": 5, "%": 5, "<<": 5, ">>": 5, "&": 5,
+	"+": 4, "-": 4, "|": 4, "^": 4,
+	"==": 3, "!=": 3, "<": 3, "<=": 3, ">": 3, ">=": 3,
+	"&&":  2,
+	"||":  1,
+	"==>": 0,
+}
+
+// infixTree converts a to an infixNode. This must be called with the
+// ctx.lock held.
+func infixTree(c C.Z3_context, a C.Z3_ast) *infixNode {
+	switch C.Z3_get_ast_kind(c, a) {
+	case C.Z3_NUMERAL_AST:
+		return &infixNode{text: C.GoString(C.Z3_get_numeral_string(c, a))}
+	case C.Z3_APP_AST:
+	default:
+		return &infixNode{text: C.GoString(C.Z3_ast_to_string(c, a))}
+	}
+	app := C.Z3_to_app(c, a)
+	decl := C.Z3_get_app_decl(c, app)
+	kind := C.Z3_get_decl_kind(c, decl)
+	nargs := int(C.Z3_get_app_num_args(c, app))
+	if kind == C.Z3_OP_TRUE {
+		return &infixNode{text: "true"}
+	} else if kind == C.Z3_OP_FALSE {
+		return &infixNode{text: "false"}
+	}
+	if z3ToBool(C.Z3_is_string(c, a)) {
+		return &infixNode{text: strconv.Quote(C.GoString(C.Z3_get_string(c, a)))}
+	}
+	if nargs == 0 {
+		return &infixNode{text: C.GoString(C.Z3_ast_to_string(c, a))}
+	}
+	n := &infixNode{op: opCall, text: C.GoString(C.Z3_get_symbol_string(c, C.Z3_get_decl_name(c, decl)))}
+	if op, ok := infixOps[kind]; ok && nargs >= 2 {
+		n.op, n.text = opInfix, op.op
+		if op.assoc {
+			n.op = opInfixA
+		}
+	} else if op, ok := unaryOps[kind]; ok && nargs == 1 {
+		n.op, n.text = opUnary, op
+	} else if kind == C.Z3_OP_DISTINCT && nargs == 2 {
+		n.op, n.text = opInfix, "!="
+	} else if kind == C.Z3_OP_ITE {
+		n.text = "ite"
+	}
+	for i := 0; i < nargs; i++ {
+		n.args = append(n.args, infixTree(c, C.Z3_get_app_arg(c, app, C.uint(i))))
+	}
+	return n
+}
+
+// unaryPrec is the precedence of unary operations and leaves.
+const unaryPrec = 1 << 10
+
+func (f *InfixFormatter) prec(n *infixNode) int {
+	switch n.op {
+	case opInfix, opInfixA:
+		if p, ok := f.Precedence[n.text]; ok {
+			return p
+		}
+		return defaultPrecedence[n.text]
+	case opUnary:
+		return unaryPrec - 1
+	case opLeaf:
+		// Negative and rational literals behave like the
+		// operations they look like.
+		if strings.HasPrefix(n.text, "-") {
+			return unaryPrec - 1
+		}
+		if strings.Contains(n.text, "/") && !strings.HasPrefix(n.text, `"`) {
+			return f.prec(&infixNode{op: opInfix, text: "/"})
+		}
+	}
+	return unaryPrec
+}
+
+// needParens reports whether argument i of n must be parenthesized.
+func (f *InfixFormatter) needParens(n *infixNode, i int) bool {
+	arg := n.args[i]
+	pa, pn := f.prec(arg), f.prec(n)
+	switch {
+	case pa > pn:
+		return false
+	case pa < pn:
+		return true
+	case n.op == opUnary:
+		return arg.op == opUnary || arg.op == opLeaf
+	case n.op == opInfixA && arg.op == opInfixA && arg.text == n.text:
+		return false
+	}
+	// Same precedence: left-associative, except that comparisons
+	// do not chain.
+	return i > 0 || pn == f.prec(&infixNode{op: opInfix, text: "=="})
+}
+
+// render renders n, which starts a line indented by indent if it
+// needs to be broken.
+func (f *InfixFormatter) render(n *infixNode, indent string) string {
+	flat := f.flat(n)
+	if f.Width <= 0 || len(indent)+len(flat) <= f.Width || n.op == opLeaf {
+		return flat
+	}
+	inner := indent + "    "
+	var buf strings.Builder
+	switch n.op {
+	case opUnary:
+		buf.WriteString(n.text)
+		f.writeArg(&buf, n, 0, indent)
+	case opCall:
+		buf.WriteString(n.text)
+		buf.WriteString("(\n")
+		for i, arg := range n.args {
+			buf.WriteString(inner)
+			buf.WriteString(f.render(arg, inner))
+			if i < len(n.args)-1 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(indent)
+		buf.WriteString(")")
+	default:
+		for i := range n.args {
+			if i > 0 {
+				buf.WriteString("\n")
+				buf.WriteString(indent)
+				buf.WriteString(n.text)
+				buf.WriteString(" ")
+			}
+			f.writeArg(&buf, n, i, indent)
+		}
+	}
+	return buf.String()
+}
+
+// writeArg writes argument i of n, in parentheses if needed.
+func (f *InfixFormatter) writeArg(buf *strings.Builder, n *infixNode, i int, indent string) {
+	if flat := f.flatArg(n, i); len(indent)+len(flat) <= f.Width {
+		buf.WriteString(flat)
+		return
+	}
+	if !f.needParens(n, i) {
+		buf.WriteString(f.render(n.args[i], indent))
+		return
+	}
+	inner := indent + "    "
+	buf.WriteString("(\n" + inner + f.render(n.args[i], inner) + "\n" + indent + ")")
+}
+
+// flat renders n on a single line.
+func (f *InfixFormatter) flat(n *infixNode) string {
+	return f.flatIn(n, false)
+}
+
+// flatArg renders argument i of n on a single line, in parentheses if
+// needed.
+func (f *InfixFormatter) flatArg(n *infixNode, i int) string {
+	arg := n.args[i]
+	if f.needParens(n, i) {
+		return "(" + f.flat(arg) + ")"
+	}
+	// Like gofmt, omit the spaces around tightly binding operators
+	// that are operands of looser ones, as in x + 2*y.
+	compact := (n.op == opInfix || n.op == opInfixA) && (arg.op == opInfix || arg.op == opInfixA) &&
+		f.prec(arg) >= f.prec(&infixNode{op: opInfix, text: "*"}) && f.prec(arg) > f.prec(n)
+	return f.flatIn(arg, compact)
+}
+
+// flatIn renders n on a single line, without spaces around its
+// operator if compact is set.
+func (f *InfixFormatter) flatIn(n *infixNode, compact bool) string {
+	var buf strings.Builder
+	switch n.op {
+	case opLeaf:
+		return n.text
+	case opUnary:
+		buf.WriteString(n.text)
+		buf.WriteString(f.flatArg(n, 0))
+	case opCall:
+		buf.WriteString(n.text)
+		buf.WriteString("(")
+		for i := range n.args {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(f.flat(n.args[i]))
+		}
+		buf.WriteString(")")
+	default:
+		sep := " " + n.text + " "
+		if compact {
+			sep = n.text
+		}
+		for i := range n.args {
+			if i > 0 {
+				buf.WriteString(sep)
+			}
+			buf.WriteString(f.flatArg(n, i))
+		}
+	}
+	return buf.String()
+}
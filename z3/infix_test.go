@@ -0,0 +1,49 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestFormatInfix(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y, z := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z")
+	b := ctx.BoolConst("b")
+	u, v := ctx.BVConst("u", 8), ctx.BVConst("v", 8)
+	for _, test := range []struct {
+		val  Value
+		want string
+	}{
+		{x.Add(ctx.Int(2).Mul(y)).LE(ctx.Int(7)), "x + 2*y <= 7"},
+		{x.Add(y).Mul(z), "(x + y) * z"},
+		{x.Sub(y.Sub(z)), "x - (y - z)"},
+		{x.Sub(y).Sub(z), "x - y - z"},
+		{x.Add(y.Add(z)), "x + y + z"},
+		{x.Neg(), "-x"},
+		{x.Eq(y).And(b.Or(z.NE(ctx.Int(0)))), "x == y && (b || z != 0)"},
+		{b.Not().Implies(x.GT(y)), "!b ==> x > y"},
+		{b.IfThenElse(x, y), "ite(b, x, y)"},
+		{u.And(v).Or(u.Not()), "u&v | ^u"},
+		{u.SLT(v), "bvslt(u, v)"},
+		{u.ULE(v), "u <= v"},
+		{ctx.FromString("a\"b"), `"a\"b"`},
+	} {
+		if got := FormatInfix(test.val); got != test.want {
+			t.Errorf("FormatInfix(%v) = %q, want %q", test.val, got, test.want)
+		}
+	}
+
+	f := &InfixFormatter{Width: 20}
+	long := x.Add(y).LE(ctx.Int(7)).And(y.Mul(z).GE(ctx.Int(100)), b)
+	want := "x + y <= 7\n&& y*z >= 100\n&& b"
+	if got := f.Format(long); got != want {
+		t.Errorf("Format with width 20 = %q, want %q", got, want)
+	}
+
+	c, d := ctx.BoolConst("c"), ctx.BoolConst("d")
+	f = &InfixFormatter{Precedence: map[string]int{"||": 3}}
+	if got, want := f.Format(b.And(c.Or(d))), "b && c || d"; got != want {
+		t.Errorf("Format with || binding tighter = %q, want %q", got, want)
+	}
+}
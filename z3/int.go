@@ -78,7 +78,7 @@ func (lit Int) AsBigInt() (val *big.Int, isConst bool) {
 	return lit.asBigInt()
 }
 
-//go:generate go run genwrap.go -t Int $GOFILE intreal.go
+//go:generate go run genwrap.go -t Int -e $GOFILE intreal.go
 
 // Div returns the floor of l / r.
 //
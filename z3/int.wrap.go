@@ -16,12 +16,22 @@ func (l Int) Eq(r Int) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_eq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// EqE is like Eq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) EqE(r Int) (res Bool, err error) {
+	chk := argChecker{method: "Int.Eq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Eq(r) })
+	return
+}
+
 // NE returns a Value that is true if l and r are not equal.
 func (l Int) NE(r Int) Bool {
 	return l.ctx.Distinct(l, r)
@@ -34,30 +44,50 @@ func (l Int) NE(r Int) Bool {
 // Note that this differs from Go division: Go rounds toward zero
 // (truncated division), whereas this rounds toward -inf.
 func (l Int) Div(r Int) Int {
-	// Generated from int.go:68.
+	// Generated from int.go:90.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_div(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Int(val)
 }
 
+// DivE is like Div, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) DivE(r Int) (res Int, err error) {
+	chk := argChecker{method: "Int.Div"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Div(r) })
+	return
+}
+
 // Mod returns modulus of l / r.
 //
 // The sign of the result follows the sign of r.
 func (l Int) Mod(r Int) Int {
-	// Generated from int.go:74.
+	// Generated from int.go:96.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_mod(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Int(val)
 }
 
+// ModE is like Mod, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) ModE(r Int) (res Int, err error) {
+	chk := argChecker{method: "Int.Mod"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Mod(r) })
+	return
+}
+
 // Rem returns remainder of l / r.
 //
 // The sign of the result follows the sign of l.
@@ -65,63 +95,110 @@ func (l Int) Mod(r Int) Int {
 // Note that this differs subtly from Go's remainder operator because
 // this is based floored division rather than truncated division.
 func (l Int) Rem(r Int) Int {
-	// Generated from int.go:83.
+	// Generated from int.go:105.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_rem(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Int(val)
 }
 
+// RemE is like Rem, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) RemE(r Int) (res Int, err error) {
+	chk := argChecker{method: "Int.Rem"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Rem(r) })
+	return
+}
+
 // ToReal converts l to sort Real.
 func (l Int) ToReal() Real {
-	// Generated from int.go:87.
+	// Generated from int.go:109.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_int2real(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Real(val)
 }
 
+// ToRealE is like ToReal, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) ToRealE() (res Real, err error) {
+	chk := argChecker{method: "Int.ToReal"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.ToReal() })
+	return
+}
+
 // ToBV converts l to a bit-vector of width bits.
 func (l Int) ToBV(bits int) BV {
-	// Generated from int.go:91.
+	// Generated from int.go:113.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_int2bv(ctx.c, C.unsigned(bits), l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// ToBVE is like ToBV, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) ToBVE(bits int) (res BV, err error) {
+	chk := argChecker{method: "Int.ToBV"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.ToBV(bits) })
+	return
+}
+
 // Abs returns the absolute value of l.
 func (l Int) Abs() Int {
-	// Generated from int.go:95.
+	// Generated from int.go:117.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_abs(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Int(val)
 }
 
+// AbsE is like Abs, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) AbsE() (res Int, err error) {
+	chk := argChecker{method: "Int.Abs"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Abs() })
+	return
+}
+
 // Divides returns true if l divides r.
 // For the predicate to be part of linear integer arithmetic,
 // l must be a non-zero integer literal.
 func (l Int) Divides(r Int) Bool {
-	// Generated from int.go:101.
+	// Generated from int.go:123.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_divides(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// DividesE is like Divides, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) DividesE(r Int) (res Bool, err error) {
+	chk := argChecker{method: "Int.Divides"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Divides(r) })
+	return
+}
+
 // Add returns the sum l + r[0] + r[1] + ...
 func (l Int) Add(r ...Int) Int {
 	// Generated from intreal.go:12.
@@ -133,11 +210,23 @@ func (l Int) Add(r ...Int) Int {
 	}
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_add(ctx.c, C.uint(len(cargs)), &cargs[0])
-	})
+	}, cargs...)
 	runtime.KeepAlive(&cargs[0])
 	return Int(val)
 }
 
+// AddE is like Add, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) AddE(r ...Int) (res Int, err error) {
+	chk := argChecker{method: "Int.Add"}
+	chk.value("l", l)
+	for i, v := range r {
+		chk.elem("r", i, v)
+	}
+	err = chk.do(func() { res = l.Add(r...) })
+	return
+}
+
 // Mul returns the product l * r[0] * r[1] * ...
 func (l Int) Mul(r ...Int) Int {
 	// Generated from intreal.go:16.
@@ -149,11 +238,23 @@ func (l Int) Mul(r ...Int) Int {
 	}
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_mul(ctx.c, C.uint(len(cargs)), &cargs[0])
-	})
+	}, cargs...)
 	runtime.KeepAlive(&cargs[0])
 	return Int(val)
 }
 
+// MulE is like Mul, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) MulE(r ...Int) (res Int, err error) {
+	chk := argChecker{method: "Int.Mul"}
+	chk.value("l", l)
+	for i, v := range r {
+		chk.elem("r", i, v)
+	}
+	err = chk.do(func() { res = l.Mul(r...) })
+	return
+}
+
 // Sub returns l - r[0] - r[1] - ...
 func (l Int) Sub(r ...Int) Int {
 	// Generated from intreal.go:20.
@@ -165,78 +266,149 @@ func (l Int) Sub(r ...Int) Int {
 	}
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_sub(ctx.c, C.uint(len(cargs)), &cargs[0])
-	})
+	}, cargs...)
 	runtime.KeepAlive(&cargs[0])
 	return Int(val)
 }
 
+// SubE is like Sub, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) SubE(r ...Int) (res Int, err error) {
+	chk := argChecker{method: "Int.Sub"}
+	chk.value("l", l)
+	for i, v := range r {
+		chk.elem("r", i, v)
+	}
+	err = chk.do(func() { res = l.Sub(r...) })
+	return
+}
+
 // Neg returns -l.
 func (l Int) Neg() Int {
 	// Generated from intreal.go:24.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_unary_minus(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Int(val)
 }
 
+// NegE is like Neg, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) NegE() (res Int, err error) {
+	chk := argChecker{method: "Int.Neg"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Neg() })
+	return
+}
+
 // Exp returns lᶠ.
 func (l Int) Exp(r Int) Int {
 	// Generated from intreal.go:28.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_power(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Int(val)
 }
 
+// ExpE is like Exp, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) ExpE(r Int) (res Int, err error) {
+	chk := argChecker{method: "Int.Exp"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Exp(r) })
+	return
+}
+
 // LT returns l < r.
 func (l Int) LT(r Int) Bool {
 	// Generated from intreal.go:32.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_lt(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// LTE is like LT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) LTE(r Int) (res Bool, err error) {
+	chk := argChecker{method: "Int.LT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.LT(r) })
+	return
+}
+
 // LE returns l <= r.
 func (l Int) LE(r Int) Bool {
 	// Generated from intreal.go:36.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_le(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// LEE is like LE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) LEE(r Int) (res Bool, err error) {
+	chk := argChecker{method: "Int.LE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.LE(r) })
+	return
+}
+
 // GT returns l > r.
 func (l Int) GT(r Int) Bool {
 	// Generated from intreal.go:40.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_gt(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// GTE is like GT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) GTE(r Int) (res Bool, err error) {
+	chk := argChecker{method: "Int.GT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.GT(r) })
+	return
+}
+
 // GE returns l >= r.
 func (l Int) GE(r Int) Bool {
 	// Generated from intreal.go:44.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_ge(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
+
+// GEE is like GE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Int) GEE(r Int) (res Bool, err error) {
+	chk := argChecker{method: "Int.GE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.GE(r) })
+	return
+}
@@ -0,0 +1,648 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"runtime"
+)
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// A Problem is a set of constants and constraints over them that can
+// be exchanged as JSON, for example between a web frontend and a
+// solving service.
+//
+// A Problem is encoded as a JSON object of the form
+//
+//	{
+//	  "vars": [
+//	    {"name": "x", "sort": "int"},
+//	    {"name": "b", "sort": "bv", "bits": 8}
+//	  ],
+//	  "constraints": [
+//	    {"op": ">", "args": [{"var": "x"}, {"int": 3}]},
+//	    {"op": "bvult", "args": [{"var": "b"}, {"bv": 16, "bits": 8}]}
+//	  ]
+//	}
+//
+// The sort of a variable is bool, int, real, string, or bv, as in the
+// tags used by Bind. An expression is an object with exactly one of
+// the following keys:
+//
+//	"var"     a variable, by name
+//	"bool"    a Boolean literal
+//	"int"     an integer literal
+//	"real"    a rational literal, as a string like "1/3" or "0.25"
+//	"bv"      an unsigned bit-vector literal, with a "bits" key
+//	"string"  a string literal
+//	"op"      an operation applied to "args", with integer "params"
+//	          for the indexed operations extract, zero_extend, and
+//	          sign_extend
+//
+// Integer and bit-vector literals may be JSON numbers or strings,
+// which clients should use for values that do not fit in a float64.
+//
+// Operations use their SMT-LIB names. The supported operations are
+//
+//	Boolean     not and or xor => ite = distinct
+//	arithmetic  + - * < <= > >= div mod rem to_real / to_int is_int
+//	bit-vector  bvnot bvneg bvand bvor bvxor bvadd bvsub bvmul
+//	            bvudiv bvsdiv bvurem bvsrem bvsmod bvshl bvlshr bvashr
+//	            bvult bvule bvugt bvuge bvslt bvsle bvsgt bvsge
+//	            concat extract zero_extend sign_extend
+//	string      str.++ str.len str.contains str.prefixof str.suffixof
+//	            str.< str.<=
+//
+// The arguments of an arithmetic operation must all be integers or
+// all be reals; use to_real to mix them.
+type Problem struct {
+	// Vars are the constants of the problem. When a Problem is
+	// marshaled, constants that appear in Constraints are added to
+	// the encoded vars even if they are not listed here.
+	Vars []Value
+
+	// Constraints are the formulas that a solution must satisfy.
+	Constraints []Bool
+}
+
+type jsonProblem struct {
+	Vars        []jsonVar   `json:"vars"`
+	Constraints []*jsonExpr `json:"constraints"`
+}
+
+type jsonVar struct {
+	Name string `json:"name"`
+	Sort string `json:"sort"`
+	Bits int    `json:"bits,omitempty"`
+}
+
+type jsonExpr struct {
+	Var    string      `json:"var,omitempty"`
+	Bool   *bool       `json:"bool,omitempty"`
+	Int    json.Number `json:"int,omitempty"`
+	Real   string      `json:"real,omitempty"`
+	BV     json.Number `json:"bv,omitempty"`
+	Bits   int         `json:"bits,omitempty"`
+	String *string     `json:"string,omitempty"`
+	Op     string      `json:"op,omitempty"`
+	Params []int       `json:"params,omitempty"`
+	Args   []*jsonExpr `json:"args,omitempty"`
+}
+
+// Var returns the constant of p named name, or nil if there is none.
+func (p *Problem) Var(name string) Value {
+	for _, v := range p.Vars {
+		if n, ok := constName(v); ok && n == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes p in the format described by Problem. It
+// returns an error if a constraint uses an operation or sort that the
+// format does not support.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	enc := &jsonEncoder{seen: make(map[string]bool)}
+	for _, v := range p.Vars {
+		if _, ok := constName(v); !ok {
+			return nil, fmt.Errorf("z3: Problem var %s is not a constant", v)
+		}
+		if err := enc.addVar(v); err != nil {
+			return nil, err
+		}
+	}
+	jp := jsonProblem{Constraints: []*jsonExpr{}}
+	for _, c := range p.Constraints {
+		e, err := enc.encode(c)
+		if err != nil {
+			return nil, err
+		}
+		jp.Constraints = append(jp.Constraints, e)
+	}
+	jp.Vars = enc.vars
+	if jp.Vars == nil {
+		jp.Vars = []jsonVar{}
+	}
+	return json.Marshal(jp)
+}
+
+// UnmarshalProblem decodes a Problem in the format described by
+// Problem, declaring its variables as constants in ctx.
+func (ctx *Context) UnmarshalProblem(data []byte) (*Problem, error) {
+	var jp jsonProblem
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return nil, fmt.Errorf("z3: %v", err)
+	}
+	dec := &jsonDecoder{ctx: ctx, vars: make(map[string]Value)}
+	p := new(Problem)
+	for _, jv := range jp.Vars {
+		if _, ok := dec.vars[jv.Name]; ok {
+			return nil, fmt.Errorf("z3: var %q declared twice", jv.Name)
+		}
+		sort, err := dec.sort(jv)
+		if err != nil {
+			return nil, fmt.Errorf("z3: var %q: %v", jv.Name, err)
+		}
+		v := ctx.Const(jv.Name, sort)
+		dec.vars[jv.Name] = v
+		p.Vars = append(p.Vars, v)
+	}
+	for i, je := range jp.Constraints {
+		v, err := dec.decode(je)
+		if err != nil {
+			return nil, fmt.Errorf("z3: constraint %d: %v", i, err)
+		}
+		b, ok := v.(Bool)
+		if !ok {
+			return nil, fmt.Errorf("z3: constraint %d has sort %s, not Bool", i, v.Sort())
+		}
+		p.Constraints = append(p.Constraints, b)
+	}
+	return p, nil
+}
+
+// MarshalJSONValue encodes the expression v in the format described by
+// Problem. Constants are encoded by name, without their sorts.
+func MarshalJSONValue(v Value) ([]byte, error) {
+	enc := &jsonEncoder{seen: make(map[string]bool)}
+	e, err := enc.encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(e)
+}
+
+// UnmarshalJSONValue decodes an expression in the format described by
+// Problem. The variables it refers to must be among vars, which are
+// matched by name.
+func (ctx *Context) UnmarshalJSONValue(data []byte, vars []Value) (Value, error) {
+	var je jsonExpr
+	if err := json.Unmarshal(data, &je); err != nil {
+		return nil, fmt.Errorf("z3: %v", err)
+	}
+	dec := &jsonDecoder{ctx: ctx, vars: make(map[string]Value)}
+	for _, v := range vars {
+		name, ok := constName(v)
+		if !ok {
+			return nil, fmt.Errorf("z3: var %s is not a constant", v)
+		}
+		dec.vars[name] = v
+	}
+	v, err := dec.decode(&je)
+	if err != nil {
+		return nil, fmt.Errorf("z3: %v", err)
+	}
+	return v, nil
+}
+
+// constName returns the name of v if it is an uninterpreted constant.
+func constName(v Value) (string, bool) {
+	name, kind, nargs, ok := v.impl().appDecl()
+	return name, ok && kind == C.Z3_OP_UNINTERPRETED && nargs == 0
+}
+
+// appDecl returns the name and kind of the declaration of expr and
+// its number of arguments. If expr is not an application, it returns
+// ok == false.
+func (expr *valueImpl) appDecl() (name string, kind C.Z3_decl_kind, nargs int, ok bool) {
+	var sym Symbol
+	expr.ctx.do(func() {
+		if !z3ToBool(C.Z3_is_app(expr.ctx.c, expr.c)) {
+			return
+		}
+		ok = true
+		app := C.Z3_to_app(expr.ctx.c, expr.c)
+		decl := C.Z3_get_app_decl(expr.ctx.c, app)
+		kind = C.Z3_get_decl_kind(expr.ctx.c, decl)
+		nargs = int(C.Z3_get_app_num_args(expr.ctx.c, app))
+		sym = Symbol{expr.ctx, C.Z3_get_decl_name(expr.ctx.c, decl)}
+	})
+	runtime.KeepAlive(expr)
+	if ok {
+		name = sym.String()
+	}
+	return
+}
+
+type jsonEncoder struct {
+	vars []jsonVar
+	seen map[string]bool
+}
+
+func (enc *jsonEncoder) addVar(v Value) error {
+	name, _ := constName(v)
+	if enc.seen[name] {
+		return nil
+	}
+	jv := jsonVar{Name: name}
+	s := v.Sort()
+	switch s.Kind() {
+	case KindBool:
+		jv.Sort = "bool"
+	case KindInt:
+		jv.Sort = "int"
+	case KindReal:
+		jv.Sort = "real"
+	case KindBV:
+		jv.Sort, jv.Bits = "bv", s.BVSize()
+	case KindSeq:
+		if !s.IsStringSort() {
+			return fmt.Errorf("z3: var %s has unsupported sort %s", name, s)
+		}
+		jv.Sort = "string"
+	default:
+		return fmt.Errorf("z3: var %s has unsupported sort %s", name, s)
+	}
+	enc.seen[name] = true
+	enc.vars = append(enc.vars, jv)
+	return nil
+}
+
+func (enc *jsonEncoder) encode(v Value) (*jsonExpr, error) {
+	switch v := v.(type) {
+	case Bool:
+		if b, ok := v.AsBool(); ok {
+			return &jsonExpr{Bool: &b}, nil
+		}
+	case String:
+		if s, ok := v.AsString(); ok {
+			return &jsonExpr{String: &s}, nil
+		}
+	case Int:
+		if x, ok := v.AsBigInt(); ok {
+			return &jsonExpr{Int: json.Number(x.String())}, nil
+		}
+	case Real:
+		if x, ok := v.AsBigRat(); ok {
+			return &jsonExpr{Real: x.RatString()}, nil
+		}
+	case BV:
+		if x, ok := v.AsBigUnsigned(); ok {
+			return &jsonExpr{BV: json.Number(x.String()), Bits: v.Sort().BVSize()}, nil
+		}
+	}
+
+	name, kind, nargs, ok := v.impl().appDecl()
+	if !ok {
+		return nil, fmt.Errorf("z3: cannot encode %s as JSON", v)
+	}
+	if kind == C.Z3_OP_UNINTERPRETED {
+		if nargs != 0 {
+			return nil, fmt.Errorf("z3: cannot encode uninterpreted function %s as JSON", name)
+		}
+		if err := enc.addVar(v); err != nil {
+			return nil, err
+		}
+		return &jsonExpr{Var: name}, nil
+	}
+	if n, ok := z3OpNames[name]; ok {
+		name = n
+	}
+	op, ok := jsonOps[name]
+	if !ok || nargs < op.min || op.max >= 0 && nargs > op.max {
+		return nil, fmt.Errorf("z3: cannot encode operation %s as JSON", name)
+	}
+	_, args, _ := v.impl().appArgs()
+	e := &jsonExpr{Op: name}
+	if op.params > 0 {
+		e.Params = v.impl().declIntParams()
+	}
+	for _, arg := range args {
+		ae, err := enc.encode(arg)
+		if err != nil {
+			return nil, err
+		}
+		e.Args = append(e.Args, ae)
+	}
+	return e, nil
+}
+
+type jsonDecoder struct {
+	ctx  *Context
+	vars map[string]Value
+}
+
+func (dec *jsonDecoder) sort(jv jsonVar) (Sort, error) {
+	if jv.Bits != 0 && jv.Sort != "bv" {
+		return Sort{}, fmt.Errorf("bits given for sort %q", jv.Sort)
+	}
+	switch jv.Sort {
+	case "bool":
+		return dec.ctx.BoolSort(), nil
+	case "int":
+		return dec.ctx.IntSort(), nil
+	case "real":
+		return dec.ctx.RealSort(), nil
+	case "string":
+		return dec.ctx.StringSort(), nil
+	case "bv":
+		if jv.Bits <= 0 {
+			return Sort{}, fmt.Errorf("bv sort needs positive bits")
+		}
+		return dec.ctx.BVSort(jv.Bits), nil
+	}
+	return Sort{}, fmt.Errorf("unknown sort %q", jv.Sort)
+}
+
+func (dec *jsonDecoder) decode(je *jsonExpr) (v Value, err error) {
+	if je == nil {
+		return nil, fmt.Errorf("null expression")
+	}
+	n := 0
+	for _, set := range []bool{je.Var != "", je.Bool != nil, je.Int != "", je.Real != "", je.BV != "", je.String != nil, je.Op != ""} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, fmt.Errorf("expression must have exactly one of var, bool, int, real, bv, string, or op")
+	}
+	if je.Bits != 0 && je.BV == "" {
+		return nil, fmt.Errorf("bits given without bv")
+	}
+	if je.Op == "" && (je.Args != nil || je.Params != nil) {
+		return nil, fmt.Errorf("args or params given without op")
+	}
+
+	switch {
+	case je.Var != "":
+		v, ok := dec.vars[je.Var]
+		if !ok {
+			return nil, fmt.Errorf("undeclared var %q", je.Var)
+		}
+		return v, nil
+	case je.Bool != nil:
+		return dec.ctx.FromBool(*je.Bool), nil
+	case je.Int != "":
+		x, ok := new(big.Int).SetString(string(je.Int), 10)
+		if !ok {
+			return nil, fmt.Errorf("bad int %q", je.Int)
+		}
+		return dec.ctx.FromBigInt(x, dec.ctx.IntSort()), nil
+	case je.Real != "":
+		x, ok := new(big.Rat).SetString(je.Real)
+		if !ok {
+			return nil, fmt.Errorf("bad real %q", je.Real)
+		}
+		return dec.ctx.FromBigRat(x), nil
+	case je.BV != "":
+		x, ok := new(big.Int).SetString(string(je.BV), 10)
+		if !ok || x.Sign() < 0 || je.Bits <= 0 || x.BitLen() > je.Bits {
+			return nil, fmt.Errorf("bad bv %q with %d bits", je.BV, je.Bits)
+		}
+		return dec.ctx.FromBigInt(x, dec.ctx.BVSort(je.Bits)), nil
+	case je.String != nil:
+		return dec.ctx.FromString(*je.String), nil
+	}
+
+	op, ok := jsonOps[je.Op]
+	if !ok {
+		return nil, fmt.Errorf("unknown op %q", je.Op)
+	}
+	if len(je.Args) < op.min || op.max >= 0 && len(je.Args) > op.max {
+		return nil, fmt.Errorf("%s: wrong number of args (%d)", je.Op, len(je.Args))
+	}
+	if len(je.Params) != op.params {
+		return nil, fmt.Errorf("%s: want %d params, got %d", je.Op, op.params, len(je.Params))
+	}
+	for _, p := range je.Params {
+		if p < 0 {
+			return nil, fmt.Errorf("%s: negative param %d", je.Op, p)
+		}
+	}
+	args := make([]Value, len(je.Args))
+	for i, ja := range je.Args {
+		if args[i], err = dec.decode(ja); err != nil {
+			return nil, err
+		}
+	}
+	// Z3 reports mismatched bit-vector widths and the like.
+	if zerr := dec.ctx.Catch(func() { v, ok = op.build(args, je.Params) }); zerr != nil {
+		return nil, fmt.Errorf("%s: %v", je.Op, zerr)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s: bad argument sorts", je.Op)
+	}
+	return v, nil
+}
+
+// A jsonOp is an operation of the JSON format. build returns false if
+// the arguments have the wrong sorts.
+type jsonOp struct {
+	min, max int // Number of arguments; max < 0 means no limit
+	params   int
+	build    func(args []Value, params []int) (Value, bool)
+}
+
+var jsonOps map[string]jsonOp
+
+// z3OpNames maps the names Z3 gives some declarations to their
+// SMT-LIB names.
+var z3OpNames = map[string]string{
+	"if": "ite",
+}
+
+func init() {
+	jsonOps = map[string]jsonOp{
+		"not": {1, 1, 0, boolOp(func(x Bool, _ []Bool) Value { return x.Not() })},
+		"and": {1, -1, 0, boolOp(func(x Bool, ys []Bool) Value { return x.And(ys...) })},
+		"or":  {1, -1, 0, boolOp(func(x Bool, ys []Bool) Value { return x.Or(ys...) })},
+		"xor": {2, 2, 0, boolOp(func(x Bool, ys []Bool) Value { return x.Xor(ys[0]) })},
+		"=>":  {2, 2, 0, boolOp(func(x Bool, ys []Bool) Value { return x.Implies(ys[0]) })},
+		"ite": {3, 3, 0, func(args []Value, _ []int) (Value, bool) {
+			c, ok := args[0].(Bool)
+			if !ok || !sameSort(args[1:]) {
+				return nil, false
+			}
+			return c.IfThenElse(args[1], args[2]), true
+		}},
+		"=": {2, 2, 0, func(args []Value, _ []int) (Value, bool) {
+			if !sameSort(args) {
+				return nil, false
+			}
+			return valueEq(args[0], args[1]), true
+		}},
+		"distinct": {2, -1, 0, func(args []Value, _ []int) (Value, bool) {
+			if !sameSort(args) {
+				return nil, false
+			}
+			return args[0].Context().Distinct(args...), true
+		}},
+
+		"+": {1, -1, 0, arithOp(
+			func(x Int, ys []Int) Value { return x.Add(ys...) },
+			func(x Real, ys []Real) Value { return x.Add(ys...) })},
+		"*": {1, -1, 0, arithOp(
+			func(x Int, ys []Int) Value { return x.Mul(ys...) },
+			func(x Real, ys []Real) Value { return x.Mul(ys...) })},
+		"-": {1, -1, 0, arithOp(
+			func(x Int, ys []Int) Value {
+				if len(ys) == 0 {
+					return x.Neg()
+				}
+				return x.Sub(ys...)
+			},
+			func(x Real, ys []Real) Value {
+				if len(ys) == 0 {
+					return x.Neg()
+				}
+				return x.Sub(ys...)
+			})},
+		"<": {2, 2, 0, arithOp(
+			func(x Int, ys []Int) Value { return x.LT(ys[0]) },
+			func(x Real, ys []Real) Value { return x.LT(ys[0]) })},
+		"<=": {2, 2, 0, arithOp(
+			func(x Int, ys []Int) Value { return x.LE(ys[0]) },
+			func(x Real, ys []Real) Value { return x.LE(ys[0]) })},
+		">": {2, 2, 0, arithOp(
+			func(x Int, ys []Int) Value { return x.GT(ys[0]) },
+			func(x Real, ys []Real) Value { return x.GT(ys[0]) })},
+		">=": {2, 2, 0, arithOp(
+			func(x Int, ys []Int) Value { return x.GE(ys[0]) },
+			func(x Real, ys []Real) Value { return x.GE(ys[0]) })},
+		"div":     {2, 2, 0, arithOp(func(x Int, ys []Int) Value { return x.Div(ys[0]) }, nil)},
+		"mod":     {2, 2, 0, arithOp(func(x Int, ys []Int) Value { return x.Mod(ys[0]) }, nil)},
+		"rem":     {2, 2, 0, arithOp(func(x Int, ys []Int) Value { return x.Rem(ys[0]) }, nil)},
+		"to_real": {1, 1, 0, arithOp(func(x Int, _ []Int) Value { return x.ToReal() }, nil)},
+		"/":       {2, 2, 0, arithOp(nil, func(x Real, ys []Real) Value { return x.Div(ys[0]) })},
+		"to_int":  {1, 1, 0, arithOp(nil, func(x Real, _ []Real) Value { return x.ToInt() })},
+		"is_int":  {1, 1, 0, arithOp(nil, func(x Real, _ []Real) Value { return x.IsInt() })},
+
+		"bvnot":  {1, 1, 0, bvOp(func(x BV, _ []BV, _ []int) Value { return x.Not() })},
+		"bvneg":  {1, 1, 0, bvOp(func(x BV, _ []BV, _ []int) Value { return x.Neg() })},
+		"bvand":  bvBinOp(BV.And),
+		"bvor":   bvBinOp(BV.Or),
+		"bvxor":  bvBinOp(BV.Xor),
+		"bvadd":  bvBinOp(BV.Add),
+		"bvsub":  bvBinOp(BV.Sub),
+		"bvmul":  bvBinOp(BV.Mul),
+		"bvudiv": bvBinOp(BV.UDiv),
+		"bvsdiv": bvBinOp(BV.SDiv),
+		"bvurem": bvBinOp(BV.URem),
+		"bvsrem": bvBinOp(BV.SRem),
+		"bvsmod": bvBinOp(BV.SMod),
+		"bvshl":  bvBinOp(BV.Lsh),
+		"bvlshr": bvBinOp(BV.URsh),
+		"bvashr": bvBinOp(BV.SRsh),
+		"concat": bvBinOp(BV.Concat),
+		"bvult":  bvBinOp(BV.ULT),
+		"bvule":  bvBinOp(BV.ULE),
+		"bvugt":  bvBinOp(BV.UGT),
+		"bvuge":  bvBinOp(BV.UGE),
+		"bvslt":  bvBinOp(BV.SLT),
+		"bvsle":  bvBinOp(BV.SLE),
+		"bvsgt":  bvBinOp(BV.SGT),
+		"bvsge":  bvBinOp(BV.SGE),
+		"extract": {1, 1, 2, bvOp(func(x BV, _ []BV, params []int) Value {
+			return x.Extract(params[0], params[1])
+		})},
+		"zero_extend": {1, 1, 1, bvOp(func(x BV, _ []BV, params []int) Value { return x.ZeroExtend(params[0]) })},
+		"sign_extend": {1, 1, 1, bvOp(func(x BV, _ []BV, params []int) Value { return x.SignExtend(params[0]) })},
+
+		"str.++":       {1, -1, 0, stringOp(func(x String, ys []String) Value { return x.Concat(ys...) })},
+		"str.len":      {1, 1, 0, stringOp(func(x String, _ []String) Value { return x.Length() })},
+		"str.contains": {2, 2, 0, stringOp(func(x String, ys []String) Value { return x.Contains(ys[0]) })},
+		"str.prefixof": {2, 2, 0, stringOp(func(x String, ys []String) Value { return x.PrefixOf(ys[0]) })},
+		"str.suffixof": {2, 2, 0, stringOp(func(x String, ys []String) Value { return x.SuffixOf(ys[0]) })},
+		"str.<":        {2, 2, 0, stringOp(func(x String, ys []String) Value { return x.LT(ys[0]) })},
+		"str.<=":       {2, 2, 0, stringOp(func(x String, ys []String) Value { return x.LE(ys[0]) })},
+	}
+}
+
+// argsAs returns args converted to T if they all have type T.
+func argsAs[T Value](args []Value) ([]T, bool) {
+	xs := make([]T, len(args))
+	for i, arg := range args {
+		x, ok := arg.(T)
+		if !ok {
+			return nil, false
+		}
+		xs[i] = x
+	}
+	return xs, true
+}
+
+func boolOp(f func(x Bool, ys []Bool) Value) func([]Value, []int) (Value, bool) {
+	return func(args []Value, _ []int) (Value, bool) {
+		xs, ok := argsAs[Bool](args)
+		if !ok {
+			return nil, false
+		}
+		return f(xs[0], xs[1:]), true
+	}
+}
+
+func stringOp(f func(x String, ys []String) Value) func([]Value, []int) (Value, bool) {
+	return func(args []Value, _ []int) (Value, bool) {
+		xs, ok := argsAs[String](args)
+		if !ok {
+			return nil, false
+		}
+		return f(xs[0], xs[1:]), true
+	}
+}
+
+// arithOp returns a build function that applies fi to Int arguments
+// and fr to Real arguments. Either may be nil if the operation does
+// not apply to that sort.
+func arithOp(fi func(x Int, ys []Int) Value, fr func(x Real, ys []Real) Value) func([]Value, []int) (Value, bool) {
+	return func(args []Value, _ []int) (Value, bool) {
+		if xs, ok := argsAs[Int](args); ok && fi != nil {
+			return fi(xs[0], xs[1:]), true
+		}
+		if xs, ok := argsAs[Real](args); ok && fr != nil {
+			return fr(xs[0], xs[1:]), true
+		}
+		return nil, false
+	}
+}
+
+func bvOp(f func(x BV, ys []BV, params []int) Value) func([]Value, []int) (Value, bool) {
+	return func(args []Value, params []int) (Value, bool) {
+		xs, ok := argsAs[BV](args)
+		if !ok {
+			return nil, false
+		}
+		return f(xs[0], xs[1:], params), true
+	}
+}
+
+func bvBinOp[R Value](f func(x, y BV) R) jsonOp {
+	return jsonOp{2, 2, 0, bvOp(func(x BV, ys []BV, _ []int) Value { return f(x, ys[0]) })}
+}
+
+// sameSort reports whether all of vals have the same sort.
+func sameSort(vals []Value) bool {
+	for _, v := range vals[1:] {
+		if !v.Sort().Equal(vals[0].Sort()) {
+			return false
+		}
+	}
+	return true
+}
+
+// valueEq returns x == y for x and y of the same sort.
+func valueEq(x, y Value) Bool {
+	switch x := x.(type) {
+	case Bool:
+		return x.Eq(y.(Bool))
+	case Int:
+		return x.Eq(y.(Int))
+	case Real:
+		return x.Eq(y.(Real))
+	case BV:
+		return x.Eq(y.(BV))
+	case String:
+		return x.Eq(y.(String))
+	}
+	return x.Context().Distinct(x, y).Not()
+}
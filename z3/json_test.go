@@ -0,0 +1,149 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalProblem(t *testing.T) {
+	ctx := NewContext(nil)
+	p, err := ctx.UnmarshalProblem([]byte(`{
+		"vars": [
+			{"name": "x", "sort": "int"},
+			{"name": "r", "sort": "real"},
+			{"name": "b", "sort": "bv", "bits": 8},
+			{"name": "s", "sort": "string"},
+			{"name": "f", "sort": "bool"}
+		],
+		"constraints": [
+			{"op": "=", "args": [{"op": "*", "args": [{"var": "x"}, {"int": "3"}]}, {"int": 21}]},
+			{"op": "=", "args": [{"op": "+", "args": [{"var": "r"}, {"real": "1/3"}]}, {"op": "to_real", "args": [{"var": "x"}]}]},
+			{"op": "bvugt", "args": [{"var": "b"}, {"bv": 250, "bits": 8}]},
+			{"op": "=", "args": [{"op": "extract", "params": [3, 0], "args": [{"var": "b"}]}, {"bv": 15, "bits": 4}]},
+			{"op": "str.prefixof", "args": [{"string": "go"}, {"var": "s"}]},
+			{"op": "=", "args": [{"op": "str.len", "args": [{"var": "s"}]}, {"int": 4}]},
+			{"op": "=>", "args": [{"var": "f"}, {"bool": false}]}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSolver(ctx)
+	s.AssertAll(p.Constraints)
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v", sat, err)
+	}
+	m := s.Model()
+	if x, _, _ := m.Eval(p.Var("x"), true).(Int).AsInt64(); x != 7 {
+		t.Errorf("x = %d, want 7", x)
+	}
+	if r, _ := m.Eval(p.Var("r"), true).(Real).AsBigRat(); r.RatString() != "20/3" {
+		t.Errorf("r = %s, want 20/3", r.RatString())
+	}
+	if b, _, _ := m.Eval(p.Var("b"), true).(BV).AsUint64(); b != 255 {
+		t.Errorf("b = %d, want 255", b)
+	}
+	if s, _ := m.Eval(p.Var("s"), true).(String).AsString(); !strings.HasPrefix(s, "go") || len(s) != 4 {
+		t.Errorf("s = %q", s)
+	}
+	if f, _ := m.Eval(p.Var("f"), true).(Bool).AsBool(); f {
+		t.Errorf("f = true")
+	}
+	if p.Var("y") != nil {
+		t.Errorf("Var(y) != nil")
+	}
+}
+
+func TestMarshalProblem(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	b := ctx.BVConst("b", 16)
+	s := ctx.StringConst("s")
+	p := &Problem{
+		Vars: []Value{y},
+		Constraints: []Bool{
+			x.Sub(y).Neg().LE(ctx.Int(-3)),
+			ctx.Distinct(x, y, ctx.Int(10)),
+			b.ZeroExtend(16).Extract(19, 4).SLT(ctx.FromInt(-1, ctx.BVSort(16)).(BV)),
+			s.Concat(ctx.FromString("!")).Length().ToReal().Div(ctx.FromBigRat(big.NewRat(3, 2))).GE(ctx.FromBigRat(big.NewRat(1, 1))),
+			ctx.FromBool(true).And(x.GT(y).IfThenElse(ctx.FromBool(true), ctx.FromBool(false)).(Bool)),
+		},
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), `{"vars":[{"name":"y","sort":"int"},{"name":"x","sort":"int"},{"name":"b","sort":"bv","bits":16}`) {
+		t.Errorf("Marshal = %s", data)
+	}
+
+	// Decoding into a new Context must give the same constraints.
+	ctx2 := NewContext(nil)
+	p2, err := ctx2.UnmarshalProblem(data)
+	if err != nil {
+		t.Fatalf("%v in %s", err, data)
+	}
+	if len(p2.Vars) != 4 || len(p2.Constraints) != len(p.Constraints) {
+		t.Fatalf("decoded %d vars, %d constraints", len(p2.Vars), len(p2.Constraints))
+	}
+	for i, c := range p.Constraints {
+		if got, want := p2.Constraints[i].String(), c.String(); got != want {
+			t.Errorf("constraint %d = %s, want %s", i, got, want)
+		}
+	}
+
+	// A single Value round trips against the decoded vars.
+	data, err = MarshalJSONValue(x.Mul(y, ctx.Int(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != `{"op":"*","args":[{"var":"x"},{"var":"y"},{"int":2}]}` {
+		t.Errorf("MarshalJSONValue = %s", got)
+	}
+	v, err := ctx2.UnmarshalJSONValue(data, p2.Vars)
+	if err != nil || v.String() != "(* x y 2)" {
+		t.Errorf("UnmarshalJSONValue = %v, %v", v, err)
+	}
+
+	// Uninterpreted functions cannot be encoded.
+	f := ctx.FuncDecl("f", []Sort{ctx.IntSort()}, ctx.IntSort())
+	if _, err := MarshalJSONValue(f.Apply(x)); err == nil {
+		t.Errorf("MarshalJSONValue of uninterpreted function succeeded")
+	}
+}
+
+func TestUnmarshalProblemErrors(t *testing.T) {
+	ctx := NewContext(nil)
+	vars := `"vars": [{"name": "x", "sort": "int"}, {"name": "b", "sort": "bv", "bits": 8}]`
+	for _, test := range []struct {
+		json, err string
+	}{
+		{`{"vars": [{"name": "x", "sort": "float"}]}`, `unknown sort "float"`},
+		{`{"vars": [{"name": "x", "sort": "bv"}]}`, "positive bits"},
+		{`{"vars": [{"name": "x", "sort": "int"}, {"name": "x", "sort": "int"}]}`, "declared twice"},
+		{`{` + vars + `, "constraints": [{"var": "x"}]}`, "not Bool"},
+		{`{` + vars + `, "constraints": [{"var": "y"}]}`, `undeclared var "y"`},
+		{`{` + vars + `, "constraints": [{"op": "frob"}]}`, `unknown op "frob"`},
+		{`{` + vars + `, "constraints": [{"op": "not"}]}`, "wrong number of args"},
+		{`{` + vars + `, "constraints": [{"op": "<", "args": [{"var": "x"}, {"real": "1.5"}]}]}`, "bad argument sorts"},
+		{`{` + vars + `, "constraints": [{"op": "=", "args": [{"var": "b"}, {"bv": 1, "bits": 4}]}]}`, "bad argument sorts"},
+		{`{` + vars + `, "constraints": [{"op": "bvult", "args": [{"var": "b"}, {"bv": 1, "bits": 4}]}]}`, "bvult:"},
+		{`{` + vars + `, "constraints": [{"op": "extract", "params": [1], "args": [{"var": "b"}]}]}`, "want 2 params"},
+		{`{` + vars + `, "constraints": [{"bv": 256, "bits": 8}]}`, "bad bv"},
+		{`{` + vars + `, "constraints": [{"int": "1.5"}]}`, "bad int"},
+		{`{` + vars + `, "constraints": [{"var": "x", "int": 1}]}`, "exactly one"},
+		{`{` + vars + `, "constraints": [null]}`, "null expression"},
+		{`{"vars": 1}`, "cannot unmarshal"},
+	} {
+		_, err := ctx.UnmarshalProblem([]byte(test.json))
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("UnmarshalProblem(%s): got %v, want %q", test.json, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+/*
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// A LetBinding names a subterm factored out by Letify.
+type LetBinding struct {
+	// Const is a fresh constant that stands for Def.
+	Const Value
+
+	// Def is the subterm, in terms of the constants of earlier
+	// bindings.
+	Def Value
+}
+
+// Letify factors the subterms that occur more than once in x into
+// bindings, the way an SMT-LIB let expression would. It returns the
+// bindings, in an order where each refers only to earlier ones, and
+// the body: x with the shared subterms replaced by their constants.
+// Substituting the definitions back into body gives x.
+//
+// Only compound subterms are shared; constants and literals are left
+// in place. Subterms under quantifiers are not shared.
+//
+// Letify is meant for making large expressions readable. The
+// constants it creates are fresh, so they never collide with
+// constants in x.
+func Letify(x Value) (bindings []LetBinding, body Value) {
+	ctx := x.impl().ctx
+	type lifted struct {
+		val  value
+		kind Kind
+	}
+	var defs, consts []lifted
+	var res lifted
+	prefix := C.CString("t")
+	defer C.free(unsafe.Pointer(prefix))
+	ctx.do(func() {
+		c := ctx.c
+		wrap := func(a C.Z3_ast) lifted {
+			v := value{(*valueImpl)(wrapAST(ctx, a).astImpl), noEq{}}
+			return lifted{v, Kind(C.Z3_get_sort_kind(c, C.Z3_get_sort(c, a)))}
+		}
+
+		// Count the references to each subterm and list the
+		// subterms in post-order.
+		count := make(map[C.uint]int)
+		var order []C.Z3_ast
+		var walk func(a C.Z3_ast)
+		walk = func(a C.Z3_ast) {
+			id := C.Z3_get_ast_id(c, a)
+			count[id]++
+			if count[id] > 1 || C.Z3_get_ast_kind(c, a) != C.Z3_APP_AST {
+				return
+			}
+			app := C.Z3_to_app(c, a)
+			for i, n := C.uint(0), C.Z3_get_app_num_args(c, app); i < n; i++ {
+				walk(C.Z3_get_app_arg(c, app, i))
+			}
+			order = append(order, a)
+		}
+		walk(x.impl().c)
+
+		var from, to []C.Z3_ast
+		substitute := func(a C.Z3_ast) C.Z3_ast {
+			if len(from) == 0 {
+				return a
+			}
+			return C.Z3_substitute(c, a, C.uint(len(from)), &from[0], &to[0])
+		}
+		for _, a := range order {
+			if count[C.Z3_get_ast_id(c, a)] < 2 || C.Z3_get_app_num_args(c, C.Z3_to_app(c, a)) == 0 {
+				continue
+			}
+			def := wrap(substitute(a))
+			k := wrap(C.Z3_mk_fresh_const(c, prefix, C.Z3_get_sort(c, a)))
+			defs = append(defs, def)
+			consts = append(consts, k)
+			from = append(from, a)
+			to = append(to, k.val.c)
+		}
+		res = wrap(substitute(x.impl().c))
+	})
+	runtime.KeepAlive(x)
+	for i := range defs {
+		bindings = append(bindings, LetBinding{consts[i].val.lift(consts[i].kind), defs[i].val.lift(defs[i].kind)})
+	}
+	return bindings, res.val.lift(res.kind)
+}
+
+// LetString formats the result of Letify as one binding per line,
+// followed by the body.
+func LetString(bindings []LetBinding, body Value) string {
+	var buf strings.Builder
+	for _, b := range bindings {
+		buf.WriteString(b.Const.String())
+		buf.WriteString(" = ")
+		buf.WriteString(b.Def.String())
+		buf.WriteString("\n")
+	}
+	buf.WriteString(body.String())
+	return buf.String()
+}
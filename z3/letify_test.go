@@ -0,0 +1,48 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLetify(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	sum := x.Add(y)
+	sq := sum.Mul(sum)
+	e := sq.Add(sq).GT(sum.Sub(ctx.Int(1)))
+
+	bindings, body := Letify(e)
+	if len(bindings) != 2 {
+		t.Fatalf("got %d bindings, want 2:\n%s", len(bindings), LetString(bindings, body))
+	}
+	if got := bindings[0].Def.String(); got != "(+ x y)" {
+		t.Errorf("first binding is %s, want (+ x y)", got)
+	}
+	// The second binding refers to the first.
+	if got := bindings[1].Def.String(); !strings.Contains(got, bindings[0].Const.String()) {
+		t.Errorf("second binding %s does not use %v", got, bindings[0].Const)
+	}
+	if _, ok := body.(Bool); !ok {
+		t.Errorf("body is %T, want Bool", body)
+	}
+
+	// The bindings and body are equivalent to e.
+	var defs []Bool
+	for _, b := range bindings {
+		defs = append(defs, b.Const.(Int).Eq(b.Def.(Int)))
+	}
+	if ok, _, err := ctx.ProveEquivalent(body, e, defs...); !ok || err != nil {
+		t.Errorf("letified expression is not equivalent: %v", err)
+	}
+
+	// An expression without sharing is unchanged.
+	bindings, body = Letify(sum)
+	if len(bindings) != 0 || body.String() != sum.String() {
+		t.Errorf("Letify(%v) = %v, %v", sum, bindings, body)
+	}
+}
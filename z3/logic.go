@@ -59,7 +59,34 @@ func (l Bool) AsBool() (val bool, isLiteral bool) {
 	return res == C.Z3_L_TRUE, res != C.Z3_L_UNDEF
 }
 
-//go:generate go run genwrap.go -t Bool $GOFILE
+// Xor returns a Value that is true if an odd number of bs are true,
+// if parity is true, or an even number, if parity is false. This is a
+// linear equation over GF(2), as found in CRCs, linear codes, and
+// hash-based sampling.
+//
+// Xor combines bs in a balanced tree of binary xors, which is only
+// logarithmically deep even for long parity constraints.
+func (ctx *Context) Xor(bs []Bool, parity bool) Bool {
+	if len(bs) == 0 {
+		return ctx.FromBool(!parity)
+	}
+	for len(bs) > 1 {
+		next := make([]Bool, 0, (len(bs)+1)/2)
+		for i := 0; i+1 < len(bs); i += 2 {
+			next = append(next, bs[i].Xor(bs[i+1]))
+		}
+		if len(bs)%2 == 1 {
+			next = append(next, bs[len(bs)-1])
+		}
+		bs = next
+	}
+	if parity {
+		return bs[0]
+	}
+	return bs[0].Not()
+}
+
+//go:generate go run genwrap.go -t Bool -e $GOFILE
 
 // Distinct returns a Value that is true if no two vals are equal.
 //
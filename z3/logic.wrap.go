@@ -16,12 +16,22 @@ func (l Bool) Eq(r Bool) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_eq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// EqE is like Eq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Bool) EqE(r Bool) (res Bool, err error) {
+	chk := argChecker{method: "Bool.Eq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Eq(r) })
+	return
+}
+
 // NE returns a Value that is true if l and r are not equal.
 func (l Bool) NE(r Bool) Bool {
 	return l.ctx.Distinct(l, r)
@@ -31,86 +41,147 @@ func (l Bool) NE(r Bool) Bool {
 //
 // All Values must have the same sort.
 func (ctx *Context) Distinct(vals ...Value) Bool {
-	// Generated from logic.go:68.
+	// Generated from logic.go:95.
 	cargs := make([]C.Z3_ast, len(vals)+0)
 	for i, arg := range vals {
 		cargs[i+0] = arg.impl().c
 	}
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_distinct(ctx.c, C.uint(len(cargs)), &cargs[0])
-	})
+	}, cargs...)
 	runtime.KeepAlive(&cargs[0])
 	return Bool(val)
 }
 
+// DistinctE is like Distinct, but returns an error instead of
+// panicking if the arguments are invalid.
+func (ctx *Context) DistinctE(vals ...Value) (res Bool, err error) {
+	chk := argChecker{method: "Context.Distinct", ctx: ctx}
+	for i, v := range vals {
+		chk.elem("vals", i, v)
+	}
+	err = chk.do(func() { res = ctx.Distinct(vals...) })
+	return
+}
+
 // Not returns the boolean negation of l.
 func (l Bool) Not() Bool {
-	// Generated from logic.go:72.
+	// Generated from logic.go:99.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_not(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// NotE is like Not, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Bool) NotE() (res Bool, err error) {
+	chk := argChecker{method: "Bool.Not"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Not() })
+	return
+}
+
 // IfThenElse returns a Value equal to cons if cond is true, otherwise
 // alt.
 //
 // cons and alt must have the same sort. The result will have the same
 // sort as cons and alt.
 func (cond Bool) IfThenElse(cons Value, alt Value) Value {
-	// Generated from logic.go:80.
+	// Generated from logic.go:107.
 	ctx := cond.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_ite(ctx.c, cond.c, cons.impl().c, alt.impl().c)
-	})
+	}, cond.c, cons.impl().c, alt.impl().c)
 	runtime.KeepAlive(cond)
 	runtime.KeepAlive(cons)
 	runtime.KeepAlive(alt)
 	return val.lift(KindUnknown)
 }
 
+// IfThenElseE is like IfThenElse, but returns an error instead of
+// panicking if the arguments are invalid.
+func (cond Bool) IfThenElseE(cons Value, alt Value) (res Value, err error) {
+	chk := argChecker{method: "Bool.IfThenElse"}
+	chk.value("cond", cond)
+	chk.value("cons", cons)
+	chk.value("alt", alt)
+	err = chk.do(func() { res = cond.IfThenElse(cons, alt) })
+	return
+}
+
 // Iff returns a Value that is true if l and r are equal (l
 // if-and-only-if r).
 func (l Bool) Iff(r Bool) Bool {
-	// Generated from logic.go:85.
+	// Generated from logic.go:112.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_iff(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// IffE is like Iff, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Bool) IffE(r Bool) (res Bool, err error) {
+	chk := argChecker{method: "Bool.Iff"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Iff(r) })
+	return
+}
+
 // Implies returns a Value that is true if l implies r.
 func (l Bool) Implies(r Bool) Bool {
-	// Generated from logic.go:89.
+	// Generated from logic.go:116.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_implies(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// ImpliesE is like Implies, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Bool) ImpliesE(r Bool) (res Bool, err error) {
+	chk := argChecker{method: "Bool.Implies"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Implies(r) })
+	return
+}
+
 // Xor returns a Value that is true if l xor r.
 func (l Bool) Xor(r Bool) Bool {
-	// Generated from logic.go:93.
+	// Generated from logic.go:120.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_xor(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// XorE is like Xor, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Bool) XorE(r Bool) (res Bool, err error) {
+	chk := argChecker{method: "Bool.Xor"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Xor(r) })
+	return
+}
+
 // And returns a Value that is true if l and all arguments are true.
 func (l Bool) And(r ...Bool) Bool {
-	// Generated from logic.go:97.
+	// Generated from logic.go:124.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
 	cargs[0] = l.c
@@ -119,14 +190,26 @@ func (l Bool) And(r ...Bool) Bool {
 	}
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_and(ctx.c, C.uint(len(cargs)), &cargs[0])
-	})
+	}, cargs...)
 	runtime.KeepAlive(&cargs[0])
 	return Bool(val)
 }
 
+// AndE is like And, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Bool) AndE(r ...Bool) (res Bool, err error) {
+	chk := argChecker{method: "Bool.And"}
+	chk.value("l", l)
+	for i, v := range r {
+		chk.elem("r", i, v)
+	}
+	err = chk.do(func() { res = l.And(r...) })
+	return
+}
+
 // Or returns a Value that is true if l or any argument is true.
 func (l Bool) Or(r ...Bool) Bool {
-	// Generated from logic.go:101.
+	// Generated from logic.go:128.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
 	cargs[0] = l.c
@@ -135,7 +218,19 @@ func (l Bool) Or(r ...Bool) Bool {
 	}
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_or(ctx.c, C.uint(len(cargs)), &cargs[0])
-	})
+	}, cargs...)
 	runtime.KeepAlive(&cargs[0])
 	return Bool(val)
 }
+
+// OrE is like Or, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Bool) OrE(r ...Bool) (res Bool, err error) {
+	chk := argChecker{method: "Bool.Or"}
+	chk.value("l", l)
+	for i, v := range r {
+		chk.elem("r", i, v)
+	}
+	err = chk.do(func() { res = l.Or(r...) })
+	return
+}
@@ -0,0 +1,242 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lp reads linear programs in the LP and MPS file formats into
+// a z3.Optimize, so that existing operations research models can be
+// checked and optimized with Z3.
+//
+// Both readers support continuous, general integer, and binary
+// variables, bounds, equality, inequality, and ranged constraints, and
+// a linear objective. Coefficients are read exactly as rationals.
+// Quadratic terms, semi-continuous variables, and special ordered
+// sets are not supported.
+//
+// Integer variables become z3.Int constants and all other variables
+// become z3.Real constants. Constraints and the objective are over
+// the reals, with integer variables converted by ToReal.
+package lp
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// A Program is a linear program that has been added to a z3.Optimize.
+type Program struct {
+	// Name is the name of the program, if the file gives one.
+	Name string
+
+	// Vars maps the name of each variable to its constant, which
+	// is a z3.Int for integer variables and a z3.Real otherwise.
+	Vars map[string]z3.Value
+
+	// VarNames lists the variables in the order they first appear
+	// in the file.
+	VarNames []string
+
+	// Constraints maps the name of each constraint to its formula.
+	// Unnamed constraints of LP files are named c1, c2, and so on,
+	// by their position in the file.
+	Constraints map[string]z3.Bool
+
+	// Objective is the objective function. If the file has no
+	// objective, it is 0 and Goal is nil.
+	Objective z3.Real
+
+	// Maximize is true if the objective is maximized rather than
+	// minimized.
+	Maximize bool
+
+	// Goal is the handle of the objective in the Optimize, which
+	// gives the optimal value after a Check.
+	Goal *z3.Objective
+}
+
+// Value returns the value of variable name in model m as a rational.
+// It returns false if there is no such variable.
+func (p *Program) Value(m *z3.Model, name string) (*big.Rat, bool) {
+	v, ok := p.Vars[name]
+	if !ok {
+		return nil, false
+	}
+	return rat(m.Eval(v, true))
+}
+
+// rat returns the value of the numeral v as a rational.
+func rat(v z3.Value) (*big.Rat, bool) {
+	switch v := v.(type) {
+	case z3.Int:
+		x, ok := v.AsBigInt()
+		if !ok {
+			return nil, false
+		}
+		return new(big.Rat).SetInt(x), true
+	case z3.Real:
+		return v.AsBigRat()
+	}
+	return nil, false
+}
+
+// A builder collects a linear program while it is parsed.
+type builder struct {
+	name     string
+	vars     map[string]*variable
+	varNames []string
+	rows     []*row
+	rowNames map[string]bool
+	obj      expr
+	maximize bool
+}
+
+type variable struct {
+	integer bool
+	lo, hi  *big.Rat // nil for infinite
+}
+
+type term struct {
+	coef *big.Rat
+	v    string
+}
+
+// An expr is a linear expression.
+type expr struct {
+	terms    []term
+	constant big.Rat
+}
+
+type row struct {
+	name   string
+	expr   expr
+	lo, hi *big.Rat // nil for infinite
+}
+
+func newBuilder() *builder {
+	return &builder{vars: make(map[string]*variable), rowNames: make(map[string]bool)}
+}
+
+// variable returns the variable named name, creating it with the
+// default bounds [0, ∞) if necessary.
+func (b *builder) variable(name string) *variable {
+	v, ok := b.vars[name]
+	if !ok {
+		v = &variable{lo: new(big.Rat)}
+		b.vars[name] = v
+		b.varNames = append(b.varNames, name)
+	}
+	return v
+}
+
+func (b *builder) addRow(r *row) error {
+	if b.rowNames[r.name] {
+		return fmt.Errorf("duplicate constraint %s", r.name)
+	}
+	b.rowNames[r.name] = true
+	b.rows = append(b.rows, r)
+	return nil
+}
+
+func (e *expr) add(coef *big.Rat, v string) {
+	e.terms = append(e.terms, term{coef, v})
+}
+
+// build adds the program to o.
+func (b *builder) build(ctx *z3.Context, o *z3.Optimize) *Program {
+	p := &Program{
+		Name:        b.name,
+		Vars:        make(map[string]z3.Value),
+		VarNames:    b.varNames,
+		Constraints: make(map[string]z3.Bool),
+		Maximize:    b.maximize,
+	}
+	reals := make(map[string]z3.Real)
+	for _, name := range b.varNames {
+		v := b.vars[name]
+		if v.integer {
+			x := ctx.IntConst(name)
+			p.Vars[name], reals[name] = x, x.ToReal()
+		} else {
+			x := ctx.RealConst(name)
+			p.Vars[name], reals[name] = x, x
+		}
+		if c, ok := bounds(reals[name], v.lo, v.hi); ok {
+			o.Assert(c)
+		}
+	}
+
+	linear := func(e *expr) z3.Real {
+		sum := ctx.FromBigRat(&e.constant)
+		var terms []z3.Real
+		for _, t := range e.terms {
+			x := reals[t.v]
+			if t.coef.Cmp(big.NewRat(1, 1)) != 0 {
+				x = ctx.FromBigRat(t.coef).Mul(x)
+			}
+			terms = append(terms, x)
+		}
+		if len(terms) == 0 {
+			return sum
+		}
+		if e.constant.Sign() == 0 {
+			return terms[0].Add(terms[1:]...)
+		}
+		return sum.Add(terms...)
+	}
+	for _, r := range b.rows {
+		if c, ok := bounds(linear(&r.expr), r.lo, r.hi); ok {
+			p.Constraints[r.name] = c
+			o.Assert(c)
+		}
+	}
+
+	p.Objective = linear(&b.obj)
+	if len(b.obj.terms) > 0 {
+		if p.Maximize {
+			p.Goal = o.Maximize(p.Objective)
+		} else {
+			p.Goal = o.Minimize(p.Objective)
+		}
+	}
+	return p
+}
+
+// bounds returns the constraint lo <= x <= hi, where nil means an
+// infinite bound. It returns false if both bounds are infinite.
+func bounds(x z3.Real, lo, hi *big.Rat) (z3.Bool, bool) {
+	ctx := x.Context()
+	switch {
+	case lo != nil && hi != nil && lo.Cmp(hi) == 0:
+		return x.Eq(ctx.FromBigRat(lo)), true
+	case lo != nil && hi != nil:
+		return x.GE(ctx.FromBigRat(lo)).And(x.LE(ctx.FromBigRat(hi))), true
+	case lo != nil:
+		return x.GE(ctx.FromBigRat(lo)), true
+	case hi != nil:
+		return x.LE(ctx.FromBigRat(hi)), true
+	}
+	return z3.Bool{}, false
+}
+
+// infinity is the magnitude at or above which numbers in LP and MPS
+// files mean infinity, following the convention of most solvers.
+var infinity, _ = new(big.Rat).SetString("1e30")
+
+// parseNumber parses a number of an LP or MPS file. It returns nil for
+// infinite values.
+func parseNumber(s string) (x *big.Rat, err error) {
+	switch strings.ToLower(strings.TrimLeft(s, "+-")) {
+	case "inf", "infinity":
+		return nil, nil
+	}
+	x, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("bad number %q", s)
+	}
+	if new(big.Rat).Abs(x).Cmp(infinity) >= 0 {
+		return nil, nil
+	}
+	return x, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lp
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// solve checks o and returns the optimal value of p's objective.
+func solve(t *testing.T, o *z3.Optimize, p *Program) (*z3.Model, *big.Rat) {
+	t.Helper()
+	sat, err := o.Check()
+	if !sat {
+		t.Fatalf("program is not satisfiable: %v", err)
+	}
+	x, ok := rat(p.Goal.Lower())
+	if !ok {
+		t.Fatalf("objective %v is not a rational", p.Goal.Lower())
+	}
+	return o.Model(), x
+}
+
+func TestReadMPS(t *testing.T) {
+	const src = `* A test program.
+NAME          TESTLP
+ROWS
+ N  COST
+ L  LIM1
+ G  LIM2
+ E  MYEQN
+COLUMNS
+    X1        COST         1.0   LIM1         1.0
+    X1        LIM2         1.0
+    MARKER    'MARKER'     'INTORG'
+    X2        COST         2.0   LIM1         1.0
+    X2        MYEQN       -1.0
+    MARKER    'MARKER'     'INTEND'
+    X3        COST        -1.0   MYEQN        1.0
+RHS
+    RHS       LIM1         4.0   LIM2         1.0
+    RHS       MYEQN        7.0
+RANGES
+    RNG       LIM1         2.5
+BOUNDS
+ UP BND       X1           4.0
+ LO BND       X2          -1.0
+ UP BND       X2           1.0
+ENDATA
+`
+	ctx := z3.NewContext(nil)
+	o := z3.NewOptimize(ctx)
+	p, err := ReadMPS(ctx, o, strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "TESTLP" || p.Maximize {
+		t.Errorf("got name %q, maximize %v", p.Name, p.Maximize)
+	}
+	if got := strings.Join(p.VarNames, " "); got != "X1 X2 X3" {
+		t.Errorf("got variables %s", got)
+	}
+	if _, ok := p.Vars["X2"].(z3.Int); !ok {
+		t.Errorf("X2 is %T, want z3.Int", p.Vars["X2"])
+	}
+	if _, ok := p.Vars["X1"].(z3.Real); !ok {
+		t.Errorf("X1 is %T, want z3.Real", p.Vars["X1"])
+	}
+	if len(p.Constraints) != 3 {
+		t.Errorf("got %d constraints, want 3", len(p.Constraints))
+	}
+
+	// Minimize x1 + 2 x2 - x3 = x1 + x2 - 7 subject to
+	// 1.5 <= x1 + x2 <= 4.
+	m, opt := solve(t, o, p)
+	if want := big.NewRat(-11, 2); opt.Cmp(want) != 0 {
+		t.Errorf("got optimum %s, want %s", opt.RatString(), want.RatString())
+	}
+	x2, _ := p.Value(m, "X2")
+	x3, _ := p.Value(m, "X3")
+	if !x2.IsInt() || new(big.Rat).Sub(x3, x2).Cmp(big.NewRat(7, 1)) != 0 {
+		t.Errorf("got X2 = %s, X3 = %s", x2.RatString(), x3.RatString())
+	}
+}
+
+func TestReadLP(t *testing.T) {
+	const src = `\ A small mixed-integer program.
+Maximize
+ obj: 3 x + 2 y
+Subject To
+ c1: x + y <= 4
+ c2: x + 3 y >= 2
+ -2 <= x - y <= 2
+Bounds
+ y <= 3.5
+General
+ x
+End
+`
+	ctx := z3.NewContext(nil)
+	o := z3.NewOptimize(ctx)
+	p, err := ReadLP(ctx, o, strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Maximize {
+		t.Errorf("objective is not maximized")
+	}
+	for _, name := range []string{"c1", "c2", "c3"} {
+		if _, ok := p.Constraints[name]; !ok {
+			t.Errorf("missing constraint %s", name)
+		}
+	}
+	m, opt := solve(t, o, p)
+	if want := big.NewRat(11, 1); opt.Cmp(want) != 0 {
+		t.Errorf("got optimum %s, want %s", opt.RatString(), want.RatString())
+	}
+	if x, _ := p.Value(m, "x"); x.Cmp(big.NewRat(3, 1)) != 0 {
+		t.Errorf("got x = %s, want 3", x.RatString())
+	}
+	if y, _ := p.Value(m, "y"); y.Cmp(big.NewRat(1, 1)) != 0 {
+		t.Errorf("got y = %s, want 1", y.RatString())
+	}
+	if _, ok := p.Value(m, "z"); ok {
+		t.Errorf("got value for unknown variable z")
+	}
+}
+
+func TestReadLPBounds(t *testing.T) {
+	const src = `minimize
+ cost: 2 a - b + c + 1.5
+st
+ a + b + c = 10
+ b - 2.5 c >= -inf
+bounds
+ a free
+ -3 <= b <= 4
+binary
+ c
+end
+`
+	ctx := z3.NewContext(nil)
+	o := z3.NewOptimize(ctx)
+	p, err := ReadLP(ctx, o, strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a = 10 - b - c, so the cost is 21.5 - 3b - c, which is
+	// minimized by b = 4 and c = 1.
+	m, opt := solve(t, o, p)
+	if want := big.NewRat(17, 2); opt.Cmp(want) != 0 {
+		t.Errorf("got optimum %s, want %s", opt.RatString(), want.RatString())
+	}
+	if a, _ := p.Value(m, "a"); a.Cmp(big.NewRat(5, 1)) != 0 {
+		t.Errorf("got a = %s, want 5", a.RatString())
+	}
+	// The second constraint has infinite bounds.
+	if len(p.Constraints) != 1 {
+		t.Errorf("got %d constraints, want 1", len(p.Constraints))
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	lp := []string{
+		"subject to\n x <= 1\nend\n",
+		"max\n x + y\nst\n x + y <= 1\n",
+		"max\n x\nst\n [ x ^ 2 ] <= 1\nend\n",
+		"max\n x\nst\n c: x <= 1\n c: x >= 0\nend\n",
+		"max\n x\nst\n x <= y\nend\n",
+		"max\n x\nsos\n s1: x:1\nend\n",
+	}
+	for _, src := range lp {
+		ctx := z3.NewContext(nil)
+		if _, err := ReadLP(ctx, z3.NewOptimize(ctx), strings.NewReader(src)); err == nil {
+			t.Errorf("ReadLP(%q) succeeded", src)
+		}
+	}
+
+	mps := []string{
+		"ROWS\n N obj\nCOLUMNS\n x obj 1\n",
+		"ROWS\n N obj\nCOLUMNS\n x lim 1\nENDATA\n",
+		"ROWS\n N obj\n Q lim\nENDATA\n",
+		"ROWS\n N obj\nCOLUMNS\n x obj 1\nBOUNDS\n SC BND x 1\nENDATA\n",
+		"ROWS\n N obj\nCOLUMNS\n x obj abc\nENDATA\n",
+	}
+	for _, src := range mps {
+		ctx := z3.NewContext(nil)
+		if _, err := ReadMPS(ctx, z3.NewOptimize(ctx), strings.NewReader(src)); err == nil {
+			t.Errorf("ReadMPS(%q) succeeded", src)
+		}
+	}
+}
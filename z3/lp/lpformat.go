@@ -0,0 +1,521 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lp
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// ReadLP reads a linear program in CPLEX LP format from r and adds its
+// variables, constraints, and objective to o, which must belong to
+// ctx.
+//
+// An LP file has an objective section (Maximize or Minimize), a
+// Subject To section of constraints, and optional Bounds, General,
+// and Binary sections, ending with End. For example:
+//
+//	\ A small mixed-integer program.
+//	Maximize
+//	 obj: 3 x + 2 y
+//	Subject To
+//	 c1: x + y <= 4
+//	 c2: x + 3 y >= 2
+//	 -2 <= x - y <= 2
+//	Bounds
+//	 y <= 3.5
+//	General
+//	 x
+//	End
+//
+// Variables are non-negative unless the Bounds section says
+// otherwise. Section keywords must start a line.
+func ReadLP(ctx *z3.Context, o *z3.Optimize, r io.Reader) (*Program, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	toks, err := lexLP(string(data))
+	if err != nil {
+		return nil, err
+	}
+	p := &lpParser{b: newBuilder(), toks: toks}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.b.build(ctx, o), nil
+}
+
+type lpTokenKind int
+
+const (
+	lpEOF lpTokenKind = iota
+	lpName
+	lpNumber
+	lpSign  // + or -
+	lpOp    // <=, >=, or =
+	lpColon // :
+	lpSection
+)
+
+type lpToken struct {
+	kind lpTokenKind
+	text string // For lpSection, the canonical section name
+	line int
+}
+
+// lpSections maps the keywords that start sections, in lower case, to
+// canonical section names. Multi-word keywords are matched a word at
+// a time by lexLP.
+var lpSections = map[string]string{
+	"maximize": "max", "maximise": "max", "maximum": "max", "max": "max",
+	"minimize": "min", "minimise": "min", "minimum": "min", "min": "min",
+	"subject to": "st", "such that": "st", "st": "st", "s.t.": "st", "st.": "st",
+	"bounds": "bounds", "bound": "bounds",
+	"general": "general", "generals": "general", "gen": "general",
+	"integer": "general", "integers": "general",
+	"binary": "binary", "binaries": "binary", "bin": "binary",
+	"semi-continuous": "semi", "semis": "semi", "semi": "semi",
+	"sos": "sos",
+	"end": "end",
+}
+
+func isNameChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		strings.IndexByte("!\"#$%&()/,.;?@_`'{}|~", c) >= 0
+}
+
+// lexLP splits src into tokens. Names at the start of a line that are
+// section keywords become lpSection tokens.
+func lexLP(src string) ([]lpToken, error) {
+	var toks []lpToken
+	line, lineStart := 1, true
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			lineStart = true
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+		case c == '\\':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		}
+
+		tok := lpToken{line: line}
+		start := i
+		switch {
+		case c == '+' || c == '-':
+			tok.kind, tok.text = lpSign, src[i:i+1]
+			i++
+		case c == ':':
+			tok.kind, tok.text = lpColon, ":"
+			i++
+		case c == '<' || c == '>' || c == '=':
+			i++
+			if i < len(src) && (src[i] == '=' || src[i] == '<' || src[i] == '>') {
+				i++
+			}
+			tok.kind = lpOp
+			switch op := src[start:i]; op {
+			case "<", "<=", "=<":
+				tok.text = "<="
+			case ">", ">=", "=>":
+				tok.text = ">="
+			case "=":
+				tok.text = "="
+			default:
+				return nil, fmt.Errorf("lp: LP line %d: bad operator %s", line, op)
+			}
+		case '0' <= c && c <= '9' || c == '.' && i+1 < len(src) && '0' <= src[i+1] && src[i+1] <= '9':
+			for i < len(src) && ('0' <= src[i] && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			// An exponent must have digits, so that 2e1x is not
+			// confused with 2 e1x.
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				j := i + 1
+				if j < len(src) && (src[j] == '+' || src[j] == '-') {
+					j++
+				}
+				if j < len(src) && '0' <= src[j] && src[j] <= '9' {
+					for j < len(src) && '0' <= src[j] && src[j] <= '9' {
+						j++
+					}
+					i = j
+				}
+			}
+			tok.kind, tok.text = lpNumber, src[start:i]
+		case isNameChar(c):
+			for i < len(src) && (isNameChar(src[i]) || src[i] == '[' || src[i] == ']') {
+				i++
+			}
+			tok.kind, tok.text = lpName, src[start:i]
+			word := strings.ToLower(tok.text)
+			switch word {
+			case "inf", "infinity":
+				tok.kind = lpNumber
+			case "subject", "such", "semi":
+				// Look for the second word of the keyword.
+				j := i
+				for j < len(src) && (src[j] == ' ' || src[j] == '\t' || src[j] == '-') {
+					j++
+				}
+				k := j
+				for k < len(src) && isNameChar(src[k]) {
+					k++
+				}
+				next := strings.ToLower(src[j:k])
+				if lineStart && (word == "subject" && next == "to" || word == "such" && next == "that") {
+					word += " " + next
+					i = k
+				} else if lineStart && word == "semi" && next == "continuous" {
+					word = "semi-continuous"
+					i = k
+				}
+			}
+			if s, ok := lpSections[word]; ok && lineStart {
+				tok.kind, tok.text = lpSection, s
+			}
+		case c == '[':
+			return nil, fmt.Errorf("lp: LP line %d: quadratic terms are not supported", line)
+		default:
+			return nil, fmt.Errorf("lp: LP line %d: unexpected character %q", line, c)
+		}
+		lineStart = false
+		toks = append(toks, tok)
+	}
+	return append(toks, lpToken{kind: lpEOF, line: line}), nil
+}
+
+type lpParser struct {
+	b    *builder
+	toks []lpToken
+	pos  int
+}
+
+func (p *lpParser) peek() lpToken { return p.toks[p.pos] }
+
+func (p *lpParser) peek2() lpToken {
+	if p.pos+1 < len(p.toks) {
+		return p.toks[p.pos+1]
+	}
+	return p.toks[len(p.toks)-1]
+}
+
+func (p *lpParser) next() lpToken {
+	t := p.toks[p.pos]
+	if t.kind != lpEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *lpParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("lp: LP line %d: %s", p.peek().line, fmt.Sprintf(format, args...))
+}
+
+// atEnd reports whether the current section has ended.
+func (p *lpParser) atEnd() bool {
+	k := p.peek().kind
+	return k == lpSection || k == lpEOF
+}
+
+func (p *lpParser) parse() error {
+	t := p.next()
+	if t.kind != lpSection || t.text != "max" && t.text != "min" {
+		return fmt.Errorf("lp: LP line %d: file must start with Maximize or Minimize", t.line)
+	}
+	p.b.maximize = t.text == "max"
+	if p.peek().kind == lpName && p.peek2().kind == lpColon {
+		p.next()
+		p.next()
+	}
+	if err := p.expr(&p.b.obj, true); err != nil {
+		return err
+	}
+	if !p.atEnd() {
+		return p.errorf("unexpected %s in objective", p.peek().text)
+	}
+
+	for {
+		t := p.next()
+		var err error
+		switch {
+		case t.kind == lpEOF:
+			return fmt.Errorf("lp: LP file has no End")
+		case t.kind != lpSection:
+			return fmt.Errorf("lp: LP line %d: unexpected %s", t.line, t.text)
+		case t.text == "end":
+			return nil
+		case t.text == "st":
+			for err == nil && !p.atEnd() {
+				err = p.constraint()
+			}
+		case t.text == "bounds":
+			for err == nil && !p.atEnd() {
+				err = p.bound()
+			}
+		case t.text == "general" || t.text == "binary":
+			for !p.atEnd() {
+				n := p.next()
+				if n.kind != lpName {
+					return fmt.Errorf("lp: LP line %d: expected variable name", n.line)
+				}
+				v := p.b.variable(n.text)
+				v.integer = true
+				if t.text == "binary" {
+					v.lo, v.hi = new(big.Rat), big.NewRat(1, 1)
+				}
+			}
+		case t.text == "semi":
+			return fmt.Errorf("lp: LP line %d: semi-continuous variables are not supported", t.line)
+		case t.text == "sos":
+			return fmt.Errorf("lp: LP line %d: special ordered sets are not supported", t.line)
+		default:
+			return fmt.Errorf("lp: LP line %d: misplaced section", t.line)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// expr parses a linear expression into e. If constOK, terms without a
+// variable are allowed and added to e's constant.
+func (p *lpParser) expr(e *expr, constOK bool) error {
+	first := true
+	for !p.atEnd() && p.peek().kind != lpOp {
+		// A name followed by a colon starts the next constraint.
+		if p.peek().kind == lpName && p.peek2().kind == lpColon {
+			break
+		}
+		neg, signed := false, false
+		for p.peek().kind == lpSign {
+			signed = true
+			if p.next().text == "-" {
+				neg = !neg
+			}
+		}
+		if !first && !signed {
+			break
+		}
+		first = false
+		coef := big.NewRat(1, 1)
+		hasCoef := false
+		if p.peek().kind == lpNumber {
+			x, err := p.number()
+			if err != nil {
+				return err
+			}
+			coef, hasCoef = x, true
+		}
+		if neg {
+			coef.Neg(coef)
+		}
+		if p.peek().kind == lpName {
+			name := p.next().text
+			p.b.variable(name)
+			e.add(coef, name)
+			continue
+		}
+		if !hasCoef {
+			return p.errorf("expected term")
+		}
+		if !constOK {
+			return p.errorf("constant term on the left-hand side")
+		}
+		e.constant.Add(&e.constant, coef)
+	}
+	return nil
+}
+
+// number parses a finite number.
+func (p *lpParser) number() (*big.Rat, error) {
+	t := p.next()
+	x, err := parseNumber(t.text)
+	if err != nil {
+		return nil, fmt.Errorf("lp: LP line %d: %v", t.line, err)
+	}
+	if x == nil {
+		return nil, fmt.Errorf("lp: LP line %d: infinite coefficient", t.line)
+	}
+	return x, nil
+}
+
+// signedNumber parses a number with optional signs, returning nil for
+// infinity.
+func (p *lpParser) signedNumber() (x *big.Rat, neg bool, err error) {
+	for p.peek().kind == lpSign {
+		if p.next().text == "-" {
+			neg = !neg
+		}
+	}
+	t := p.next()
+	if t.kind != lpNumber {
+		return nil, false, fmt.Errorf("lp: LP line %d: expected number", t.line)
+	}
+	x, err = parseNumber(t.text)
+	if err != nil {
+		return nil, false, fmt.Errorf("lp: LP line %d: %v", t.line, err)
+	}
+	if x != nil && neg {
+		x.Neg(x)
+	}
+	return x, neg, nil
+}
+
+func (p *lpParser) op() (string, error) {
+	t := p.next()
+	if t.kind != lpOp {
+		return "", fmt.Errorf("lp: LP line %d: expected <=, >=, or =", t.line)
+	}
+	return t.text, nil
+}
+
+// isNumberStart reports whether the next tokens are a signed number.
+func (p *lpParser) isNumberStart() bool {
+	for i := p.pos; i < len(p.toks); i++ {
+		switch p.toks[i].kind {
+		case lpSign:
+			continue
+		case lpNumber:
+			return i+1 < len(p.toks) && p.toks[i+1].kind == lpOp
+		}
+		return false
+	}
+	return false
+}
+
+func (p *lpParser) constraint() error {
+	r := &row{name: fmt.Sprintf("c%d", len(p.b.rows)+1)}
+	if p.peek().kind == lpName && p.peek2().kind == lpColon {
+		r.name = p.next().text
+		p.next()
+	}
+
+	// A ranged constraint lo <= expr <= hi.
+	var lo *big.Rat
+	ranged := p.isNumberStart()
+	if ranged {
+		var err error
+		if lo, _, err = p.signedNumber(); err != nil {
+			return err
+		}
+		if op, err := p.op(); err != nil {
+			return err
+		} else if op != "<=" {
+			return p.errorf("ranged constraint must use <=")
+		}
+	}
+
+	if err := p.expr(&r.expr, false); err != nil {
+		return err
+	}
+	if len(r.expr.terms) == 0 {
+		return p.errorf("constraint %s has no terms", r.name)
+	}
+	op, err := p.op()
+	if err != nil {
+		return err
+	}
+	rhs, _, err := p.signedNumber()
+	if err != nil {
+		return err
+	}
+	switch {
+	case ranged && op != "<=":
+		return p.errorf("ranged constraint must use <=")
+	case ranged:
+		r.lo, r.hi = lo, rhs
+	case op == "<=":
+		r.hi = rhs
+	case op == ">=":
+		r.lo = rhs
+	default:
+		if rhs == nil {
+			return p.errorf("constraint %s is equal to infinity", r.name)
+		}
+		r.lo, r.hi = rhs, rhs
+	}
+	if err := p.b.addRow(r); err != nil {
+		return p.errorf("%v", err)
+	}
+	return nil
+}
+
+// bound parses one statement of the Bounds section.
+func (p *lpParser) bound() error {
+	var lo *big.Rat
+	hasLo := false
+	if p.peek().kind != lpName {
+		// lo <= x [<= hi]
+		x, _, err := p.signedNumber()
+		if err != nil {
+			return err
+		}
+		op, err := p.op()
+		if err != nil {
+			return err
+		}
+		if op != "<=" {
+			return p.errorf("bound must be lo <= x")
+		}
+		lo, hasLo = x, true
+	}
+	t := p.next()
+	if t.kind != lpName {
+		return fmt.Errorf("lp: LP line %d: expected variable name", t.line)
+	}
+	v := p.b.variable(t.text)
+	if hasLo {
+		v.lo = lo
+		if p.peek().kind != lpOp {
+			return nil
+		}
+	} else if n := p.peek(); n.kind == lpName && strings.EqualFold(n.text, "free") {
+		p.next()
+		v.lo, v.hi = nil, nil
+		return nil
+	}
+	op, err := p.op()
+	if err != nil {
+		return err
+	}
+	x, neg, err := p.signedNumber()
+	if err != nil {
+		return err
+	}
+	switch {
+	case hasLo && op != "<=":
+		return p.errorf("bound must be lo <= x <= hi")
+	case op == "<=":
+		v.hi = x
+		if x == nil && neg {
+			return p.errorf("upper bound of -infinity")
+		}
+	case op == ">=":
+		v.lo = x
+		if x == nil && !neg {
+			return p.errorf("lower bound of infinity")
+		}
+	default:
+		if x == nil {
+			return p.errorf("variable %s fixed to infinity", t.text)
+		}
+		v.lo, v.hi = x, x
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// ReadMPS reads a linear program in MPS format from r and adds its
+// variables, constraints, and objective to o, which must belong to
+// ctx.
+//
+// ReadMPS accepts both fixed and free MPS, as long as names do not
+// contain spaces. The first N row is the objective, which is
+// minimized unless an OBJSENSE section says MAX; other N rows are
+// ignored. Variables are non-negative unless the BOUNDS section says
+// otherwise, except that an UP bound below zero also removes the
+// lower bound of 0. Integer variables are marked by INTORG and INTEND
+// markers or by BV, LI, and UI bounds.
+func ReadMPS(ctx *z3.Context, o *z3.Optimize, r io.Reader) (*Program, error) {
+	b := newBuilder()
+	if err := parseMPS(b, r); err != nil {
+		return nil, err
+	}
+	return b.build(ctx, o), nil
+}
+
+// An mpsRow is a row of an MPS file before its bounds are known.
+type mpsRow struct {
+	*row
+	typ       byte // E, L, or G
+	rhs, rng  *big.Rat
+	hasRange  bool
+	ignored   bool // Free rows other than the objective
+	objective bool
+}
+
+func parseMPS(b *builder, r io.Reader) error {
+	rows := make(map[string]*mpsRow)
+	var order []*mpsRow
+	var objName string
+	section := ""
+	integer := false
+
+	scan := bufio.NewScanner(r)
+	scan.Buffer(nil, 16<<20)
+	for lineno := 1; scan.Scan(); lineno++ {
+		line := scan.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(line, "*") {
+			continue
+		}
+		errorf := func(format string, args ...interface{}) error {
+			return fmt.Errorf("lp: MPS line %d: %s", lineno, fmt.Sprintf(format, args...))
+		}
+
+		if line[0] != ' ' && line[0] != '\t' {
+			// A section header.
+			section = strings.ToUpper(fields[0])
+			switch section {
+			case "NAME":
+				if len(fields) > 1 {
+					b.name = fields[1]
+				}
+			case "OBJSENSE":
+				if len(fields) > 1 {
+					if err := mpsSense(b, fields[1]); err != nil {
+						return errorf("%v", err)
+					}
+				}
+			case "ROWS", "COLUMNS", "RHS", "RANGES", "BOUNDS":
+			case "ENDATA":
+				return finishMPS(b, order)
+			default:
+				return errorf("unsupported section %s", fields[0])
+			}
+			continue
+		}
+
+		switch section {
+		case "OBJSENSE":
+			if err := mpsSense(b, fields[0]); err != nil {
+				return errorf("%v", err)
+			}
+
+		case "ROWS":
+			if len(fields) != 2 {
+				return errorf("malformed row")
+			}
+			typ, name := strings.ToUpper(fields[0]), fields[1]
+			if rows[name] != nil {
+				return errorf("duplicate row %s", name)
+			}
+			mr := &mpsRow{row: &row{name: name}}
+			switch typ {
+			case "N":
+				if objName == "" {
+					objName = name
+					mr.objective = true
+				} else {
+					mr.ignored = true
+				}
+			case "E", "L", "G":
+				mr.typ = typ[0]
+			default:
+				return errorf("unknown row type %s", fields[0])
+			}
+			rows[name] = mr
+			order = append(order, mr)
+
+		case "COLUMNS":
+			if len(fields) >= 3 && fields[1] == "'MARKER'" {
+				switch fields[2] {
+				case "'INTORG'":
+					integer = true
+				case "'INTEND'":
+					integer = false
+				default:
+					return errorf("unknown marker %s", fields[2])
+				}
+				continue
+			}
+			if len(fields) != 3 && len(fields) != 5 {
+				return errorf("malformed column entry")
+			}
+			v := b.variable(fields[0])
+			if integer {
+				v.integer = true
+			}
+			for i := 1; i < len(fields); i += 2 {
+				mr := rows[fields[i]]
+				if mr == nil {
+					return errorf("unknown row %s", fields[i])
+				}
+				x, err := mpsNumber(fields[i+1])
+				if err != nil {
+					return errorf("%v", err)
+				}
+				if mr.objective {
+					b.obj.add(x, fields[0])
+				} else {
+					mr.expr.add(x, fields[0])
+				}
+			}
+
+		case "RHS", "RANGES":
+			// The set name is optional.
+			if len(fields)%2 == 1 {
+				fields = fields[1:]
+			}
+			if len(fields) == 0 {
+				return errorf("malformed %s entry", section)
+			}
+			for i := 0; i < len(fields); i += 2 {
+				mr := rows[fields[i]]
+				if mr == nil {
+					return errorf("unknown row %s", fields[i])
+				}
+				x, err := mpsNumber(fields[i+1])
+				if err != nil {
+					return errorf("%v", err)
+				}
+				switch {
+				case section == "RANGES":
+					if mr.objective || mr.ignored {
+						return errorf("range on free row %s", mr.name)
+					}
+					mr.rng, mr.hasRange = x, true
+				case mr.objective:
+					// The RHS of the objective is minus its
+					// constant term.
+					b.obj.constant.Neg(x)
+				default:
+					mr.rhs = x
+				}
+			}
+
+		case "BOUNDS":
+			if err := mpsBound(b, fields); err != nil {
+				return errorf("%v", err)
+			}
+
+		default:
+			return errorf("data outside of a section")
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("lp: MPS file has no ENDATA")
+}
+
+func mpsSense(b *builder, s string) error {
+	switch strings.ToUpper(s) {
+	case "MAX", "MAXIMIZE":
+		b.maximize = true
+	case "MIN", "MINIMIZE":
+		b.maximize = false
+	default:
+		return fmt.Errorf("unknown objective sense %s", s)
+	}
+	return nil
+}
+
+// mpsNumber parses a finite number of an MPS file.
+func mpsNumber(s string) (*big.Rat, error) {
+	x, err := parseNumber(s)
+	if err == nil && x == nil {
+		err = fmt.Errorf("infinite value %s", s)
+	}
+	return x, err
+}
+
+func mpsBound(b *builder, fields []string) error {
+	if len(fields) < 2 {
+		return fmt.Errorf("malformed bound")
+	}
+	typ := strings.ToUpper(fields[0])
+	valued := true
+	switch typ {
+	case "FR", "MI", "PL", "BV":
+		valued = false
+	case "UP", "LO", "FX", "LI", "UI":
+	case "SC":
+		return fmt.Errorf("semi-continuous variables are not supported")
+	default:
+		return fmt.Errorf("unknown bound type %s", fields[0])
+	}
+	// The bound set name is optional.
+	want := 2
+	if valued {
+		want++
+	}
+	switch len(fields) {
+	case want:
+	case want + 1:
+		fields = append(fields[:1], fields[2:]...)
+	default:
+		// BV bounds sometimes have a value anyway.
+		if typ != "BV" || len(fields) != want+2 {
+			return fmt.Errorf("malformed %s bound", typ)
+		}
+		fields = fields[:3]
+	}
+	v := b.variable(fields[1])
+	var x *big.Rat
+	if valued {
+		var err error
+		if x, err = parseNumber(fields[2]); err != nil {
+			return err
+		}
+	}
+	switch typ {
+	case "UP", "UI":
+		v.hi = x
+		if x != nil && x.Sign() < 0 && v.lo != nil && v.lo.Sign() == 0 {
+			v.lo = nil
+		}
+	case "LO", "LI":
+		v.lo = x
+	case "FX":
+		if x == nil {
+			return fmt.Errorf("infinite FX bound")
+		}
+		v.lo, v.hi = x, x
+	case "FR":
+		v.lo, v.hi = nil, nil
+	case "MI":
+		v.lo = nil
+	case "PL":
+		v.hi = nil
+	case "BV":
+		v.lo, v.hi = new(big.Rat), big.NewRat(1, 1)
+	}
+	if typ == "LI" || typ == "UI" || typ == "BV" {
+		v.integer = true
+	}
+	return nil
+}
+
+// finishMPS computes the bounds of the rows from their type, RHS, and
+// range, and adds them to b.
+func finishMPS(b *builder, order []*mpsRow) error {
+	for _, mr := range order {
+		if mr.objective || mr.ignored {
+			continue
+		}
+		rhs := mr.rhs
+		if rhs == nil {
+			rhs = new(big.Rat)
+		}
+		switch mr.typ {
+		case 'E':
+			mr.lo, mr.hi = rhs, rhs
+		case 'L':
+			mr.hi = rhs
+		case 'G':
+			mr.lo = rhs
+		}
+		if mr.hasRange {
+			abs := new(big.Rat).Abs(mr.rng)
+			switch {
+			case mr.typ == 'L':
+				mr.lo = new(big.Rat).Sub(rhs, abs)
+			case mr.typ == 'G':
+				mr.hi = new(big.Rat).Add(rhs, abs)
+			case mr.rng.Sign() >= 0:
+				mr.hi = new(big.Rat).Add(rhs, abs)
+			default:
+				mr.lo = new(big.Rat).Sub(rhs, abs)
+			}
+		}
+		if err := b.addRow(mr.row); err != nil {
+			return fmt.Errorf("lp: MPS: %v", err)
+		}
+	}
+	return nil
+}
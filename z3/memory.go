@@ -0,0 +1,109 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// ErrMemoryExceeded is matched by errors that are caused by Z3
+// exceeding a memory limit set by SetMemoryMaxSize or
+// SetMemoryHighWatermark. Use errors.Is to test for it: both *Error
+// and *ErrSatUnknown can match it.
+var ErrMemoryExceeded = errors.New("z3: memory limit exceeded")
+
+// ErrResourceExhausted is returned by Check methods when Z3 gives up
+// or fails because it exceeded a memory limit. It matches
+// ErrMemoryExceeded.
+type ErrResourceExhausted struct {
+	// Reason is Z3's description of the failure.
+	Reason string
+}
+
+func (e *ErrResourceExhausted) Error() string {
+	return "z3: resource exhausted: " + e.Reason
+}
+
+// Is reports whether target is ErrMemoryExceeded.
+func (e *ErrResourceExhausted) Is(target error) bool {
+	return target == ErrMemoryExceeded
+}
+
+// unknownError returns the error for a check that returned unknown
+// for the given reason.
+func unknownError(reason string) error {
+	err := &ErrSatUnknown{reason}
+	if err.Is(ErrMemoryExceeded) {
+		return &ErrResourceExhausted{reason}
+	}
+	return err
+}
+
+// recoverMemout must be deferred by Check methods. It turns a Z3
+// out-of-memory panic into an *ErrResourceExhausted stored in *err.
+func recoverMemout(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if e, ok := r.(*Error); ok && e.Code == ErrorMemout {
+		*err = &ErrResourceExhausted{e.Message}
+		return
+	}
+	panic(r)
+}
+
+// EstimatedAllocSize returns an estimate of the number of bytes of
+// memory currently allocated by Z3. This includes all Contexts in the
+// process.
+func EstimatedAllocSize() uint64 {
+	return uint64(C.Z3_get_estimated_alloc_size())
+}
+
+// SetMemoryMaxSize sets a hard limit on the memory Z3 may allocate,
+// in megabytes. If 0, there is no limit.
+//
+// Z3 enforces memory limits for the whole process, not per Context.
+// Check methods that exceed the limit return an
+// *ErrResourceExhausted. Other operations that exceed it panic with
+// an *Error that matches ErrMemoryExceeded.
+func SetMemoryMaxSize(mb uint) {
+	setGlobalParam("memory_max_size", mb)
+}
+
+// SetMemoryHighWatermark sets a soft limit on the memory Z3 may
+// allocate, in megabytes. If 0, there is no limit.
+//
+// Like SetMemoryMaxSize, this applies to the whole process. Once the
+// limit is exceeded, running checks give up and return an
+// *ErrResourceExhausted.
+func SetMemoryHighWatermark(mb uint) {
+	setGlobalParam("memory_high_watermark_mb", mb)
+}
+
+// globalParams are parameters that Z3 only supports globally. Configs
+// set these using Z3_global_param_set.
+var globalParams = map[string]bool{
+	"memory_max_size":          true,
+	"memory_high_watermark_mb": true,
+	"parallel.enable":          true,
+	"parallel.threads.max":     true,
+}
+
+func setGlobalParam(name string, val interface{}) {
+	cname := C.CString(name)
+	cval := C.CString(fmt.Sprint(val))
+	defer C.free(unsafe.Pointer(cname))
+	defer C.free(unsafe.Pointer(cval))
+	C.Z3_global_param_set(cname, cval)
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEstimatedAllocSize(t *testing.T) {
+	ctx := NewContext(nil)
+	ctx.IntConst("x")
+	if EstimatedAllocSize() == 0 {
+		t.Error("expected non-zero allocation size")
+	}
+}
+
+func TestErrMemoryExceeded(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&Error{ErrorMemout, "out of memory"}, true},
+		{&Error{ErrorSort, "sort mismatch"}, false},
+		{&ErrSatUnknown{"max. memory exceeded"}, true},
+		{&ErrSatUnknown{"timeout"}, false},
+	}
+	for _, test := range tests {
+		if got := errors.Is(test.err, ErrMemoryExceeded); got != test.want {
+			t.Errorf("errors.Is(%v, ErrMemoryExceeded) = %v, want %v", test.err, got, test.want)
+		}
+	}
+}
+
+func TestSetMemoryLimits(t *testing.T) {
+	SetMemoryMaxSize(0)
+	SetMemoryHighWatermark(0)
+}
+
+func TestErrResourceExhausted(t *testing.T) {
+	if err := unknownError("max. memory exceeded"); !errors.Is(err, ErrMemoryExceeded) {
+		t.Errorf("expected ErrMemoryExceeded, got %v", err)
+	} else if _, ok := err.(*ErrResourceExhausted); !ok {
+		t.Errorf("expected *ErrResourceExhausted, got %T", err)
+	}
+	if _, ok := unknownError("timeout").(*ErrSatUnknown); !ok {
+		t.Error("expected *ErrSatUnknown for timeout")
+	}
+
+	check := func() (sat bool, err error) {
+		defer recoverMemout(&err)
+		panic(&Error{ErrorMemout, "out of memory"})
+	}
+	if sat, err := check(); sat || !errors.Is(err, ErrMemoryExceeded) {
+		t.Errorf("expected false, ErrMemoryExceeded, got %v, %v", sat, err)
+	}
+}
+
+func TestConfigMemoryMaxSize(t *testing.T) {
+	ctx := NewContext(NewContextConfig().SetMemoryMaxSize(0))
+	ctx.Config().SetUint("memory_high_watermark_mb", 0)
+	if sat, err := NewSolver(ctx).Check(); !sat || err != nil {
+		t.Errorf("expected SAT, got %v, %v", sat, err)
+	}
+}
@@ -4,7 +4,11 @@
 
 package z3
 
-import "runtime"
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
 
 /*
 #cgo LDFLAGS: -lz3
@@ -22,21 +26,47 @@ type Model struct {
 type modelImpl struct {
 	ctx *Context
 	c   C.Z3_model
+
+	// completion is the completion flag used by EvalE.
+	completion bool
 }
 
 // wrapModel wraps a C Z3_model as a Go Model. This must be called
 // with the ctx.lock held.
 func wrapModel(ctx *Context, c C.Z3_model) *Model {
-	impl := &modelImpl{ctx, c}
+	impl := &modelImpl{ctx: ctx, c: c}
 	C.Z3_model_inc_ref(ctx.c, c)
 	runtime.SetFinalizer(impl, func(impl *modelImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_model_dec_ref(impl.ctx.c, impl.c)
 		})
 	})
 	return &Model{impl, noEq{}}
 }
 
+// Close releases the Z3 resources held by m without waiting for the
+// garbage collector to finalize m. m must not be used after Close.
+// Values obtained from m remain valid. Close is idempotent.
+func (m *Model) Close() {
+	m.ctx.release(func() {
+		if m.c != nil {
+			C.Z3_model_dec_ref(m.ctx.c, m.c)
+			m.c = nil
+		}
+	})
+	runtime.SetFinalizer(m.modelImpl, nil)
+}
+
+// Translate copies m into the target Context.
+func (m *Model) Translate(target *Context) *Model {
+	var res *Model
+	target.do(func() {
+		res = wrapModel(target, C.Z3_model_translate(m.ctx.c, m.c, target.c))
+	})
+	runtime.KeepAlive(m)
+	return res
+}
+
 // Eval evaluates val using the concrete interpretations of constants
 // and functions in model m.
 //
@@ -46,7 +76,8 @@ func wrapModel(ctx *Context, c C.Z3_model) *Model {
 //
 // Eval returns nil if val cannot be evaluated. This can happen if val
 // contains a quantifier or is type-incorrect, or if m is a partial
-// model (that is, the option MODEL_PARTIAL was set to true).
+// model (that is, the option MODEL_PARTIAL was set to true). EvalE
+// reports these cases as errors instead.
 func (m *Model) Eval(val Value, completion bool) Value {
 	var ok bool
 	var ast AST
@@ -65,6 +96,159 @@ func (m *Model) Eval(val Value, completion bool) Value {
 	return ast.AsValue()
 }
 
+// SetCompletion sets the completion flag that EvalE uses. It is false
+// for new models.
+func (m *Model) SetCompletion(completion bool) {
+	m.completion = completion
+}
+
+// Completion returns the completion flag that EvalE uses.
+func (m *Model) Completion() bool {
+	return m.completion
+}
+
+// An EvalError is an error from Model.EvalE.
+type EvalError struct {
+	// Val is the value that was evaluated.
+	Val Value
+
+	// Missing lists the constants and functions in Val that have
+	// no interpretation in the model. It is empty if Z3 could not
+	// evaluate Val at all.
+	Missing []FuncDecl
+}
+
+func (e *EvalError) Error() string {
+	if len(e.Missing) == 0 {
+		return fmt.Sprintf("z3: cannot evaluate %v", e.Val)
+	}
+	var names []string
+	for _, d := range e.Missing {
+		names = append(names, d.Name().String())
+	}
+	return fmt.Sprintf("z3: no interpretation for %s in %v", strings.Join(names, ", "), e.Val)
+}
+
+// EvalE evaluates val like Eval, using m's completion flag (see
+// SetCompletion). Unlike Eval, it returns an *EvalError if Z3 cannot
+// evaluate val, or if completion is off and the result still depends
+// on constants or functions that m does not interpret.
+func (m *Model) EvalE(val Value) (Value, error) {
+	completion := m.completion
+	var ok bool
+	var ast AST
+	var missing []FuncDecl
+	m.ctx.do(func() {
+		var cast C.Z3_ast
+		ok = z3ToBool(C.Z3_model_eval(m.ctx.c, m.c, val.impl().c, boolToZ3(completion), &cast))
+		if !ok {
+			return
+		}
+		ast = wrapAST(m.ctx, cast)
+		if !completion {
+			missing = m.uninterpreted(cast)
+		}
+	})
+	runtime.KeepAlive(m)
+	runtime.KeepAlive(val)
+	if !ok {
+		return nil, &EvalError{Val: val}
+	}
+	if len(missing) > 0 {
+		return nil, &EvalError{Val: val, Missing: missing}
+	}
+	return ast.AsValue(), nil
+}
+
+// uninterpreted returns the uninterpreted constants and functions in
+// a, which has been evaluated in m. This must be called with the
+// ctx.lock held.
+func (m *Model) uninterpreted(a C.Z3_ast) []FuncDecl {
+	var res []FuncDecl
+	uninterpretedApps(m.ctx.c, a, func(a C.Z3_ast, decl C.Z3_func_decl) {
+		if !m.inUniverse(a) {
+			res = append(res, wrapFuncDecl(m.ctx, decl))
+		}
+	})
+	return res
+}
+
+// uninterpretedApps calls f for each distinct application a of an
+// uninterpreted constant or function in x, including those under
+// quantifiers. This must be called with the ctx.lock held.
+func uninterpretedApps(c C.Z3_context, x C.Z3_ast, f func(a C.Z3_ast, decl C.Z3_func_decl)) {
+	seen := make(map[C.uint]bool)
+	var walk func(a C.Z3_ast)
+	walk = func(a C.Z3_ast) {
+		id := C.Z3_get_ast_id(c, a)
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		switch C.Z3_get_ast_kind(c, a) {
+		case C.Z3_QUANTIFIER_AST:
+			walk(C.Z3_get_quantifier_body(c, a))
+		case C.Z3_APP_AST:
+			app := C.Z3_to_app(c, a)
+			decl := C.Z3_get_app_decl(c, app)
+			if C.Z3_get_decl_kind(c, decl) == C.Z3_OP_UNINTERPRETED {
+				f(a, decl)
+			}
+			for i, n := C.uint(0), C.Z3_get_app_num_args(c, app); i < n; i++ {
+				walk(C.Z3_get_app_arg(c, app, i))
+			}
+		}
+	}
+	walk(x)
+}
+
+// inUniverse reports whether a is an element of the universe of its
+// sort in m. This must be called with the ctx.lock held.
+func (m *Model) inUniverse(a C.Z3_ast) bool {
+	c := m.ctx.c
+	sort := C.Z3_get_sort(c, a)
+	if C.Z3_get_sort_kind(c, sort) != C.Z3_UNINTERPRETED_SORT {
+		return false
+	}
+	found := false
+	for i, n := C.uint(0), C.Z3_model_get_num_sorts(c, m.c); i < n && !found; i++ {
+		found = z3ToBool(C.Z3_is_eq_sort(c, sort, C.Z3_model_get_sort(c, m.c, i)))
+	}
+	if !found {
+		return false
+	}
+	vec := C.Z3_model_get_sort_universe(c, m.c, sort)
+	C.Z3_ast_vector_inc_ref(c, vec)
+	defer C.Z3_ast_vector_dec_ref(c, vec)
+	for i, n := C.uint(0), C.Z3_ast_vector_size(c, vec); i < n; i++ {
+		if z3ToBool(C.Z3_is_eq_ast(c, a, C.Z3_ast_vector_get(c, vec, i))) {
+			return true
+		}
+	}
+	return false
+}
+
+// Satisfies evaluates each of assertions in m, with model completion,
+// and reports whether they all evaluate to true. If not, failing lists
+// the indexes of those that don't. This is a cheap check, independent
+// of the solver that produced m, against modeling mistakes such as
+// checking a model against assertions it was not built for.
+//
+// An assertion that does not evaluate to a Boolean literal, such as a
+// quantifier that Z3 cannot evaluate, counts as failing.
+func (m *Model) Satisfies(assertions []Bool) (ok bool, failing []int) {
+	for i, a := range assertions {
+		v, isLiteral := false, false
+		if b, _ := m.Eval(a, true).(Bool); b.valueImpl != nil {
+			v, isLiteral = b.AsBool()
+		}
+		if !v || !isLiteral {
+			failing = append(failing, i)
+		}
+	}
+	return len(failing) == 0, failing
+}
+
 // String returns a string representation of m.
 func (m *Model) String() string {
 	var res string
@@ -91,6 +275,18 @@ func (m *Model) EvalAsInt64(val Value, completion bool) (int64, bool, bool) {
 	return intVal.AsInt64()
 }
 
+// EvalAsUint64 evaluates val, which must be an Int, BV, or
+// FiniteDomain, and returns its value as a uint64. It returns the
+// value, whether the result is a literal, and whether the value fits
+// in a uint64.
+func (m *Model) EvalAsUint64(val Value, completion bool) (uint64, bool, bool) {
+	result := m.Eval(val, completion)
+	if result == nil {
+		return 0, false, false
+	}
+	return result.impl().asUint64()
+}
+
 // Sorts returns the uninterpreted sorts that m assigns an
 // interpretation to.
 //
@@ -134,3 +330,216 @@ func (m *Model) SortUniverse(s Sort) []Uninterpreted {
 	runtime.KeepAlive(m)
 	return res
 }
+
+// EvalAsString evaluates val and returns its value as a Go string.
+// It returns "", false if val cannot be evaluated to a string literal.
+func (m *Model) EvalAsString(val String, completion bool) (string, bool) {
+	result, ok := m.Eval(val, completion).(String)
+	if !ok {
+		return "", false
+	}
+	return result.AsString()
+}
+
+// EvalAsRune evaluates val and returns its value as a Go rune. It
+// returns 0, false if val cannot be evaluated to a character literal.
+func (m *Model) EvalAsRune(val Char, completion bool) (rune, bool) {
+	result, ok := m.Eval(val, completion).(Char)
+	if !ok {
+		return 0, false
+	}
+	return result.AsRune()
+}
+
+// EvalSeq evaluates val and returns the elements of the resulting
+// sequence. It returns nil, false if val cannot be evaluated to a
+// sequence literal.
+func (m *Model) EvalSeq(val Seq, completion bool) ([]Value, bool) {
+	result, ok := m.Eval(val, completion).(Seq)
+	if !ok {
+		return nil, false
+	}
+	return result.AsValues()
+}
+
+// EvalSeqAsInt64 is like EvalSeq, but returns the elements as int64s.
+// The elements of val must be Ints or BVs. It returns nil, false if
+// any element is not a literal representable as an int64.
+func (m *Model) EvalSeqAsInt64(val Seq, completion bool) ([]int64, bool) {
+	vals, ok := m.EvalSeq(val, completion)
+	if !ok {
+		return nil, false
+	}
+	res := make([]int64, len(vals))
+	for i, v := range vals {
+		lit, isNum := v.(interface {
+			AsInt64() (int64, bool, bool)
+		})
+		if !isNum {
+			return nil, false
+		}
+		n, isLiteral, ok := lit.AsInt64()
+		if !isLiteral || !ok {
+			return nil, false
+		}
+		res[i] = n
+	}
+	return res, true
+}
+
+// EvalSeqAsStrings is like EvalSeq, but returns the elements of a
+// sequence of strings as Go strings.
+func (m *Model) EvalSeqAsStrings(val Seq, completion bool) ([]string, bool) {
+	vals, ok := m.EvalSeq(val, completion)
+	if !ok {
+		return nil, false
+	}
+	res := make([]string, len(vals))
+	for i, v := range vals {
+		s, isStr := v.(String)
+		if !isStr {
+			return nil, false
+		}
+		if res[i], ok = s.AsString(); !ok {
+			return nil, false
+		}
+	}
+	return res, true
+}
+
+// ArrayInterp is the interpretation of an array as a finite map plus a
+// default value.
+type ArrayInterp struct {
+	// Entries lists the indexes at which the array may differ
+	// from Default.
+	Entries []ArrayEntry
+
+	// Default is the value of the array at every index not in
+	// Entries. It is nil if the model does not define one.
+	Default Value
+}
+
+// ArrayEntry is a single index/value pair of an ArrayInterp. Index has
+// one element for each domain sort of the array.
+type ArrayEntry struct {
+	Index []Value
+	Value Value
+}
+
+// ArrayInterp evaluates a in m and returns its interpretation as a
+// finite map plus a default value. It handles arrays built from
+// constant arrays and stores, as well as arrays represented by the
+// function interpretations that models use for as-array terms.
+//
+// It returns nil, false if the value of a cannot be represented this
+// way, for example, because it is a lambda.
+func (m *Model) ArrayInterp(a Array) (*ArrayInterp, bool) {
+	val, ok := m.Eval(a, true).(Array)
+	if !ok {
+		return nil, false
+	}
+
+	// Stores closer to the root take precedence over inner
+	// stores and the base array.
+	seen := make(map[string]bool)
+	fresh := func(idx []Value) bool {
+		key := make([]uint64, len(idx))
+		for i, v := range idx {
+			key[i] = v.AsAST().ID()
+		}
+		k := fmt.Sprint(key)
+		if seen[k] {
+			return false
+		}
+		seen[k] = true
+		return true
+	}
+
+	var stores []ArrayEntry
+	expr := val.impl()
+	kind, args, ok := expr.appArgs()
+	for ok && kind == C.Z3_OP_STORE {
+		entry := ArrayEntry{args[1 : len(args)-1], args[len(args)-1]}
+		if fresh(entry.Index) {
+			stores = append(stores, entry)
+		}
+		expr = args[0].impl()
+		kind, args, ok = expr.appArgs()
+	}
+
+	interp := &ArrayInterp{}
+	switch {
+	case ok && kind == C.Z3_OP_CONST_ARRAY:
+		interp.Default = args[0]
+	case ok && kind == C.Z3_OP_AS_ARRAY:
+		entries, def, ok := m.asArrayInterp(expr)
+		if !ok {
+			return nil, false
+		}
+		for _, entry := range entries {
+			if fresh(entry.Index) {
+				interp.Entries = append(interp.Entries, entry)
+			}
+		}
+		interp.Default = def
+	default:
+		return nil, false
+	}
+	for i := len(stores) - 1; i >= 0; i-- {
+		interp.Entries = append(interp.Entries, stores[i])
+	}
+	return interp, true
+}
+
+// asArrayInterp returns the entries and else value of the function
+// interpretation in m of as-array term expr.
+func (m *Model) asArrayInterp(expr *valueImpl) (entries []ArrayEntry, def Value, ok bool) {
+	type entryASTs struct {
+		args []AST
+		val  AST
+	}
+	var asts []entryASTs
+	var defAST AST
+	m.ctx.do(func() {
+		decl := C.Z3_get_as_array_func_decl(m.ctx.c, expr.c)
+		fi := C.Z3_model_get_func_interp(m.ctx.c, m.c, decl)
+		if fi == nil {
+			return
+		}
+		ok = true
+		C.Z3_func_interp_inc_ref(m.ctx.c, fi)
+		defer C.Z3_func_interp_dec_ref(m.ctx.c, fi)
+		n := C.Z3_func_interp_get_num_entries(m.ctx.c, fi)
+		for i := C.uint(0); i < n; i++ {
+			e := C.Z3_func_interp_get_entry(m.ctx.c, fi, i)
+			C.Z3_func_entry_inc_ref(m.ctx.c, e)
+			var entry entryASTs
+			nargs := C.Z3_func_entry_get_num_args(m.ctx.c, e)
+			for j := C.uint(0); j < nargs; j++ {
+				entry.args = append(entry.args, wrapAST(m.ctx, C.Z3_func_entry_get_arg(m.ctx.c, e, j)))
+			}
+			entry.val = wrapAST(m.ctx, C.Z3_func_entry_get_value(m.ctx.c, e))
+			C.Z3_func_entry_dec_ref(m.ctx.c, e)
+			asts = append(asts, entry)
+		}
+		if cdef := C.Z3_func_interp_get_else(m.ctx.c, fi); cdef != nil {
+			defAST = wrapAST(m.ctx, cdef)
+		}
+	})
+	runtime.KeepAlive(m)
+	runtime.KeepAlive(expr)
+	if !ok {
+		return nil, nil, false
+	}
+	for _, e := range asts {
+		entry := ArrayEntry{Value: e.val.AsValue()}
+		for _, arg := range e.args {
+			entry.Index = append(entry.Index, arg.AsValue())
+		}
+		entries = append(entries, entry)
+	}
+	if defAST.astImpl != nil {
+		def = defAST.AsValue()
+	}
+	return entries, def, true
+}
@@ -4,7 +4,11 @@
 
 package z3
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
 
 func TestModel(t *testing.T) {
 	// Create a simple formula with a unique solution.
@@ -28,3 +32,181 @@ func TestModel(t *testing.T) {
 		t.Fatalf("expected x -> true, y -> false; got\n%s", m)
 	}
 }
+
+func TestModelEvalAsString(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	x := ctx.StringConst("x")
+	s.Assert(x.Eq(ctx.FromString("hi")))
+	if sat, _ := s.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	if got, ok := s.Model().EvalAsString(x, true); !ok || got != "hi" {
+		t.Errorf("expected \"hi\", got %q, %v", got, ok)
+	}
+}
+
+func TestModelArrayInterp(t *testing.T) {
+	ctx := NewContext(nil)
+	intSort := ctx.IntSort()
+	a := ctx.Const("a", ctx.ArraySort(intSort, intSort)).(Array)
+
+	solver := NewSolver(ctx)
+	solver.Assert(a.Select(ctx.Int(1)).(Int).Eq(ctx.Int(10)))
+	solver.Assert(a.Select(ctx.Int(2)).(Int).Eq(ctx.Int(20)))
+	solver.Assert(a.Select(ctx.Int(3)).(Int).Eq(ctx.Int(30)))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	interp, ok := solver.Model().ArrayInterp(a)
+	if !ok {
+		t.Fatal("expected array interpretation")
+	}
+	got := make(map[int64]int64)
+	for _, e := range interp.Entries {
+		if len(e.Index) != 1 {
+			t.Fatalf("expected 1 index, got %d", len(e.Index))
+		}
+		k, _, _ := e.Index[0].(Int).AsInt64()
+		v, _, _ := e.Value.(Int).AsInt64()
+		got[k] = v
+	}
+	if interp.Default == nil {
+		t.Fatal("expected default value")
+	}
+	def, _, _ := interp.Default.(Int).AsInt64()
+	for k, want := range map[int64]int64{1: 10, 2: 20, 3: 30} {
+		v, ok := got[k]
+		if !ok {
+			v = def
+		}
+		if v != want {
+			t.Errorf("a[%d]: expected %d, got %d", k, want, v)
+		}
+	}
+}
+
+func TestModelArrayInterpStores(t *testing.T) {
+	ctx := NewContext(nil)
+	intSort := ctx.IntSort()
+	a := ctx.ConstArray(intSort, ctx.Int(0)).
+		Store(ctx.Int(1), ctx.Int(5)).
+		Store(ctx.Int(2), ctx.Int(6)).
+		Store(ctx.Int(1), ctx.Int(7))
+	b := ctx.Const("b", a.Sort()).(Array)
+
+	solver := NewSolver(ctx)
+	solver.Assert(b.Eq(a))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	interp, ok := solver.Model().ArrayInterp(b)
+	if !ok {
+		t.Fatal("expected array interpretation")
+	}
+	if def, _, _ := interp.Default.(Int).AsInt64(); def != 0 {
+		t.Errorf("expected default 0, got %d", def)
+	}
+	got := make(map[int64]int64)
+	for _, e := range interp.Entries {
+		k, _, _ := e.Index[0].(Int).AsInt64()
+		v, _, _ := e.Value.(Int).AsInt64()
+		got[k] = v
+	}
+	if len(got) != 2 || got[1] != 7 || got[2] != 6 {
+		t.Errorf("expected map[1:7 2:6], got %v", got)
+	}
+}
+
+func TestModelEvalE(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	f := ctx.FuncDecl("f", []Sort{ctx.IntSort()}, ctx.IntSort())
+	elem := ctx.UninterpretedSort("Elem")
+	a, b := ctx.Const("a", elem), ctx.Const("b", elem)
+	s.Assert(x.Eq(ctx.Int(3)))
+	s.Assert(ctx.Distinct(a, b))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("got unsat, err %v", err)
+	}
+	m := s.Model()
+
+	if v, err := m.EvalE(x.Add(ctx.Int(1))); err != nil || v.String() != "4" {
+		t.Errorf("x+1: got %v, %v; want 4", v, err)
+	}
+	// Universe elements are values, not missing constants.
+	if _, err := m.EvalE(a); err != nil {
+		t.Errorf("a: %v", err)
+	}
+
+	_, err := m.EvalE(x.Add(y).Add(f.Apply(x).(Int)))
+	var eerr *EvalError
+	if !errors.As(err, &eerr) {
+		t.Fatalf("x+y+f(x): got %v, want *EvalError", err)
+	}
+	var names []string
+	for _, d := range eerr.Missing {
+		names = append(names, d.Name().String())
+	}
+	if len(names) != 2 || names[0] != "y" && names[1] != "y" {
+		t.Errorf("got missing %v, want y and f", names)
+	}
+
+	m.SetCompletion(true)
+	if !m.Completion() {
+		t.Errorf("completion not set")
+	}
+	if v, err := m.EvalE(x.Add(y)); err != nil {
+		t.Errorf("x+y with completion: %v", err)
+	} else if _, isLit, _ := v.(Int).AsInt64(); !isLit {
+		t.Errorf("x+y with completion: got %v, want a literal", v)
+	}
+}
+
+func TestModelPrimeImplicant(t *testing.T) {
+	ctx := NewContext(nil)
+	a, b := ctx.BoolConst("a"), ctx.BoolConst("b")
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	f := a.Or(b).And(x.GT(ctx.Int(0)).Or(y.GT(ctx.Int(0))))
+
+	s := NewSolver(ctx)
+	s.Assert(f)
+	s.Assert(a.And(b.Not()))
+	s.Assert(x.Eq(ctx.Int(3)).And(y.Eq(ctx.Int(-1))))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	m := s.Model()
+	got, err := m.PrimeImplicant(f, []Value{a, b, x, y})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := fmt.Sprint(got); s != "[a (= x 3)]" {
+		t.Errorf("PrimeImplicant = %s, want [a (= x 3)]", s)
+	}
+
+	if _, err := m.PrimeImplicant(b, []Value{a, b, x, y}); err == nil {
+		t.Errorf("PrimeImplicant of false formula succeeded")
+	}
+}
+
+func TestModelSatisfies(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	s := NewSolver(ctx)
+	s.Assert(x.GT(ctx.Int(2)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	m := s.Model()
+	if ok, failing := m.Satisfies(s.Assertions()); !ok || failing != nil {
+		t.Errorf("model does not satisfy its own assertions: %v", failing)
+	}
+
+	// y is not in the model, so completion makes it 0.
+	asserts := []Bool{x.GT(ctx.Int(2)), y.Eq(ctx.Int(1)), x.LT(ctx.Int(0)), y.Eq(ctx.Int(0))}
+	if ok, failing := m.Satisfies(asserts); ok || fmt.Sprint(failing) != "[1 2]" {
+		t.Errorf("Satisfies = %v, %v; want false, [1 2]", ok, failing)
+	}
+}
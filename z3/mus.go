@@ -0,0 +1,256 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "errors"
+
+// A subsetSolver checks subsets of a list of constraints on a Solver.
+// Each constraint is tracked by an indicator literal that implies it,
+// so a subset is checked by assuming its indicators.
+type subsetSolver struct {
+	s     *Solver
+	cs    []Bool
+	lits  []Bool
+	index map[uint64]int // Constraint index by indicator AST ID
+}
+
+// newSubsetSolver opens a scope on s that tracks cs. The caller must
+// call close to pop it.
+func newSubsetSolver(s *Solver, cs []Bool) *subsetSolver {
+	ss := &subsetSolver{s: s, cs: cs, index: make(map[uint64]int)}
+	s.Push()
+	for i, c := range cs {
+		lit := s.ctx.FreshConst("subset", s.ctx.BoolSort()).(Bool)
+		s.Assert(lit.Implies(c))
+		ss.lits = append(ss.lits, lit)
+		ss.index[lit.AsAST().ID()] = i
+	}
+	return ss
+}
+
+func (ss *subsetSolver) close() {
+	ss.s.Pop()
+}
+
+// check checks the constraints selected by subset.
+func (ss *subsetSolver) check(subset []bool) (bool, error) {
+	var assume []Bool
+	for i, in := range subset {
+		if in {
+			assume = append(assume, ss.lits[i])
+		}
+	}
+	return ss.s.CheckAssumptions(assume...)
+}
+
+// core restricts subset to the unsat core of the last check.
+func (ss *subsetSolver) core(subset []bool) {
+	in := make([]bool, len(subset))
+	for _, lit := range ss.s.UnsatCore() {
+		if i, ok := ss.index[lit.AsAST().ID()]; ok {
+			in[i] = true
+		}
+	}
+	for i := range subset {
+		subset[i] = subset[i] && in[i]
+	}
+}
+
+// shrink reduces the unsatisfiable subset to a minimal one by trying
+// to remove each constraint in turn.
+func (ss *subsetSolver) shrink(subset []bool) error {
+	for i := range subset {
+		if !subset[i] {
+			continue
+		}
+		subset[i] = false
+		sat, err := ss.check(subset)
+		if err != nil {
+			return err
+		}
+		if sat {
+			subset[i] = true
+		} else {
+			ss.core(subset)
+		}
+	}
+	return nil
+}
+
+// grow extends the satisfiable subset to a maximal one by trying to
+// add each constraint in turn.
+func (ss *subsetSolver) grow(subset []bool) error {
+	for i := range subset {
+		if subset[i] {
+			continue
+		}
+		subset[i] = true
+		sat, err := ss.check(subset)
+		if err != nil {
+			return err
+		}
+		subset[i] = sat
+	}
+	return nil
+}
+
+// selected returns the constraints whose entry in subset is want.
+func (ss *subsetSolver) selected(subset []bool, want bool) []Bool {
+	var res []Bool
+	for i, in := range subset {
+		if in == want {
+			res = append(res, ss.cs[i])
+		}
+	}
+	return res
+}
+
+// EnumerateMUS calls f with each minimal unsatisfiable subset (MUS) of
+// constraints: each subset that is unsatisfiable together with the
+// assertions of s, but becomes satisfiable if any one of its members
+// is removed. Each MUS is an independent reason why the constraints
+// conflict, so diagnosis tools can report all of them rather than
+// just the one an unsat core happens to find.
+//
+// EnumerateMUS uses the MARCO algorithm. It keeps a map of the subsets
+// it has not yet explored, picks a large unexplored subset, and either
+// shrinks it to a MUS or grows it to a maximal satisfiable subset,
+// then removes everything those rule out from the map. It checks
+// subsets with CheckAssumptions within a Push and Pop on s, so s is
+// left as it was. The number of MUSes can be exponential in the number
+// of constraints.
+//
+// Each MUS lists its constraints in their order in constraints. If f
+// returns false, enumeration stops. EnumerateMUS returns the number of
+// MUSes passed to f, and the error of any Check that fails.
+func (s *Solver) EnumerateMUS(constraints []Bool, f func(mus []Bool) bool) (int, error) {
+	ss := newSubsetSolver(s, constraints)
+	defer ss.close()
+	unexplored := NewSolver(s.ctx)
+	defer unexplored.Close()
+
+	n := 0
+	for {
+		sat, err := unexplored.Check()
+		if err != nil || !sat {
+			return n, err
+		}
+		// Start from the largest subset the map allows: every
+		// constraint not forced out of it.
+		m := unexplored.Model()
+		seed := make([]bool, len(constraints))
+		for i, lit := range ss.lits {
+			v, isLiteral := m.Eval(lit, false).(Bool).AsBool()
+			seed[i] = v || !isLiteral
+		}
+		m.Close()
+
+		sat, err = ss.check(seed)
+		if err != nil {
+			return n, err
+		}
+		if sat {
+			// Block the subsets of this maximal satisfiable
+			// subset.
+			if err := ss.grow(seed); err != nil {
+				return n, err
+			}
+			block := s.ctx.FromBool(false)
+			for i, in := range seed {
+				if !in {
+					block = block.Or(ss.lits[i])
+				}
+			}
+			unexplored.Assert(block)
+			continue
+		}
+
+		// Block the supersets of this MUS.
+		ss.core(seed)
+		if err := ss.shrink(seed); err != nil {
+			return n, err
+		}
+		block := s.ctx.FromBool(false)
+		for i, in := range seed {
+			if in {
+				block = block.Or(ss.lits[i].Not())
+			}
+		}
+		unexplored.Assert(block)
+		n++
+		if !f(ss.selected(seed, true)) {
+			return n, nil
+		}
+	}
+}
+
+// MCS returns a minimal correction set (MCS) of constraints: a
+// smallest-by-inclusion subset whose removal leaves the rest
+// satisfiable together with the assertions of s. It also returns the
+// rest, a maximal satisfiable subset (MSS). For an over-constrained
+// problem, the MCS answers which requirements must be dropped.
+//
+// MCS keeps constraints in the order given whenever it can, so listing
+// them by priority makes it drop the least important ones. The result
+// is minimal but not necessarily of minimum size. Both subsets list
+// their constraints in their order in constraints.
+//
+// If the assertions of s are unsatisfiable on their own, no
+// constraints can be kept and MCS returns an error.
+func (s *Solver) MCS(constraints []Bool) (mcs, mss []Bool, err error) {
+	ss := newSubsetSolver(s, constraints)
+	defer ss.close()
+	subset := make([]bool, len(constraints))
+	sat, err := ss.check(subset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !sat {
+		return nil, nil, errors.New("z3: MCS: assertions are unsatisfiable")
+	}
+	if err := ss.grow(subset); err != nil {
+		return nil, nil, err
+	}
+	return ss.selected(subset, false), ss.selected(subset, true), nil
+}
+
+// EnumerateMCS calls f with each minimal correction set (MCS) of
+// constraints and the maximal satisfiable subset (MSS) that
+// complements it. See MCS.
+//
+// After finding an MCS, EnumerateMCS requires later ones to keep at
+// least one of its constraints, until no satisfiable choice remains.
+// Its checks use CheckAssumptions within a Push and Pop on s, so s is
+// left as it was. If the constraints are satisfiable together with s,
+// the only MCS is empty.
+//
+// If f returns false, enumeration stops. EnumerateMCS returns the
+// number of MCSes passed to f, and the error of any Check that fails.
+func (s *Solver) EnumerateMCS(constraints []Bool, f func(mcs, mss []Bool) bool) (int, error) {
+	ss := newSubsetSolver(s, constraints)
+	defer ss.close()
+	n := 0
+	for {
+		subset := make([]bool, len(constraints))
+		sat, err := ss.check(subset)
+		if err != nil || !sat {
+			return n, err
+		}
+		if err := ss.grow(subset); err != nil {
+			return n, err
+		}
+		block := s.ctx.FromBool(false)
+		for i, in := range subset {
+			if !in {
+				block = block.Or(ss.lits[i])
+			}
+		}
+		s.Assert(block)
+		n++
+		if !f(ss.selected(subset, false), ss.selected(subset, true)) {
+			return n, nil
+		}
+	}
+}
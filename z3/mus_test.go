@@ -0,0 +1,104 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestEnumerateMUS(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	s := NewSolver(ctx)
+	s.Assert(y.GT(ctx.Int(2)))
+	constraints := []Bool{
+		x.GT(ctx.Int(0)),
+		x.LT(ctx.Int(0)),
+		x.GT(ctx.Int(5)),
+		x.LT(ctx.Int(3)),
+		y.Eq(ctx.Int(1)),
+	}
+
+	var got []string
+	n, err := s.EnumerateMUS(constraints, func(mus []Bool) bool {
+		got = append(got, fmt.Sprint(mus))
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+	want := []string{
+		"[(< x 0) (> x 5)]",
+		"[(= y 1)]",
+		"[(> x 0) (< x 0)]",
+		"[(> x 5) (< x 3)]",
+	}
+	if n != len(want) || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %d MUSes %v, want %v", n, got, want)
+	}
+	if s.NumScopes() != 0 || s.NumAssertions() != 1 {
+		t.Errorf("EnumerateMUS changed the solver")
+	}
+
+	n, err = s.EnumerateMUS(constraints, func([]Bool) bool { return false })
+	if n != 1 || err != nil {
+		t.Errorf("stopping after one MUS: got %d, %v", n, err)
+	}
+
+	n, err = s.EnumerateMUS(constraints[:1], func([]Bool) bool { return true })
+	if n != 0 || err != nil {
+		t.Errorf("satisfiable constraints: got %d, %v", n, err)
+	}
+}
+
+func TestMCS(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	s := NewSolver(ctx)
+	s.Assert(x.GE(ctx.Int(0)))
+	constraints := []Bool{
+		x.GT(ctx.Int(5)),
+		x.LT(ctx.Int(3)),
+		x.LT(ctx.Int(0)),
+		x.Eq(ctx.Int(4)),
+	}
+
+	mcs, mss, err := s.MCS(constraints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(mcs, mss), "[(< x 3) (< x 0) (= x 4)] [(> x 5)]"; got != want {
+		t.Errorf("MCS = %s, want %s", got, want)
+	}
+
+	var got []string
+	n, err := s.EnumerateMCS(constraints, func(mcs, mss []Bool) bool {
+		got = append(got, fmt.Sprint(mcs))
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+	want := []string{
+		"[(< x 3) (< x 0) (= x 4)]",
+		"[(> x 5) (< x 0) (= x 4)]",
+		"[(> x 5) (< x 3) (< x 0)]",
+	}
+	if n != len(want) || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %d MCSes %v, want %v", n, got, want)
+	}
+	if s.NumScopes() != 0 || s.NumAssertions() != 1 {
+		t.Errorf("EnumerateMCS changed the solver")
+	}
+
+	s.Assert(x.LT(ctx.Int(0)))
+	if _, _, err := s.MCS(constraints); err == nil {
+		t.Errorf("MCS of unsatisfiable assertions succeeded")
+	}
+}
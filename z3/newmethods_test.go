@@ -4,7 +4,11 @@
 
 package z3
 
-import "testing"
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
 
 func TestIntAbs(t *testing.T) {
 	ctx := NewContext(nil)
@@ -277,3 +281,65 @@ func TestSolverUnsatCore(t *testing.T) {
 		t.Log("Note: UnsatCore may be empty depending on Z3 configuration")
 	}
 }
+
+func TestSolverUnsatCoreNames(t *testing.T) {
+	ctx := NewContext(nil)
+	solver := NewSolver(ctx)
+	x := ctx.IntConst("x")
+
+	solver.AssertNamed("x positive", x.GT(ctx.Int(0)))
+	solver.AssertNamed("x small", x.LT(ctx.Int(10)))
+	solver.Push()
+	solver.AssertNamed("x negative", x.LT(ctx.Int(0)))
+	if sat, err := solver.Check(); sat || err != nil {
+		t.Fatalf("got sat %v, err %v; want unsat", sat, err)
+	}
+	core := solver.UnsatCoreNames()
+	sort.Strings(core)
+	if want := []string{"x negative", "x positive"}; !reflect.DeepEqual(core, want) {
+		t.Errorf("got core %q, want %q", core, want)
+	}
+
+	// After Pop, the popped name is gone and the core refers to the
+	// remaining assertions.
+	solver.Pop()
+	solver.Assert(x.GT(ctx.Int(20)))
+	if sat, err := solver.Check(); sat || err != nil {
+		t.Fatalf("got sat %v, err %v; want unsat", sat, err)
+	}
+	if core, want := solver.UnsatCoreNames(), []string{"x small"}; !reflect.DeepEqual(core, want) {
+		t.Errorf("got core %q, want %q", core, want)
+	}
+	if len(solver.named) != 2 {
+		t.Errorf("got %d named assertions after Pop, want 2", len(solver.named))
+	}
+}
+
+func TestContextXor(t *testing.T) {
+	ctx := NewContext(nil)
+	var bs []Bool
+	var vars []Value
+	for i := 0; i < 5; i++ {
+		b := ctx.FreshConst("b", ctx.BoolSort()).(Bool)
+		bs = append(bs, b)
+		vars = append(vars, b)
+	}
+	for _, parity := range []bool{true, false} {
+		s := NewSolver(ctx)
+		s.Assert(ctx.Xor(bs, parity))
+		if n := countModels(t, s, vars); n != 16 {
+			t.Errorf("Xor(bs, %v) has %d models, want 16", parity, n)
+		}
+		// Three true values have odd parity.
+		s.Assert(bs[0].And(bs[1], bs[2], bs[3].Not(), bs[4].Not()))
+		if sat, err := s.Check(); sat != parity || err != nil {
+			t.Errorf("Xor(bs, %v) with three true: got %v, %v", parity, sat, err)
+		}
+	}
+	if got := ctx.Xor(nil, false).String(); got != "true" {
+		t.Errorf("Xor(nil, false) = %s, want true", got)
+	}
+	if got := ctx.Xor(nil, true).String(); got != "false" {
+		t.Errorf("Xor(nil, true) = %s, want false", got)
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// Numeric is the set of Value types that support arithmetic.
+type Numeric interface {
+	Int | Real | BV | Float
+}
+
+// Num provides the arithmetic operations of numeric type T. Since the
+// methods of Int, Real, BV, and Float differ in their details (for
+// example, BV comparisons are signed or unsigned), Num lets code such
+// as sums, dot products, and sorting networks be written once for all
+// of them:
+//
+//	func sum[T z3.Numeric](n z3.Num[T], xs []T) T {
+//		return n.Sum(xs...)
+//	}
+//
+// Each method uses the operation of T's own method of the same name,
+// except as noted. Float operations use the Context's rounding mode.
+// Vectors are slices of T and matrices are slices of rows.
+//
+// The zero Num is ready to use and treats bit-vectors as signed.
+type Num[T Numeric] struct {
+	// Unsigned makes Num treat bit-vectors as unsigned in
+	// comparisons and division. It has no effect on other types.
+	Unsigned bool
+}
+
+// FromInt returns val as a value of numeric sort s.
+func (n Num[T]) FromInt(val int64, s Sort) T {
+	return s.Context().FromInt(val, s).(T)
+}
+
+// Add returns x+y.
+func (n Num[T]) Add(x, y T) T {
+	switch x := any(x).(type) {
+	case Int:
+		return any(x.Add(any(y).(Int))).(T)
+	case Real:
+		return any(x.Add(any(y).(Real))).(T)
+	case BV:
+		return any(x.Add(any(y).(BV))).(T)
+	case Float:
+		return any(x.Add(any(y).(Float))).(T)
+	}
+	panic("unreachable")
+}
+
+// Sub returns x-y.
+func (n Num[T]) Sub(x, y T) T {
+	switch x := any(x).(type) {
+	case Int:
+		return any(x.Sub(any(y).(Int))).(T)
+	case Real:
+		return any(x.Sub(any(y).(Real))).(T)
+	case BV:
+		return any(x.Sub(any(y).(BV))).(T)
+	case Float:
+		return any(x.Sub(any(y).(Float))).(T)
+	}
+	panic("unreachable")
+}
+
+// Mul returns x*y.
+func (n Num[T]) Mul(x, y T) T {
+	switch x := any(x).(type) {
+	case Int:
+		return any(x.Mul(any(y).(Int))).(T)
+	case Real:
+		return any(x.Mul(any(y).(Real))).(T)
+	case BV:
+		return any(x.Mul(any(y).(BV))).(T)
+	case Float:
+		return any(x.Mul(any(y).(Float))).(T)
+	}
+	panic("unreachable")
+}
+
+// Div returns x/y. For bit-vectors, this is SDiv or UDiv.
+func (n Num[T]) Div(x, y T) T {
+	switch x := any(x).(type) {
+	case Int:
+		return any(x.Div(any(y).(Int))).(T)
+	case Real:
+		return any(x.Div(any(y).(Real))).(T)
+	case BV:
+		if n.Unsigned {
+			return any(x.UDiv(any(y).(BV))).(T)
+		}
+		return any(x.SDiv(any(y).(BV))).(T)
+	case Float:
+		return any(x.Div(any(y).(Float))).(T)
+	}
+	panic("unreachable")
+}
+
+// Neg returns -x.
+func (n Num[T]) Neg(x T) T {
+	switch x := any(x).(type) {
+	case Int:
+		return any(x.Neg()).(T)
+	case Real:
+		return any(x.Neg()).(T)
+	case BV:
+		return any(x.Neg()).(T)
+	case Float:
+		return any(x.Neg()).(T)
+	}
+	panic("unreachable")
+}
+
+// Eq returns a Value that is true if x and y are equal.
+func (n Num[T]) Eq(x, y T) Bool {
+	switch x := any(x).(type) {
+	case Int:
+		return x.Eq(any(y).(Int))
+	case Real:
+		return x.Eq(any(y).(Real))
+	case BV:
+		return x.Eq(any(y).(BV))
+	case Float:
+		return x.Eq(any(y).(Float))
+	}
+	panic("unreachable")
+}
+
+// LT returns x < y. For bit-vectors, this is SLT or ULT.
+func (n Num[T]) LT(x, y T) Bool {
+	switch x := any(x).(type) {
+	case Int:
+		return x.LT(any(y).(Int))
+	case Real:
+		return x.LT(any(y).(Real))
+	case BV:
+		if n.Unsigned {
+			return x.ULT(any(y).(BV))
+		}
+		return x.SLT(any(y).(BV))
+	case Float:
+		return x.LT(any(y).(Float))
+	}
+	panic("unreachable")
+}
+
+// LE returns x <= y. For bit-vectors, this is SLE or ULE.
+func (n Num[T]) LE(x, y T) Bool {
+	switch x := any(x).(type) {
+	case Int:
+		return x.LE(any(y).(Int))
+	case Real:
+		return x.LE(any(y).(Real))
+	case BV:
+		if n.Unsigned {
+			return x.ULE(any(y).(BV))
+		}
+		return x.SLE(any(y).(BV))
+	case Float:
+		return x.LE(any(y).(Float))
+	}
+	panic("unreachable")
+}
+
+// GT returns x > y.
+func (n Num[T]) GT(x, y T) Bool {
+	return n.LT(y, x)
+}
+
+// GE returns x >= y.
+func (n Num[T]) GE(x, y T) Bool {
+	return n.LE(y, x)
+}
+
+// Cmp returns an Int that is -1 if x < y, 0 if x == y, and +1 if
+// x > y. For floating-point NaNs, which are unordered, Cmp is 0.
+func (n Num[T]) Cmp(x, y T) Int {
+	ctx := any(x).(Value).Context()
+	return n.LT(x, y).IfThenElse(ctx.Int(-1),
+		n.GT(x, y).IfThenElse(ctx.Int(1), ctx.Int(0))).(Int)
+}
+
+// Min returns the lesser of x and y.
+func (n Num[T]) Min(x, y T) T {
+	return n.LE(x, y).IfThenElse(any(x).(Value), any(y).(Value)).(T)
+}
+
+// Max returns the greater of x and y.
+func (n Num[T]) Max(x, y T) T {
+	return n.LE(x, y).IfThenElse(any(y).(Value), any(x).(Value)).(T)
+}
+
+// Sum returns xs[0]+xs[1]+... Sum panics if xs is empty, since it
+// has no sort to give the result.
+func (n Num[T]) Sum(xs ...T) T {
+	if len(xs) == 0 {
+		panic("z3: Sum of no values")
+	}
+	sum := xs[0]
+	for _, x := range xs[1:] {
+		sum = n.Add(sum, x)
+	}
+	return sum
+}
+
+// Dot returns the dot product of xs and ys, which must have the same
+// non-zero length.
+func (n Num[T]) Dot(xs, ys []T) T {
+	checkVecs("Dot", xs, ys)
+	prods := make([]T, len(xs))
+	for i := range xs {
+		prods[i] = n.Mul(xs[i], ys[i])
+	}
+	return n.Sum(prods...)
+}
+
+// VecEq returns a Value that is true if xs and ys, which must have
+// the same non-zero length, are equal element-wise.
+func (n Num[T]) VecEq(xs, ys []T) Bool {
+	checkVecs("VecEq", xs, ys)
+	eqs := make([]Bool, len(xs))
+	for i := range xs {
+		eqs[i] = n.Eq(xs[i], ys[i])
+	}
+	return eqs[0].And(eqs[1:]...)
+}
+
+// VecAdd returns the element-wise sum of xs and ys, which must have
+// the same length.
+func (n Num[T]) VecAdd(xs, ys []T) []T {
+	if len(xs) != len(ys) {
+		panic("z3: VecAdd of vectors with different lengths")
+	}
+	sum := make([]T, len(xs))
+	for i := range xs {
+		sum[i] = n.Add(xs[i], ys[i])
+	}
+	return sum
+}
+
+// Scale returns xs with each element multiplied by k.
+func (n Num[T]) Scale(k T, xs []T) []T {
+	res := make([]T, len(xs))
+	for i, x := range xs {
+		res[i] = n.Mul(k, x)
+	}
+	return res
+}
+
+// MatVec returns the product of matrix m, given as a slice of rows,
+// and column vector v. Each row of m must have the same length as v.
+func (n Num[T]) MatVec(m [][]T, v []T) []T {
+	res := make([]T, len(m))
+	for i, row := range m {
+		res[i] = n.Dot(row, v)
+	}
+	return res
+}
+
+// MatMul returns the matrix product of a and b, which are given as
+// slices of rows. Each row of a must have len(b) elements, and the
+// rows of b must all have the same non-zero length.
+func (n Num[T]) MatMul(a, b [][]T) [][]T {
+	if len(b) == 0 || len(b[0]) == 0 {
+		panic("z3: MatMul of empty matrix")
+	}
+	// Collect the columns of b.
+	cols := make([][]T, len(b[0]))
+	for j := range cols {
+		cols[j] = make([]T, len(b))
+		for k, row := range b {
+			if len(row) != len(cols) {
+				panic("z3: MatMul of ragged matrix")
+			}
+			cols[j][k] = row[j]
+		}
+	}
+	res := make([][]T, len(a))
+	for i, row := range a {
+		res[i] = n.MatVec(cols, row)
+	}
+	return res
+}
+
+func checkVecs[T any](op string, xs, ys []T) {
+	if len(xs) != len(ys) {
+		panic("z3: " + op + " of vectors with different lengths")
+	}
+	if len(xs) == 0 {
+		panic("z3: " + op + " of empty vectors")
+	}
+}
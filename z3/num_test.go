@@ -0,0 +1,165 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+// sortNet sorts xs in place with a bubble sorting network.
+func sortNet[T Numeric](n Num[T], xs []T) {
+	for i := len(xs) - 1; i > 0; i-- {
+		for j := 0; j < i; j++ {
+			xs[j], xs[j+1] = n.Min(xs[j], xs[j+1]), n.Max(xs[j], xs[j+1])
+		}
+	}
+}
+
+// testSortNet checks that sortNet sorts every assignment of consts.
+func testSortNet[T Numeric](t *testing.T, n Num[T], s Sort) {
+	t.Helper()
+	ctx := s.Context()
+	var xs []T
+	for _, name := range []string{"a", "b", "c", "d"} {
+		xs = append(xs, ctx.Const(name, s).(T))
+	}
+	orig := n.Sum(xs...)
+	sortNet(n, xs)
+
+	solver := NewSolver(ctx)
+	var sorted []Bool
+	for i := 0; i+1 < len(xs); i++ {
+		sorted = append(sorted, n.LE(xs[i], xs[i+1]))
+	}
+	// The output must be ordered and have the same sum.
+	solver.Assert(ctx.FromBool(true).And(sorted...).And(n.Eq(orig, n.Sum(xs...))).Not())
+	sat, err := solver.Check()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sat {
+		t.Errorf("%s: sorting network does not sort: %s", s, solver.Model())
+	}
+}
+
+func TestNumSortNet(t *testing.T) {
+	ctx := NewContext(nil)
+	testSortNet(t, Num[Int]{}, ctx.IntSort())
+	testSortNet(t, Num[Real]{}, ctx.RealSort())
+	testSortNet(t, Num[BV]{}, ctx.BVSort(4))
+	testSortNet(t, Num[BV]{Unsigned: true}, ctx.BVSort(4))
+	// NaNs are unordered, so for Float just check Min <= Max on
+	// non-NaN values.
+	fs := ctx.FloatSort(3, 4)
+	n := Num[Float]{}
+	a, b := ctx.Const("fa", fs).(Float), ctx.Const("fb", fs).(Float)
+	solver := NewSolver(ctx)
+	solver.Assert(a.IsNaN().Not())
+	solver.Assert(b.IsNaN().Not())
+	solver.Assert(n.GT(n.Min(a, b), n.Max(a, b)))
+	if sat, err := solver.Check(); err != nil || sat {
+		t.Errorf("Float Min > Max: sat %v, err %v", sat, err)
+	}
+}
+
+func TestNumOps(t *testing.T) {
+	ctx := NewContext(nil)
+
+	ni := Num[Int]{}
+	is := ctx.IntSort()
+	xs := []Int{ctx.Int(1), ctx.Int(2), ctx.Int(3)}
+	ys := []Int{ctx.Int(4), ctx.Int(-5), ctx.Int(6)}
+	for _, test := range []struct {
+		got  Int
+		want int64
+	}{
+		{ni.Sum(xs...), 6},
+		{ni.Dot(xs, ys), 12},
+		{ni.Sub(xs[0], xs[2]), -2},
+		{ni.Div(ys[2], xs[2]), 2},
+		{ni.Neg(ys[1]), 5},
+		{ni.Cmp(xs[0], xs[1]), -1},
+		{ni.Cmp(xs[1], xs[1]), 0},
+		{ni.Cmp(ys[2], xs[1]), 1},
+		{ni.FromInt(42, is), 42},
+	} {
+		if !simplifyBool(t, ctx, test.got.Eq(ctx.Int(int(test.want)))) {
+			t.Errorf("%s != %d", test.got, test.want)
+		}
+	}
+
+	// Signed and unsigned bit-vectors differ on the high bit.
+	bs := ctx.BVSort(8)
+	signed, unsigned := Num[BV]{}, Num[BV]{Unsigned: true}
+	m1, two := signed.FromInt(-1, bs), signed.FromInt(2, bs)
+	if !simplifyBool(t, ctx, signed.LT(m1, two)) {
+		t.Errorf("signed -1 < 2 is false")
+	}
+	if simplifyBool(t, ctx, unsigned.LT(m1, two)) {
+		t.Errorf("unsigned 255 < 2 is true")
+	}
+	if !simplifyBool(t, ctx, signed.Div(m1, two).Eq(signed.FromInt(0, bs))) {
+		t.Errorf("signed -1/2 != 0")
+	}
+	if !simplifyBool(t, ctx, unsigned.Div(m1, two).Eq(signed.FromInt(127, bs))) {
+		t.Errorf("unsigned 255/2 != 127")
+	}
+
+	// Float arithmetic.
+	fs := ctx.Float64Sort()
+	nf := Num[Float]{}
+	f := nf.Dot([]Float{nf.FromInt(3, fs), nf.FromInt(2, fs)}, []Float{nf.FromInt(5, fs), nf.FromInt(-4, fs)})
+	if !simplifyBool(t, ctx, nf.Eq(f, nf.FromInt(7, fs))) {
+		t.Errorf("Float dot product %s != 7", f)
+	}
+
+	wantPanic(t, "Sum of no values", func() { ni.Sum() })
+	wantPanic(t, "different lengths", func() { ni.Dot(xs, ys[:1]) })
+}
+
+func TestNumLinear(t *testing.T) {
+	ctx := NewContext(nil)
+	n := Num[Int]{}
+	vec := func(xs ...int) []Int {
+		v := make([]Int, len(xs))
+		for i, x := range xs {
+			v[i] = ctx.Int(x)
+		}
+		return v
+	}
+
+	// A linear layer y = Wx + b.
+	w := [][]Int{vec(1, 2), vec(3, -1), vec(0, 4)}
+	b := vec(1, 0, -2)
+	x := []Int{ctx.IntConst("x0"), ctx.IntConst("x1")}
+	y := n.VecAdd(n.MatVec(w, x), b)
+	s := NewSolver(ctx)
+	s.Assert(n.VecEq(y, vec(6, 1, 6)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v", sat, err)
+	}
+	m := s.Model()
+	x0, _, _ := m.EvalAsInt64(x[0], true)
+	x1, _, _ := m.EvalAsInt64(x[1], true)
+	if x0 != 1 || x1 != 2 {
+		t.Errorf("got x = (%d, %d), want (1, 2)", x0, x1)
+	}
+
+	// (AB)x == A(Bx).
+	a := [][]Int{vec(1, 2, 3), vec(4, 5, 6)}
+	bm := [][]Int{vec(7, 8), vec(9, 10), vec(11, 12)}
+	ab := n.MatMul(a, bm)
+	if !simplifyBool(t, ctx, n.VecEq(ab[0], vec(58, 64)).And(n.VecEq(ab[1], vec(139, 154)))) {
+		t.Errorf("MatMul = %v", ab)
+	}
+	s = NewSolver(ctx)
+	s.Assert(n.VecEq(n.MatVec(ab, x), n.MatVec(a, n.MatVec(bm, x))).Not())
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("(AB)x != A(Bx) is satisfiable")
+	}
+
+	if !simplifyBool(t, ctx, n.VecEq(n.Scale(ctx.Int(3), vec(1, -2)), vec(3, -6))) {
+		t.Errorf("Scale(3, (1, -2)) != (3, -6)")
+	}
+	wantPanic(t, "ragged", func() { n.MatMul(a, [][]Int{vec(1), vec(2, 3), vec(4)}) })
+}
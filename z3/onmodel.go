@@ -0,0 +1,122 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"runtime/cgo"
+)
+
+/*
+#include <z3.h>
+#include <stdint.h>
+*/
+import "C"
+
+// objectiveVals records the value of each objective of an Optimize,
+// indexed by handle. Soft constraint groups have no value.
+type objectiveVals struct {
+	vals []Value
+}
+
+func (o *objectiveVals) set(handle C.uint, val Value) {
+	for uint(len(o.vals)) <= uint(handle) {
+		o.vals = append(o.vals, nil)
+	}
+	o.vals[handle] = val
+}
+
+// onModelState is the state of OnModel. It must not refer to the
+// Optimize, so that the Optimize can be finalized.
+type onModelState struct {
+	ctx  *Context
+	c    C.Z3_optimize
+	m    C.Z3_model // Updated by Z3 before each call
+	objs *objectiveVals
+	f    func(m *Model, objectives []Value)
+
+	// progress, if not nil, is called before f with the ctx.lock
+	// held. It is set by CheckProgress.
+	progress func()
+}
+
+// OnModel registers f to be called during Check each time Z3 finds a
+// model that improves on the objectives. f receives a copy of the
+// model and the value of each objective in it, in the order the
+// objectives were added. For a group of soft constraints added by
+// AssertSoft, the value is the total weight of the violated
+// constraints. This allows anytime use of Optimize: if Check is
+// interrupted or times out, the last model passed to f is the best
+// found so far.
+//
+// f is called while Check holds o's Context, so f must not use the
+// Context or any objects belonging to it, including m and the
+// objectives, until Check returns; doing so deadlocks. f may save them
+// for later and may call Context.Interrupt. Calling OnModel again
+// replaces f, and a nil f stops the calls.
+func (o *Optimize) OnModel(f func(m *Model, objectives []Value)) {
+	o.ctx.do(func() {
+		o.modelState().f = f
+	})
+	runtime.KeepAlive(o)
+}
+
+// modelState returns the state of OnModel, registering the callback
+// with Z3 if this is the first use. This must be called with the
+// ctx.lock held.
+func (o *Optimize) modelState() *onModelState {
+	if o.onModel == 0 {
+		st := &onModelState{ctx: o.ctx, c: o.c, objs: o.objs}
+		st.m = C.Z3_mk_model(o.ctx.c)
+		C.Z3_model_inc_ref(o.ctx.c, st.m)
+		o.onModel = cgo.NewHandle(st)
+		registerModelEH(o.ctx.c, o.c, st.m, o.onModel)
+	}
+	return o.onModel.Value().(*onModelState)
+}
+
+// deleteOnModel releases the state of OnModel, if any.
+func (o *optimizeImpl) deleteOnModel() {
+	if o.onModel == 0 {
+		return
+	}
+	st := o.onModel.Value().(*onModelState)
+	o.ctx.release(func() {
+		C.Z3_model_dec_ref(o.ctx.c, st.m)
+	})
+	o.onModel.Delete()
+	o.onModel = 0
+}
+
+//export goZ3OnModel
+func goZ3OnModel(h C.uintptr_t) {
+	st := cgo.Handle(h).Value().(*onModelState)
+	if st.progress != nil {
+		st.progress()
+	}
+	if st.f == nil {
+		return
+	}
+	// The Context lock is held by Check, so use Z3 directly.
+	ctx, c := st.ctx, st.ctx.c
+	m := wrapModel(ctx, C.Z3_model_translate(c, st.m, c))
+	vec := C.Z3_optimize_get_objectives(c, st.c)
+	C.Z3_ast_vector_inc_ref(c, vec)
+	defer C.Z3_ast_vector_dec_ref(c, vec)
+	vals := make([]Value, int(C.Z3_ast_vector_size(c, vec)))
+	for i := range vals {
+		obj := C.Z3_ast_vector_get(c, vec, C.uint(i))
+		if i < len(st.objs.vals) && st.objs.vals[i] != nil {
+			obj = st.objs.vals[i].impl().c
+		}
+		var res C.Z3_ast
+		if !z3ToBool(C.Z3_model_eval(c, m.c, obj, true, &res)) {
+			continue
+		}
+		val := value{(*valueImpl)(wrapAST(ctx, res).astImpl), noEq{}}
+		vals[i] = val.lift(Kind(C.Z3_get_sort_kind(c, C.Z3_get_sort(c, res))))
+	}
+	st.f(m, vals)
+}
@@ -6,6 +6,7 @@ package z3
 
 import (
 	"runtime"
+	"runtime/cgo"
 	"unsafe"
 )
 
@@ -13,6 +14,23 @@ import (
 #cgo LDFLAGS: -lz3
 #include <z3.h>
 #include <stdlib.h>
+#include <stdint.h>
+
+extern void goZ3OnModel(uintptr_t h);
+
+static void z3go_on_model(void *h) {
+	goZ3OnModel((uintptr_t)h);
+}
+
+static void z3go_optimize_register_model_eh(Z3_context c, Z3_optimize o, Z3_model m, uintptr_t h) {
+	Z3_optimize_register_model_eh(c, o, m, (void*)h, z3go_on_model);
+}
+
+static void z3go_optimize_assert_all(Z3_context c, Z3_optimize s, unsigned n, Z3_ast *vals) {
+	for (unsigned i = 0; i < n; i++) {
+		Z3_optimize_assert(c, s, vals[i]);
+	}
+}
 */
 import "C"
 
@@ -29,6 +47,12 @@ type Optimize struct {
 type optimizeImpl struct {
 	ctx *Context
 	c   C.Z3_optimize
+
+	// objs records the values of the objectives, for OnModel.
+	objs *objectiveVals
+
+	// onModel is the handle of the state of OnModel, or 0.
+	onModel cgo.Handle
 }
 
 // NewOptimize returns a new, empty optimization context.
@@ -36,21 +60,43 @@ func NewOptimize(ctx *Context) *Optimize {
 	var impl *optimizeImpl
 	ctx.do(func() {
 		impl = &optimizeImpl{
-			ctx,
-			C.Z3_mk_optimize(ctx.c),
+			ctx:  ctx,
+			c:    C.Z3_mk_optimize(ctx.c),
+			objs: new(objectiveVals),
 		}
 	})
 	ctx.do(func() {
 		C.Z3_optimize_inc_ref(ctx.c, impl.c)
 	})
 	runtime.SetFinalizer(impl, func(impl *optimizeImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_optimize_dec_ref(impl.ctx.c, impl.c)
 		})
+		impl.deleteOnModel()
 	})
 	return &Optimize{impl, noEq{}}
 }
 
+// registerModelEH registers goZ3OnModel with Z3 to be called with h.
+// This must be called with the ctx.lock held.
+func registerModelEH(c C.Z3_context, o C.Z3_optimize, m C.Z3_model, h cgo.Handle) {
+	C.z3go_optimize_register_model_eh(c, o, m, C.uintptr_t(h))
+}
+
+// Close releases the Z3 resources held by o without waiting for the
+// garbage collector to finalize o. o must not be used after Close.
+// Close is idempotent.
+func (o *Optimize) Close() {
+	o.ctx.release(func() {
+		if o.c != nil {
+			C.Z3_optimize_dec_ref(o.ctx.c, o.c)
+			o.c = nil
+		}
+	})
+	o.deleteOnModel()
+	runtime.SetFinalizer(o.optimizeImpl, nil)
+}
+
 // Assert adds val as a hard constraint to the optimization context.
 func (o *Optimize) Assert(val Bool) {
 	o.ctx.do(func() {
@@ -82,12 +128,31 @@ func (o *Optimize) AssertSoft(val Bool, weight string, id string) uint {
 	var handle C.uint
 	o.ctx.do(func() {
 		handle = C.Z3_optimize_assert_soft(o.ctx.c, o.c, val.c, cweight, sym)
+		o.objs.set(handle, nil)
 	})
 	runtime.KeepAlive(o)
 	runtime.KeepAlive(val)
 	return uint(handle)
 }
 
+// AssertAll adds all of vals as hard constraints to the optimization
+// context. It is equivalent to calling Assert for each value, but much
+// faster for large numbers of values.
+func (o *Optimize) AssertAll(vals []Bool) {
+	if len(vals) == 0 {
+		return
+	}
+	cvals := make([]C.Z3_ast, len(vals))
+	for i, val := range vals {
+		cvals[i] = val.c
+	}
+	o.ctx.do(func() {
+		C.z3go_optimize_assert_all(o.ctx.c, o.c, C.uint(len(cvals)), &cvals[0])
+	})
+	runtime.KeepAlive(o)
+	runtime.KeepAlive(vals)
+}
+
 // Push saves the current state of the Optimize so it can be restored
 // with Pop.
 func (o *Optimize) Push() {
@@ -118,6 +183,7 @@ func (o *Optimize) Maximize(val Value) *Objective {
 	var handle C.uint
 	o.ctx.do(func() {
 		handle = C.Z3_optimize_maximize(o.ctx.c, o.c, val.impl().c)
+		o.objs.set(handle, val)
 	})
 	runtime.KeepAlive(o)
 	runtime.KeepAlive(val)
@@ -130,6 +196,7 @@ func (o *Optimize) Minimize(val Value) *Objective {
 	var handle C.uint
 	o.ctx.do(func() {
 		handle = C.Z3_optimize_minimize(o.ctx.c, o.c, val.impl().c)
+		o.objs.set(handle, val)
 	})
 	runtime.KeepAlive(o)
 	runtime.KeepAlive(val)
@@ -158,19 +225,65 @@ func (obj *Objective) Upper() Value {
 	return ast.AsValue()
 }
 
+// IsInfinite reports whether the lower bound of the objective is -oo
+// and whether its upper bound is +oo after a successful Check. Lower
+// and Upper represent these bounds as terms mentioning "oo", which
+// callers should not need to parse.
+func (obj *Objective) IsInfinite() (lower, upper bool) {
+	return obj.infinity(true) < 0, obj.infinity(false) > 0
+}
+
+// IsUnbounded reports whether the objective has no finite optimum
+// after a successful Check: a maximized objective can grow without
+// bound, or a minimized one shrink without bound.
+func (obj *Objective) IsUnbounded() bool {
+	lower, upper := obj.IsInfinite()
+	return lower || upper
+}
+
+// infinity returns the sign of the coefficient of oo in the lower or
+// upper bound of obj. Z3 represents each bound as a vector
+// (inf, val, eps) standing for inf*oo + val + eps*epsilon.
+func (obj *Objective) infinity(lower bool) int {
+	ctx := obj.opt.ctx
+	var sign int
+	ctx.do(func() {
+		var vec C.Z3_ast_vector
+		if lower {
+			vec = C.Z3_optimize_get_lower_as_vector(ctx.c, obj.opt.c, obj.handle)
+		} else {
+			vec = C.Z3_optimize_get_upper_as_vector(ctx.c, obj.opt.c, obj.handle)
+		}
+		C.Z3_ast_vector_inc_ref(ctx.c, vec)
+		defer C.Z3_ast_vector_dec_ref(ctx.c, vec)
+		inf := C.Z3_ast_vector_get(ctx.c, vec, 0)
+		switch {
+		case bool(C.Z3_algebraic_is_pos(ctx.c, inf)):
+			sign = 1
+		case bool(C.Z3_algebraic_is_neg(ctx.c, inf)):
+			sign = -1
+		}
+	})
+	runtime.KeepAlive(obj)
+	return sign
+}
+
 // Check determines whether the predicates in the Optimize context are
 // satisfiable and produces optimal values. If Z3 is unable to determine
-// satisfiability, it returns an *ErrSatUnknown error.
+// satisfiability, it returns an *ErrSatUnknown error, or an
+// *ErrResourceExhausted error if it ran out of memory.
 func (o *Optimize) Check() (sat bool, err error) {
+	defer recoverMemout(&err)
 	var res C.Z3_lbool
 	o.ctx.do(func() {
+		o.ctx.checks++
 		res = C.Z3_optimize_check(o.ctx.c, o.c, 0, nil)
 	})
 	if res == C.Z3_L_UNDEF {
 		// Get the reason.
 		o.ctx.do(func() {
 			cerr := C.Z3_optimize_get_reason_unknown(o.ctx.c, o.c)
-			err = &ErrSatUnknown{C.GoString(cerr)}
+			err = unknownError(C.GoString(cerr))
 		})
 	}
 	runtime.KeepAlive(o)
@@ -179,8 +292,10 @@ func (o *Optimize) Check() (sat bool, err error) {
 
 // CheckAssumptions determines whether the predicates in the Optimize context
 // together with the given assumptions are satisfiable and produces optimal values.
-// If Z3 is unable to determine satisfiability, it returns an *ErrSatUnknown error.
+// If Z3 is unable to determine satisfiability, it returns an *ErrSatUnknown error,
+// or an *ErrResourceExhausted error if it ran out of memory.
 func (o *Optimize) CheckAssumptions(assumptions ...Bool) (sat bool, err error) {
+	defer recoverMemout(&err)
 	cargs := make([]C.Z3_ast, len(assumptions))
 	for i, arg := range assumptions {
 		cargs[i] = arg.c
@@ -191,13 +306,14 @@ func (o *Optimize) CheckAssumptions(assumptions ...Bool) (sat bool, err error) {
 		if len(cargs) > 0 {
 			cap = &cargs[0]
 		}
+		o.ctx.checks++
 		res = C.Z3_optimize_check(o.ctx.c, o.c, C.uint(len(cargs)), cap)
 	})
 	if res == C.Z3_L_UNDEF {
 		// Get the reason.
 		o.ctx.do(func() {
 			cerr := C.Z3_optimize_get_reason_unknown(o.ctx.c, o.c)
-			err = &ErrSatUnknown{C.GoString(cerr)}
+			err = unknownError(C.GoString(cerr))
 		})
 	}
 	runtime.KeepAlive(o)
@@ -251,7 +367,21 @@ func (o *Optimize) String() string {
 	return res
 }
 
-// SetParams sets parameters on the optimization context.
+// NewOptimizeConfig returns *Config for configuring an Optimize with
+// SetParams.
+func NewOptimizeConfig(ctx *Context) *Config {
+	var desc []ParamDescr
+	ctx.do(func() {
+		o := C.Z3_mk_optimize(ctx.c)
+		C.Z3_optimize_inc_ref(ctx.c, o)
+		defer C.Z3_optimize_dec_ref(ctx.c, o)
+		desc = paramDescrs(ctx, C.Z3_optimize_get_param_descrs(ctx.c, o))
+	})
+	return newConfig(desc)
+}
+
+// SetParams sets parameters on the optimization context. config
+// should have been created with NewOptimizeConfig.
 func (o *Optimize) SetParams(config *Config) {
 	cparams := config.toC(o.ctx)
 	o.ctx.do(func() {
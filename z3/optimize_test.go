@@ -47,6 +47,27 @@ func TestOptimize(t *testing.T) {
 	}
 }
 
+func TestOptimizeConfig(t *testing.T) {
+	ctx := NewContext(nil)
+	config := NewOptimizeConfig(ctx).SetString("priority", "pareto")
+	if err := config.Err(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := NewOptimizeConfig(ctx).SetBool("priority", true).Err(); err == nil {
+		t.Error("want type error for priority")
+	}
+	if err := NewOptimizeConfig(ctx).SetString("no_such_param", "x").Err(); err == nil {
+		t.Error("want unknown parameter error")
+	}
+	if err := NewContextConfig().SetString("priority", "pareto").Err(); err == nil {
+		t.Error("priority accepted as a context parameter")
+	}
+
+	// An Optimize accepts its own parameters.
+	opt := NewOptimize(ctx)
+	opt.SetParams(config)
+}
+
 // Based on an example from the z3 optimization tutorial
 func TestOptimizeSoft(t *testing.T) {
 	ctx := NewContext(nil)
@@ -199,3 +220,106 @@ func TestOptimizeAssertions(t *testing.T) {
 		t.Fatalf("expected 2 assertions, got %d", len(assertions))
 	}
 }
+
+func TestOptimizeOnModel(t *testing.T) {
+	ctx := NewContext(nil)
+	opt := NewOptimize(ctx)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	opt.Assert(x.Add(y).LE(ctx.Int(20)))
+	opt.Assert(x.GE(ctx.Int(0)).And(y.GE(ctx.Int(0))))
+	a, b := ctx.BoolConst("a"), ctx.BoolConst("b")
+	opt.Assert(a.Xor(b))
+	obj := opt.Maximize(x.Mul(ctx.Int(3)).Add(y))
+	opt.AssertSoft(a, "2", "soft")
+	opt.AssertSoft(b, "5", "soft")
+
+	type call struct {
+		m    *Model
+		vals []Value
+	}
+	var calls []call
+	opt.OnModel(func(m *Model, vals []Value) {
+		calls = append(calls, call{m, vals})
+	})
+	if sat, err := opt.Check(); !sat {
+		t.Fatalf("got unsat, err %v", err)
+	}
+	if len(calls) == 0 {
+		t.Fatal("OnModel callback not called")
+	}
+	last := calls[len(calls)-1]
+	if len(last.vals) != 2 {
+		t.Fatalf("got %d objective values, want 2", len(last.vals))
+	}
+	if got, want := last.vals[0].String(), obj.Lower().String(); got != want {
+		t.Errorf("last objective value %s, want %s", got, want)
+	}
+	// Penalties may be Int or Real.
+	if got := last.vals[1].String(); got != "2" && got != "2.0" {
+		t.Errorf("last soft penalty %s, want 2", got)
+	}
+	if got := last.m.Eval(x, true).String(); got != "20" {
+		t.Errorf("last model has x = %s, want 20", got)
+	}
+
+	// A nil callback stops the calls.
+	opt.OnModel(nil)
+	n := len(calls)
+	opt.Assert(x.LE(ctx.Int(10)))
+	if sat, _ := opt.Check(); !sat || len(calls) != n {
+		t.Errorf("got %d calls after OnModel(nil)", len(calls)-n)
+	}
+	opt.Close()
+}
+
+func TestObjectiveIsUnbounded(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.RealConst("y")
+	opt := NewOptimize(ctx)
+	opt.Assert(x.LE(ctx.Int(10)))
+	opt.Assert(y.GE(ctx.FromInt(0, ctx.RealSort()).(Real)))
+	up := opt.Maximize(x)
+	bounded := opt.Minimize(y)
+	if sat, err := opt.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	for _, tt := range []struct {
+		name         string
+		obj          *Objective
+		lower, upper bool
+	}{
+		{"maximize x", up, false, false},
+		{"minimize y", bounded, false, false},
+	} {
+		lower, upper := tt.obj.IsInfinite()
+		if lower != tt.lower || upper != tt.upper {
+			t.Errorf("%s: IsInfinite() = %v, %v; want %v, %v", tt.name, lower, upper, tt.lower, tt.upper)
+		}
+		if got, want := tt.obj.IsUnbounded(), tt.lower || tt.upper; got != want {
+			t.Errorf("%s: IsUnbounded() = %v, want %v", tt.name, got, want)
+		}
+	}
+
+	opt = NewOptimize(ctx)
+	opt.Assert(x.LE(ctx.Int(10)))
+	down := opt.Minimize(x)
+	if sat, err := opt.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	if lower, upper := down.IsInfinite(); !lower || upper {
+		t.Errorf("minimize x: IsInfinite() = %v, %v; want true, false", lower, upper)
+	}
+	if !down.IsUnbounded() {
+		t.Errorf("minimize x: IsUnbounded() = false, want true")
+	}
+
+	opt = NewOptimize(ctx)
+	opt.Assert(y.GE(ctx.FromInt(0, ctx.RealSort()).(Real)))
+	grow := opt.Maximize(y)
+	if sat, err := opt.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	if lower, upper := grow.IsInfinite(); lower || !upper {
+		t.Errorf("maximize y: IsInfinite() = %v, %v; want false, true", lower, upper)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// SetParallel enables or disables Z3's internal parallel solving and
+// sets the maximum number of threads it may use. If maxThreads is 0,
+// Z3 chooses the number of threads.
+//
+// When parallel solving is enabled, Check on a Solver for a
+// quantifier-free logic splits the search into cubes and solves them
+// on several threads, within the single call. Like the memory limits,
+// this setting applies to the whole process. See Portfolio and
+// EnumerateParallel for parallelism across Contexts instead.
+func SetParallel(enable bool, maxThreads uint) {
+	setGlobalParam("parallel.enable", enable)
+	if maxThreads > 0 {
+		setGlobalParam("parallel.threads.max", maxThreads)
+	}
+}
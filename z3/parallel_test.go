@@ -0,0 +1,19 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSetParallel(t *testing.T) {
+	SetParallel(true, 2)
+	defer SetParallel(false, 0)
+
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	pigeonhole(ctx, s, 6)
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("got sat %v, err %v; want unsat", sat, err)
+	}
+}
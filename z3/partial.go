@@ -0,0 +1,46 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// A PartialFunc models a function that is defined only on part of its
+// domain, such as division, array indexing, or a method with a
+// precondition. It pairs a FuncDecl, which gives the function's value
+// where it is defined, with a domain predicate saying where that is.
+//
+// Z3 functions are total, so applying a PartialFunc still yields a
+// value outside the domain, but nothing constrains it. Apply returns
+// the definedness condition alongside the value so that callers can
+// assert it, or check that it follows from the rest of a model: a
+// verification condition typically requires that every application in
+// the program is defined.
+type PartialFunc struct {
+	// Func gives the value of the function where it is defined.
+	Func FuncDecl
+
+	// Defined returns whether the function is defined at args.
+	Defined func(args ...Value) Bool
+}
+
+// NewPartialFunc returns a PartialFunc that applies f where defined
+// holds. If defined is nil, the domain is a fresh uninterpreted
+// predicate, so the solver may choose any domain consistent with the
+// constraints.
+func NewPartialFunc(f FuncDecl, defined func(args ...Value) Bool) *PartialFunc {
+	if defined == nil {
+		ctx := f.Context()
+		pred := ctx.FreshFuncDecl(f.Name().String()+"_defined", f.Domain(), ctx.BoolSort())
+		defined = func(args ...Value) Bool {
+			return pred.Apply(args...).(Bool)
+		}
+	}
+	return &PartialFunc{f, defined}
+}
+
+// Apply applies p to args. It returns the value of the application and
+// a condition that holds exactly when args are in p's domain. The
+// value is unconstrained when the condition does not hold.
+func (p *PartialFunc) Apply(args ...Value) (val Value, defined Bool) {
+	return p.Func.Apply(args...), p.Defined(args...)
+}
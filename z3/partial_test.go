@@ -0,0 +1,52 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestPartialFunc(t *testing.T) {
+	ctx := NewContext(nil)
+	is := ctx.IntSort()
+	zero := ctx.FromInt(0, is).(Int)
+	div := NewPartialFunc(ctx.FuncDecl("div", []Sort{is, is}, is), func(args ...Value) Bool {
+		return args[1].(Int).NE(zero)
+	})
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	q, ok := div.Apply(x, y)
+
+	// Nothing stops the division from being undefined...
+	s := NewSolver(ctx)
+	s.Assert(q.(Int).Eq(ctx.FromInt(3, is).(Int)))
+	s.Assert(ok.Not())
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("got %v, %v; want sat", sat, err)
+	}
+	if got := s.Model().Eval(y, true).String(); got != "0" {
+		t.Errorf("undefined at y = %s, want 0", got)
+	}
+
+	// ...unless y is known to be positive.
+	s.Reset()
+	s.Assert(y.GT(zero))
+	s.Assert(ok.Not())
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("got %v, %v; want unsat", sat, err)
+	}
+
+	// With an uninterpreted domain, definedness is up to the solver,
+	// but consistent across equal arguments.
+	f := NewPartialFunc(ctx.FuncDecl("f", []Sort{is}, is), nil)
+	_, d1 := f.Apply(x)
+	_, d2 := f.Apply(y)
+	s.Reset()
+	s.Assert(d1.And(d2.Not()))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Errorf("got %v, %v; want sat", sat, err)
+	}
+	s.Assert(x.Eq(y))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("got %v, %v; want unsat", sat, err)
+	}
+}
@@ -10,7 +10,11 @@ package z3
 #include <stdlib.h>
 */
 import "C"
-import "runtime"
+import (
+	"math"
+	"math/big"
+	"runtime"
+)
 
 // Pseudo-Boolean constraints are cardinality constraints over Boolean variables.
 
@@ -106,3 +110,248 @@ func (ctx *Context) PbEq(args []Bool, coeffs []int, k int) Bool {
 	runtime.KeepAlive(&ccoeffs[0])
 	return Bool(val)
 }
+
+// PbLEInt64 is like PbLE, but takes int64 coefficients and bound.
+func (ctx *Context) PbLEInt64(args []Bool, coeffs []int64, k int64) Bool {
+	return ctx.pbBig(pbLE, args, bigInts(coeffs), big.NewInt(k))
+}
+
+// PbGEInt64 is like PbGE, but takes int64 coefficients and bound.
+func (ctx *Context) PbGEInt64(args []Bool, coeffs []int64, k int64) Bool {
+	return ctx.pbBig(pbGE, args, bigInts(coeffs), big.NewInt(k))
+}
+
+// PbEqInt64 is like PbEq, but takes int64 coefficients and bound.
+func (ctx *Context) PbEqInt64(args []Bool, coeffs []int64, k int64) Bool {
+	return ctx.pbBig(pbEq, args, bigInts(coeffs), big.NewInt(k))
+}
+
+// PbLEBigInt is like PbLE, but takes arbitrary-precision coefficients
+// and bound.
+//
+// Z3's pseudo-Boolean constraints only support coefficients that fit
+// in a C int. If any coefficient or the bound is larger, PbLEBigInt
+// and the other Int64 and BigInt variants instead constrain the
+// integer sum of the coefficients of the true args.
+func (ctx *Context) PbLEBigInt(args []Bool, coeffs []*big.Int, k *big.Int) Bool {
+	return ctx.pbBig(pbLE, args, coeffs, k)
+}
+
+// PbGEBigInt is like PbGE, but takes arbitrary-precision coefficients
+// and bound.
+func (ctx *Context) PbGEBigInt(args []Bool, coeffs []*big.Int, k *big.Int) Bool {
+	return ctx.pbBig(pbGE, args, coeffs, k)
+}
+
+// PbEqBigInt is like PbEq, but takes arbitrary-precision coefficients
+// and bound.
+func (ctx *Context) PbEqBigInt(args []Bool, coeffs []*big.Int, k *big.Int) Bool {
+	return ctx.pbBig(pbEq, args, coeffs, k)
+}
+
+type pbOp int
+
+const (
+	pbLE pbOp = iota
+	pbGE
+	pbEq
+)
+
+func bigInts(xs []int64) []*big.Int {
+	bs := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		bs[i] = big.NewInt(x)
+	}
+	return bs
+}
+
+func (ctx *Context) pbBig(op pbOp, args []Bool, coeffs []*big.Int, k *big.Int) Bool {
+	if len(args) != len(coeffs) {
+		panic("args and coeffs must have the same length")
+	}
+
+	// Use the pseudo-Boolean theory if everything fits.
+	fitsC := func(x *big.Int) bool {
+		return x.IsInt64() && x.Int64() >= math.MinInt32 && x.Int64() <= math.MaxInt32
+	}
+	small := len(args) > 0 && fitsC(k)
+	ints := make([]int, len(coeffs))
+	for i, c := range coeffs {
+		small = small && fitsC(c)
+		if small {
+			ints[i] = int(c.Int64())
+		}
+	}
+	if small {
+		switch op {
+		case pbLE:
+			return ctx.PbLE(args, ints, int(k.Int64()))
+		case pbGE:
+			return ctx.PbGE(args, ints, int(k.Int64()))
+		}
+		return ctx.PbEq(args, ints, int(k.Int64()))
+	}
+
+	is := ctx.IntSort()
+	zero := ctx.Int(0)
+	terms := make([]Int, len(args))
+	for i, arg := range args {
+		terms[i] = arg.IfThenElse(ctx.FromBigInt(coeffs[i], is), zero).(Int)
+	}
+	sum := zero.Add(terms...)
+	bound := ctx.FromBigInt(k, is).(Int)
+	switch op {
+	case pbLE:
+		return sum.LE(bound)
+	case pbGE:
+		return sum.GE(bound)
+	}
+	return sum.Eq(bound)
+}
+
+// A CardEncoding selects how AtMostEnc and AtLeastEnc encode a
+// cardinality constraint.
+type CardEncoding int
+
+const (
+	// EncodePB uses Z3's pseudo-Boolean theory, like AtMost and
+	// AtLeast.
+	EncodePB CardEncoding = iota
+
+	// EncodeAtMostSeq uses Sinz's sequential counter, a CNF
+	// encoding with about n*k auxiliary variables.
+	EncodeAtMostSeq
+
+	// EncodeAtMostTotalizer uses the totalizer of Bailleux and
+	// Boufkhad, a CNF encoding that counts in unary up a binary
+	// tree. It uses O(n log n) auxiliary variables but often
+	// propagates better than the sequential counter.
+	EncodeAtMostTotalizer
+)
+
+// AtMostEnc is like AtMost, but uses encoding enc.
+//
+// The CNF encodings introduce fresh auxiliary constants and are only
+// equisatisfiable with the constraint, so the result must be asserted
+// as is rather than negated or combined into a larger formula.
+func (ctx *Context) AtMostEnc(args []Bool, k uint, enc CardEncoding) Bool {
+	if enc == EncodePB {
+		return ctx.AtMost(args, k)
+	}
+	if k >= uint(len(args)) {
+		return ctx.FromBool(true)
+	}
+	if k == 0 {
+		clauses := make([]Bool, len(args))
+		for i, x := range args {
+			clauses[i] = x.Not()
+		}
+		return ctx.FromBool(true).And(clauses...)
+	}
+	switch enc {
+	case EncodeAtMostSeq:
+		return ctx.atMostSeq(args, int(k))
+	case EncodeAtMostTotalizer:
+		return ctx.atMostTotalizer(args, int(k))
+	}
+	panic("z3: unknown CardEncoding")
+}
+
+// AtLeastEnc is like AtLeast, but uses encoding enc. At least k of
+// args are true if at most len(args)-k are false. See AtMostEnc for
+// restrictions on the result.
+func (ctx *Context) AtLeastEnc(args []Bool, k uint, enc CardEncoding) Bool {
+	if enc == EncodePB {
+		return ctx.AtLeast(args, k)
+	}
+	if k > uint(len(args)) {
+		return ctx.FromBool(false)
+	}
+	negs := make([]Bool, len(args))
+	for i, x := range args {
+		negs[i] = x.Not()
+	}
+	return ctx.AtMostEnc(negs, uint(len(args))-k, enc)
+}
+
+// clause returns the disjunction of lits.
+func (ctx *Context) clause(lits ...Bool) Bool {
+	return ctx.FromBool(false).Or(lits...)
+}
+
+// atMostSeq encodes "at most k of xs", for 0 < k < len(xs), using a
+// sequential counter: s[i][j] implies that more than j of xs[0..i]
+// are true.
+func (ctx *Context) atMostSeq(xs []Bool, k int) Bool {
+	n := len(xs)
+	s := make([][]Bool, n-1)
+	for i := range s {
+		s[i] = make([]Bool, k)
+		for j := range s[i] {
+			s[i][j] = ctx.FreshConst("seq", ctx.BoolSort()).(Bool)
+		}
+	}
+	var cs []Bool
+	cs = append(cs, ctx.clause(xs[0].Not(), s[0][0]))
+	for j := 1; j < k; j++ {
+		cs = append(cs, s[0][j].Not())
+	}
+	for i := 1; i < n-1; i++ {
+		cs = append(cs,
+			ctx.clause(xs[i].Not(), s[i][0]),
+			ctx.clause(s[i-1][0].Not(), s[i][0]))
+		for j := 1; j < k; j++ {
+			cs = append(cs,
+				ctx.clause(xs[i].Not(), s[i-1][j-1].Not(), s[i][j]),
+				ctx.clause(s[i-1][j].Not(), s[i][j]))
+		}
+		cs = append(cs, ctx.clause(xs[i].Not(), s[i-1][k-1].Not()))
+	}
+	cs = append(cs, ctx.clause(xs[n-1].Not(), s[n-2][k-1].Not()))
+	return ctx.FromBool(true).And(cs...)
+}
+
+// atMostTotalizer encodes "at most k of xs", for 0 < k < len(xs),
+// using a totalizer.
+func (ctx *Context) atMostTotalizer(xs []Bool, k int) Bool {
+	var cs []Bool
+	// count returns unary outputs for xs: out[i] is implied if more
+	// than i of xs are true. Counting stops at k+1.
+	var count func(xs []Bool) []Bool
+	count = func(xs []Bool) []Bool {
+		if len(xs) == 1 {
+			return xs
+		}
+		a, b := count(xs[:len(xs)/2]), count(xs[len(xs)/2:])
+		m := len(a) + len(b)
+		if m > k+1 {
+			m = k + 1
+		}
+		out := make([]Bool, m)
+		for i := range out {
+			out[i] = ctx.FreshConst("tot", ctx.BoolSort()).(Bool)
+		}
+		// a[i-1] and b[j-1] imply out[i+j-1], where a[-1] and
+		// b[-1] are true.
+		for i := 0; i <= len(a); i++ {
+			for j := 0; j <= len(b); j++ {
+				if i+j == 0 {
+					continue
+				}
+				var lits []Bool
+				if i > 0 {
+					lits = append(lits, a[i-1].Not())
+				}
+				if j > 0 {
+					lits = append(lits, b[j-1].Not())
+				}
+				lits = append(lits, out[min(i+j, m)-1])
+				cs = append(cs, ctx.clause(lits...))
+			}
+		}
+		return out
+	}
+	out := count(xs)
+	cs = append(cs, out[k].Not())
+	return ctx.FromBool(true).And(cs...)
+}
@@ -4,7 +4,11 @@
 
 package z3
 
-import "testing"
+import (
+	"math/big"
+	"math/bits"
+	"testing"
+)
 
 func TestAtMost(t *testing.T) {
 	ctx := NewContext(nil)
@@ -150,3 +154,113 @@ func TestPbGE(t *testing.T) {
 		t.Error("expected SAT for 2+3 >= 5")
 	}
 }
+
+func TestCardEncodings(t *testing.T) {
+	ctx := NewContext(nil)
+	var xs []Bool
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		xs = append(xs, ctx.BoolConst(name))
+	}
+	for _, enc := range []CardEncoding{EncodeAtMostSeq, EncodeAtMostTotalizer} {
+		for k := uint(0); k <= 7; k++ {
+			// Count the models of each encoding, projected onto
+			// xs, and compare with the number of subsets of xs
+			// of each size.
+			for _, atLeast := range []bool{false, true} {
+				s := NewSolver(ctx)
+				if atLeast {
+					s.Assert(ctx.AtLeastEnc(xs, k, enc))
+				} else {
+					s.Assert(ctx.AtMostEnc(xs, k, enc))
+				}
+				got := 0
+				for {
+					sat, err := s.Check()
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !sat {
+						break
+					}
+					got++
+					m := s.Model()
+					var block []Bool
+					for _, x := range xs {
+						v, _ := m.Eval(x, true).(Bool).AsBool()
+						block = append(block, x.Eq(ctx.FromBool(!v)))
+					}
+					s.Assert(ctx.FromBool(false).Or(block...))
+				}
+				want := 0
+				for set := 0; set < 1<<len(xs); set++ {
+					n := uint(bits.OnesCount(uint(set)))
+					if atLeast && n >= k || !atLeast && n <= k {
+						want++
+					}
+				}
+				if got != want {
+					t.Errorf("encoding %d, k=%d, atLeast=%v: %d models, want %d", enc, k, atLeast, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestPbBig(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y, z := ctx.BoolConst("x"), ctx.BoolConst("y"), ctx.BoolConst("z")
+	args := []Bool{x, y, z}
+
+	// Weights beyond int32.
+	w := []int64{5e9, 3e9, 2e9}
+	for _, test := range []struct {
+		c    Bool
+		want []bool // The unique solution, or nil if unsat
+	}{
+		{ctx.PbEqInt64(args, w, 7e9), []bool{true, false, true}},
+		{ctx.PbEqInt64(args, w, 8e9).And(ctx.PbLEInt64(args, w, 5e9+3e9-1)), nil},
+		{ctx.PbGEInt64(args, w, 10e9), []bool{true, true, true}},
+		{ctx.PbLEInt64(args, w, 1e9), []bool{false, false, false}},
+		// Small weights use the PB theory.
+		{ctx.PbEqInt64(args, []int64{1, 2, 4}, 6), []bool{false, true, true}},
+	} {
+		s := NewSolver(ctx)
+		s.Assert(test.c)
+		sat, err := s.Check()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sat != (test.want != nil) {
+			t.Errorf("%s: sat = %v", test.c, sat)
+			continue
+		}
+		if !sat {
+			continue
+		}
+		m := s.Model()
+		var block []Bool
+		for i, arg := range args {
+			v, _ := m.Eval(arg, true).(Bool).AsBool()
+			if v != test.want[i] {
+				t.Errorf("%s: %s = %v, want %v", test.c, arg, v, test.want[i])
+			}
+			block = append(block, arg.Eq(ctx.FromBool(!v)))
+		}
+		s.Assert(ctx.FromBool(false).Or(block...))
+		if sat, _ := s.Check(); sat {
+			t.Errorf("%s: more than one solution", test.c)
+		}
+	}
+
+	// Coefficients that don't fit in int64.
+	huge, _ := new(big.Int).SetString("100000000000000000000000", 10)
+	c := ctx.PbGEBigInt(args, []*big.Int{huge, big.NewInt(1), big.NewInt(1)}, huge)
+	s := NewSolver(ctx)
+	s.Assert(c.And(x.Not()))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("PbGEBigInt satisfied without x")
+	}
+	if !simplifyBool(t, ctx, ctx.PbEqBigInt(nil, nil, big.NewInt(0))) {
+		t.Errorf("PbEqBigInt of no args != 0")
+	}
+}
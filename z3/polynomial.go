@@ -0,0 +1,45 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+import "runtime"
+
+// Subresultants returns the nonzero subresultants of polynomials p and
+// q with respect to the variable x.
+//
+// p, q and x must be Ints or Reals, and x must be a constant. p and q
+// are viewed as univariate polynomials in x whose coefficients may
+// contain other variables. If p and q have degree m and n in x, the
+// result contains up to min(m, n) polynomials. In particular, the
+// last subresultant is the resultant, which is zero for some
+// assignment of the other variables exactly when p and q have a
+// common root in x.
+func (ctx *Context) Subresultants(p, q, x Value) []Value {
+	var asts []AST
+	ctx.do(func() {
+		vec := C.Z3_polynomial_subresultants(ctx.c, p.impl().c, q.impl().c, x.impl().c)
+		C.Z3_ast_vector_inc_ref(ctx.c, vec)
+		defer C.Z3_ast_vector_dec_ref(ctx.c, vec)
+		size := int(C.Z3_ast_vector_size(ctx.c, vec))
+		asts = make([]AST, size)
+		for i := range asts {
+			asts[i] = wrapAST(ctx, C.Z3_ast_vector_get(ctx.c, vec, C.uint(i)))
+		}
+	})
+	runtime.KeepAlive(p)
+	runtime.KeepAlive(q)
+	runtime.KeepAlive(x)
+	result := make([]Value, len(asts))
+	for i, ast := range asts {
+		result[i] = ast.AsValue()
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSubresultants(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.RealConst("x"), ctx.RealConst("y")
+	// p = x^2 + y^2 - 1, q = x - y. These share a root in x iff
+	// 2y^2 - 1 = 0.
+	p := x.Mul(x).Add(y.Mul(y)).Sub(ctx.FromInt(1, ctx.RealSort()).(Real))
+	q := x.Sub(y)
+	res := ctx.Subresultants(p, q, x)
+	if len(res) == 0 {
+		t.Fatal("expected at least one subresultant")
+	}
+	resultant := res[len(res)-1].(Real)
+
+	// The resultant vanishes exactly when y^2 = 1/2.
+	solver := NewSolver(ctx)
+	half := ctx.FromBigRat(big.NewRat(1, 2))
+	solver.Assert(resultant.Eq(ctx.FromInt(0, ctx.RealSort()).(Real)).Eq(y.Mul(y).Eq(half)).Not())
+	if sat, _ := solver.Check(); sat {
+		t.Errorf("resultant %v does not characterize common roots", resultant)
+	}
+}
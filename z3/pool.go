@@ -0,0 +1,140 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"sync"
+)
+
+// A ContextPool is a set of Contexts that can be reused by multiple
+// goroutines, for example, to solve independent problems in parallel
+// from an HTTP server. A ContextPool is safe for concurrent use.
+//
+// Each Context is used by one goroutine at a time. Contexts are
+// replaced after they have run MaxChecks checks, fail a health check,
+// or encounter an error.
+//
+// The zero value is a pool of Contexts with the default
+// configuration.
+type ContextPool struct {
+	// Config, if non-nil, is the configuration used to create
+	// each new Context. It must not be modified while the pool
+	// is in use.
+	Config *Config
+
+	// MaxChecks is the number of checks, by Check or
+	// CheckAssumptions of any Solver or Optimize, that a Context
+	// may run before it is closed and replaced when it is returned
+	// with Put. If MaxChecks is 0, Contexts are reused
+	// indefinitely.
+	MaxChecks int
+
+	// MaxIdle is the maximum number of idle Contexts to keep. If
+	// MaxIdle is 0, there is no limit.
+	MaxIdle int
+
+	// Healthy, if non-nil, is called before an idle Context is
+	// handed out again. If it returns false, the Context is
+	// closed and replaced.
+	Healthy func(*Context) bool
+
+	mu     sync.Mutex
+	idle   []*Context
+	closed bool
+}
+
+// ErrPoolClosed is returned by ContextPool.Do after the pool has been
+// closed.
+var ErrPoolClosed = errors.New("z3: context pool closed")
+
+// Get returns a Context from p, creating a new one if no healthy idle
+// Context is available. The caller has exclusive use of the Context
+// and must return it with Put.
+//
+// Get panics if p has been closed.
+func (p *ContextPool) Get() *Context {
+	ctx, err := p.get()
+	if err != nil {
+		panic(err)
+	}
+	return ctx
+}
+
+func (p *ContextPool) get() (*Context, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, ErrPoolClosed
+		}
+		if len(p.idle) == 0 {
+			p.mu.Unlock()
+			break
+		}
+		ctx := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		p.mu.Unlock()
+
+		if p.Healthy == nil || p.Healthy(ctx) {
+			return ctx, nil
+		}
+		ctx.Close()
+	}
+
+	// Creating a Context can be slow, so do it without the lock.
+	return NewContext(p.Config), nil
+}
+
+// Put returns ctx, which must have been obtained from p.Get, to p. If
+// err is non-nil, it is assumed that ctx may be in a bad state and
+// ctx is closed instead of being reused.
+func (p *ContextPool) Put(ctx *Context, err error) {
+	worn := p.MaxChecks != 0 && ctx.numChecks() >= p.MaxChecks
+	p.mu.Lock()
+	if err == nil && !p.closed && !worn &&
+		(p.MaxIdle == 0 || len(p.idle) < p.MaxIdle) {
+		p.idle = append(p.idle, ctx)
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+	ctx.Close()
+}
+
+// Do calls f with a Context from p and returns the Context to p when
+// f returns. If f returns an error or panics with a Z3 error, the
+// Context is replaced. Z3 errors are returned as errors from Do.
+// Other panics are propagated after closing the Context.
+func (p *ContextPool) Do(f func(ctx *Context) error) (err error) {
+	ctx, err := p.get()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		r := recover()
+		if z3err, ok := r.(*Error); ok {
+			err = z3err
+		} else if r != nil {
+			p.Put(ctx, errors.New("panic"))
+			panic(r)
+		}
+		p.Put(ctx, err)
+	}()
+	return f(ctx)
+}
+
+// Close closes all idle Contexts in p. Contexts that are in use are
+// closed when they are returned with Put. After Close, Get panics
+// and Do returns ErrPoolClosed.
+func (p *ContextPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	for _, ctx := range p.idle {
+		ctx.Close()
+	}
+	p.idle = nil
+}
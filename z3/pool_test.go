@@ -0,0 +1,105 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestContextPool(t *testing.T) {
+	pool := &ContextPool{MaxChecks: 2}
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := pool.Do(func(ctx *Context) error {
+				x := ctx.IntConst("x")
+				solver := NewSolver(ctx)
+				solver.Assert(x.Mul(ctx.Int(2)).Eq(ctx.Int(2 * i)))
+				if sat, err := solver.Check(); !sat {
+					return errors.New("unexpected UNSAT")
+				} else if err != nil {
+					return err
+				}
+				if v, _, _ := solver.Model().EvalAsInt64(x, true); v != int64(i) {
+					return errors.New("wrong model")
+				}
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestContextPoolReplace(t *testing.T) {
+	pool := &ContextPool{MaxChecks: 2}
+	defer pool.Close()
+
+	check := func(ctx *Context) {
+		s := NewSolver(ctx)
+		defer s.Close()
+		s.Check()
+	}
+	a := pool.Get()
+	pool.Put(a, nil)
+	if b := pool.Get(); b != a {
+		t.Error("expected idle context to be reused")
+	}
+	check(a)
+	pool.Put(a, nil)
+	if b := pool.Get(); b != a {
+		t.Error("expected context to be reused after one check")
+	}
+	check(a)
+	pool.Put(a, nil)
+	if c := pool.Get(); c == a {
+		t.Error("expected context to be replaced after MaxChecks")
+	} else {
+		pool.Put(c, errors.New("failed"))
+	}
+	if d := pool.Get(); d.closed {
+		t.Error("got closed context")
+	} else {
+		pool.Put(d, nil)
+	}
+
+	unhealthy := &ContextPool{Healthy: func(*Context) bool { return false }}
+	defer unhealthy.Close()
+	e := unhealthy.Get()
+	unhealthy.Put(e, nil)
+	if f := unhealthy.Get(); f == e {
+		t.Error("expected unhealthy context to be replaced")
+	}
+}
+
+func TestContextPoolError(t *testing.T) {
+	pool := &ContextPool{}
+	var first *Context
+	err := pool.Do(func(ctx *Context) error {
+		first = ctx
+		ctx.BVConst("x", 1).Eq(ctx.BVConst("y", 2))
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "incompatible") {
+		t.Errorf("expected sort mismatch error, got %v", err)
+	}
+	if !first.closed {
+		t.Error("expected failed context to be closed")
+	}
+
+	pool.Close()
+	if err := pool.Do(func(*Context) error { return nil }); err != ErrPoolClosed {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"time"
+)
+
+// Portfolio checks the conjunction of asserts with several solvers in
+// parallel and returns the first definitive answer.
+//
+// Each member of the portfolio gets a new Context, a copy of asserts
+// translated into it, and a Solver configured with one of configs,
+// which should have been created with NewSolverConfig. Giving the
+// members different random seeds or strategies lets them explore the
+// search space differently, so that one of them often finishes much
+// sooner than a single Solver would. If configs is empty, Portfolio
+// runs one member per CPU, with random seeds 0, 1, and so on; see
+// Config.SetRandomSeed.
+//
+// When a member finds the assertions satisfiable or unsatisfiable,
+// Portfolio interrupts the others and waits for them to stop. It
+// returns the answer, the index in configs of the member that gave
+// it, and if the answer is sat, its model translated into ctx. If no
+// member gives a definitive answer, Portfolio returns the error of
+// the first member.
+func (ctx *Context) Portfolio(asserts []Bool, configs ...*Config) (sat bool, m *Model, winner int, err error) {
+	if len(configs) == 0 {
+		for i := 0; i < runtime.NumCPU(); i++ {
+			configs = append(configs, NewSolverConfig(ctx).SetRandomSeed(uint(i)))
+		}
+	}
+	for _, cfg := range configs {
+		if err := cfg.Err(); err != nil {
+			return false, nil, -1, err
+		}
+	}
+
+	type result struct {
+		i   int
+		sat bool
+		m   *Model
+		err error
+	}
+	members := make([]*Context, len(configs))
+	solvers := make([]*Solver, len(configs))
+	for i, cfg := range configs {
+		mctx := NewContext(nil)
+		s := NewSolver(mctx)
+		s.SetParams(cfg)
+		for _, a := range asserts {
+			s.Assert(a.AsAST().Translate(mctx).AsValue().(Bool))
+		}
+		members[i], solvers[i] = mctx, s
+	}
+	defer func() {
+		for _, mctx := range members {
+			mctx.Close()
+		}
+	}()
+
+	results := make(chan result, len(configs))
+	for i, s := range solvers {
+		go func(i int, s *Solver) {
+			r := result{i: i}
+			r.sat, r.err = s.Check()
+			if r.sat {
+				// The member may be interrupted after Check
+				// has returned.
+				r.err = s.ctx.Catch(func() { r.m = s.Model() })
+				r.sat = r.err == nil
+			}
+			results <- r
+		}(i, s)
+	}
+
+	// An Interrupt before a member starts its Check is lost, so keep
+	// interrupting the remaining members until they stop.
+	tick := time.NewTicker(10 * time.Millisecond)
+	defer tick.Stop()
+	finished := make([]bool, len(configs))
+	var first error
+	winner = -1
+	for n := 0; n < len(configs); {
+		select {
+		case r := <-results:
+			n++
+			finished[r.i] = true
+			switch {
+			case winner >= 0:
+			case r.err == nil:
+				winner, sat = r.i, r.sat
+				if sat {
+					m = r.m.Translate(ctx)
+				}
+			case first == nil:
+				first = r.err
+			}
+			if winner < 0 {
+				continue
+			}
+		case <-tick.C:
+			if winner < 0 {
+				continue
+			}
+		}
+		for i, mctx := range members {
+			if !finished[i] {
+				mctx.Interrupt()
+			}
+		}
+	}
+	if winner < 0 {
+		return false, nil, -1, first
+	}
+	return sat, m, winner, nil
+}
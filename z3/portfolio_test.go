@@ -0,0 +1,50 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestPortfolio(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	asserts := []Bool{
+		x.Add(y).Eq(ctx.Int(10)),
+		x.GT(y),
+		y.GT(ctx.Int(2)),
+	}
+	sat, m, winner, err := ctx.Portfolio(asserts)
+	if !sat || err != nil {
+		t.Fatalf("got sat %v, err %v; want sat", sat, err)
+	}
+	if winner < 0 {
+		t.Errorf("got winner %d", winner)
+	}
+	// The model belongs to ctx, so it can evaluate ctx's values.
+	for _, a := range asserts {
+		if v := m.Eval(a, true); v.String() != "true" {
+			t.Errorf("model does not satisfy %v", a)
+		}
+	}
+
+	// Pigeonhole is unsatisfiable.
+	s := NewSolver(ctx)
+	pigeonhole(ctx, s, 6)
+	var configs []*Config
+	for _, seed := range []uint{1, 2, 3} {
+		configs = append(configs, NewSolverConfig(ctx).SetUint("random_seed", seed))
+	}
+	sat, m, winner, err = ctx.Portfolio(s.Assertions(), configs...)
+	if sat || m != nil || err != nil {
+		t.Errorf("got sat %v, err %v; want unsat", sat, err)
+	}
+	if winner < 0 || winner >= len(configs) {
+		t.Errorf("got winner %d", winner)
+	}
+
+	bad := NewSolverConfig(ctx).SetUint("no_such_param", 1)
+	if _, _, _, err := ctx.Portfolio(asserts, bad); err == nil {
+		t.Errorf("bad config succeeded")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A profile is a named preset of parameters.
+type profile struct {
+	solver   []profileParam
+	optimize []profileParam
+}
+
+type profileParam struct {
+	name  string
+	value interface{}
+}
+
+// profiles are the presets for SetProfile. Solver presets may use
+// module parameters such as smt.relevancy, but Optimize accepts only
+// its own parameters.
+var profiles = map[string]profile{
+	"qfbv-fast": {
+		solver: []profileParam{
+			// Relevancy tracking pays off for quantifiers,
+			// not for bit-blasted problems.
+			{"smt.relevancy", uint(0)},
+			{"smt.bv.eq_axioms", false},
+		},
+		optimize: []profileParam{
+			{"enable_sat", true},
+			{"elim_01", true},
+			{"pb.compile_equality", true},
+		},
+	},
+	"strings": {
+		solver: []profileParam{
+			{"smt.string_solver", "seq"},
+			{"smt.seq.split_w_len", true},
+		},
+	},
+	"nl-arith": {
+		solver: []profileParam{
+			{"smt.arith.nl", true},
+			{"smt.arith.nl.grobner", true},
+			{"smt.arith.nl.nra", true},
+			{"smt.arith.random_initial_value", true},
+		},
+	},
+	"incremental": {
+		solver: []profileParam{
+			// Always use the incremental SMT core instead of
+			// re-running the non-incremental tactic for each
+			// Check made outside any Push.
+			{"combined_solver.ignore_solver1", true},
+		},
+	},
+	"maxsat": {
+		optimize: []profileParam{
+			{"maxsat_engine", "maxres"},
+			{"maxres.hill_climb", true},
+			{"maxres.maximize_assignment", true},
+		},
+	},
+}
+
+// ProfileNames returns the names of the parameter profiles accepted
+// by Solver.SetProfile and Optimize.SetProfile, in sorted order.
+func ProfileNames() []string {
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyProfile sets params on cfg and returns cfg.
+func applyProfile(cfg *Config, params []profileParam) *Config {
+	for _, p := range params {
+		switch v := p.value.(type) {
+		case bool:
+			cfg.SetBool(p.name, v)
+		case uint:
+			cfg.SetUint(p.name, v)
+		case string:
+			cfg.SetString(p.name, v)
+		}
+	}
+	return cfg
+}
+
+// SetProfile sets a preset of parameters on s, chosen for a kind of
+// problem. The profiles are:
+//
+//	qfbv-fast    quantifier-free bit-vector problems
+//	strings      string and sequence constraints
+//	nl-arith     nonlinear integer and real arithmetic
+//	incremental  many Checks with small changes between them
+//
+// The profiles encode settings that often help; they do not change
+// the meaning of the assertions, only how fast Z3 finds an answer.
+// Measure before relying on one. SetProfile returns an error if name
+// is not a profile for Solvers.
+func (s *Solver) SetProfile(name string) error {
+	p, ok := profiles[name]
+	if !ok || p.solver == nil {
+		return fmt.Errorf("z3: unknown solver profile %q", name)
+	}
+	cfg := applyProfile(NewSolverConfig(s.ctx), p.solver)
+	if err := cfg.Err(); err != nil {
+		return err
+	}
+	// Z3 checks the module parameters, which vary between versions.
+	return s.ctx.Catch(func() { s.SetParams(cfg) })
+}
+
+// SetProfile sets a preset of parameters on o, chosen for a kind of
+// problem. The profiles are:
+//
+//	qfbv-fast  bit-vector and pseudo-Boolean objectives
+//	maxsat     many soft constraints
+//
+// Optimize does not accept the theory settings of the other solver
+// profiles. SetProfile returns an error if name is not a profile for
+// Optimize.
+func (o *Optimize) SetProfile(name string) error {
+	p, ok := profiles[name]
+	if !ok || p.optimize == nil {
+		return fmt.Errorf("z3: unknown optimize profile %q", name)
+	}
+	cfg := applyProfile(NewOptimizeConfig(o.ctx), p.optimize)
+	if err := cfg.Err(); err != nil {
+		return err
+	}
+	return o.ctx.Catch(func() { o.SetParams(cfg) })
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSetProfile(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	for _, name := range []string{"qfbv-fast", "strings", "nl-arith", "incremental"} {
+		s := NewSolver(ctx)
+		if err := s.SetProfile(name); err != nil {
+			t.Errorf("Solver.SetProfile(%q): %v", name, err)
+			continue
+		}
+		s.Assert(x.Mul(x).Eq(ctx.Int(49)))
+		if sat, err := s.Check(); !sat || err != nil {
+			t.Errorf("profile %s: got %v, %v; want sat", name, sat, err)
+		}
+	}
+	for _, name := range []string{"qfbv-fast", "maxsat"} {
+		o := NewOptimize(ctx)
+		if err := o.SetProfile(name); err != nil {
+			t.Errorf("Optimize.SetProfile(%q): %v", name, err)
+			continue
+		}
+		o.Assert(x.GE(ctx.Int(0)))
+		o.Minimize(x)
+		if sat, err := o.Check(); !sat || err != nil {
+			t.Errorf("profile %s: got %v, %v; want sat", name, sat, err)
+		}
+	}
+
+	if err := NewSolver(ctx).SetProfile("maxsat"); err == nil {
+		t.Errorf("Solver.SetProfile(maxsat) succeeded")
+	}
+	if err := NewOptimize(ctx).SetProfile("strings"); err == nil {
+		t.Errorf("Optimize.SetProfile(strings) succeeded")
+	}
+	if err := NewSolver(ctx).SetProfile("no-such"); err == nil {
+		t.Errorf("SetProfile(no-such) succeeded")
+	}
+	if got := len(ProfileNames()); got != len(profiles) {
+		t.Errorf("ProfileNames has %d names, want %d", got, len(profiles))
+	}
+}
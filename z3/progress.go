@@ -0,0 +1,232 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// Progress reports the state of a check started by CheckProgress.
+//
+// A Z3 Context must not be used by two goroutines at once, and a check
+// holds its Context until it returns, so a periodic report cannot read
+// the solver's state directly. Instead, Optimize.CheckProgress takes a
+// snapshot of its statistics and bounds each time Z3 finds a model
+// that improves on the objectives, and periodic reports carry the
+// latest snapshot. Z3 offers no such point during a Solver check, so
+// Solver.CheckProgress reports statistics only in the final report.
+type Progress struct {
+	// Elapsed is the time since the check started.
+	Elapsed time.Duration
+
+	// AllocSize is EstimatedAllocSize at the time of the report:
+	// the memory allocated by Z3 in the whole process, in bytes.
+	AllocSize uint64
+
+	// Done is true for the final report, made after the check
+	// returns.
+	Done bool
+
+	// Stats holds Z3's statistics, such as "conflicts" and
+	// "decisions", keyed by name. In a periodic report it is the
+	// latest snapshot, or nil if none has been taken yet.
+	Stats map[string]float64
+
+	// Bounds holds the lower and upper bounds of each objective of
+	// an Optimize, in the order the objectives were added. In a
+	// periodic report it is the latest snapshot, or nil if none has
+	// been taken yet. If the check was interrupted, these are the
+	// best bounds found so far.
+	Bounds []ObjectiveBounds
+}
+
+// ObjectiveBounds are the bounds of an optimization objective.
+type ObjectiveBounds struct {
+	Lower, Upper Value
+}
+
+// CheckProgress is like Check, but calls f every interval while the
+// check runs, and once more when it returns. The calls are made one at
+// a time from another goroutine, except for the final call, which is
+// made before CheckProgress returns. Only the final call reports the
+// statistics of the check; see Progress. interval must be positive.
+//
+// f must not use the Solver or its Context, except that it may call
+// Context.Interrupt to stop the check. In that case CheckProgress
+// returns an *ErrSatUnknown.
+func (s *Solver) CheckProgress(interval time.Duration, f func(Progress)) (sat bool, err error) {
+	if interval <= 0 {
+		return false, fmt.Errorf("z3: CheckProgress: non-positive interval %v", interval)
+	}
+	start := time.Now()
+	stop := watchProgress(start, interval, nil, f)
+	sat, err = s.Check()
+	stop()
+	f(Progress{Elapsed: time.Since(start), AllocSize: EstimatedAllocSize(), Done: true, Stats: s.Statistics()})
+	return sat, err
+}
+
+// CheckProgress is like Check, but calls f every interval while the
+// check runs, and once more when it returns, with the statistics and
+// the objectives' bounds as of the last improved model. See
+// Solver.CheckProgress.
+//
+// CheckProgress may be combined with OnModel.
+func (o *Optimize) CheckProgress(interval time.Duration, f func(Progress)) (sat bool, err error) {
+	if interval <= 0 {
+		return false, fmt.Errorf("z3: CheckProgress: non-positive interval %v", interval)
+	}
+	snap := new(progressSnapshot)
+	ctx, c := o.ctx, o.c
+	o.ctx.do(func() {
+		o.modelState().progress = func() {
+			stats := statsMap(ctx, C.Z3_optimize_get_statistics(ctx.c, c))
+			snap.set(stats, optimizeBounds(ctx, c))
+		}
+	})
+	defer o.ctx.do(func() {
+		o.modelState().progress = nil
+	})
+	start := time.Now()
+	stop := watchProgress(start, interval, snap, f)
+	sat, err = o.Check()
+	stop()
+	f(Progress{Elapsed: time.Since(start), AllocSize: EstimatedAllocSize(), Done: true, Stats: o.Statistics(), Bounds: o.bounds()})
+	return sat, err
+}
+
+// progressSnapshot is the latest state of a check taken for periodic
+// reports.
+type progressSnapshot struct {
+	mu     sync.Mutex
+	stats  map[string]float64
+	bounds []ObjectiveBounds
+}
+
+func (p *progressSnapshot) set(stats map[string]float64, bounds []ObjectiveBounds) {
+	p.mu.Lock()
+	p.stats, p.bounds = stats, bounds
+	p.mu.Unlock()
+}
+
+func (p *progressSnapshot) get() (map[string]float64, []ObjectiveBounds) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats, p.bounds
+}
+
+// watchProgress calls f with the elapsed time, memory use and the
+// latest snapshot in snap, if any, every interval until the returned
+// function is called. That function waits for any call to f in
+// progress to return.
+func watchProgress(start time.Time, interval time.Duration, snap *progressSnapshot, f func(Progress)) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				p := Progress{Elapsed: time.Since(start), AllocSize: EstimatedAllocSize()}
+				if snap != nil {
+					p.Stats, p.Bounds = snap.get()
+				}
+				f(p)
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// Statistics returns the statistics of the last check of s, keyed by
+// name.
+func (s *Solver) Statistics() map[string]float64 {
+	var res map[string]float64
+	s.ctx.do(func() {
+		res = statsMap(s.ctx, C.Z3_solver_get_statistics(s.ctx.c, s.c))
+	})
+	runtime.KeepAlive(s)
+	return res
+}
+
+// Statistics returns the statistics of the last check of o, keyed by
+// name.
+func (o *Optimize) Statistics() map[string]float64 {
+	var res map[string]float64
+	o.ctx.do(func() {
+		res = statsMap(o.ctx, C.Z3_optimize_get_statistics(o.ctx.c, o.c))
+	})
+	runtime.KeepAlive(o)
+	return res
+}
+
+// statsMap converts stats to a map and releases it. This must be
+// called with the ctx.lock held.
+func statsMap(ctx *Context, stats C.Z3_stats) map[string]float64 {
+	C.Z3_stats_inc_ref(ctx.c, stats)
+	defer C.Z3_stats_dec_ref(ctx.c, stats)
+	n := C.Z3_stats_size(ctx.c, stats)
+	res := make(map[string]float64, int(n))
+	for i := C.uint(0); i < n; i++ {
+		key := C.GoString(C.Z3_stats_get_key(ctx.c, stats, i))
+		if C.Z3_stats_is_uint(ctx.c, stats, i) {
+			res[key] = float64(C.Z3_stats_get_uint_value(ctx.c, stats, i))
+		} else {
+			res[key] = float64(C.Z3_stats_get_double_value(ctx.c, stats, i))
+		}
+	}
+	return res
+}
+
+// bounds returns the current bounds of o's objectives.
+func (o *Optimize) bounds() []ObjectiveBounds {
+	var res []ObjectiveBounds
+	o.ctx.do(func() {
+		res = optimizeBounds(o.ctx, o.c)
+	})
+	runtime.KeepAlive(o)
+	return res
+}
+
+// optimizeBounds returns the current bounds of the objectives of c.
+// Objective handles are numbered from 0 in the order they were added.
+// This must be called with the ctx.lock held.
+func optimizeBounds(ctx *Context, c C.Z3_optimize) []ObjectiveBounds {
+	vec := C.Z3_optimize_get_objectives(ctx.c, c)
+	C.Z3_ast_vector_inc_ref(ctx.c, vec)
+	n := int(C.Z3_ast_vector_size(ctx.c, vec))
+	C.Z3_ast_vector_dec_ref(ctx.c, vec)
+	res := make([]ObjectiveBounds, n)
+	for i := range res {
+		res[i] = ObjectiveBounds{
+			boundValue(ctx, C.Z3_optimize_get_lower(ctx.c, c, C.uint(i))),
+			boundValue(ctx, C.Z3_optimize_get_upper(ctx.c, c, C.uint(i))),
+		}
+	}
+	return res
+}
+
+// boundValue wraps the bound b as a Value. This must be called with
+// the ctx.lock held.
+func boundValue(ctx *Context, b C.Z3_ast) Value {
+	val := value{(*valueImpl)(wrapAST(ctx, b).astImpl), noEq{}}
+	return val.lift(Kind(C.Z3_get_sort_kind(ctx.c, C.Z3_get_sort(ctx.c, b))))
+}
@@ -0,0 +1,165 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// pigeonhole asserts that n+1 pigeons fit in n holes, which takes Z3
+// exponential time to refute.
+func pigeonhole(ctx *Context, s *Solver, n int) {
+	p := make([][]Bool, n+1)
+	for i := range p {
+		p[i] = make([]Bool, n)
+		for j := range p[i] {
+			p[i][j] = ctx.BoolConst(fmt.Sprintf("p%d_%d", i, j))
+		}
+		s.Assert(p[i][0].Or(p[i][1:]...))
+	}
+	for j := 0; j < n; j++ {
+		for i := range p {
+			for k := i + 1; k < len(p); k++ {
+				s.Assert(p[i][j].And(p[k][j]).Not())
+			}
+		}
+	}
+}
+
+func TestCheckProgress(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	pigeonhole(ctx, s, 5)
+
+	var last Progress
+	calls := 0
+	sat, err := s.CheckProgress(time.Millisecond, func(p Progress) {
+		if last.Done {
+			t.Errorf("call after final report")
+		}
+		if p.AllocSize == 0 {
+			t.Errorf("report has no memory use")
+		}
+		if !p.Done && (p.Stats != nil || p.Bounds != nil) {
+			t.Errorf("periodic report has statistics")
+		}
+		last = p
+		calls++
+	})
+	if sat || err != nil {
+		t.Fatalf("got sat %v, err %v; want unsat", sat, err)
+	}
+	if !last.Done || calls == 0 {
+		t.Fatalf("no final report")
+	}
+	if len(last.Stats) == 0 {
+		t.Errorf("final report has no statistics")
+	}
+}
+
+func TestCheckProgressInterrupt(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	pigeonhole(ctx, s, 12)
+
+	start := time.Now()
+	_, err := s.CheckProgress(10*time.Millisecond, func(p Progress) {
+		if p.Elapsed > 50*time.Millisecond {
+			ctx.Interrupt()
+		}
+	})
+	var unknown *ErrSatUnknown
+	if !errors.As(err, &unknown) {
+		t.Fatalf("got %v, want *ErrSatUnknown", err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("interrupt took %v", d)
+	}
+}
+
+func TestOptimizeCheckProgress(t *testing.T) {
+	ctx := NewContext(nil)
+	o := NewOptimize(ctx)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	o.Assert(x.Add(y).LE(ctx.Int(10)))
+	o.Assert(x.GE(ctx.Int(0)).And(y.GE(ctx.Int(2))))
+	o.Maximize(x)
+	o.Minimize(y)
+
+	var last Progress
+	if sat, err := o.CheckProgress(time.Millisecond, func(p Progress) { last = p }); !sat {
+		t.Fatalf("got unsat, err %v", err)
+	}
+	if len(last.Bounds) != 2 {
+		t.Fatalf("got %d bounds, want 2", len(last.Bounds))
+	}
+	if got := last.Bounds[0].Lower.String(); got != "8" {
+		t.Errorf("max x: got lower bound %s, want 8", got)
+	}
+	if got := last.Bounds[1].Upper.String(); got != "2" {
+		t.Errorf("min y: got upper bound %s, want 2", got)
+	}
+}
+
+func TestCheckProgressInterval(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	called := false
+	if _, err := s.CheckProgress(0, func(Progress) { called = true }); err == nil {
+		t.Error("Solver.CheckProgress accepted a zero interval")
+	}
+	o := NewOptimize(ctx)
+	if _, err := o.CheckProgress(-time.Second, func(Progress) { called = true }); err == nil {
+		t.Error("Optimize.CheckProgress accepted a negative interval")
+	}
+	if called {
+		t.Error("f called for an invalid interval")
+	}
+}
+
+func TestOptimizeCheckProgressSnapshot(t *testing.T) {
+	ctx := NewContext(nil)
+	o := NewOptimize(ctx)
+	// Minimize the number of pigeons left out of n holes. Each
+	// improvement on the way to the optimum is a new snapshot.
+	const n = 7
+	x := make([]Int, n+1)
+	left := ctx.Int(0)
+	for i := range x {
+		x[i] = ctx.IntConst(fmt.Sprintf("x%d", i))
+		o.Assert(x[i].GE(ctx.Int(0)).And(x[i].LE(ctx.Int(n))))
+		left = left.Add(x[i].Eq(ctx.Int(0)).IfThenElse(ctx.Int(1), ctx.Int(0)).(Int))
+	}
+	for i := range x {
+		for k := i + 1; k < len(x); k++ {
+			o.Assert(x[i].Eq(ctx.Int(0)).Or(x[i].NE(x[k])))
+		}
+	}
+	o.Minimize(left)
+
+	var snapshots int
+	var last Progress
+	sat, err := o.CheckProgress(time.Millisecond, func(p Progress) {
+		if !p.Done && p.Bounds != nil {
+			if len(p.Bounds) != 1 || len(p.Stats) == 0 {
+				t.Errorf("periodic report has %d bounds and %d statistics", len(p.Bounds), len(p.Stats))
+			}
+			snapshots++
+		}
+		last = p
+	})
+	if !sat {
+		t.Fatalf("got unsat, err %v", err)
+	}
+	if snapshots == 0 {
+		t.Errorf("no periodic report had a snapshot")
+	}
+	if got := last.Bounds[0].Upper.String(); got != "1" {
+		t.Errorf("got upper bound %s, want 1", got)
+	}
+}
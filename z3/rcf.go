@@ -0,0 +1,257 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"runtime"
+	"unsafe"
+)
+
+// RCFNum is an exact number in the real closed field. This includes
+// all rationals, real algebraic numbers such as the roots of
+// polynomials, transcendental numbers such as π and e, and
+// infinitesimals.
+//
+// Unlike Values, RCFNums are concrete numbers, not symbolic
+// expressions. All operations are exact.
+type RCFNum struct {
+	*rcfNumImpl
+	noEq
+}
+
+type rcfNumImpl struct {
+	ctx *Context
+	c   C.Z3_rcf_num
+}
+
+// wrapRCFNum wraps a C Z3_rcf_num. This must be called with the
+// ctx.lock held.
+func wrapRCFNum(ctx *Context, c C.Z3_rcf_num) RCFNum {
+	impl := &rcfNumImpl{ctx, c}
+	runtime.SetFinalizer(impl, func(impl *rcfNumImpl) {
+		impl.ctx.release(func() {
+			C.Z3_rcf_del(impl.ctx.c, impl.c)
+		})
+	})
+	return RCFNum{impl, noEq{}}
+}
+
+// RCFRational returns the rational number val, which must be a
+// decimal integer, fraction, or decimal number, such as "-3", "1/3",
+// or "0.25".
+func (ctx *Context) RCFRational(val string) RCFNum {
+	cval := C.CString(val)
+	defer C.free(unsafe.Pointer(cval))
+	var res RCFNum
+	ctx.do(func() {
+		res = wrapRCFNum(ctx, C.Z3_rcf_mk_rational(ctx.c, cval))
+	})
+	return res
+}
+
+// RCFInt returns the integer val.
+func (ctx *Context) RCFInt(val int) RCFNum {
+	var res RCFNum
+	ctx.do(func() {
+		res = wrapRCFNum(ctx, C.Z3_rcf_mk_small_int(ctx.c, C.int(val)))
+	})
+	return res
+}
+
+// RCFPi returns π.
+func (ctx *Context) RCFPi() RCFNum {
+	var res RCFNum
+	ctx.do(func() {
+		res = wrapRCFNum(ctx, C.Z3_rcf_mk_pi(ctx.c))
+	})
+	return res
+}
+
+// RCFE returns e, the base of the natural logarithm.
+func (ctx *Context) RCFE() RCFNum {
+	var res RCFNum
+	ctx.do(func() {
+		res = wrapRCFNum(ctx, C.Z3_rcf_mk_e(ctx.c))
+	})
+	return res
+}
+
+// RCFInfinitesimal returns a new positive infinitesimal, which is
+// greater than 0 but smaller than every positive real.
+func (ctx *Context) RCFInfinitesimal() RCFNum {
+	var res RCFNum
+	ctx.do(func() {
+		res = wrapRCFNum(ctx, C.Z3_rcf_mk_infinitesimal(ctx.c))
+	})
+	return res
+}
+
+// RCFRoots returns the real roots of the polynomial
+//
+//	coeffs[0] + coeffs[1]*x + ... + coeffs[n-1]*x^(n-1)
+//
+// in increasing order. The leading coefficient coeffs[n-1] must not
+// be zero.
+func (ctx *Context) RCFRoots(coeffs ...RCFNum) []RCFNum {
+	ccoeffs := make([]C.Z3_rcf_num, len(coeffs))
+	for i, c := range coeffs {
+		ccoeffs[i] = c.c
+	}
+	croots := make([]C.Z3_rcf_num, len(coeffs))
+	var roots []RCFNum
+	ctx.do(func() {
+		n := C.Z3_rcf_mk_roots(ctx.c, C.uint(len(ccoeffs)), &ccoeffs[0], &croots[0])
+		roots = make([]RCFNum, n)
+		for i := range roots {
+			roots[i] = wrapRCFNum(ctx, croots[i])
+		}
+	})
+	runtime.KeepAlive(coeffs)
+	return roots
+}
+
+// binop applies a Z3 RCF arithmetic function to l and r.
+func (l RCFNum) binop(r RCFNum, op func(C.Z3_context, C.Z3_rcf_num, C.Z3_rcf_num) C.Z3_rcf_num) RCFNum {
+	var res RCFNum
+	l.ctx.do(func() {
+		res = wrapRCFNum(l.ctx, op(l.ctx.c, l.c, r.c))
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return res
+}
+
+// cmp applies a Z3 RCF comparison function to l and r.
+func (l RCFNum) cmp(r RCFNum, op func(C.Z3_context, C.Z3_rcf_num, C.Z3_rcf_num) C.bool) bool {
+	var res bool
+	l.ctx.do(func() {
+		res = bool(op(l.ctx.c, l.c, r.c))
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return res
+}
+
+// Add returns l + r.
+func (l RCFNum) Add(r RCFNum) RCFNum {
+	return l.binop(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.Z3_rcf_num { return C.Z3_rcf_add(c, a, b) })
+}
+
+// Sub returns l - r.
+func (l RCFNum) Sub(r RCFNum) RCFNum {
+	return l.binop(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.Z3_rcf_num { return C.Z3_rcf_sub(c, a, b) })
+}
+
+// Mul returns l * r.
+func (l RCFNum) Mul(r RCFNum) RCFNum {
+	return l.binop(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.Z3_rcf_num { return C.Z3_rcf_mul(c, a, b) })
+}
+
+// Div returns l / r. r must not be zero.
+func (l RCFNum) Div(r RCFNum) RCFNum {
+	return l.binop(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.Z3_rcf_num { return C.Z3_rcf_div(c, a, b) })
+}
+
+// Neg returns -l.
+func (l RCFNum) Neg() RCFNum {
+	var res RCFNum
+	l.ctx.do(func() {
+		res = wrapRCFNum(l.ctx, C.Z3_rcf_neg(l.ctx.c, l.c))
+	})
+	runtime.KeepAlive(l)
+	return res
+}
+
+// Inv returns 1/l. l must not be zero.
+func (l RCFNum) Inv() RCFNum {
+	var res RCFNum
+	l.ctx.do(func() {
+		res = wrapRCFNum(l.ctx, C.Z3_rcf_inv(l.ctx.c, l.c))
+	})
+	runtime.KeepAlive(l)
+	return res
+}
+
+// Power returns l^k.
+func (l RCFNum) Power(k uint) RCFNum {
+	var res RCFNum
+	l.ctx.do(func() {
+		res = wrapRCFNum(l.ctx, C.Z3_rcf_power(l.ctx.c, l.c, C.uint(k)))
+	})
+	runtime.KeepAlive(l)
+	return res
+}
+
+// LT returns true if l < r.
+func (l RCFNum) LT(r RCFNum) bool {
+	return l.cmp(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.bool { return C.Z3_rcf_lt(c, a, b) })
+}
+
+// LE returns true if l <= r.
+func (l RCFNum) LE(r RCFNum) bool {
+	return l.cmp(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.bool { return C.Z3_rcf_le(c, a, b) })
+}
+
+// GT returns true if l > r.
+func (l RCFNum) GT(r RCFNum) bool {
+	return l.cmp(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.bool { return C.Z3_rcf_gt(c, a, b) })
+}
+
+// GE returns true if l >= r.
+func (l RCFNum) GE(r RCFNum) bool {
+	return l.cmp(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.bool { return C.Z3_rcf_ge(c, a, b) })
+}
+
+// Eq returns true if l == r.
+func (l RCFNum) Eq(r RCFNum) bool {
+	return l.cmp(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.bool { return C.Z3_rcf_eq(c, a, b) })
+}
+
+// NE returns true if l != r.
+func (l RCFNum) NE(r RCFNum) bool {
+	return l.cmp(r, func(c C.Z3_context, a, b C.Z3_rcf_num) C.bool { return C.Z3_rcf_neq(c, a, b) })
+}
+
+// String returns a compact representation of l, such as
+// "root(x^2 + -2, (0, +oo), {})".
+func (l RCFNum) String() string {
+	var res string
+	l.ctx.do(func() {
+		res = C.GoString(C.Z3_rcf_num_to_string(l.ctx.c, l.c, true, false))
+	})
+	runtime.KeepAlive(l)
+	return res
+}
+
+// Decimal returns l as a decimal string with prec digits after the
+// decimal point. If l is not exactly representable, the result ends
+// in "?".
+func (l RCFNum) Decimal(prec int) string {
+	var res string
+	l.ctx.do(func() {
+		res = C.GoString(C.Z3_rcf_num_to_decimal_string(l.ctx.c, l.c, C.uint(prec)))
+	})
+	runtime.KeepAlive(l)
+	return res
+}
+
+// NumDen returns the numerator and denominator of l, such that
+// l = num/den, in lowest terms.
+func (l RCFNum) NumDen() (num, den RCFNum) {
+	l.ctx.do(func() {
+		var cnum, cden C.Z3_rcf_num
+		C.Z3_rcf_get_numerator_denominator(l.ctx.c, l.c, &cnum, &cden)
+		num = wrapRCFNum(l.ctx, cnum)
+		den = wrapRCFNum(l.ctx, cden)
+	})
+	runtime.KeepAlive(l)
+	return
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRCFArith(t *testing.T) {
+	ctx := NewContext(nil)
+	third := ctx.RCFRational("1/3")
+	sum := third.Add(third).Add(third)
+	if !sum.Eq(ctx.RCFInt(1)) {
+		t.Errorf("1/3+1/3+1/3 = %s, want 1", sum)
+	}
+	if got := ctx.RCFInt(2).Power(10).Sub(ctx.RCFInt(24)).Div(ctx.RCFInt(10)); !got.Eq(ctx.RCFInt(100)) {
+		t.Errorf("(2^10-24)/10 = %s, want 100", got)
+	}
+	if !third.Inv().Neg().Eq(ctx.RCFInt(-3)) {
+		t.Error("-(1/(1/3)) != -3")
+	}
+	num, den := ctx.RCFRational("6/4").NumDen()
+	if !num.Eq(ctx.RCFInt(3)) || !den.Eq(ctx.RCFInt(2)) {
+		t.Errorf("NumDen(6/4) = %s/%s, want 3/2", num, den)
+	}
+}
+
+func TestRCFTranscendental(t *testing.T) {
+	ctx := NewContext(nil)
+	pi, e := ctx.RCFPi(), ctx.RCFE()
+	if !e.LT(pi) || !pi.GT(ctx.RCFInt(3)) || !pi.LE(ctx.RCFRational("3.1416")) {
+		t.Error("unexpected ordering of e, pi")
+	}
+	if d := pi.Decimal(4); !strings.HasPrefix(d, "3.1415") {
+		t.Errorf("pi.Decimal(4) = %s", d)
+	}
+
+	eps := ctx.RCFInfinitesimal()
+	if !eps.GT(ctx.RCFInt(0)) || !eps.LT(ctx.RCFRational("1/1000000")) {
+		t.Error("infinitesimal not between 0 and 1e-6")
+	}
+}
+
+func TestRCFRoots(t *testing.T) {
+	ctx := NewContext(nil)
+	// x^2 - 2
+	roots := ctx.RCFRoots(ctx.RCFInt(-2), ctx.RCFInt(0), ctx.RCFInt(1))
+	if len(roots) != 2 {
+		t.Fatalf("expected 2 roots, got %d", len(roots))
+	}
+	if !roots[0].LT(roots[1]) || !roots[0].Neg().Eq(roots[1]) {
+		t.Errorf("unexpected roots %s, %s", roots[0], roots[1])
+	}
+	if sq := roots[1].Mul(roots[1]); !sq.Eq(ctx.RCFInt(2)) {
+		t.Errorf("sqrt(2)^2 = %s", sq)
+	}
+	if d := roots[1].Decimal(3); !strings.HasPrefix(d, "1.414") {
+		t.Errorf("sqrt(2) = %s", d)
+	}
+}
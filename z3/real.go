@@ -119,7 +119,7 @@ func (lit Real) Approx(precision int) (lower, upper Real, isLiteralIrrational bo
 // TODO: AsBigFloat? AsFloat64? AsFloat32? I don't actually know how
 // to implement those without potentially double rounding.
 
-//go:generate go run genwrap.go -t Real $GOFILE intreal.go
+//go:generate go run genwrap.go -t Real -e $GOFILE intreal.go
 
 // Div returns l / r.
 //
@@ -16,12 +16,22 @@ func (l Real) Eq(r Real) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_eq(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// EqE is like Eq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) EqE(r Real) (res Bool, err error) {
+	chk := argChecker{method: "Real.Eq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Eq(r) })
+	return
+}
+
 // NE returns a Value that is true if l and r are not equal.
 func (l Real) NE(r Real) Bool {
 	return l.ctx.Distinct(l, r)
@@ -31,84 +41,142 @@ func (l Real) NE(r Real) Bool {
 //
 // If r is 0, the result is unconstrained.
 func (l Real) Div(r Real) Real {
-	// Generated from real.go:124.
+	// Generated from real.go:128.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_div(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Real(val)
 }
 
+// DivE is like Div, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) DivE(r Real) (res Real, err error) {
+	chk := argChecker{method: "Real.Div"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Div(r) })
+	return
+}
+
 // ToInt returns the floor of l as sort Int.
 //
 // Note that this is not truncation. For example, ToInt(-1.3) is -2.
 func (l Real) ToInt() Int {
-	// Generated from real.go:130.
+	// Generated from real.go:134.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_real2int(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Int(val)
 }
 
+// ToIntE is like ToInt, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) ToIntE() (res Int, err error) {
+	chk := argChecker{method: "Real.ToInt"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.ToInt() })
+	return
+}
+
 // IsInt returns a Value that is true if l has no fractional part.
 func (l Real) IsInt() Bool {
-	// Generated from real.go:134.
+	// Generated from real.go:138.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_is_int(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
+// IsIntE is like IsInt, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) IsIntE() (res Bool, err error) {
+	chk := argChecker{method: "Real.IsInt"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.IsInt() })
+	return
+}
+
 // ToFloat converts l into a floating-point number.
 //
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l Real) ToFloat(s Sort) Float {
-	// Generated from real.go:141.
+	// Generated from real.go:145.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_fp_real(ctx.c, rm.c, l.c, s.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
 	return Float(val)
 }
 
+// ToFloatE is like ToFloat, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) ToFloatE(s Sort) (res Float, err error) {
+	chk := argChecker{method: "Real.ToFloat"}
+	chk.value("l", l)
+	chk.sort("s", s)
+	err = chk.do(func() { res = l.ToFloat(s) })
+	return
+}
+
 // ToFloatExp converts l into a floating-point number l*2^exp.
 //
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l Real) ToFloatExp(exp Int, s Sort) Float {
-	// Generated from real.go:148.
+	// Generated from real.go:152.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_fpa_to_fp_int_real(ctx.c, rm.c, exp.c, l.c, s.c)
-	})
+	}, exp.c, l.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(exp)
 	runtime.KeepAlive(s)
 	return Float(val)
 }
 
+// ToFloatExpE is like ToFloatExp, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) ToFloatExpE(exp Int, s Sort) (res Float, err error) {
+	chk := argChecker{method: "Real.ToFloatExp"}
+	chk.value("l", l)
+	chk.value("exp", exp)
+	chk.sort("s", s)
+	err = chk.do(func() { res = l.ToFloatExp(exp, s) })
+	return
+}
+
 // Abs returns the absolute value of l.
 func (l Real) Abs() Real {
-	// Generated from real.go:152.
+	// Generated from real.go:156.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_abs(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Real(val)
 }
 
+// AbsE is like Abs, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) AbsE() (res Real, err error) {
+	chk := argChecker{method: "Real.Abs"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Abs() })
+	return
+}
+
 // Add returns the sum l + r[0] + r[1] + ...
 func (l Real) Add(r ...Real) Real {
 	// Generated from intreal.go:12.
@@ -120,11 +188,23 @@ func (l Real) Add(r ...Real) Real {
 	}
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_add(ctx.c, C.uint(len(cargs)), &cargs[0])
-	})
+	}, cargs...)
 	runtime.KeepAlive(&cargs[0])
 	return Real(val)
 }
 
+// AddE is like Add, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) AddE(r ...Real) (res Real, err error) {
+	chk := argChecker{method: "Real.Add"}
+	chk.value("l", l)
+	for i, v := range r {
+		chk.elem("r", i, v)
+	}
+	err = chk.do(func() { res = l.Add(r...) })
+	return
+}
+
 // Mul returns the product l * r[0] * r[1] * ...
 func (l Real) Mul(r ...Real) Real {
 	// Generated from intreal.go:16.
@@ -136,11 +216,23 @@ func (l Real) Mul(r ...Real) Real {
 	}
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_mul(ctx.c, C.uint(len(cargs)), &cargs[0])
-	})
+	}, cargs...)
 	runtime.KeepAlive(&cargs[0])
 	return Real(val)
 }
 
+// MulE is like Mul, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) MulE(r ...Real) (res Real, err error) {
+	chk := argChecker{method: "Real.Mul"}
+	chk.value("l", l)
+	for i, v := range r {
+		chk.elem("r", i, v)
+	}
+	err = chk.do(func() { res = l.Mul(r...) })
+	return
+}
+
 // Sub returns l - r[0] - r[1] - ...
 func (l Real) Sub(r ...Real) Real {
 	// Generated from intreal.go:20.
@@ -152,78 +244,149 @@ func (l Real) Sub(r ...Real) Real {
 	}
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_sub(ctx.c, C.uint(len(cargs)), &cargs[0])
-	})
+	}, cargs...)
 	runtime.KeepAlive(&cargs[0])
 	return Real(val)
 }
 
+// SubE is like Sub, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) SubE(r ...Real) (res Real, err error) {
+	chk := argChecker{method: "Real.Sub"}
+	chk.value("l", l)
+	for i, v := range r {
+		chk.elem("r", i, v)
+	}
+	err = chk.do(func() { res = l.Sub(r...) })
+	return
+}
+
 // Neg returns -l.
 func (l Real) Neg() Real {
 	// Generated from intreal.go:24.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_unary_minus(ctx.c, l.c)
-	})
+	}, l.c)
 	runtime.KeepAlive(l)
 	return Real(val)
 }
 
+// NegE is like Neg, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) NegE() (res Real, err error) {
+	chk := argChecker{method: "Real.Neg"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Neg() })
+	return
+}
+
 // Exp returns lᶠ.
 func (l Real) Exp(r Real) Real {
 	// Generated from intreal.go:28.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_power(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Real(val)
 }
 
+// ExpE is like Exp, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) ExpE(r Real) (res Real, err error) {
+	chk := argChecker{method: "Real.Exp"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Exp(r) })
+	return
+}
+
 // LT returns l < r.
 func (l Real) LT(r Real) Bool {
 	// Generated from intreal.go:32.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_lt(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// LTE is like LT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) LTE(r Real) (res Bool, err error) {
+	chk := argChecker{method: "Real.LT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.LT(r) })
+	return
+}
+
 // LE returns l <= r.
 func (l Real) LE(r Real) Bool {
 	// Generated from intreal.go:36.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_le(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// LEE is like LE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) LEE(r Real) (res Bool, err error) {
+	chk := argChecker{method: "Real.LE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.LE(r) })
+	return
+}
+
 // GT returns l > r.
 func (l Real) GT(r Real) Bool {
 	// Generated from intreal.go:40.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_gt(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
 
+// GTE is like GT, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) GTE(r Real) (res Bool, err error) {
+	chk := argChecker{method: "Real.GT"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.GT(r) })
+	return
+}
+
 // GE returns l >= r.
 func (l Real) GE(r Real) Bool {
 	// Generated from intreal.go:44.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
 		return C.Z3_mk_ge(ctx.c, l.c, r.c)
-	})
+	}, l.c, r.c)
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Bool(val)
 }
+
+// GEE is like GE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Real) GEE(r Real) (res Bool, err error) {
+	chk := argChecker{method: "Real.GE"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.GE(r) })
+	return
+}
@@ -0,0 +1,183 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Reduce returns a subset of asserts for which interesting still
+// returns true, using the ddmin delta-debugging algorithm. The result
+// is 1-minimal: removing any single assertion from it makes it
+// uninteresting. interesting(asserts) must be true.
+//
+// interesting is called many times, with subsets of asserts in their
+// original order. It should be deterministic; if it is not, the result
+// is still interesting but may not be minimal.
+func Reduce(asserts []Bool, interesting func(asserts []Bool) bool) []Bool {
+	cur := append([]Bool(nil), asserts...)
+	n := 2
+	for len(cur) >= 2 {
+		if n > len(cur) {
+			n = len(cur)
+		}
+		// Split cur into n chunks of nearly equal size.
+		chunks := make([][]Bool, n)
+		for i := range chunks {
+			chunks[i] = cur[i*len(cur)/n : (i+1)*len(cur)/n]
+		}
+
+		// Try each chunk, then each complement.
+		var next []Bool
+		for _, c := range chunks {
+			if interesting(c) {
+				next, n = c, 2
+				break
+			}
+		}
+		if next == nil && n > 2 {
+			for i := range chunks {
+				var comp []Bool
+				for j, c := range chunks {
+					if j != i {
+						comp = append(comp, c...)
+					}
+				}
+				if interesting(comp) {
+					next, n = comp, n-1
+					break
+				}
+			}
+		}
+		if next != nil {
+			cur = append([]Bool(nil), next...)
+			continue
+		}
+		if n == len(cur) {
+			break
+		}
+		n *= 2
+	}
+	if len(cur) == 1 && interesting(nil) {
+		return nil
+	}
+	return cur
+}
+
+// A Behavior reports whether a Solver exhibits some behavior of
+// interest, for use with Solver.Reduce. A Behavior may call Check
+// and other methods of the Solver it is given, which is discarded
+// afterwards.
+type Behavior func(s *Solver) bool
+
+// BehaviorUnsat is the Behavior of a Solver whose assertions are
+// unsatisfiable.
+func BehaviorUnsat(s *Solver) bool {
+	sat, err := s.Check()
+	return !sat && err == nil
+}
+
+// BehaviorUnknown is the Behavior of a Solver for which Check cannot
+// determine satisfiability.
+func BehaviorUnknown(s *Solver) bool {
+	_, err := s.Check()
+	var unknown *ErrSatUnknown
+	return errors.As(err, &unknown)
+}
+
+// BehaviorSlow returns the Behavior of a Solver whose Check takes at
+// least d. The Check is interrupted after d, so each trial takes at
+// most a little more than d.
+func BehaviorSlow(d time.Duration) Behavior {
+	return func(s *Solver) bool {
+		done := make(chan struct{})
+		timer := time.AfterFunc(d, func() {
+			// An Interrupt between Z3's checks of its cancel
+			// flag can be lost, so repeat it until Check returns.
+			tick := time.NewTicker(10 * time.Millisecond)
+			defer tick.Stop()
+			for {
+				s.ctx.Interrupt()
+				select {
+				case <-done:
+					return
+				case <-tick.C:
+				}
+			}
+		})
+		start := time.Now()
+		s.Check()
+		elapsed := time.Since(start)
+		timer.Stop()
+		close(done)
+		return elapsed >= d
+	}
+}
+
+// BehaviorCrash returns the Behavior of a Solver whose assertions,
+// written as an SMT-LIB 2 script as by Solver.Reduce, make the command
+// name with the given arguments fail, for example
+//
+//	BehaviorCrash("z3", "-in")
+//
+// The script is passed on the command's standard input. The command
+// fails if it is killed by a signal or exits with a non-zero status.
+// Running the solver in another process lets Reduce minimize problems
+// that crash Z3 rather than return an answer.
+func BehaviorCrash(name string, args ...string) Behavior {
+	return func(s *Solver) bool {
+		var script strings.Builder
+		writeScript(&script, s)
+		cmd := exec.Command(name, args...)
+		cmd.Stdin = strings.NewReader(script.String())
+		return cmd.Run() != nil
+	}
+}
+
+// Reduce minimizes the assertions of s while preserving behavior, and
+// writes an SMT-LIB 2 script that reproduces it to w. This turns a
+// large generated problem that triggers a Z3 bug or performance
+// problem into a small test case for a bug report.
+//
+// Each trial asserts a subset of the assertions on a new Solver in
+// s's Context, with default parameters, and passes it to behavior.
+// The script declares the symbols used by the remaining assertions,
+// asserts them, and ends with (check-sat). Reduce returns the
+// remaining assertions. s itself is not modified.
+//
+// If s does not exhibit behavior to begin with, Reduce returns an
+// error and writes nothing.
+func (s *Solver) Reduce(w io.Writer, behavior Behavior) ([]Bool, error) {
+	interesting := func(asserts []Bool) bool {
+		trial := NewSolver(s.ctx)
+		defer trial.Close()
+		trial.AssertAll(asserts)
+		return behavior(trial)
+	}
+	asserts := s.Assertions()
+	if !interesting(asserts) {
+		return nil, errors.New("z3: Reduce: solver does not exhibit the behavior")
+	}
+	asserts = Reduce(asserts, interesting)
+
+	tmp := NewSolver(s.ctx)
+	defer tmp.Close()
+	tmp.AssertAll(asserts)
+	var script strings.Builder
+	writeScript(&script, tmp)
+	_, err := io.WriteString(w, script.String())
+	return asserts, err
+}
+
+// writeScript writes an SMT-LIB 2 script that checks the assertions of
+// s to buf.
+func writeScript(buf *strings.Builder, s *Solver) {
+	buf.WriteString(s.String())
+	buf.WriteString("(check-sat)\n")
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestReduce(t *testing.T) {
+	ctx := NewContext(nil)
+	var asserts []Bool
+	for i := 0; i < 20; i++ {
+		asserts = append(asserts, ctx.FreshConst("b", ctx.BoolSort()).(Bool))
+	}
+	// Interesting if both 3 and 17 are present.
+	has := func(as []Bool, i int) bool {
+		for _, a := range as {
+			if a.AsAST().ID() == asserts[i].AsAST().ID() {
+				return true
+			}
+		}
+		return false
+	}
+	got := Reduce(asserts, func(as []Bool) bool { return has(as, 3) && has(as, 17) })
+	if len(got) != 2 || !has(got, 3) || !has(got, 17) {
+		t.Errorf("Reduce = %v, want [%v %v]", got, asserts[3], asserts[17])
+	}
+	if got := Reduce(asserts, func([]Bool) bool { return true }); len(got) != 0 {
+		t.Errorf("Reduce with always interesting = %v, want []", got)
+	}
+}
+
+func TestSolverReduce(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y, z := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z")
+	s := NewSolver(ctx)
+	s.Assert(y.GT(ctx.Int(0)))
+	s.Assert(x.GT(ctx.Int(5)))
+	s.Assert(z.Eq(x.Add(y)))
+	s.Assert(y.LT(ctx.Int(100)))
+	s.Assert(x.LT(ctx.Int(3)))
+	s.Assert(z.GT(ctx.Int(1)))
+
+	var buf strings.Builder
+	got, err := s.Reduce(&buf, BehaviorUnsat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].String() != "(> x 5)" || got[1].String() != "(< x 3)" {
+		t.Errorf("Reduce = %v, want [(> x 5) (< x 3)]", got)
+	}
+	script := buf.String()
+	if !strings.Contains(script, "(declare-fun x () Int)") || strings.Contains(script, "declare-fun y") ||
+		!strings.HasSuffix(script, "(check-sat)\n") {
+		t.Errorf("unexpected script:\n%s", script)
+	}
+	if n := s.NumAssertions(); n != 6 {
+		t.Errorf("Reduce changed the solver: %d assertions", n)
+	}
+
+	sat := NewSolver(ctx)
+	sat.Assert(x.GT(ctx.Int(0)))
+	if _, err := sat.Reduce(&buf, BehaviorUnsat); err == nil {
+		t.Errorf("Reduce of satisfiable solver succeeded")
+	}
+
+	// Simulate a crash in an external solver triggered by z.
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	buf.Reset()
+	crash := BehaviorCrash("sh", "-c", "! grep -q 'declare-fun z'")
+	got, err = s.Reduce(&buf, crash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !strings.Contains(buf.String(), "declare-fun z") {
+		t.Errorf("Reduce with crash = %v, script:\n%s", got, buf.String())
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"unicode"
+)
+
+// maxChar is the largest character code point Z3 supports with its
+// default (Unicode) string encoding.
+const maxChar = 0x2FFFF
+
+// CompileRegexp parses a regular expression in Go's regexp syntax
+// (see regexp/syntax) and returns the equivalent RE over strings.
+//
+// The resulting RE matches a string if the whole string matches the
+// pattern. That is, the pattern is implicitly anchored at both ends,
+// and ^ and $ (and \A and \z) are accepted only at the beginning and
+// end of the pattern, respectively. For Go's unanchored search
+// semantics, surround the result with REFull on both sides.
+//
+// Character classes are limited to code points Z3 can represent.
+// Word-boundary assertions (\b and \B) cannot be expressed and
+// produce an error.
+func (ctx *Context) CompileRegexp(pattern string) (RE, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return RE{}, err
+	}
+	c := regexpCompiler{ctx, ctx.RESort(ctx.StringSort())}
+	return c.compile(re, true, true)
+}
+
+// MustCompileRegexp is like CompileRegexp, but panics if pattern
+// cannot be compiled.
+func (ctx *Context) MustCompileRegexp(pattern string) RE {
+	re, err := ctx.CompileRegexp(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return re
+}
+
+// REFold returns the RE matching any string that is equal to s under
+// simple Unicode case folding, like strings.EqualFold.
+func (ctx *Context) REFold(s string) RE {
+	c := regexpCompiler{ctx, ctx.RESort(ctx.StringSort())}
+	var parts []RE
+	for _, r := range s {
+		parts = append(parts, c.foldRune(r))
+	}
+	return c.concat(parts)
+}
+
+// REDigit returns the RE matching a single ASCII digit, [0-9], like
+// \d in Go regular expressions.
+func (ctx *Context) REDigit() RE {
+	return ctx.reClass('0', '9')
+}
+
+// REAlpha returns the RE matching a single ASCII letter, [A-Za-z].
+func (ctx *Context) REAlpha() RE {
+	return ctx.reClass('A', 'Z', 'a', 'z')
+}
+
+// REAlnum returns the RE matching a single ASCII letter or digit,
+// [0-9A-Za-z].
+func (ctx *Context) REAlnum() RE {
+	return ctx.reClass('0', '9', 'A', 'Z', 'a', 'z')
+}
+
+// REWhitespace returns the RE matching a single ASCII whitespace
+// character, [\t\n\f\r ], like \s in Go regular expressions.
+func (ctx *Context) REWhitespace() RE {
+	return ctx.reClass('\t', '\n', '\f', '\r', ' ', ' ')
+}
+
+// REWord returns the RE matching a single ASCII word character,
+// [0-9A-Za-z_], like \w in Go regular expressions.
+func (ctx *Context) REWord() RE {
+	return ctx.reClass('0', '9', 'A', 'Z', '_', '_', 'a', 'z')
+}
+
+// reClass returns the RE over strings matching any rune in ranges,
+// given as lo/hi pairs.
+func (ctx *Context) reClass(ranges ...rune) RE {
+	c := regexpCompiler{ctx, ctx.RESort(ctx.StringSort())}
+	return c.class(ranges)
+}
+
+type regexpCompiler struct {
+	ctx  *Context
+	sort Sort
+}
+
+// compile translates re. atStart and atEnd report whether re is at
+// the beginning or end of the whole pattern, which determines whether
+// anchors can be expressed.
+func (c regexpCompiler) compile(re *syntax.Regexp, atStart, atEnd bool) (RE, error) {
+	ctx := c.ctx
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return ctx.REEmpty(c.sort), nil
+
+	case syntax.OpEmptyMatch:
+		return c.empty(), nil
+
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase == 0 {
+			return ctx.FromRunes(re.Rune).ToRE(), nil
+		}
+		parts := make([]RE, len(re.Rune))
+		for i, r := range re.Rune {
+			parts[i] = c.foldRune(r)
+		}
+		return c.concat(parts), nil
+
+	case syntax.OpCharClass:
+		return c.class(re.Rune), nil
+
+	case syntax.OpAnyCharNotNL:
+		return c.class([]rune{0, '\n' - 1, '\n' + 1, unicode.MaxRune}), nil
+
+	case syntax.OpAnyChar:
+		return ctx.REAllChar(c.sort), nil
+
+	case syntax.OpBeginLine, syntax.OpBeginText:
+		if !atStart {
+			return RE{}, fmt.Errorf("z3: regexp anchor %v is only supported at the start of the pattern", re)
+		}
+		return c.empty(), nil
+
+	case syntax.OpEndLine, syntax.OpEndText:
+		if !atEnd {
+			return RE{}, fmt.Errorf("z3: regexp anchor %v is only supported at the end of the pattern", re)
+		}
+		return c.empty(), nil
+
+	case syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return RE{}, fmt.Errorf("z3: regexp word boundary %v is not supported", re)
+
+	case syntax.OpCapture:
+		return c.compile(re.Sub[0], atStart, atEnd)
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		sub, err := c.compile(re.Sub[0], false, false)
+		if err != nil {
+			return RE{}, err
+		}
+		switch re.Op {
+		case syntax.OpStar:
+			return sub.Star(), nil
+		case syntax.OpPlus:
+			return sub.Plus(), nil
+		}
+		return sub.Option(), nil
+
+	case syntax.OpRepeat:
+		sub, err := c.compile(re.Sub[0], false, false)
+		if err != nil {
+			return RE{}, err
+		}
+		switch {
+		case re.Max == -1 && re.Min == 0:
+			return sub.Star(), nil
+		case re.Max == -1:
+			return sub.Power(uint(re.Min)).Concat(sub.Star()), nil
+		case re.Max == 0:
+			return c.empty(), nil
+		}
+		return sub.Loop(uint(re.Min), uint(re.Max)), nil
+
+	case syntax.OpConcat:
+		parts := make([]RE, len(re.Sub))
+		for i, sub := range re.Sub {
+			part, err := c.compile(sub, atStart && i == 0, atEnd && i == len(re.Sub)-1)
+			if err != nil {
+				return RE{}, err
+			}
+			parts[i] = part
+		}
+		return c.concat(parts), nil
+
+	case syntax.OpAlternate:
+		parts := make([]RE, len(re.Sub))
+		for i, sub := range re.Sub {
+			part, err := c.compile(sub, atStart, atEnd)
+			if err != nil {
+				return RE{}, err
+			}
+			parts[i] = part
+		}
+		return c.union(parts), nil
+	}
+	return RE{}, fmt.Errorf("z3: unsupported regexp operator in %v", re)
+}
+
+// empty returns the RE matching only the empty string.
+func (c regexpCompiler) empty() RE {
+	return c.ctx.FromString("").ToRE()
+}
+
+func (c regexpCompiler) concat(parts []RE) RE {
+	switch len(parts) {
+	case 0:
+		return c.empty()
+	case 1:
+		return parts[0]
+	}
+	return parts[0].Concat(parts[1:]...)
+}
+
+func (c regexpCompiler) union(parts []RE) RE {
+	switch len(parts) {
+	case 0:
+		return c.ctx.REEmpty(c.sort)
+	case 1:
+		return parts[0]
+	}
+	return parts[0].Union(parts[1:]...)
+}
+
+// foldRune returns the RE matching any rune that is equivalent to r
+// under simple Unicode case folding.
+func (c regexpCompiler) foldRune(r rune) RE {
+	parts := []RE{c.ctx.FromRunes([]rune{r}).ToRE()}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f <= maxChar {
+			parts = append(parts, c.ctx.FromRunes([]rune{f}).ToRE())
+		}
+	}
+	return c.union(parts)
+}
+
+// class returns the RE matching any rune in the given ranges, which
+// are given as lo/hi pairs in the style of syntax.Regexp.Rune.
+func (c regexpCompiler) class(ranges []rune) RE {
+	var parts []RE
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo > maxChar {
+			continue
+		}
+		if hi > maxChar {
+			hi = maxChar
+		}
+		parts = append(parts, c.ctx.RERange(c.ctx.FromRunes([]rune{lo}), c.ctx.FromRunes([]rune{hi})))
+	}
+	return c.union(parts)
+}
@@ -0,0 +1,136 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+// checkMatch reports whether the literal string s is accepted by re.
+func checkMatch(t *testing.T, ctx *Context, re RE, s string) bool {
+	if tHelper != nil {
+		tHelper(t)
+	}
+	solver := NewSolver(ctx)
+	solver.Assert(ctx.FromString(s).InRE(re))
+	sat, err := solver.Check()
+	if err != nil {
+		t.Fatalf("Check(%q): %v", s, err)
+	}
+	return sat
+}
+
+func TestCompileRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		match   []string
+		noMatch []string
+	}{
+		{`abc`, []string{"abc"}, []string{"ab", "abcd", ""}},
+		{`a|bc`, []string{"a", "bc"}, []string{"abc", "b"}},
+		{`a*b+c?`, []string{"b", "aabbc", "bbb"}, []string{"a", "abcc"}},
+		{`[a-c]{2,3}`, []string{"ab", "cba"}, []string{"a", "abca", "ad"}},
+		{`x{2,}`, []string{"xx", "xxxx"}, []string{"x"}},
+		{`^(foo|bar)$`, []string{"foo", "bar"}, []string{"foobar"}},
+		{`[^0-9]`, []string{"a", "-"}, []string{"5"}},
+		{`(?i)ok`, []string{"ok", "OK", "oK"}, []string{"no"}},
+		{`a.c`, []string{"abc", "a-c"}, []string{"a\nc", "ac"}},
+		{`\d+`, []string{"0", "123"}, []string{"", "12a"}},
+	}
+	ctx := NewContext(nil)
+	for _, test := range tests {
+		re, err := ctx.CompileRegexp(test.pattern)
+		if err != nil {
+			t.Errorf("CompileRegexp(%q): %v", test.pattern, err)
+			continue
+		}
+		for _, s := range test.match {
+			if !checkMatch(t, ctx, re, s) {
+				t.Errorf("%q should match %q", test.pattern, s)
+			}
+		}
+		for _, s := range test.noMatch {
+			if checkMatch(t, ctx, re, s) {
+				t.Errorf("%q should not match %q", test.pattern, s)
+			}
+		}
+	}
+}
+
+func TestCompileRegexpErrors(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, pattern := range []string{`a(`, `a^b`, `a$b`, `\bfoo`} {
+		if _, err := ctx.CompileRegexp(pattern); err == nil {
+			t.Errorf("CompileRegexp(%q) succeeded, want error", pattern)
+		}
+	}
+	wantPanic(t, "missing closing", func() { ctx.MustCompileRegexp(`[a`) })
+}
+
+func TestCompileRegexpSolve(t *testing.T) {
+	ctx := NewContext(nil)
+	re := ctx.MustCompileRegexp(`[a-z]+@[a-z]+\.(com|org)`)
+	x := ctx.StringConst("x")
+
+	solver := NewSolver(ctx)
+	solver.Assert(x.InRE(re))
+	solver.Assert(x.Length().LE(ctx.Int(9)))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	s, ok := solver.Model().Eval(x, true).(String).AsString()
+	if !ok || len(s) < 7 || len(s) > 9 {
+		t.Errorf("unexpected model value %q", s)
+	}
+}
+
+func TestREFold(t *testing.T) {
+	ctx := NewContext(nil)
+	re := ctx.REFold("Select")
+	for _, s := range []string{"select", "SELECT", "sElEcT"} {
+		if !checkMatch(t, ctx, re, s) {
+			t.Errorf("REFold(%q) should match %q", "Select", s)
+		}
+	}
+	for _, s := range []string{"selec", "selects", "insert"} {
+		if checkMatch(t, ctx, re, s) {
+			t.Errorf("REFold(%q) should not match %q", "Select", s)
+		}
+	}
+	if !checkMatch(t, ctx, ctx.REFold(""), "") {
+		t.Error("REFold(\"\") should match the empty string")
+	}
+}
+
+func TestREClasses(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, tt := range []struct {
+		name string
+		re   RE
+		want string
+	}{
+		{"REDigit", ctx.REDigit(), "0123456789"},
+		{"REAlpha", ctx.REAlpha(), "AZaz"},
+		{"REAlnum", ctx.REAlnum(), "09AZaz"},
+		{"REWhitespace", ctx.REWhitespace(), " \t\n\f\r"},
+		{"REWord", ctx.REWord(), "09AZaz_"},
+	} {
+		for _, c := range "09AZaz_ \t\n\f\r\v-é" {
+			want := false
+			for _, w := range tt.want {
+				want = want || c == w
+			}
+			if got := checkMatch(t, ctx, tt.re, string(c)); got != want {
+				t.Errorf("%s matches %q = %v, want %v", tt.name, c, got, want)
+			}
+		}
+	}
+
+	// A typical identifier pattern.
+	ident := ctx.REAlpha().Union(ctx.FromString("_").ToRE()).Concat(ctx.REWord().Star())
+	for s, want := range map[string]bool{"x": true, "_tmp1": true, "9lives": false, "a-b": false} {
+		if got := checkMatch(t, ctx, ident, s); got != want {
+			t.Errorf("identifier matches %q = %v, want %v", s, got, want)
+		}
+	}
+}
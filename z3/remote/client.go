@@ -0,0 +1,146 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// A Client talks to a Server.
+type Client struct {
+	// URL is the base URL of the Server, such as
+	// "http://solver:8080".
+	URL string
+
+	// HTTPClient is the client used to make requests. If it is nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Submit submits req and returns the ID of the new job.
+func (c *Client) Submit(ctx context.Context, req *Request) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	var st Status
+	if err := c.do(ctx, "POST", "", bytes.NewReader(body), &st); err != nil {
+		return "", err
+	}
+	return st.ID, nil
+}
+
+// Status returns the current status of job id.
+func (c *Client) Status(ctx context.Context, id string) (*Status, error) {
+	st := new(Status)
+	if err := c.do(ctx, "GET", url.PathEscape(id), nil, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Cancel cancels job id if it has not finished. Otherwise it deletes
+// the job from the Server. It returns the job's status.
+func (c *Client) Cancel(ctx context.Context, id string) (*Status, error) {
+	st := new(Status)
+	if err := c.do(ctx, "DELETE", url.PathEscape(id), nil, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Watch calls f with the status of job id each time it changes, until
+// the job is done, and returns the final status. If ctx is canceled
+// first, Watch returns ctx's error; the job keeps running.
+func (c *Client) Watch(ctx context.Context, id string, f func(*Status)) (*Status, error) {
+	resp, err := c.send(ctx, "GET", url.PathEscape(id)+"/events", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	scan := bufio.NewScanner(resp.Body)
+	scan.Buffer(nil, 64<<20)
+	for scan.Scan() {
+		st := new(Status)
+		if err := json.Unmarshal(scan.Bytes(), st); err != nil {
+			return nil, fmt.Errorf("remote: bad event: %v", err)
+		}
+		if f != nil {
+			f(st)
+		}
+		if st.State.Done() {
+			return st, nil
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("remote: event stream for job %s ended early", id)
+}
+
+// Solve submits req, waits for it to finish, deletes the job, and
+// returns its final status.
+func (c *Client) Solve(ctx context.Context, req *Request) (*Status, error) {
+	id, err := c.Submit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	st, err := c.Watch(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	_, err = c.Cancel(ctx, id)
+	return st, err
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader, v interface{}) error {
+	resp, err := c.send(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// send sends a request for path relative to the jobs URL and checks
+// that it succeeded.
+func (c *Client) send(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	u := strings.TrimSuffix(c.URL, "/") + "/jobs"
+	if path != "" {
+		u += "/" + path
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("remote: %s %s: %s: %s", method, u, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func newTestClient(t *testing.T) *Client {
+	return newTestServer(t, NewServer(2))
+}
+
+// newTestServer serves srv until t ends and returns a Client for it.
+func newTestServer(t *testing.T, srv *Server) *Client {
+	hs := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		hs.Close()
+		srv.Close()
+	})
+	return &Client{URL: hs.URL}
+}
+
+func TestSolveJSON(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	problem := `{
+		"vars": [{"name": "x", "sort": "int"}, {"name": "y", "sort": "int"}],
+		"constraints": [
+			{"op": "=", "args": [{"op": "+", "args": [{"var": "x"}, {"var": "y"}]}, {"int": 10}]},
+			{"op": ">=", "args": [{"var": "x"}, {"int": 0}]},
+			{"op": ">=", "args": [{"var": "y"}, {"int": 3}]}
+		]
+	}`
+	st, err := c.Solve(ctx, &Request{
+		Problem:  json.RawMessage(problem),
+		Maximize: []json.RawMessage{json.RawMessage(`{"var": "x"}`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.State != Sat || string(st.Model["x"]) != `{"int":7}` || string(st.Model["y"]) != `{"int":3}` {
+		t.Errorf("got %+v", st)
+	}
+	if len(st.Objectives) != 1 || string(st.Objectives[0]) != `{"int":7}` {
+		t.Errorf("objectives = %s", st.Objectives)
+	}
+
+	// The finished job was deleted.
+	if _, err := c.Status(ctx, st.ID); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Status of deleted job: %v", err)
+	}
+
+	// Without objectives, the problem is satisfiable or not.
+	unsat := strings.Replace(problem, `"int": 3`, `"int": 11`, 1)
+	if st, err := c.Solve(ctx, &Request{Problem: json.RawMessage(unsat)}); err != nil || st.State != Unsat {
+		t.Errorf("unsat problem: %+v, %v", st, err)
+	}
+}
+
+func TestSolveSMTLIB(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	id, err := c.Submit(ctx, &Request{SMTLIB: `
+		(declare-const a Int)
+		(assert (> (* a a) 50))
+		(assert (> a 0))
+		(minimize a)`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var states []State
+	st, err := c.Watch(ctx, id, func(st *Status) { states = append(states, st.State) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.State != Sat || !strings.Contains(st.ModelText, "8") {
+		t.Errorf("got %+v", st)
+	}
+	if len(states) == 0 || states[len(states)-1] != st.State {
+		t.Errorf("watched states %v", states)
+	}
+	if st, err := c.Status(ctx, id); err != nil || !st.State.Done() {
+		t.Errorf("Status after Watch: %+v, %v", st, err)
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	for _, test := range []struct {
+		req   Request
+		state State
+		err   string
+	}{
+		{Request{}, "", "exactly one"},
+		{Request{SMTLIB: "(assert true)", Maximize: []json.RawMessage{json.RawMessage(`{"int": 1}`)}}, "", "belong in the script"},
+		{Request{SMTLIB: "(assert (> x 0))"}, Failed, "unknown constant"},
+		{Request{Problem: json.RawMessage(`{"vars": [{"name": "x", "sort": "float"}]}`)}, Failed, "unknown sort"},
+		{Request{Problem: json.RawMessage(`{"vars": []}`), Minimize: []json.RawMessage{json.RawMessage(`{"var": "x"}`)}}, Failed, "undeclared var"},
+	} {
+		st, err := c.Solve(ctx, &test.req)
+		if test.state == "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("Solve(%+v): got %v, want %q", test.req, err, test.err)
+			}
+			continue
+		}
+		if err != nil || st.State != test.state || !strings.Contains(st.Error, test.err) {
+			t.Errorf("Solve(%+v) = %+v, %v, want state %s with %q", test.req, st, err, test.state, test.err)
+		}
+	}
+}
+
+func TestRequestTooLarge(t *testing.T) {
+	srv := NewServer(1)
+	srv.MaxRequestBytes = 100
+	c := newTestServer(t, srv)
+	ctx := context.Background()
+	big := "(assert true)" + strings.Repeat(" ", 100)
+	if _, err := c.Submit(ctx, &Request{SMTLIB: big}); err == nil || !strings.Contains(err.Error(), "413") {
+		t.Errorf("Submit of large request: %v", err)
+	}
+	if st, err := c.Solve(ctx, &Request{SMTLIB: "(assert true)"}); err != nil || st.State != Sat {
+		t.Errorf("Solve of small request: %+v, %v", st, err)
+	}
+}
+
+func TestJobPanic(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	solveFunc = func(ctx *z3.Context, req *Request) Status { panic("boom") }
+	st, err := c.Solve(ctx, &Request{SMTLIB: "(assert true)"})
+	solveFunc = solve
+	if err != nil || st.State != Failed || !strings.Contains(st.Error, "boom") {
+		t.Errorf("Solve with panic: %+v, %v", st, err)
+	}
+	// The workers survive.
+	for i := 0; i < 3; i++ {
+		if st, err := c.Solve(ctx, &Request{SMTLIB: "(assert true)"}); err != nil || st.State != Sat {
+			t.Errorf("Solve after panic: %+v, %v", st, err)
+		}
+	}
+}
+
+func TestFinishedTTL(t *testing.T) {
+	srv := NewServer(1)
+	srv.FinishedTTL = time.Millisecond
+	c := newTestServer(t, srv)
+	ctx := context.Background()
+	id, err := c.Submit(ctx, &Request{SMTLIB: "(assert true)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Watch(ctx, id, func(*Status) {}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if _, err := c.Status(ctx, id); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Status of expired job: %v", err)
+	}
+}
@@ -0,0 +1,529 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package remote offloads solving to a pool of workers behind an HTTP
+// API, so that latency-sensitive processes do not have to run Z3
+// themselves.
+//
+// A Server accepts problems as SMT-LIB 2 scripts or in the JSON format
+// of z3.Problem, solves them on a fixed number of worker goroutines,
+// and reports their status and models. A Client submits problems and
+// waits for results.
+//
+// The HTTP API is:
+//
+//	POST   /jobs              submit a Request; returns a Status
+//	GET    /jobs/{id}         get the Status of a job
+//	GET    /jobs/{id}/events  stream the Status of a job as
+//	                          newline-delimited JSON until it is done
+//	DELETE /jobs/{id}         cancel a job, or forget a finished one
+//
+// Each job is solved in its own z3.Context.
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+// A Request is a problem submitted to a Server. Exactly one of SMTLIB
+// and Problem must be set.
+type Request struct {
+	// SMTLIB is an SMT-LIB 2 script of declarations and
+	// assertions. It may use the minimize and maximize commands.
+	SMTLIB string `json:"smtlib,omitempty"`
+
+	// Problem is a problem in the JSON format of z3.Problem.
+	Problem json.RawMessage `json:"problem,omitempty"`
+
+	// Minimize and Maximize are objectives over the variables of
+	// Problem, as expressions in the JSON format.
+	Minimize []json.RawMessage `json:"minimize,omitempty"`
+	Maximize []json.RawMessage `json:"maximize,omitempty"`
+
+	// TimeoutMS limits the solving time in milliseconds. If it is
+	// 0, the Server's DefaultTimeoutMS applies.
+	TimeoutMS uint `json:"timeout_ms,omitempty"`
+}
+
+// A State is the state of a job.
+type State string
+
+const (
+	Queued   State = "queued"
+	Running  State = "running"
+	Sat      State = "sat"
+	Unsat    State = "unsat"
+	Unknown  State = "unknown" // Z3 gave up, for example on a timeout
+	Failed   State = "error"   // The request was invalid
+	Canceled State = "canceled"
+)
+
+// Done reports whether s is a final state.
+func (s State) Done() bool {
+	return s != Queued && s != Running
+}
+
+// A Status describes a job.
+type Status struct {
+	ID    string `json:"id"`
+	State State  `json:"state"`
+
+	// Error is the reason for an Unknown or Failed state.
+	Error string `json:"error,omitempty"`
+
+	// Model gives the values of the variables of a Sat JSON
+	// problem, as JSON literals.
+	Model map[string]json.RawMessage `json:"model,omitempty"`
+
+	// ModelText is the model of a Sat problem as printed by Z3.
+	ModelText string `json:"model_text,omitempty"`
+
+	// Objectives gives the optimal values of the objectives of a Sat
+	// JSON problem, in the order of Minimize followed by Maximize.
+	// An objective that is not a literal, such as an unbounded one,
+	// is given as a JSON string of its SMT-LIB form.
+	Objectives []json.RawMessage `json:"objectives,omitempty"`
+}
+
+const (
+	// maxQueued is the number of jobs that may wait for a worker
+	// before the Server rejects new ones.
+	maxQueued = 1024
+
+	// maxFinished is the number of finished jobs a Server keeps.
+	// Once it is exceeded, the oldest are forgotten.
+	maxFinished = 1024
+
+	defaultMaxRequestBytes = 8 << 20
+	defaultFinishedTTL     = 10 * time.Minute
+)
+
+// A Server solves submitted problems on a pool of workers. It
+// implements http.Handler.
+//
+// Finished jobs are kept until they are deleted, until they are older
+// than FinishedTTL, or until more than 1024 other jobs have finished
+// since, whichever comes first. Clients should delete jobs whose
+// results they have fetched.
+type Server struct {
+	// DefaultTimeoutMS is the timeout for requests that do not
+	// set one. 0 means no timeout.
+	DefaultTimeoutMS uint
+
+	// MaxRequestBytes limits the size of a submitted Request. If it
+	// is 0, the limit is 8 MiB.
+	MaxRequestBytes int64
+
+	// FinishedTTL is how long a finished job is kept. If it is 0,
+	// finished jobs are kept for 10 minutes.
+	FinishedTTL time.Duration
+
+	mux   *http.ServeMux
+	queue chan *job
+	wg    sync.WaitGroup
+
+	mu       sync.Mutex
+	jobs     map[string]*job
+	finished []*job // In the order they finished
+	nextID   int
+	closed   bool
+}
+
+type job struct {
+	id       string
+	req      Request
+	finished time.Time // Guarded by Server.mu
+
+	mu       sync.Mutex
+	status   Status
+	ctx      *z3.Context   // While running
+	changed  chan struct{} // Closed and replaced on each update
+	canceled bool
+}
+
+// NewServer returns a Server that solves up to workers problems at a
+// time.
+func NewServer(workers int) *Server {
+	if workers <= 0 {
+		panic("remote: NewServer with no workers")
+	}
+	s := &Server{
+		mux:   http.NewServeMux(),
+		queue: make(chan *job, maxQueued),
+		jobs:  make(map[string]*job),
+	}
+	s.mux.HandleFunc("POST /jobs", s.submit)
+	s.mux.HandleFunc("GET /jobs/{id}", s.get)
+	s.mux.HandleFunc("GET /jobs/{id}/events", s.events)
+	s.mux.HandleFunc("DELETE /jobs/{id}", s.delete)
+	for i := 0; i < workers; i++ {
+		s.wg.Add(1)
+		go s.worker()
+	}
+	return s
+}
+
+// Close cancels all jobs and stops the workers.
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	for _, j := range s.jobs {
+		j.cancel()
+	}
+	close(s.queue)
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
+	limit := s.MaxRequestBytes
+	if limit == 0 {
+		limit = defaultMaxRequestBytes
+	}
+	var req Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit)).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if (req.SMTLIB == "") == (req.Problem == nil) {
+		http.Error(w, "bad request: need exactly one of smtlib and problem", http.StatusBadRequest)
+		return
+	}
+	if req.SMTLIB != "" && (req.Minimize != nil || req.Maximize != nil) {
+		http.Error(w, "bad request: objectives of an smtlib request belong in the script", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		http.Error(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+	s.prune(time.Now())
+	s.nextID++
+	id := strconv.Itoa(s.nextID)
+	j := &job{id: id, req: req, status: Status{ID: id, State: Queued}, changed: make(chan struct{})}
+	select {
+	case s.queue <- j:
+		s.jobs[id] = j
+	default:
+		j = nil
+	}
+	s.mu.Unlock()
+	if j == nil {
+		http.Error(w, "too many queued jobs", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, j.get())
+}
+
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) *job {
+	s.mu.Lock()
+	s.prune(time.Now())
+	j := s.jobs[r.PathValue("id")]
+	s.mu.Unlock()
+	if j == nil {
+		http.NotFound(w, r)
+	}
+	return j
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	if j := s.lookup(w, r); j != nil {
+		writeJSON(w, j.get())
+	}
+}
+
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	j := s.lookup(w, r)
+	if j == nil {
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher, _ := w.(http.Flusher)
+	for {
+		j.mu.Lock()
+		st, changed := j.status, j.changed
+		j.mu.Unlock()
+		writeJSON(w, st)
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if st.State.Done() {
+			return
+		}
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request) {
+	j := s.lookup(w, r)
+	if j == nil {
+		return
+	}
+	if j.get().State.Done() {
+		s.mu.Lock()
+		delete(s.jobs, r.PathValue("id"))
+		s.mu.Unlock()
+	} else {
+		j.cancel()
+	}
+	writeJSON(w, j.get())
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	json.NewEncoder(w).Encode(v)
+}
+
+func (j *job) get() Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// update sets j's status and wakes up event streams. j.mu must be held.
+func (j *job) update(st Status) {
+	j.status = st
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// cancel stops j if it is queued or running.
+func (j *job) cancel() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status.State.Done() {
+		return
+	}
+	j.canceled = true
+	if j.ctx != nil {
+		j.ctx.Interrupt()
+	} else {
+		j.update(Status{ID: j.status.ID, State: Canceled})
+	}
+}
+
+// finish records that j is done, so that it is forgotten in time.
+func (s *Server) finish(j *job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j.finished = time.Now()
+	s.finished = append(s.finished, j)
+	s.prune(j.finished)
+}
+
+// prune forgets finished jobs that are too old or too many. s.mu must
+// be held.
+func (s *Server) prune(now time.Time) {
+	ttl := s.FinishedTTL
+	if ttl == 0 {
+		ttl = defaultFinishedTTL
+	}
+	n := 0
+	for n < len(s.finished) && (len(s.finished)-n > maxFinished || now.Sub(s.finished[n].finished) > ttl) {
+		j := s.finished[n]
+		// The job may already have been deleted.
+		if s.jobs[j.id] == j {
+			delete(s.jobs, j.id)
+		}
+		s.finished[n] = nil
+		n++
+	}
+	s.finished = s.finished[n:]
+}
+
+func (s *Server) worker() {
+	defer s.wg.Done()
+	for j := range s.queue {
+		s.run(j)
+		s.finish(j)
+	}
+}
+
+// run solves j. A panic while solving is reported as a Failed status,
+// so that one bad job does not stop the Server.
+func (s *Server) run(j *job) {
+	defer func() {
+		if r := recover(); r != nil {
+			j.mu.Lock()
+			defer j.mu.Unlock()
+			j.ctx = nil
+			j.update(Status{ID: j.id, State: Failed, Error: fmt.Sprintf("internal error: %v", r)})
+		}
+	}()
+	cfg := z3.NewContextConfig()
+	timeout := j.req.TimeoutMS
+	if timeout == 0 {
+		timeout = s.DefaultTimeoutMS
+	}
+	if timeout != 0 {
+		cfg.SetUint("timeout", timeout)
+	}
+	ctx := z3.NewContext(cfg)
+	defer func() {
+		// Forget ctx before closing it, so that cancel does not
+		// interrupt a deleted context.
+		j.mu.Lock()
+		j.ctx = nil
+		j.mu.Unlock()
+		ctx.Close()
+	}()
+
+	j.mu.Lock()
+	if j.canceled {
+		j.mu.Unlock()
+		return
+	}
+	j.ctx = ctx
+	j.update(Status{ID: j.status.ID, State: Running})
+	j.mu.Unlock()
+
+	st := solveFunc(ctx, &j.req)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.ctx = nil
+	st.ID = j.status.ID
+	if j.canceled && st.State != Sat && st.State != Unsat {
+		st = Status{ID: st.ID, State: Canceled}
+	}
+	j.update(st)
+}
+
+// solveFunc is solve, replaced by tests.
+var solveFunc = solve
+
+// solve solves req in ctx and returns its status, without the ID.
+func solve(ctx *z3.Context, req *Request) (st Status) {
+	fail := func(err error) Status {
+		var unknown *z3.ErrSatUnknown
+		if errors.As(err, &unknown) {
+			return Status{State: Unknown, Error: err.Error()}
+		}
+		return Status{State: Failed, Error: err.Error()}
+	}
+
+	if req.SMTLIB != "" {
+		o := z3.NewOptimize(ctx)
+		defer o.Close()
+		if err := ctx.Catch(func() { o.FromString(req.SMTLIB) }); err != nil {
+			return fail(err)
+		}
+		sat, err := o.Check()
+		if err != nil {
+			return fail(err)
+		}
+		if !sat {
+			return Status{State: Unsat}
+		}
+		return Status{State: Sat, ModelText: o.Model().String()}
+	}
+
+	p, err := ctx.UnmarshalProblem(req.Problem)
+	if err != nil {
+		return fail(err)
+	}
+	// Report the model by the names the client used.
+	var names struct {
+		Vars []struct{ Name string }
+	}
+	if err := json.Unmarshal(req.Problem, &names); err != nil {
+		return fail(err)
+	}
+	if len(names.Vars) != len(p.Vars) {
+		return fail(fmt.Errorf("problem has %d variable names for %d variables", len(names.Vars), len(p.Vars)))
+	}
+	var objs []z3.Value
+	for _, data := range append(append([]json.RawMessage(nil), req.Minimize...), req.Maximize...) {
+		v, err := ctx.UnmarshalJSONValue(data, p.Vars)
+		if err != nil {
+			return fail(fmt.Errorf("objective: %v", err))
+		}
+		objs = append(objs, v)
+	}
+
+	var sat bool
+	var model func() *z3.Model
+	var results []*z3.Objective
+	if len(objs) == 0 {
+		solver := z3.NewSolver(ctx)
+		defer solver.Close()
+		solver.AssertAll(p.Constraints)
+		sat, err = solver.Check()
+		model = solver.Model
+	} else {
+		o := z3.NewOptimize(ctx)
+		defer o.Close()
+		o.AssertAll(p.Constraints)
+		err = ctx.Catch(func() {
+			for i, v := range objs {
+				if i < len(req.Minimize) {
+					results = append(results, o.Minimize(v))
+				} else {
+					results = append(results, o.Maximize(v))
+				}
+			}
+		})
+		if err == nil {
+			sat, err = o.Check()
+		}
+		model = o.Model
+	}
+	if err != nil {
+		return fail(err)
+	}
+	if !sat {
+		return Status{State: Unsat}
+	}
+
+	m := model()
+	st = Status{State: Sat, ModelText: m.String(), Model: make(map[string]json.RawMessage)}
+	for i, v := range p.Vars {
+		data, err := z3.MarshalJSONValue(m.Eval(v, true))
+		if err != nil {
+			return fail(err)
+		}
+		st.Model[names.Vars[i].Name] = data
+	}
+	for _, obj := range results {
+		val := obj.Lower()
+		data, err := z3.MarshalJSONValue(val)
+		if err != nil {
+			data, _ = json.Marshal(val.String())
+		}
+		st.Objectives = append(st.Objectives, data)
+	}
+	return st
+}
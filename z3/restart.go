@@ -0,0 +1,81 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// CheckRestarts is like Check, but gives up on an unlucky search and
+// starts over. It runs Check in time slices of unit times the Luby
+// sequence (1, 1, 2, 1, 1, 2, 4, 1, ...), changing the random seed
+// for each slice, until one returns sat or unsat or the total time
+// reaches budget. Hard instances often have a few lucky seeds that
+// finish far sooner than the typical one; the Luby schedule finds
+// them without knowing in advance how long a good run takes.
+//
+// If configs are given, they are applied in rotation, one per slice,
+// before the seed, so the restarts can also vary the strategy. They
+// should have been created with NewSolverConfig. Each slice sets the
+// solver's timeout and random seed parameters; when CheckRestarts
+// returns, the timeout is removed, but other parameters keep the
+// values of the last slice.
+//
+// CheckRestarts returns the result of the last Check and the number of
+// slices it ran. If every slice ran out of time, or unit or budget is
+// too short for even one slice of a millisecond, the error is an
+// *ErrSatUnknown. Other errors, such as running out of memory, end the
+// restarts immediately.
+func (s *Solver) CheckRestarts(unit, budget time.Duration, configs ...*Config) (sat bool, slices int, err error) {
+	for _, cfg := range configs {
+		if err := cfg.Err(); err != nil {
+			return false, 0, err
+		}
+	}
+	defer s.SetParams(NewSolverConfig(s.ctx).SetUint("timeout", math.MaxUint32))
+	start := time.Now()
+	for i := 1; ; i++ {
+		slice := unit * time.Duration(luby(i))
+		if remaining := budget - time.Since(start); slice > remaining {
+			slice = remaining
+		}
+		if slice < time.Millisecond {
+			if err == nil {
+				// No slice fit in the budget.
+				err = &ErrSatUnknown{Reason: "budget exhausted"}
+			}
+			return false, slices, err
+		}
+		if len(configs) > 0 {
+			s.SetParams(configs[(i-1)%len(configs)])
+		}
+		s.SetParams(NewSolverConfig(s.ctx).
+			SetUint("timeout", uint(slice/time.Millisecond)).
+			SetRandomSeed(uint(i)))
+		slices++
+		sat, err = s.Check()
+		var unknown *ErrSatUnknown
+		if !errors.As(err, &unknown) {
+			return sat, slices, err
+		}
+	}
+}
+
+// luby returns the i'th element, counting from 1, of the Luby
+// sequence 1, 1, 2, 1, 1, 2, 4, 1, 1, 2, ...
+func luby(i int) int {
+	// If i = 2^k - 1, the element is 2^(k-1). Otherwise the
+	// sequence repeats from the start after the last such i.
+	for k := 1; ; k++ {
+		if i == 1<<k-1 {
+			return 1 << (k - 1)
+		}
+		if i < 1<<k-1 {
+			return luby(i - (1<<(k-1) - 1))
+		}
+	}
+}
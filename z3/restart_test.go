@@ -0,0 +1,71 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLuby(t *testing.T) {
+	var got []int
+	for i := 1; i <= 15; i++ {
+		got = append(got, luby(i))
+	}
+	if want := "[1 1 2 1 1 2 4 1 1 2 1 1 2 4 8]"; fmt.Sprint(got) != want {
+		t.Errorf("got %v, want %s", got, want)
+	}
+}
+
+func TestCheckRestarts(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	x := ctx.IntConst("x")
+	s.Assert(x.GT(ctx.Int(3)))
+	sat, slices, err := s.CheckRestarts(100*time.Millisecond, time.Second)
+	if !sat || slices != 1 || err != nil {
+		t.Errorf("easy problem: got %v, %d slices, %v", sat, slices, err)
+	}
+
+	// A pigeonhole problem too hard for the budget.
+	hard := NewSolver(ctx)
+	pigeonhole(ctx, hard, 11)
+	start := time.Now()
+	sat, slices, err = hard.CheckRestarts(5*time.Millisecond, 100*time.Millisecond,
+		NewSolverConfig(ctx).SetUint("smt.phase_selection", 0),
+		NewSolverConfig(ctx).SetUint("smt.phase_selection", 1))
+	var unknown *ErrSatUnknown
+	if sat || !errors.As(err, &unknown) {
+		t.Errorf("hard problem: got %v, %v; want unknown", sat, err)
+	}
+	if slices < 3 {
+		t.Errorf("hard problem: got %d slices, want several", slices)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("hard problem took %v, over budget", d)
+	}
+
+	// The timeout is removed afterwards.
+	hard.Reset()
+	hard.Assert(x.LT(ctx.Int(0)))
+	if sat, err := hard.Check(); !sat || err != nil {
+		t.Errorf("after restarts: got %v, %v", sat, err)
+	}
+
+	// A budget too short for a single slice is unknown, not unsat.
+	for _, d := range [][2]time.Duration{{time.Microsecond, time.Second}, {time.Millisecond, 500 * time.Microsecond}} {
+		sat, slices, err := s.CheckRestarts(d[0], d[1])
+		if sat || slices != 0 || !errors.As(err, &unknown) {
+			t.Errorf("unit %v, budget %v: got %v, %d slices, %v; want unknown", d[0], d[1], sat, slices, err)
+		}
+	}
+
+	bad := NewSolverConfig(ctx).SetUint("no_such_param", 1)
+	if _, _, err := s.CheckRestarts(time.Millisecond, time.Second, bad); err == nil {
+		t.Errorf("bad config succeeded")
+	}
+}
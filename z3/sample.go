@@ -0,0 +1,55 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// SampleDiverse returns up to k solutions to asserts that are spread
+// out over vars, for generating varied test inputs rather than the
+// near-identical models that repeated Checks tend to produce. Each
+// solution gives the values of vars, in order.
+//
+// The distance between two solutions is the number of vars on which
+// they differ. SampleDiverse picks solutions greedily: each one is
+// found by an Optimize that maximizes its smallest distance to the
+// solutions picked before it, and differs from all of them. The
+// result is not necessarily the k solutions with the largest
+// pairwise distances, but it avoids clusters of similar ones.
+//
+// If asserts has fewer than k solutions over vars, SampleDiverse
+// returns all of them. If a Check fails, it returns the solutions
+// found so far and the error.
+func (ctx *Context) SampleDiverse(asserts []Bool, vars []Value, k int) ([][]Value, error) {
+	var sols [][]Value
+	zero, one := ctx.Int(0), ctx.Int(1)
+	for len(sols) < k {
+		o := NewOptimize(ctx)
+		o.AssertAll(asserts)
+		if len(sols) > 0 {
+			d := ctx.FreshConst("distance", ctx.IntSort()).(Int)
+			for _, sol := range sols {
+				dist := zero
+				for i, v := range vars {
+					dist = dist.Add(ctx.Distinct(v, sol[i]).IfThenElse(one, zero).(Int))
+				}
+				o.Assert(d.LE(dist))
+			}
+			o.Assert(d.GE(one))
+			o.Maximize(d)
+		}
+		sat, err := o.Check()
+		if err != nil || !sat {
+			o.Close()
+			return sols, err
+		}
+		m := o.Model()
+		sol := make([]Value, len(vars))
+		for i, v := range vars {
+			sol[i] = m.Eval(v, true)
+		}
+		m.Close()
+		o.Close()
+		sols = append(sols, sol)
+	}
+	return sols, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSampleDiverse(t *testing.T) {
+	ctx := NewContext(nil)
+	var vars []Value
+	for i := 0; i < 4; i++ {
+		vars = append(vars, ctx.FreshConst("b", ctx.BoolSort()))
+	}
+	sols, err := ctx.SampleDiverse(nil, vars, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sols) != 3 {
+		t.Fatalf("got %d solutions, want 3", len(sols))
+	}
+	dist := func(a, b []Value) int {
+		n := 0
+		for i := range a {
+			if a[i].String() != b[i].String() {
+				n++
+			}
+		}
+		return n
+	}
+	// The second solution is the complement of the first, and the
+	// third is halfway between them.
+	if d := dist(sols[0], sols[1]); d != 4 {
+		t.Errorf("distance between first two solutions is %d, want 4", d)
+	}
+	if d0, d1 := dist(sols[0], sols[2]), dist(sols[1], sols[2]); d0 != 2 || d1 != 2 {
+		t.Errorf("distances to third solution are %d and %d, want 2 and 2", d0, d1)
+	}
+
+	// With fewer than k solutions, all of them are returned.
+	x := ctx.IntConst("x")
+	asserts := []Bool{x.GE(ctx.Int(0)), x.LE(ctx.Int(1))}
+	sols, err = ctx.SampleDiverse(asserts, []Value{x}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sols) != 2 || sols[0][0].String() == sols[1][0].String() {
+		t.Errorf("got %v, want both of 0 and 1", sols)
+	}
+}
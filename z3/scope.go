@@ -0,0 +1,101 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+import "runtime"
+
+// A Scope tracks the Values and ASTs created while it is active so
+// that they can be released in a single operation when it ends.
+//
+// Normally, every Value has its own finalizer that releases its Z3
+// reference once the garbage collector notices it is unreachable.
+// When building millions of short-lived terms, running these
+// finalizers can dominate run time. Values created in a Scope get no
+// finalizer. Instead, they are all released when the Scope ends.
+//
+// Values that must outlive the Scope must be passed to Keep.
+type Scope struct {
+	ctx  *Context
+	asts []*astImpl
+	kept map[*astImpl]bool
+	prev *Scope
+}
+
+// Scope calls f with a new Scope that is active for the duration of
+// f. When f returns (or panics), all Values and ASTs created in ctx
+// during f are released, except those passed to Scope.Keep. Using a
+// released Value has undefined results.
+//
+// Scopes may be nested. While f runs, Values created by other
+// goroutines using ctx are also added to the Scope, so ctx should
+// not be shared with other goroutines during f.
+//
+// Sorts and FuncDecls are not tracked by Scope.
+func (ctx *Context) Scope(f func(s *Scope)) {
+	var s *Scope
+	ctx.do(func() {
+		s = &Scope{ctx: ctx, prev: ctx.scope}
+		ctx.scope = s
+	})
+	defer s.end()
+	f(s)
+}
+
+// Keep exempts v from release when s ends, so v remains valid
+// afterwards. If s is nested in another Scope, v is instead released
+// when the outer Scope ends.
+func (s *Scope) Keep(v Value) {
+	s.KeepAST(v.AsAST())
+}
+
+// KeepAST is like Keep, but for an AST.
+func (s *Scope) KeepAST(ast AST) {
+	s.ctx.do(func() {
+		if s.kept == nil {
+			s.kept = make(map[*astImpl]bool)
+		}
+		s.kept[ast.astImpl] = true
+	})
+}
+
+// end releases the ASTs tracked by s and deactivates it.
+func (s *Scope) end() {
+	s.ctx.release(func() {
+		for _, impl := range s.asts {
+			if !s.kept[impl] {
+				C.Z3_dec_ref(s.ctx.c, impl.c)
+			} else if s.prev != nil {
+				s.prev.asts = append(s.prev.asts, impl)
+			} else {
+				runtime.SetFinalizer(impl, finalizeAST)
+			}
+		}
+	})
+	s.ctx.lock.Lock()
+	s.ctx.scope = s.prev
+	s.ctx.lock.Unlock()
+	s.asts, s.kept = nil, nil
+}
+
+// withoutScope calls f with no active Scope, so that Values created by
+// f are finalized normally. This is used for Values cached in ctx.
+func (ctx *Context) withoutScope(f func()) {
+	ctx.lock.Lock()
+	scope := ctx.scope
+	ctx.scope = nil
+	ctx.lock.Unlock()
+	defer func() {
+		ctx.lock.Lock()
+		ctx.scope = scope
+		ctx.lock.Unlock()
+	}()
+	f()
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestScope(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+
+	var sum Int
+	ctx.Scope(func(s *Scope) {
+		sum = x
+		for i := 1; i <= 100; i++ {
+			sum = sum.Add(ctx.Int(i))
+		}
+		if n := len(s.asts); n < 200 {
+			t.Errorf("expected at least 200 tracked ASTs, got %d", n)
+		}
+		s.Keep(sum)
+	})
+	if ctx.scope != nil {
+		t.Fatal("scope still active")
+	}
+	runtime.GC()
+
+	solver := NewSolver(ctx)
+	solver.Assert(sum.Eq(ctx.Int(5050)))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	if v, _, _ := solver.Model().EvalAsInt64(x, true); v != 0 {
+		t.Errorf("expected x = 0, got %d", v)
+	}
+}
+
+func TestScopeNested(t *testing.T) {
+	ctx := NewContext(nil)
+	var kept Int
+	ctx.Scope(func(outer *Scope) {
+		ctx.Scope(func(inner *Scope) {
+			kept = ctx.IntConst("y").Mul(ctx.Int(2))
+			inner.Keep(kept)
+		})
+		found := false
+		for _, impl := range outer.asts {
+			if impl == kept.AsAST().astImpl {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("value kept by inner scope not moved to outer scope")
+		}
+		outer.Keep(kept)
+	})
+	if s := kept.String(); s != "(* y 2)" {
+		t.Errorf("expected (* y 2), got %s", s)
+	}
+}
+
+func TestScopeRoundingMode(t *testing.T) {
+	ctx := NewContext(nil)
+	fs := ctx.FloatSort(8, 24)
+	ctx.Scope(func(s *Scope) {
+		ctx.FromFloat64(1, fs).Add(ctx.FromFloat64(2, fs))
+	})
+	runtime.GC()
+	// The cached rounding mode must survive the scope.
+	sum := ctx.FromFloat64(1, fs).Add(ctx.FromFloat64(2, fs))
+	solver := NewSolver(ctx)
+	solver.Assert(sum.Eq(ctx.FromFloat64(3, fs)).Not())
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT")
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// SetRandomSeed sets the seeds of the random number generators that a
+// Solver uses: those of the SMT core, and of the SAT and nonlinear
+// arithmetic solvers it may delegate to. p should have been created
+// with NewSolverConfig. The default seed is 0.
+//
+// Different seeds make Z3 explore the search space in a different
+// order, which can change its running time drastically, and which
+// model it finds.
+func (p *Config) SetRandomSeed(seed uint) *Config {
+	return p.SetUint("random_seed", seed).
+		SetUint("sat.random_seed", seed).
+		SetUint("nlsat.seed", seed)
+}
+
+// SetRandomSeed sets the seeds of the random number generators s uses.
+// See Config.SetRandomSeed.
+func (s *Solver) SetRandomSeed(seed uint) {
+	s.SetParams(NewSolverConfig(s.ctx).SetRandomSeed(seed))
+}
+
+// seedParams are the process-wide parameters that seed Z3's random
+// number generators.
+var seedParams = []string{
+	"smt.random_seed",
+	"sat.random_seed",
+	"nlsat.seed",
+	"sls.random_seed",
+	"fp.spacer.random_seed",
+}
+
+// SetReproducible pins the seeds of all of Z3's random number
+// generators to seed and disables parallel solving, so that repeated
+// runs of the same problem make the same choices and return the same
+// models. This makes benchmark comparisons meaningful and lets flaky
+// tests be replayed.
+//
+// Like SetParallel, this applies to the whole process, and to Solvers
+// and Optimizes created after the call; parameters set on a Solver
+// with SetParams or SetRandomSeed override it. Runs are only
+// reproducible with the same Z3 version and the same sequence of API
+// calls. Time limits are inherently nondeterministic, so use the
+// rlimit parameter instead of timeout to bound reproducible runs.
+func SetReproducible(seed uint) {
+	for _, name := range seedParams {
+		setGlobalParam(name, seed)
+	}
+	SetParallel(false, 0)
+	setGlobalParam("sat.threads", 1)
+	setGlobalParam("smt.threads", 1)
+}
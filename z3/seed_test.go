@@ -0,0 +1,45 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSetRandomSeed(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.RealConst("x"), ctx.RealConst("y")
+	for seed := uint(0); seed < 3; seed++ {
+		s := NewSolver(ctx)
+		s.SetRandomSeed(seed)
+		s.Assert(x.Mul(x).Add(y.Mul(y)).Eq(ctx.FromInt(2, ctx.RealSort()).(Real)))
+		s.Assert(x.GT(y))
+		if sat, err := s.Check(); !sat || err != nil {
+			t.Errorf("seed %d: got %v, %v; want sat", seed, sat, err)
+		}
+	}
+	if err := NewSolverConfig(ctx).SetRandomSeed(1).Err(); err != nil {
+		t.Errorf("Config.SetRandomSeed: %v", err)
+	}
+}
+
+func TestSetReproducible(t *testing.T) {
+	SetReproducible(42)
+	defer SetReproducible(0)
+
+	solve := func() string {
+		ctx := NewContext(nil)
+		defer ctx.Close()
+		s := NewSolver(ctx)
+		a, b, c := ctx.IntConst("a"), ctx.IntConst("b"), ctx.IntConst("c")
+		s.Assert(a.Add(b, c).Eq(ctx.Int(100)))
+		s.Assert(a.GT(b).And(b.GT(c), c.GT(ctx.Int(0))))
+		if sat, err := s.Check(); !sat || err != nil {
+			t.Fatalf("got %v, %v; want sat", sat, err)
+		}
+		return s.Model().String()
+	}
+	if m1, m2 := solve(), solve(); m1 != m2 {
+		t.Errorf("models differ:\n%s\n%s", m1, m2)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+import "runtime"
+
+// Seq is a symbolic value representing a sequence of elements of an
+// arbitrary sort.
+//
+// Sequences of characters are represented by the more specific String
+// type. All other sequence sorts produce Seq values.
+//
+// Seq implements Value.
+type Seq value
+
+func init() {
+	kindWrappers[KindSeq] = func(x value) Value {
+		if x.Sort().IsStringSort() {
+			return String(x)
+		}
+		return Seq(x)
+	}
+}
+
+// SeqConst returns a sequence constant named "name" whose elements
+// have sort elem.
+func (ctx *Context) SeqConst(name string, elem Sort) Seq {
+	return ctx.Const(name, ctx.SeqSort(elem)).(Seq)
+}
+
+// EmptySeqOf returns the empty sequence whose elements have sort
+// elem. Unlike EmptySeq, the result is a Seq, so elem should not be the
+// character sort; use EmptySeq or FromString for strings.
+func (ctx *Context) EmptySeqOf(elem Sort) Seq {
+	s := ctx.SeqSort(elem)
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_empty(ctx.c, s.c)
+	})
+	runtime.KeepAlive(s)
+	return Seq(val)
+}
+
+// SeqUnitOf returns a unit sequence containing the single element
+// elem. Unlike SeqUnit, the result is a Seq, so elem should not be a
+// character.
+func (ctx *Context) SeqUnitOf(elem Value) Seq {
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_unit(ctx.c, elem.impl().c)
+	})
+	runtime.KeepAlive(elem)
+	return Seq(val)
+}
+
+// FromValues returns the sequence of the given elements. All elements
+// must have sort elem. If vals is empty, the result is the empty
+// sequence over elem.
+func (ctx *Context) FromValues(elem Sort, vals ...Value) Seq {
+	if len(vals) == 0 {
+		return ctx.EmptySeqOf(elem)
+	}
+	units := make([]Seq, len(vals))
+	for i, v := range vals {
+		units[i] = ctx.SeqUnitOf(v)
+	}
+	if len(units) == 1 {
+		return units[0]
+	}
+	return units[0].Concat(units[1:]...)
+}
+
+// ElemSort returns the sort of l's elements.
+func (l Seq) ElemSort() Sort {
+	return l.Sort().SeqSortBasis()
+}
+
+// AsValues returns the elements of lit. lit must be a sequence literal
+// built from EmptySeqOf, SeqUnitOf and Concat, such as a sequence
+// evaluated by a Model with completion. If lit is not such a literal,
+// it returns nil, false.
+func (lit Seq) AsValues() (vals []Value, isLiteral bool) {
+	vals, isLiteral = lit.appendElems(nil)
+	if !isLiteral {
+		return nil, false
+	}
+	return vals, true
+}
+
+// MapIndexed returns the sequence of f[start+i, l[i]] for each
+// position i in l. f must have sort [Int, elem -> range], where elem is
+// l's element sort, and is typically built with Lambda. The result is
+// a Seq over range, or a String if range is the character sort.
+//
+// Position-dependent transformations, such as weighting each element
+// by its index for a checksum, can be expressed this way without
+// quantifiers or unrolling.
+func (l Seq) MapIndexed(f Array, start Int) Value {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_mapi(ctx.c, f.c, start.c, l.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(start)
+	return val.lift(KindUnknown)
+}
+
+// MapIndexedFunc is like MapIndexed, but builds the function from f.
+// f is called once with fresh symbolic index and element constants
+// and must return a Value built from them.
+func (l Seq) MapIndexedFunc(start Int, f func(i Int, x Value) Value) Value {
+	ctx := l.ctx
+	i := ctx.FreshConst("i", ctx.IntSort())
+	x := ctx.FreshConst("x", l.ElemSort())
+	return l.MapIndexed(ctx.Lambda([]Value{i, x}, f(i.(Int), x)), start)
+}
+
+func (expr *valueImpl) appendElems(vals []Value) ([]Value, bool) {
+	kind, args, ok := expr.appArgs()
+	if !ok {
+		return vals, false
+	}
+	switch kind {
+	case C.Z3_OP_SEQ_EMPTY:
+		return vals, true
+	case C.Z3_OP_SEQ_UNIT:
+		return append(vals, args[0]), true
+	case C.Z3_OP_SEQ_CONCAT:
+		for _, arg := range args {
+			if vals, ok = arg.impl().appendElems(vals); !ok {
+				return vals, false
+			}
+		}
+		return vals, true
+	}
+	return vals, false
+}
+
+//go:generate go run genwrap.go -t Seq -e $GOFILE
+
+// Concat returns the concatenation of l and r[0], r[1], ...
+//
+//wrap:expr Concat Z3_mk_seq_concat l r...
+
+// Length returns the number of elements in l.
+//
+//wrap:expr Length:Int Z3_mk_seq_length l
+
+// Contains returns true if l contains sub as a contiguous
+// subsequence.
+//
+//wrap:expr Contains:Bool Z3_mk_seq_contains l sub
+
+// PrefixOf returns true if l is a prefix of s.
+//
+//wrap:expr PrefixOf:Bool Z3_mk_seq_prefix l s
+
+// SuffixOf returns true if l is a suffix of s.
+//
+//wrap:expr SuffixOf:Bool Z3_mk_seq_suffix l s
+
+// Extract returns the subsequence of l starting at offset with the
+// given length.
+//
+//wrap:expr Extract l offset:Int length:Int : Z3_mk_seq_extract l offset length
+
+// At returns the unit sequence at position index in l. The sequence
+// is empty if index is out of bounds.
+//
+//wrap:expr At l index:Int : Z3_mk_seq_at l index
+
+// Nth returns the element at position index in l. The result is
+// unspecified if index is out of bounds.
+//
+//wrap:expr Nth:Value l index:Int : Z3_mk_seq_nth l index
+
+// IndexOf returns the index of the first occurrence of sub in l
+// starting from offset, or -1 if there is no such occurrence.
+//
+//wrap:expr IndexOf:Int l sub offset:Int : Z3_mk_seq_index l sub offset
+
+// LastIndexOf returns the index of the last occurrence of sub in l,
+// or -1 if there is no such occurrence.
+//
+//wrap:expr LastIndexOf:Int l sub : Z3_mk_seq_last_index l sub
+
+// Replace returns l with the first occurrence of src replaced by dst.
+//
+//wrap:expr Replace l src dst : Z3_mk_seq_replace l src dst
+
+// ToRE converts l to a regular expression that matches exactly l.
+//
+//wrap:expr ToRE:RE Z3_mk_seq_to_re l
+
+// InRE returns true if l is in the language of regular expression re.
+//
+//wrap:expr InRE:Bool l re:RE : Z3_mk_seq_in_re l re
@@ -0,0 +1,338 @@
+// Generated by genwrap.go. DO NOT EDIT
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// Eq returns a Value that is true if l and r are equal.
+func (l Seq) Eq(r Seq) Bool {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+	}, l.c, r.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// EqE is like Eq, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) EqE(r Seq) (res Bool, err error) {
+	chk := argChecker{method: "Seq.Eq"}
+	chk.value("l", l)
+	chk.value("r", r)
+	err = chk.do(func() { res = l.Eq(r) })
+	return
+}
+
+// NE returns a Value that is true if l and r are not equal.
+func (l Seq) NE(r Seq) Bool {
+	return l.ctx.Distinct(l, r)
+}
+
+// Concat returns the concatenation of l and r[0], r[1], ...
+func (l Seq) Concat(r ...Seq) Seq {
+	// Generated from seq.go:150.
+	ctx := l.ctx
+	cargs := make([]C.Z3_ast, len(r)+1)
+	cargs[0] = l.c
+	for i, arg := range r {
+		cargs[i+1] = arg.c
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_concat(ctx.c, C.uint(len(cargs)), &cargs[0])
+	}, cargs...)
+	runtime.KeepAlive(&cargs[0])
+	return Seq(val)
+}
+
+// ConcatE is like Concat, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) ConcatE(r ...Seq) (res Seq, err error) {
+	chk := argChecker{method: "Seq.Concat"}
+	chk.value("l", l)
+	for i, v := range r {
+		chk.elem("r", i, v)
+	}
+	err = chk.do(func() { res = l.Concat(r...) })
+	return
+}
+
+// Length returns the number of elements in l.
+func (l Seq) Length() Int {
+	// Generated from seq.go:154.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_length(ctx.c, l.c)
+	}, l.c)
+	runtime.KeepAlive(l)
+	return Int(val)
+}
+
+// LengthE is like Length, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) LengthE() (res Int, err error) {
+	chk := argChecker{method: "Seq.Length"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.Length() })
+	return
+}
+
+// Contains returns true if l contains sub as a contiguous
+// subsequence.
+func (l Seq) Contains(sub Seq) Bool {
+	// Generated from seq.go:159.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_contains(ctx.c, l.c, sub.c)
+	}, l.c, sub.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(sub)
+	return Bool(val)
+}
+
+// ContainsE is like Contains, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) ContainsE(sub Seq) (res Bool, err error) {
+	chk := argChecker{method: "Seq.Contains"}
+	chk.value("l", l)
+	chk.value("sub", sub)
+	err = chk.do(func() { res = l.Contains(sub) })
+	return
+}
+
+// PrefixOf returns true if l is a prefix of s.
+func (l Seq) PrefixOf(s Seq) Bool {
+	// Generated from seq.go:163.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_prefix(ctx.c, l.c, s.c)
+	}, l.c, s.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(s)
+	return Bool(val)
+}
+
+// PrefixOfE is like PrefixOf, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) PrefixOfE(s Seq) (res Bool, err error) {
+	chk := argChecker{method: "Seq.PrefixOf"}
+	chk.value("l", l)
+	chk.value("s", s)
+	err = chk.do(func() { res = l.PrefixOf(s) })
+	return
+}
+
+// SuffixOf returns true if l is a suffix of s.
+func (l Seq) SuffixOf(s Seq) Bool {
+	// Generated from seq.go:167.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_suffix(ctx.c, l.c, s.c)
+	}, l.c, s.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(s)
+	return Bool(val)
+}
+
+// SuffixOfE is like SuffixOf, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) SuffixOfE(s Seq) (res Bool, err error) {
+	chk := argChecker{method: "Seq.SuffixOf"}
+	chk.value("l", l)
+	chk.value("s", s)
+	err = chk.do(func() { res = l.SuffixOf(s) })
+	return
+}
+
+// Extract returns the subsequence of l starting at offset with the
+// given length.
+func (l Seq) Extract(offset Int, length Int) Seq {
+	// Generated from seq.go:172.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_extract(ctx.c, l.c, offset.c, length.c)
+	}, l.c, offset.c, length.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(offset)
+	runtime.KeepAlive(length)
+	return Seq(val)
+}
+
+// ExtractE is like Extract, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) ExtractE(offset Int, length Int) (res Seq, err error) {
+	chk := argChecker{method: "Seq.Extract"}
+	chk.value("l", l)
+	chk.value("offset", offset)
+	chk.value("length", length)
+	err = chk.do(func() { res = l.Extract(offset, length) })
+	return
+}
+
+// At returns the unit sequence at position index in l. The sequence
+// is empty if index is out of bounds.
+func (l Seq) At(index Int) Seq {
+	// Generated from seq.go:177.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_at(ctx.c, l.c, index.c)
+	}, l.c, index.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(index)
+	return Seq(val)
+}
+
+// AtE is like At, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) AtE(index Int) (res Seq, err error) {
+	chk := argChecker{method: "Seq.At"}
+	chk.value("l", l)
+	chk.value("index", index)
+	err = chk.do(func() { res = l.At(index) })
+	return
+}
+
+// Nth returns the element at position index in l. The result is
+// unspecified if index is out of bounds.
+func (l Seq) Nth(index Int) Value {
+	// Generated from seq.go:182.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_nth(ctx.c, l.c, index.c)
+	}, l.c, index.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(index)
+	return val.lift(KindUnknown)
+}
+
+// NthE is like Nth, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) NthE(index Int) (res Value, err error) {
+	chk := argChecker{method: "Seq.Nth"}
+	chk.value("l", l)
+	chk.value("index", index)
+	err = chk.do(func() { res = l.Nth(index) })
+	return
+}
+
+// IndexOf returns the index of the first occurrence of sub in l
+// starting from offset, or -1 if there is no such occurrence.
+func (l Seq) IndexOf(sub Seq, offset Int) Int {
+	// Generated from seq.go:187.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_index(ctx.c, l.c, sub.c, offset.c)
+	}, l.c, sub.c, offset.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(sub)
+	runtime.KeepAlive(offset)
+	return Int(val)
+}
+
+// IndexOfE is like IndexOf, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) IndexOfE(sub Seq, offset Int) (res Int, err error) {
+	chk := argChecker{method: "Seq.IndexOf"}
+	chk.value("l", l)
+	chk.value("sub", sub)
+	chk.value("offset", offset)
+	err = chk.do(func() { res = l.IndexOf(sub, offset) })
+	return
+}
+
+// LastIndexOf returns the index of the last occurrence of sub in l,
+// or -1 if there is no such occurrence.
+func (l Seq) LastIndexOf(sub Seq) Int {
+	// Generated from seq.go:192.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_last_index(ctx.c, l.c, sub.c)
+	}, l.c, sub.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(sub)
+	return Int(val)
+}
+
+// LastIndexOfE is like LastIndexOf, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) LastIndexOfE(sub Seq) (res Int, err error) {
+	chk := argChecker{method: "Seq.LastIndexOf"}
+	chk.value("l", l)
+	chk.value("sub", sub)
+	err = chk.do(func() { res = l.LastIndexOf(sub) })
+	return
+}
+
+// Replace returns l with the first occurrence of src replaced by dst.
+func (l Seq) Replace(src Seq, dst Seq) Seq {
+	// Generated from seq.go:196.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_replace(ctx.c, l.c, src.c, dst.c)
+	}, l.c, src.c, dst.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(src)
+	runtime.KeepAlive(dst)
+	return Seq(val)
+}
+
+// ReplaceE is like Replace, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) ReplaceE(src Seq, dst Seq) (res Seq, err error) {
+	chk := argChecker{method: "Seq.Replace"}
+	chk.value("l", l)
+	chk.value("src", src)
+	chk.value("dst", dst)
+	err = chk.do(func() { res = l.Replace(src, dst) })
+	return
+}
+
+// ToRE converts l to a regular expression that matches exactly l.
+func (l Seq) ToRE() RE {
+	// Generated from seq.go:200.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_to_re(ctx.c, l.c)
+	}, l.c)
+	runtime.KeepAlive(l)
+	return RE(val)
+}
+
+// ToREE is like ToRE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) ToREE() (res RE, err error) {
+	chk := argChecker{method: "Seq.ToRE"}
+	chk.value("l", l)
+	err = chk.do(func() { res = l.ToRE() })
+	return
+}
+
+// InRE returns true if l is in the language of regular expression re.
+func (l Seq) InRE(re RE) Bool {
+	// Generated from seq.go:204.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_in_re(ctx.c, l.c, re.c)
+	}, l.c, re.c)
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(re)
+	return Bool(val)
+}
+
+// InREE is like InRE, but returns an error instead of
+// panicking if the arguments are invalid.
+func (l Seq) InREE(re RE) (res Bool, err error) {
+	chk := argChecker{method: "Seq.InRE"}
+	chk.value("l", l)
+	chk.value("re", re)
+	err = chk.do(func() { res = l.InRE(re) })
+	return
+}
@@ -0,0 +1,187 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSeqLift(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.Const("x", ctx.SeqSort(ctx.IntSort()))
+	if _, ok := x.(Seq); !ok {
+		t.Errorf("expected Seq, got %T", x)
+	}
+	s := ctx.Const("s", ctx.StringSort())
+	if _, ok := s.(String); !ok {
+		t.Errorf("expected String, got %T", s)
+	}
+}
+
+func TestSeqConcatLength(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.SeqConst("x", ctx.IntSort())
+	y := ctx.FromValues(ctx.IntSort(), ctx.Int(1), ctx.Int(2), ctx.Int(3))
+
+	solver := NewSolver(ctx)
+	solver.Assert(x.Concat(y).Length().Eq(ctx.Int(5)))
+	solver.Assert(y.PrefixOf(x.Concat(y)).Not())
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	n, _, _ := solver.Model().EvalAsInt64(x.Length(), true)
+	if n != 2 {
+		t.Errorf("expected len(x) = 2, got %d", n)
+	}
+}
+
+func TestSeqNth(t *testing.T) {
+	ctx := NewContext(nil)
+	y := ctx.FromValues(ctx.IntSort(), ctx.Int(10), ctx.Int(20), ctx.Int(30))
+	elem := y.Nth(ctx.Int(1)).(Int)
+
+	solver := NewSolver(ctx)
+	solver.Assert(elem.NE(ctx.Int(20)))
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT for y[1] != 20")
+	}
+}
+
+func TestSeqContainsExtract(t *testing.T) {
+	ctx := NewContext(nil)
+	y := ctx.FromValues(ctx.IntSort(), ctx.Int(1), ctx.Int(2), ctx.Int(3), ctx.Int(4))
+	sub := y.Extract(ctx.Int(1), ctx.Int(2))
+	want := ctx.FromValues(ctx.IntSort(), ctx.Int(2), ctx.Int(3))
+
+	solver := NewSolver(ctx)
+	solver.Assert(sub.Eq(want).Not().Or(y.Contains(want).Not()))
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT")
+	}
+	if k := sub.ElemSort().Kind(); k != KindInt {
+		t.Errorf("expected KindInt, got %v", k)
+	}
+}
+
+func TestSeqIndexOf(t *testing.T) {
+	ctx := NewContext(nil)
+	y := ctx.FromValues(ctx.IntSort(), ctx.Int(5), ctx.Int(6), ctx.Int(5))
+	five := ctx.SeqUnitOf(ctx.Int(5))
+
+	solver := NewSolver(ctx)
+	solver.Assert(y.IndexOf(five, ctx.Int(1)).NE(ctx.Int(2)))
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT for IndexOf")
+	}
+}
+
+func TestSeqOf(t *testing.T) {
+	ctx := NewContext(nil)
+	empty := ctx.EmptySeqOf(ctx.IntSort())
+	if k := empty.ElemSort().Kind(); k != KindInt {
+		t.Errorf("expected KindInt elements, got %v", k)
+	}
+	unit := ctx.SeqUnitOf(ctx.Int(1))
+	if k := unit.ElemSort().Kind(); k != KindInt {
+		t.Errorf("expected KindInt elements, got %v", k)
+	}
+
+	solver := NewSolver(ctx)
+	solver.Assert(empty.Concat(unit).Length().NE(ctx.Int(1)))
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT for length of empty ++ unit")
+	}
+}
+
+func TestFromValuesEmpty(t *testing.T) {
+	ctx := NewContext(nil)
+	empty := ctx.FromValues(ctx.BoolSort())
+
+	solver := NewSolver(ctx)
+	solver.Assert(empty.Length().NE(ctx.Int(0)))
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT for non-empty empty sequence")
+	}
+}
+
+func TestSeqAsValues(t *testing.T) {
+	ctx := NewContext(nil)
+	y := ctx.FromValues(ctx.IntSort(), ctx.Int(1), ctx.Int(2), ctx.Int(3))
+	vals, ok := y.AsValues()
+	if !ok || len(vals) != 3 {
+		t.Fatalf("expected 3 literal elements, got %v, %v", vals, ok)
+	}
+	if n, _, _ := vals[2].(Int).AsInt64(); n != 3 {
+		t.Errorf("expected 3, got %d", n)
+	}
+	if vals, ok := ctx.EmptySeqOf(ctx.IntSort()).AsValues(); !ok || len(vals) != 0 {
+		t.Errorf("expected empty literal, got %v, %v", vals, ok)
+	}
+	if _, ok := ctx.SeqConst("x", ctx.IntSort()).AsValues(); ok {
+		t.Error("expected non-literal")
+	}
+}
+
+func TestModelEvalSeq(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.SeqConst("x", ctx.IntSort())
+	prefix := ctx.FromValues(ctx.IntSort(), ctx.Int(7), ctx.Int(-8))
+
+	solver := NewSolver(ctx)
+	solver.Assert(x.Length().Eq(ctx.Int(3)))
+	solver.Assert(prefix.PrefixOf(x))
+	solver.Assert(x.Nth(ctx.Int(2)).(Int).Eq(ctx.Int(9)))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	got, ok := solver.Model().EvalSeqAsInt64(x, true)
+	if !ok || len(got) != 3 || got[0] != 7 || got[1] != -8 || got[2] != 9 {
+		t.Errorf("expected [7 -8 9], got %v, %v", got, ok)
+	}
+}
+
+func TestModelEvalSeqAsStrings(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.SeqConst("x", ctx.StringSort())
+	want := ctx.FromValues(ctx.StringSort(), ctx.FromString("a"), ctx.FromString("bc"))
+
+	solver := NewSolver(ctx)
+	solver.Assert(x.Eq(want))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	got, ok := solver.Model().EvalSeqAsStrings(x, true)
+	if !ok || len(got) != 2 || got[0] != "a" || got[1] != "bc" {
+		t.Errorf("expected [a bc], got %q, %v", got, ok)
+	}
+}
+
+func TestSeqMapIndexed(t *testing.T) {
+	ctx := NewContext(nil)
+	is := ctx.IntSort()
+	l := ctx.FromValues(is, ctx.Int(3), ctx.Int(1), ctx.Int(4))
+	weighted := l.MapIndexedFunc(ctx.Int(1), func(i Int, x Value) Value {
+		return i.Mul(x.(Int))
+	}).(Seq)
+	s := NewSolver(ctx)
+	s.Assert(weighted.Eq(ctx.FromValues(is, ctx.Int(3), ctx.Int(2), ctx.Int(12))).Not())
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("weighted elements: got %v, %v; want unsat", sat, err)
+	}
+
+	// A checksum over a symbolic sequence.
+	x := ctx.SeqConst("x", is)
+	sum := x.MapIndexedFunc(ctx.Int(1), func(i Int, x Value) Value {
+		return i.Mul(x.(Int))
+	}).(Seq).SumRange(ctx.Int(0), x.Length())
+	s.Reset()
+	s.Assert(x.Length().Eq(ctx.Int(2)))
+	s.Assert(sum.Eq(ctx.Int(5)))
+	s.Assert(x.Nth(ctx.Int(0)).(Int).Eq(ctx.Int(1)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("checksum: got %v, %v; want sat", sat, err)
+	}
+	if got := s.Model().Eval(x.Nth(ctx.Int(1)), true).String(); got != "2" {
+		t.Errorf("x[1] = %s, want 2", got)
+	}
+}
@@ -4,6 +4,8 @@
 
 package z3
 
+import "runtime"
+
 /*
 #cgo LDFLAGS: -lz3
 #include <z3.h>
@@ -33,7 +35,185 @@ func (ctx *Context) Simplify(x Value, config *Config) Value {
 }
 
 // NewSimplifyConfig returns *Config for configuring the simplifier.
+//
+// The following are commonly useful parameters:
+//
+//	arith_lhs       bool  Move all monomials to the left-hand side of comparisons (default: false)
+//	elim_sign_ext   bool  Expand sign extension into extraction and concatenation (default: true)
+//	pull_cheap_ite  bool  Pull if-then-else terms when cheap (default: false)
+//	som             bool  Put polynomials in sum-of-monomials form (default: false)
+//	blast_distinct  bool  Expand distinct into a conjunction of disequalities (default: false)
+//	max_steps       uint  Maximum number of steps (default: ∞)
+//
+// Config.Params lists all parameters and Context.SimplifyHelp
+// describes them, including their defaults.
 func NewSimplifyConfig(ctx *Context) *Config {
-	// TODO: Get the Z3_param_descr.
-	return newConfig(nil)
+	var desc []ParamDescr
+	ctx.do(func() {
+		desc = paramDescrs(ctx, C.Z3_simplify_get_param_descrs(ctx.c))
+	})
+	return newConfig(desc)
+}
+
+// SimplifyHelp returns a description of the parameters accepted by
+// Simplify.
+func (ctx *Context) SimplifyHelp() string {
+	var res string
+	ctx.do(func() {
+		res = C.GoString(C.Z3_simplify_get_help(ctx.c))
+	})
+	return res
+}
+
+// A Simplifier simplifies expressions like Context.Simplify, but
+// remembers its results. Simplifying an expression it has seen before,
+// or one of its own results, costs a map lookup instead of a call into
+// Z3, which pays off when the same subterms are simplified over and
+// over.
+//
+// Expressions are identified by their AST ID, so structurally equal
+// expressions share a cache entry. The cache holds its own references
+// to the expressions and results until Reset or Close is called, so
+// entries made inside a Scope outlive it.
+//
+// A Simplifier may be used concurrently, like its Context.
+type Simplifier struct {
+	*simplifierImpl
+	noEq
+}
+
+type simplifierImpl struct {
+	ctx     *Context
+	cparams C.Z3_params // nil for the default configuration
+
+	// cache maps the ID of each simplified AST to its result. It is
+	// protected by ctx.lock.
+	cache map[C.uint]simplified
+
+	// hits and misses count cache lookups. They are protected by
+	// ctx.lock.
+	hits, misses uint64
+}
+
+// A simplified is a cache entry. The Simplifier holds a reference to
+// in and out; the one to in keeps the ID of the entry from being
+// reused.
+type simplified struct {
+	in, out C.Z3_ast
+	kind    Kind
+}
+
+// NewSimplifier returns a Simplifier with an empty cache. The config
+// argument must have been created with NewSimplifyConfig. If config is
+// nil, the default configuration is used.
+func NewSimplifier(ctx *Context, config *Config) *Simplifier {
+	impl := &simplifierImpl{ctx: ctx, cache: make(map[C.uint]simplified)}
+	if config != nil {
+		impl.cparams = config.toC(ctx)
+	}
+	runtime.SetFinalizer(impl, func(impl *simplifierImpl) {
+		impl.ctx.release(func() {
+			impl.releaseParams()
+			impl.releaseCache()
+		})
+	})
+	return &Simplifier{impl, noEq{}}
+}
+
+// releaseParams releases s's parameters. This must be called with the
+// ctx.lock held.
+func (s *simplifierImpl) releaseParams() {
+	if s.cparams != nil {
+		C.Z3_params_dec_ref(s.ctx.c, s.cparams)
+		s.cparams = nil
+	}
+}
+
+// releaseCache drops s's references to its cache entries and empties
+// the cache. This must be called with the ctx.lock held.
+func (s *simplifierImpl) releaseCache() {
+	for _, e := range s.cache {
+		C.Z3_dec_ref(s.ctx.c, e.in)
+		C.Z3_dec_ref(s.ctx.c, e.out)
+	}
+	s.cache = make(map[C.uint]simplified)
+}
+
+// add caches e under id. This must be called with the ctx.lock held.
+func (s *simplifierImpl) add(id C.uint, e simplified) {
+	C.Z3_inc_ref(s.ctx.c, e.in)
+	C.Z3_inc_ref(s.ctx.c, e.out)
+	if old, ok := s.cache[id]; ok {
+		C.Z3_dec_ref(s.ctx.c, old.in)
+		C.Z3_dec_ref(s.ctx.c, old.out)
+	}
+	s.cache[id] = e
+}
+
+// Simplify returns the simplification of x, from the cache if
+// possible.
+func (s *Simplifier) Simplify(x Value) Value {
+	var out value
+	var kind Kind
+	s.ctx.do(func() {
+		c := s.ctx.c
+		cin := x.impl().c
+		id := C.Z3_get_ast_id(c, cin)
+		if e, ok := s.cache[id]; ok {
+			s.hits++
+			out = value{(*valueImpl)(wrapAST(s.ctx, e.out).astImpl), noEq{}}
+			kind = e.kind
+			return
+		}
+		s.misses++
+		var cout C.Z3_ast
+		if s.cparams == nil {
+			cout = C.Z3_simplify(c, cin)
+		} else {
+			cout = C.Z3_simplify_ex(c, cin, s.cparams)
+		}
+		// Wrap cout first: it has no reference yet.
+		out = value{(*valueImpl)(wrapAST(s.ctx, cout).astImpl), noEq{}}
+		kind = Kind(C.Z3_get_sort_kind(c, C.Z3_get_sort(c, cout)))
+		s.add(id, simplified{cin, cout, kind})
+		// Simplification is idempotent, so out simplifies to
+		// itself.
+		s.add(C.Z3_get_ast_id(c, cout), simplified{cout, cout, kind})
+	})
+	runtime.KeepAlive(x)
+	return out.lift(kind)
+}
+
+// SimplifierStats are the cache statistics of a Simplifier.
+type SimplifierStats struct {
+	Entries      int    // Number of cached expressions
+	Hits, Misses uint64 // Number of calls answered from the cache or by Z3
+}
+
+// Stats returns s's cache statistics.
+func (s *Simplifier) Stats() SimplifierStats {
+	var st SimplifierStats
+	s.ctx.do(func() {
+		st = SimplifierStats{len(s.cache), s.hits, s.misses}
+	})
+	return st
+}
+
+// Reset empties s's cache and zeros its statistics.
+func (s *Simplifier) Reset() {
+	s.ctx.do(func() {
+		s.releaseCache()
+		s.hits, s.misses = 0, 0
+	})
+}
+
+// Close empties s's cache and releases its Z3 resources without
+// waiting for the garbage collector to finalize s. s must not be used
+// after Close. Close is idempotent.
+func (s *Simplifier) Close() {
+	s.ctx.release(func() {
+		s.releaseParams()
+		s.releaseCache()
+	})
+	runtime.SetFinalizer(s.simplifierImpl, nil)
 }
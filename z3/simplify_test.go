@@ -0,0 +1,82 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSimplifier(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	s := NewSimplifier(ctx, nil)
+	defer s.Close()
+
+	e := x.Add(ctx.Int(1)).Add(ctx.Int(2))
+	r1 := s.Simplify(e)
+	if _, ok := r1.(Int); !ok {
+		t.Fatalf("got %T, want Int", r1)
+	}
+	if want := ctx.Simplify(e, nil).String(); r1.String() != want {
+		t.Errorf("got %v, want %v", r1, want)
+	}
+
+	// A structurally equal expression and the result itself are
+	// answered from the cache.
+	r2 := s.Simplify(x.Add(ctx.Int(1)).Add(ctx.Int(2)))
+	s.Simplify(r1)
+	if r2.String() != r1.String() {
+		t.Errorf("cached result %v, want %v", r2, r1)
+	}
+	if st := s.Stats(); st.Hits != 2 || st.Misses != 1 || st.Entries != 2 {
+		t.Errorf("got stats %+v, want 2 hits, 1 miss, 2 entries", st)
+	}
+
+	s.Reset()
+	if st := s.Stats(); st != (SimplifierStats{}) {
+		t.Errorf("after Reset, got stats %+v", st)
+	}
+
+	// A configured Simplifier uses its parameters.
+	cfg := NewSimplifyConfig(ctx).SetBool("arith_lhs", true)
+	sc := NewSimplifier(ctx, cfg)
+	ineq := x.Add(ctx.Int(1)).LE(ctx.Int(5))
+	if got, want := sc.Simplify(ineq).String(), ctx.Simplify(ineq, cfg).String(); got != want {
+		t.Errorf("with config, got %v, want %v", got, want)
+	}
+	sc.Close()
+	sc.Close()
+}
+
+func TestSimplifierScope(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	s := NewSimplifier(ctx, nil)
+	defer s.Close()
+
+	var inScope string
+	ctx.Scope(func(*Scope) {
+		for i := 0; i < 50; i++ {
+			r := s.Simplify(x.Add(ctx.Int(i)).Add(ctx.Int(1)))
+			if i == 7 {
+				inScope = r.String()
+			}
+		}
+	})
+
+	// The Scope released its own references, but the cache kept
+	// its entries alive, so their IDs are not reused by new
+	// expressions and lookups stay correct.
+	for i := 0; i < 50; i++ {
+		e := x.Mul(ctx.Int(i + 2)).Sub(ctx.Int(3))
+		if got, want := s.Simplify(e).String(), ctx.Simplify(e, nil).String(); got != want {
+			t.Errorf("Simplify(%v) = %v, want %v", e, got, want)
+		}
+	}
+	if got := s.Simplify(x.Add(ctx.Int(7)).Add(ctx.Int(1))).String(); got != inScope {
+		t.Errorf("after Scope, got %v, want %v", got, inScope)
+	}
+	if st := s.Stats(); st.Hits != 1 {
+		t.Errorf("got stats %+v, want 1 hit", st)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"strconv"
+	"unsafe"
+)
+
+/*
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// ParseSMTLIB2 parses an SMT-LIB 2 script and returns its assertions,
+// without adding them to a solver.
+//
+// Sorts and decls give the environment the script is parsed in: a
+// sort or function symbol that the script uses without declaring it
+// refers to the Sort or FuncDecl of the same name. This way, a
+// textual library of lemmas can mention constants and functions built
+// by the program, and the returned assertions share them:
+//
+//	x := ctx.FuncDecl("x", nil, ctx.IntSort())
+//	f := ctx.FuncDecl("f", []z3.Sort{ctx.IntSort()}, ctx.IntSort())
+//	lemmas, err := ctx.ParseSMTLIB2("(assert (> (f x) x))",
+//		nil, []z3.FuncDecl{x, f})
+//
+// Commands other than declarations, definitions, and assertions are
+// ignored. If the script is malformed, ParseSMTLIB2 returns an *Error.
+// If a sort or decl is the zero value or belongs to another Context,
+// it returns an *ArgError.
+func (ctx *Context) ParseSMTLIB2(src string, sorts []Sort, decls []FuncDecl) ([]Bool, error) {
+	chk := argChecker{method: "Context.ParseSMTLIB2", ctx: ctx}
+	for i, s := range sorts {
+		chk.sort("sorts["+strconv.Itoa(i)+"]", s)
+	}
+	for i, d := range decls {
+		chk.check("decls["+strconv.Itoa(i)+"]", d.Context())
+	}
+	var asserts []Bool
+	err := chk.do(func() {
+		asserts = ctx.parseSMTLIB2(src, sorts, decls)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asserts, nil
+}
+
+// parseSMTLIB2 parses the assertions of an SMT-LIB 2 script in the
+// environment of sorts and decls.
+func (ctx *Context) parseSMTLIB2(src string, sorts []Sort, decls []FuncDecl) []Bool {
+	csrc := C.CString(src)
+	defer C.free(unsafe.Pointer(csrc))
+	var result []Bool
+	ctx.do(func() {
+		var (
+			sortNames []C.Z3_symbol
+			csorts    []C.Z3_sort
+			declNames []C.Z3_symbol
+			cdecls    []C.Z3_func_decl
+		)
+		for _, s := range sorts {
+			sortNames = append(sortNames, C.Z3_get_sort_name(ctx.c, s.c))
+			csorts = append(csorts, s.c)
+		}
+		for _, d := range decls {
+			declNames = append(declNames, C.Z3_get_decl_name(ctx.c, d.c))
+			cdecls = append(cdecls, d.c)
+		}
+		var (
+			sortNamesp *C.Z3_symbol
+			csortsp    *C.Z3_sort
+			declNamesp *C.Z3_symbol
+			cdeclsp    *C.Z3_func_decl
+		)
+		if len(sorts) > 0 {
+			sortNamesp, csortsp = &sortNames[0], &csorts[0]
+		}
+		if len(decls) > 0 {
+			declNamesp, cdeclsp = &declNames[0], &cdecls[0]
+		}
+		vec := C.Z3_parse_smtlib2_string(ctx.c, csrc,
+			C.uint(len(sorts)), sortNamesp, csortsp,
+			C.uint(len(decls)), declNamesp, cdeclsp)
+		C.Z3_ast_vector_inc_ref(ctx.c, vec)
+		defer C.Z3_ast_vector_dec_ref(ctx.c, vec)
+		// Wrap the assertions while vec still holds them.
+		result = make([]Bool, int(C.Z3_ast_vector_size(ctx.c, vec)))
+		for i := range result {
+			ast := wrapAST(ctx, C.Z3_ast_vector_get(ctx.c, vec, C.uint(i)))
+			result[i] = Bool(value{(*valueImpl)(ast.astImpl), noEq{}})
+		}
+	})
+	runtime.KeepAlive(sorts)
+	runtime.KeepAlive(decls)
+	runtime.KeepAlive(ctx)
+	return result
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSMTLIB2(t *testing.T) {
+	ctx := NewContext(nil)
+	elem := ctx.UninterpretedSort("Elem")
+	f := ctx.FuncDecl("f", []Sort{elem}, elem)
+	a := ctx.Const("a", elem).(Uninterpreted)
+
+	// The lemma library uses Elem and f without declaring them, and
+	// declares its own constant b.
+	lemmas, err := ctx.ParseSMTLIB2(`
+		(declare-const b Elem)
+		(assert (forall ((x Elem)) (= (f (f x)) x)))
+		(assert (= (f b) b))
+		(check-sat)`,
+		[]Sort{elem}, []FuncDecl{f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lemmas) != 2 {
+		t.Fatalf("got %d assertions, want 2", len(lemmas))
+	}
+
+	// The parsed f is the program's f, so the lemma applies to
+	// constraints built in Go.
+	s := NewSolver(ctx)
+	s.AssertAll(lemmas)
+	fa := f.Apply(a)
+	s.Assert(f.Apply(fa).(Uninterpreted).NE(a))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("got sat %v, err %v; want unsat", sat, err)
+	}
+
+	_, err = ctx.ParseSMTLIB2("(assert (g 1))", nil, nil)
+	var zerr *Error
+	if !errors.As(err, &zerr) {
+		t.Errorf("undeclared function: got %v, want *Error", err)
+	}
+
+	ctx2 := NewContext(nil)
+	_, err = ctx.ParseSMTLIB2("(assert true)", nil, []FuncDecl{ctx2.FuncDecl("g", nil, ctx2.BoolSort())})
+	if !errors.Is(err, ErrContextMismatch) {
+		t.Errorf("decl from another Context: got %v, want ErrContextMismatch", err)
+	}
+}
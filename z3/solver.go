@@ -4,12 +4,21 @@
 
 package z3
 
-import "runtime"
+import (
+	"runtime"
+	"strings"
+)
 
 /*
 #cgo LDFLAGS: -lz3
 #include <z3.h>
 #include <stdlib.h>
+
+static void z3go_solver_assert_all(Z3_context c, Z3_solver s, unsigned n, Z3_ast *vals) {
+	for (unsigned i = 0; i < n; i++) {
+		Z3_solver_assert(c, s, vals[i]);
+	}
+}
 */
 import "C"
 
@@ -26,6 +35,31 @@ type Solver struct {
 type solverImpl struct {
 	ctx *Context
 	c   C.Z3_solver
+
+	// marks records the number of assertions at each Push, so Save
+	// can tell which scope each assertion belongs to. It is
+	// protected by ctx.lock.
+	marks []uint
+
+	// named lists the tracking literals added by AssertNamed, and
+	// namedMarks records len(named) at each Push. They are
+	// protected by ctx.lock.
+	named      []namedLit
+	namedMarks []int
+
+	// params records the parameters set by SetParams, so Save can
+	// write them. It is protected by ctx.lock.
+	params map[string]interface{}
+}
+
+// A namedLit is a tracking literal and the name of the assertion it
+// tracks.
+type namedLit struct {
+	lit  Bool
+	name string
+
+	// index is the position of the assertion in Assertions.
+	index uint
 }
 
 // NewSolver returns a new, empty solver.
@@ -33,34 +67,99 @@ func NewSolver(ctx *Context) *Solver {
 	var impl *solverImpl
 	ctx.do(func() {
 		impl = &solverImpl{
-			ctx,
-			C.Z3_mk_solver(ctx.c),
+			ctx: ctx,
+			c:   C.Z3_mk_solver(ctx.c),
 		}
 	})
 	ctx.do(func() {
 		C.Z3_solver_inc_ref(ctx.c, impl.c)
 	})
 	runtime.SetFinalizer(impl, func(impl *solverImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_solver_dec_ref(impl.ctx.c, impl.c)
 		})
 	})
 	return &Solver{impl, noEq{}}
 }
 
+// Close releases the Z3 resources held by s without waiting for the
+// garbage collector to finalize s. s must not be used after Close.
+// Close is idempotent.
+func (s *Solver) Close() {
+	s.ctx.release(func() {
+		if s.c != nil {
+			C.Z3_solver_dec_ref(s.ctx.c, s.c)
+			s.c = nil
+		}
+	})
+	runtime.SetFinalizer(s.solverImpl, nil)
+}
+
+// NewSolverConfig returns *Config for configuring a Solver with
+// SetParams.
+func NewSolverConfig(ctx *Context) *Config {
+	var desc []ParamDescr
+	ctx.do(func() {
+		s := C.Z3_mk_solver(ctx.c)
+		C.Z3_solver_inc_ref(ctx.c, s)
+		defer C.Z3_solver_dec_ref(ctx.c, s)
+		desc = paramDescrs(ctx, C.Z3_solver_get_param_descrs(ctx.c, s))
+	})
+	return newConfig(desc)
+}
+
+// SetParams sets parameters on the solver. config should have been
+// created with NewSolverConfig.
+func (s *Solver) SetParams(config *Config) {
+	cparams := config.toC(s.ctx)
+	s.ctx.do(func() {
+		C.Z3_solver_set_params(s.ctx.c, s.c, cparams)
+		if s.params == nil {
+			s.params = make(map[string]interface{})
+		}
+		for k, v := range config.m {
+			s.params[k] = v
+		}
+	})
+	s.ctx.do(func() {
+		C.Z3_params_dec_ref(s.ctx.c, cparams)
+	})
+	runtime.KeepAlive(s)
+}
+
 // Assert adds val to the set of predicates that must be satisfied.
 func (s *Solver) Assert(val Bool) {
-	s.ctx.do(func() {
+	s.ctx.doArgs([]C.Z3_ast{val.c}, func() {
 		C.Z3_solver_assert(s.ctx.c, s.c, val.c)
 	})
 	runtime.KeepAlive(s)
 	runtime.KeepAlive(val)
 }
 
+// AssertAll adds all of vals to the set of predicates that must be
+// satisfied. It is equivalent to calling Assert for each value, but
+// much faster for large numbers of values.
+func (s *Solver) AssertAll(vals []Bool) {
+	if len(vals) == 0 {
+		return
+	}
+	cvals := make([]C.Z3_ast, len(vals))
+	for i, val := range vals {
+		cvals[i] = val.c
+	}
+	s.ctx.doArgs(cvals, func() {
+		C.z3go_solver_assert_all(s.ctx.c, s.c, C.uint(len(cvals)), &cvals[0])
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(vals)
+}
+
 // Push saves the current state of the Solver so it can be restored
 // with Pop.
 func (s *Solver) Push() {
 	s.ctx.do(func() {
+		s.marks = append(s.marks, s.numAssertions())
+		s.namedMarks = append(s.namedMarks, len(s.named))
 		C.Z3_solver_push(s.ctx.c, s.c)
 	})
 	runtime.KeepAlive(s)
@@ -70,6 +169,9 @@ func (s *Solver) Push() {
 func (s *Solver) Pop() {
 	s.ctx.do(func() {
 		C.Z3_solver_pop(s.ctx.c, s.c, 1)
+		s.marks = s.marks[:len(s.marks)-1]
+		s.named = s.named[:s.namedMarks[len(s.namedMarks)-1]]
+		s.namedMarks = s.namedMarks[:len(s.namedMarks)-1]
 	})
 	runtime.KeepAlive(s)
 }
@@ -78,6 +180,8 @@ func (s *Solver) Pop() {
 func (s *Solver) Reset() {
 	s.ctx.do(func() {
 		C.Z3_solver_reset(s.ctx.c, s.c)
+		s.marks = nil
+		s.named, s.namedMarks = nil, nil
 	})
 	runtime.KeepAlive(s)
 }
@@ -94,25 +198,100 @@ func (e *ErrSatUnknown) Error() string {
 	return e.Reason
 }
 
+// Is reports whether e matches target. e matches ErrMemoryExceeded if
+// Z3 gave up because it ran out of memory.
+func (e *ErrSatUnknown) Is(target error) bool {
+	return target == ErrMemoryExceeded &&
+		(strings.Contains(e.Reason, "memory") || strings.Contains(e.Reason, "memout"))
+}
+
 // Check determines whether the predicates in Solver s are satisfiable
 // or unsatisfiable. If Z3 is unable to determine satisfiability, it
-// returns an *ErrSatUnknown error.
+// returns an *ErrSatUnknown error, or an *ErrResourceExhausted error
+// if it ran out of memory.
 func (s *Solver) Check() (sat bool, err error) {
+	defer recoverMemout(&err)
 	var res C.Z3_lbool
 	s.ctx.do(func() {
+		s.ctx.checks++
 		res = C.Z3_solver_check(s.ctx.c, s.c)
 	})
 	if res == C.Z3_L_UNDEF {
 		// Get the reason.
 		s.ctx.do(func() {
 			cerr := C.Z3_solver_get_reason_unknown(s.ctx.c, s.c)
-			err = &ErrSatUnknown{C.GoString(cerr)}
+			err = unknownError(C.GoString(cerr))
 		})
 	}
 	runtime.KeepAlive(s)
 	return res == C.Z3_L_TRUE, err
 }
 
+// Prove checks whether claim holds in every model of assumptions, that
+// is, whether the assumptions imply claim. It does this by checking on
+// a new Solver that the assumptions together with the negation of
+// claim are unsatisfiable.
+//
+// If claim is valid, Prove returns true. Otherwise it returns false
+// and a counterexample: a model of the assumptions in which claim is
+// false. If Z3 cannot decide, Prove returns the error from Check.
+func (ctx *Context) Prove(claim Bool, assumptions ...Bool) (proved bool, counterexample *Model, err error) {
+	s := NewSolver(ctx)
+	defer s.Close()
+	s.AssertAll(assumptions)
+	s.Assert(claim.Not())
+	sat, err := s.Check()
+	if err != nil {
+		return false, nil, err
+	}
+	if !sat {
+		return true, nil, nil
+	}
+	return false, s.Model(), nil
+}
+
+// ProveEquivalent checks whether x and y, which must have the same
+// sort, are equal in every model of assumptions. If they are not, it
+// returns a distinguishing model in which they differ.
+func (ctx *Context) ProveEquivalent(x, y Value, assumptions ...Bool) (equiv bool, distinguishing *Model, err error) {
+	return ctx.Prove(ctx.Distinct(x, y).Not(), assumptions...)
+}
+
+// ProveImplies checks whether p implies q in every model of
+// assumptions. If it does not, it returns a model in which p is true
+// and q is false.
+func (ctx *Context) ProveImplies(p, q Bool, assumptions ...Bool) (implies bool, counterexample *Model, err error) {
+	return ctx.Prove(p.Implies(q), assumptions...)
+}
+
+// ProveEquivalentFuncs checks whether f and g return equal values for
+// all arguments of the sorts in domain, by applying them to fresh
+// constants. f and g are typically Go functions that build
+// constraints, such as two versions of a constraint generator.
+//
+// If f and g are not equivalent, ProveEquivalentFuncs returns
+// arguments on which they differ.
+func (ctx *Context) ProveEquivalentFuncs(domain []Sort, f, g func(args ...Value) Value) (equiv bool, counterexample []Value, err error) {
+	args := make([]Value, len(domain))
+	for i, s := range domain {
+		args[i] = ctx.FreshConst("arg", s)
+	}
+	equiv, m, err := ctx.ProveEquivalent(f(args...), g(args...))
+	if equiv || err != nil {
+		return equiv, nil, err
+	}
+	for _, arg := range args {
+		counterexample = append(counterexample, m.Eval(arg, true))
+	}
+	return false, counterexample, nil
+}
+
+// ProveEquivalentDecls is like ProveEquivalentFuncs for the functions
+// f and g, which must have the same domain and range.
+func (ctx *Context) ProveEquivalentDecls(f, g FuncDecl) (equiv bool, counterexample []Value, err error) {
+	return ctx.ProveEquivalentFuncs(f.Domain(), f.Apply, g.Apply)
+}
+
 // Model returns the model for the last Check. Model panics if Check
 // has not been called or the last Check did not return true.
 func (s *Solver) Model() *Model {
@@ -149,13 +328,64 @@ func (s *Solver) NumScopes() uint {
 func (s *Solver) NumAssertions() uint {
 	var res uint
 	s.ctx.do(func() {
-		vec := C.Z3_solver_get_assertions(s.ctx.c, s.c)
-		res = uint(C.Z3_ast_vector_size(s.ctx.c, vec))
+		res = s.numAssertions()
 	})
 	runtime.KeepAlive(s)
 	return res
 }
 
+// numAssertions is NumAssertions for callers that hold ctx.lock.
+func (s *Solver) numAssertions() uint {
+	vec := C.Z3_solver_get_assertions(s.ctx.c, s.c)
+	return uint(C.Z3_ast_vector_size(s.ctx.c, vec))
+}
+
+// AssertNamed adds val to the set of predicates that must be satisfied
+// and gives it a name. If a later Check returns false, UnsatCoreNames
+// reports which named assertions were needed to show
+// unsatisfiability.
+//
+// The name is for the caller's benefit and need not be a valid
+// symbol; Z3 tracks val with a fresh constant.
+func (s *Solver) AssertNamed(name string, val Bool) {
+	s.assertTracked(name, s.ctx.FreshConst("named", s.ctx.BoolSort()).(Bool), val)
+}
+
+// assertTracked asserts val, tracked by the Boolean constant lit, as
+// the assertion called name.
+func (s *Solver) assertTracked(name string, lit, val Bool) {
+	s.ctx.doArgs([]C.Z3_ast{val.c, lit.c}, func() {
+		index := s.numAssertions()
+		C.Z3_solver_assert_and_track(s.ctx.c, s.c, val.c, lit.c)
+		s.named = append(s.named, namedLit{lit, name, index})
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(val)
+}
+
+// UnsatCoreNames returns the names of the assertions added by
+// AssertNamed that are in the unsat core, after a Check or
+// CheckAssumptions call that returned false. Assertions added by
+// Assert are never in the core, and assumptions passed to
+// CheckAssumptions are omitted.
+func (s *Solver) UnsatCoreNames() []string {
+	var named []namedLit
+	s.ctx.do(func() {
+		named = append([]namedLit(nil), s.named...)
+	})
+	names := make(map[uint64]string, len(named))
+	for _, n := range named {
+		names[n.lit.AsAST().ID()] = n.name
+	}
+	var res []string
+	for _, lit := range s.UnsatCore() {
+		if name, ok := names[lit.AsAST().ID()]; ok {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
 // Assertions returns the assertions in the solver.
 func (s *Solver) Assertions() []Bool {
 	var asts []C.Z3_ast
@@ -180,8 +410,10 @@ func (s *Solver) Assertions() []Bool {
 
 // CheckAssumptions determines whether the predicates in Solver s
 // together with the given assumptions are satisfiable or unsatisfiable.
-// If Z3 is unable to determine satisfiability, it returns an *ErrSatUnknown error.
+// If Z3 is unable to determine satisfiability, it returns an *ErrSatUnknown error,
+// or an *ErrResourceExhausted error if it ran out of memory.
 func (s *Solver) CheckAssumptions(assumptions ...Bool) (sat bool, err error) {
+	defer recoverMemout(&err)
 	cargs := make([]C.Z3_ast, len(assumptions))
 	for i, arg := range assumptions {
 		cargs[i] = arg.c
@@ -192,17 +424,18 @@ func (s *Solver) CheckAssumptions(assumptions ...Bool) (sat bool, err error) {
 		if len(cargs) > 0 {
 			cap = &cargs[0]
 		}
+		s.ctx.checks++
 		res = C.Z3_solver_check_assumptions(s.ctx.c, s.c, C.uint(len(cargs)), cap)
 	})
 	if res == C.Z3_L_UNDEF {
 		// Get the reason.
 		s.ctx.do(func() {
 			cerr := C.Z3_solver_get_reason_unknown(s.ctx.c, s.c)
-			err = &ErrSatUnknown{C.GoString(cerr)}
+			err = unknownError(C.GoString(cerr))
 		})
 	}
 	runtime.KeepAlive(s)
-	runtime.KeepAlive(&cargs[0])
+	runtime.KeepAlive(assumptions)
 	return res == C.Z3_L_TRUE, err
 }
 
@@ -41,7 +41,7 @@ func wrapSort(ctx *Context, c C.Z3_sort, kind Kind) Sort {
 	}
 	impl := &sortImpl{ctx, c, kind}
 	runtime.SetFinalizer(impl, func(impl *sortImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.release(func() {
 			C.Z3_dec_ref(impl.ctx.c, C.Z3_sort_to_ast(impl.ctx.c, impl.c))
 		})
 	})
@@ -91,7 +91,9 @@ func (s Sort) FloatSize() (ebits, sbits int) {
 	return
 }
 
-// DomainAndRange returns the domain and range of an array sort.
+// DomainAndRange returns the domain and range of an array sort. For
+// arrays with more than one index, domain is the first index sort;
+// use ArrayDomainN for the others.
 func (s Sort) DomainAndRange() (domain, range_ Sort) {
 	s.ctx.do(func() {
 		domain = wrapSort(s.ctx, C.Z3_get_array_sort_domain(s.ctx.c, s.c), KindUnknown)
@@ -101,6 +103,28 @@ func (s Sort) DomainAndRange() (domain, range_ Sort) {
 	return
 }
 
+// ArrayArity returns the number of indexes of array sort s. This is
+// 1 for sorts created by ArraySort.
+func (s Sort) ArrayArity() int {
+	var arity int
+	s.ctx.do(func() {
+		arity = int(C.Z3_get_array_arity(s.ctx.c, s.c))
+	})
+	runtime.KeepAlive(s)
+	return arity
+}
+
+// ArrayDomainN returns the sort of the i'th index of array sort s,
+// where 0 <= i < s.ArrayArity().
+func (s Sort) ArrayDomainN(i int) Sort {
+	var domain Sort
+	s.ctx.do(func() {
+		domain = wrapSort(s.ctx, C.Z3_get_array_sort_domain_n(s.ctx.c, s.c, C.uint(i)), KindUnknown)
+	})
+	runtime.KeepAlive(s)
+	return domain
+}
+
 // AsAST returns the AST representation of s.
 func (s Sort) AsAST() AST {
 	var ast AST
@@ -110,3 +134,31 @@ func (s Sort) AsAST() AST {
 	runtime.KeepAlive(s)
 	return ast
 }
+
+// Equal returns true if s and o are the same sort. Sorts, like ASTs,
+// cannot be compared with ==. Sorts from different Contexts are never
+// equal, and neither is the zero Sort to any sort.
+func (s Sort) Equal(o Sort) bool {
+	if ctx := s.Context(); ctx == nil || ctx != o.Context() {
+		return false
+	}
+	var out bool
+	s.ctx.do(func() {
+		out = z3ToBool(C.Z3_is_eq_sort(s.ctx.c, s.c, o.c))
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(o)
+	return out
+}
+
+// ID returns the unique identifier for s. Within a Context, two sorts
+// have the same ID if and only if they are Equal, so the ID can be
+// used as a map key.
+func (s Sort) ID() uint64 {
+	var res uint64
+	s.ctx.do(func() {
+		res = uint64(C.Z3_get_sort_id(s.ctx.c, s.c))
+	})
+	runtime.KeepAlive(s)
+	return res
+}
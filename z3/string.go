@@ -17,15 +17,11 @@ import (
 
 // String is a symbolic value representing a string (sequence of characters).
 //
+// Sequences of other element sorts are represented by Seq.
+//
 // String implements Value.
 type String value
 
-func init() {
-	kindWrappers[KindSeq] = func(x value) Value {
-		return String(x)
-	}
-}
-
 // StringSort returns the string sort.
 func (ctx *Context) StringSort() Sort {
 	var sort Sort
@@ -81,7 +77,43 @@ func (ctx *Context) StringConst(name string) String {
 }
 
 // FromString returns a string literal with value val.
+//
+// Each rune of val becomes one character of the result, so strings
+// containing non-ASCII text round-trip through AsString. Invalid
+// UTF-8 is converted to U+FFFD, as with a []rune conversion.
+// Backslashes have no special meaning; see FromEscapedString for
+// Z3's escape syntax.
 func (ctx *Context) FromString(val string) String {
+	return ctx.FromRunes([]rune(val))
+}
+
+// FromRunes returns a string literal whose characters are the code
+// points rs.
+//
+// Every code point must be representable by Z3's string encoding.
+// With the default (Unicode) encoding, this is 0 through 0x2FFFF.
+func (ctx *Context) FromRunes(rs []rune) String {
+	cchars := make([]C.uint, len(rs))
+	for i, r := range rs {
+		cchars[i] = C.uint(r)
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		var cp *C.uint
+		if len(cchars) > 0 {
+			cp = &cchars[0]
+		}
+		return C.Z3_mk_u32string(ctx.c, C.uint(len(cchars)), cp)
+	})
+	runtime.KeepAlive(cchars)
+	return String(val)
+}
+
+// FromEscapedString returns a string literal with value val, where
+// val uses Z3's escape syntax. Characters outside printable ASCII
+// must be written as escape sequences like \u{e9}. This is the
+// format produced by String.AsEscapedString and SMT-LIB string
+// literals.
+func (ctx *Context) FromEscapedString(val string) String {
 	cstr := C.CString(val)
 	defer C.free(unsafe.Pointer(cstr))
 	return String(wrapValue(ctx, func() C.Z3_ast {
@@ -91,20 +123,55 @@ func (ctx *Context) FromString(val string) String {
 
 // AsString returns the value of lit as a Go string. If lit is not a
 // string literal, it returns "", false.
+//
+// Characters are converted to UTF-8, so AsString is the inverse of
+// FromString.
 func (lit String) AsString() (val string, isLiteral bool) {
-	var result C.Z3_string
-	var isStr bool
+	rs, isLiteral := lit.AsRunes()
+	if !isLiteral {
+		return "", false
+	}
+	return string(rs), true
+}
+
+// AsRunes returns the characters of lit as code points. If lit is
+// not a string literal, it returns nil, false.
+func (lit String) AsRunes() (val []rune, isLiteral bool) {
+	var cchars []C.uint
 	lit.ctx.do(func() {
-		isStr = z3ToBool(C.Z3_is_string(lit.ctx.c, lit.c))
-		if isStr {
-			result = C.Z3_get_string(lit.ctx.c, lit.c)
+		isLiteral = z3ToBool(C.Z3_is_string(lit.ctx.c, lit.c))
+		if !isLiteral {
+			return
+		}
+		n := C.Z3_get_string_length(lit.ctx.c, lit.c)
+		cchars = make([]C.uint, n)
+		if n > 0 {
+			C.Z3_get_string_contents(lit.ctx.c, lit.c, n, &cchars[0])
 		}
 	})
 	runtime.KeepAlive(lit)
-	if !isStr {
-		return "", false
+	if !isLiteral {
+		return nil, false
 	}
-	return C.GoString(result), true
+	val = make([]rune, len(cchars))
+	for i, c := range cchars {
+		val[i] = rune(c)
+	}
+	return val, true
+}
+
+// AsEscapedString returns the value of lit in Z3's escape syntax,
+// where characters outside printable ASCII are written as escape
+// sequences. If lit is not a string literal, it returns "", false.
+func (lit String) AsEscapedString() (val string, isLiteral bool) {
+	lit.ctx.do(func() {
+		isLiteral = z3ToBool(C.Z3_is_string(lit.ctx.c, lit.c))
+		if isLiteral {
+			val = C.GoString(C.Z3_get_string(lit.ctx.c, lit.c))
+		}
+	})
+	runtime.KeepAlive(lit)
+	return val, isLiteral
 }
 
 // Empty returns an empty string/sequence of the given sort.
@@ -334,3 +401,28 @@ func (l String) InRE(re RE) Bool {
 	runtime.KeepAlive(re)
 	return Bool(val)
 }
+
+// MatchesFull returns true if all of l matches re. This is the same
+// as l.InRE(re).
+func (l String) MatchesFull(re RE) Bool {
+	return l.InRE(re)
+}
+
+// MatchesAnywhere returns true if some substring of l matches re,
+// like Go's regexp.MatchString for an unanchored pattern.
+func (l String) MatchesAnywhere(re RE) Bool {
+	full := l.ctx.REFull(re.Sort())
+	return l.InRE(full.Concat(re, full))
+}
+
+// EqualFold returns true if l is equal to s under simple Unicode case
+// folding, like strings.EqualFold.
+func (l String) EqualFold(s string) Bool {
+	return l.InRE(l.ctx.REFold(s))
+}
+
+// ContainsFold returns true if l contains a substring that is equal
+// to s under simple Unicode case folding.
+func (l String) ContainsFold(s string) Bool {
+	return l.MatchesAnywhere(l.ctx.REFold(s))
+}
@@ -182,3 +182,105 @@ func TestStringSymbolic(t *testing.T) {
 	yVal := model.Eval(y, true)
 	t.Logf("x = %v, y = %v", xVal, yVal)
 }
+
+func TestStringUnicodeRoundTrip(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, want := range []string{"", "héllo", "日本語", "a\\u{41}b", "tab\there", "\x00"} {
+		got, ok := ctx.FromString(want).AsString()
+		if !ok || got != want {
+			t.Errorf("round trip of %q produced %q, %v", want, got, ok)
+		}
+	}
+}
+
+func TestStringUnicodeLength(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.FromString("日本語")
+
+	solver := NewSolver(ctx)
+	solver.Assert(s.Length().NE(ctx.Int(3)))
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected length 3 for a three-rune string")
+	}
+}
+
+func TestFromRunes(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.FromRunes([]rune{'G', 'ö', 0x1F600})
+	rs, ok := s.AsRunes()
+	if !ok || len(rs) != 3 || rs[1] != 'ö' || rs[2] != 0x1F600 {
+		t.Errorf("unexpected runes %v, %v", rs, ok)
+	}
+	if !ctx.FromRunes(nil).AsAST().Equal(ctx.FromString("").AsAST()) {
+		t.Error("expected FromRunes(nil) to be the empty string")
+	}
+}
+
+func TestFromEscapedString(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.FromEscapedString(`caf\u{e9}`)
+	if got, _ := s.AsString(); got != "café" {
+		t.Errorf("expected café, got %q", got)
+	}
+	esc, ok := ctx.FromString("café").AsEscapedString()
+	if !ok || !ctx.FromEscapedString(esc).AsAST().Equal(s.AsAST()) {
+		t.Errorf("escaped form %q does not round trip", esc)
+	}
+	if _, ok := ctx.StringConst("x").AsRunes(); ok {
+		t.Error("expected non-literal")
+	}
+}
+
+func TestStringMatches(t *testing.T) {
+	ctx := NewContext(nil)
+	re := ctx.MustCompileRegexp(`[0-9]+`)
+	tests := []struct {
+		s              string
+		full, anywhere bool
+	}{
+		{"123", true, true},
+		{"abc123def", false, true},
+		{"abc", false, false},
+		{"", false, false},
+	}
+	for _, test := range tests {
+		s := ctx.FromString(test.s)
+		for _, check := range []struct {
+			name string
+			cond Bool
+			want bool
+		}{
+			{"MatchesFull", s.MatchesFull(re), test.full},
+			{"MatchesAnywhere", s.MatchesAnywhere(re), test.anywhere},
+		} {
+			solver := NewSolver(ctx)
+			solver.Assert(check.cond)
+			if sat, _ := solver.Check(); sat != check.want {
+				t.Errorf("%q.%s: expected %v, got %v", test.s, check.name, check.want, sat)
+			}
+		}
+	}
+}
+
+func TestStringFold(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.StringConst("x")
+
+	solver := NewSolver(ctx)
+	solver.Assert(x.EqualFold("go"))
+	solver.Assert(x.NE(ctx.FromString("go")))
+	solver.Assert(x.NE(ctx.FromString("GO")))
+	solver.Assert(x.NE(ctx.FromString("Go")))
+	if sat, _ := solver.Check(); !sat {
+		t.Fatal("expected SAT")
+	}
+	if s, _ := solver.Model().EvalAsString(x, true); s != "gO" {
+		t.Errorf("expected gO, got %q", s)
+	}
+
+	solver = NewSolver(ctx)
+	solver.Assert(ctx.FromString("DROP TABLE users").ContainsFold("table").Not())
+	if sat, _ := solver.Check(); sat {
+		t.Error("expected UNSAT for ContainsFold")
+	}
+}
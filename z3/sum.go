@@ -0,0 +1,63 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// SumRange returns the sum of x[i] for lo <= i < hi, or 0 if hi <= lo.
+// x must have sort [Int -> Int].
+//
+// The sum is a recursive function that Z3 unfolds on demand, so unlike
+// an explicit x[lo] + x[lo+1] + ... it stays small however wide the
+// range is, and lo and hi may be symbolic. Only solvers unfold it:
+// Model.Eval leaves applications of the sum unevaluated.
+func (x Array) SumRange(lo, hi Int) Int {
+	ctx := x.ctx
+	domain, range_ := x.Sort().DomainAndRange()
+	if domain.Kind() != KindInt || range_.Kind() != KindInt {
+		panic("z3: SumRange: array sort " + x.Sort().String() + " is not [Int -> Int]")
+	}
+	sum := ctx.sumRangeFunc(x.Sort(), func(f FuncDecl, a Value, i, j Int) Int {
+		return a.(Array).Select(i).(Int).Add(f.Apply(a, i.Add(ctx.Int(1)), j).(Int))
+	})
+	return sum.Apply(x, lo, hi).(Int)
+}
+
+// SumRange returns the sum of l[i] for lo <= i < hi, or 0 if hi <= lo.
+// Indexes outside l contribute 0. l must be a sequence of Ints.
+//
+// Like Array.SumRange, the sum is a recursive function that Z3 unfolds
+// on demand.
+func (l Seq) SumRange(lo, hi Int) Int {
+	ctx := l.ctx
+	if l.ElemSort().Kind() != KindInt {
+		panic("z3: SumRange: sequence sort " + l.Sort().String() + " does not have Int elements")
+	}
+	sum := ctx.sumRangeFunc(l.Sort(), func(f FuncDecl, a Value, i, j Int) Int {
+		s := a.(Seq)
+		in := i.GE(ctx.Int(0)).And(i.LT(s.Length()))
+		elem := in.IfThenElse(s.Nth(i), ctx.Int(0)).(Int)
+		return elem.Add(f.Apply(s, i.Add(ctx.Int(1)), j).(Int))
+	})
+	return sum.Apply(l, lo, hi).(Int)
+}
+
+// sumRangeKey is the Context.Extra key of the sum function over
+// collections of a sort, identified by its ID.
+type sumRangeKey uint64
+
+// sumRangeFunc returns the recursive function sum(a, i, j) over
+// collections of sort s, defining it the first time ctx needs it. sum
+// is 0 if j <= i and step(sum, a, i, j) otherwise.
+func (ctx *Context) sumRangeFunc(s Sort, step func(f FuncDecl, a Value, i, j Int) Int) FuncDecl {
+	key := sumRangeKey(s.ID())
+	if f, ok := ctx.Extra(key).(FuncDecl); ok {
+		return f
+	}
+	is := ctx.IntSort()
+	f := ctx.RecFuncDecl("sum_range", []Sort{s, is, is}, is)
+	a, i, j := ctx.FreshConst("a", s), ctx.FreshConst("i", is).(Int), ctx.FreshConst("j", is).(Int)
+	f.AddRecDef([]Value{a, i, j}, j.LE(i).IfThenElse(ctx.Int(0), step(f, a, i, j)))
+	ctx.SetExtra(key, f)
+	return f
+}
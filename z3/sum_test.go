@@ -0,0 +1,54 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestArraySumRange(t *testing.T) {
+	ctx := NewContext(nil)
+	is := ctx.IntSort()
+	a := ctx.Const("a", ctx.ArraySort(is, is)).(Array)
+	s := NewSolver(ctx)
+	for i := 0; i < 5; i++ {
+		s.Assert(a.Select(ctx.Int(i)).(Int).Eq(ctx.Int(i + 1)))
+	}
+	for _, tt := range []struct{ lo, hi, want int }{
+		{1, 4, 9}, {0, 5, 15}, {3, 3, 0}, {4, 2, 0},
+	} {
+		s.Push()
+		s.Assert(a.SumRange(ctx.Int(tt.lo), ctx.Int(tt.hi)).NE(ctx.Int(tt.want)))
+		if sat, err := s.Check(); sat || err != nil {
+			t.Errorf("sum a[%d:%d] != %d: got %v, %v; want unsat", tt.lo, tt.hi, tt.want, sat, err)
+		}
+		s.Pop()
+	}
+
+	// Find a window of width 3 with sum 12.
+	k := ctx.IntConst("k")
+	s.Assert(a.SumRange(k, k.Add(ctx.Int(3))).Eq(ctx.Int(12)))
+	s.Assert(k.GE(ctx.Int(0)).And(k.LE(ctx.Int(2))))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("window: got %v, %v; want sat", sat, err)
+	}
+	if got := s.Model().Eval(k, true).String(); got != "2" {
+		t.Errorf("window starts at %s, want 2", got)
+	}
+}
+
+func TestSeqSumRange(t *testing.T) {
+	ctx := NewContext(nil)
+	is := ctx.IntSort()
+	l := ctx.FromValues(is, ctx.Int(4), ctx.Int(-1), ctx.Int(7))
+	for _, tt := range []struct{ lo, hi, want int }{
+		{0, 3, 10}, {1, 3, 6}, {-5, 10, 10}, {2, 1, 0},
+	} {
+		sum := ctx.Simplify(l.SumRange(ctx.Int(tt.lo), ctx.Int(tt.hi)), nil)
+		s := NewSolver(ctx)
+		s.Assert(sum.(Int).NE(ctx.Int(tt.want)))
+		if sat, err := s.Check(); sat || err != nil {
+			t.Errorf("sum l[%d:%d] != %d: got %v, %v; want unsat", tt.lo, tt.hi, tt.want, sat, err)
+		}
+	}
+}
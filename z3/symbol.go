@@ -0,0 +1,107 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+import (
+	"runtime"
+	"strconv"
+)
+
+// A Symbol names a constant, function, or sort. A Symbol is either a
+// string or an integer. Integer symbols are convenient for
+// machine-generated problems, such as DIMACS variables, that are
+// naturally numbered.
+//
+// Two Symbols from the same Context are equal if and only if they are
+// the same string or the same integer; integer symbols are distinct
+// from all string symbols.
+type Symbol struct {
+	ctx *Context
+	c   C.Z3_symbol
+}
+
+// IntSymbol returns the integer symbol i. i must be non-negative and
+// less than 2^30.
+func (ctx *Context) IntSymbol(i int) Symbol {
+	var sym Symbol
+	ctx.do(func() {
+		sym = Symbol{ctx, C.Z3_mk_int_symbol(ctx.c, C.int(i))}
+	})
+	return sym
+}
+
+// StringSymbol returns the string symbol s.
+func (ctx *Context) StringSymbol(s string) Symbol {
+	return Symbol{ctx, ctx.symbol(s)}
+}
+
+// IsInt reports whether sym is an integer symbol.
+func (sym Symbol) IsInt() bool {
+	var res bool
+	sym.ctx.do(func() {
+		res = C.Z3_get_symbol_kind(sym.ctx.c, sym.c) == C.Z3_INT_SYMBOL
+	})
+	return res
+}
+
+// AsInt returns the value of sym if it is an integer symbol.
+// Otherwise, it returns 0, false.
+func (sym Symbol) AsInt() (val int, ok bool) {
+	sym.ctx.do(func() {
+		if C.Z3_get_symbol_kind(sym.ctx.c, sym.c) == C.Z3_INT_SYMBOL {
+			val, ok = int(C.Z3_get_symbol_int(sym.ctx.c, sym.c)), true
+		}
+	})
+	return
+}
+
+// String returns the name of sym. Integer symbols are written as
+// "k!i", following Z3's printer.
+func (sym Symbol) String() string {
+	var res string
+	sym.ctx.do(func() {
+		if C.Z3_get_symbol_kind(sym.ctx.c, sym.c) == C.Z3_INT_SYMBOL {
+			res = "k!" + strconv.Itoa(int(C.Z3_get_symbol_int(sym.ctx.c, sym.c)))
+		} else {
+			res = C.GoString(C.Z3_get_symbol_string(sym.ctx.c, sym.c))
+		}
+	})
+	return res
+}
+
+// ConstSym is like Const, but names the constant with sym, which may
+// be an integer symbol.
+func (ctx *Context) ConstSym(sym Symbol, sort Sort) Value {
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_const(ctx.c, sym.c, sort.c)
+	})
+	runtime.KeepAlive(sort)
+	return val.lift(sort.Kind())
+}
+
+// FuncDeclSym is like FuncDecl, but names the function with sym,
+// which may be an integer symbol.
+func (ctx *Context) FuncDeclSym(sym Symbol, domain []Sort, range_ Sort) FuncDecl {
+	cdomain := make([]C.Z3_sort, len(domain))
+	for i, sort := range domain {
+		cdomain[i] = sort.c
+	}
+	var funcdecl FuncDecl
+	ctx.do(func() {
+		var cdp *C.Z3_sort
+		if len(cdomain) > 0 {
+			cdp = &cdomain[0]
+		}
+		funcdecl = wrapFuncDecl(ctx, C.Z3_mk_func_decl(ctx.c, sym.c, C.uint(len(cdomain)), cdp, range_.c))
+	})
+	runtime.KeepAlive(domain)
+	runtime.KeepAlive(range_)
+	return funcdecl
+}
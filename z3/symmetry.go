@@ -0,0 +1,77 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// LexLE returns a constraint that xs is lexicographically less than or
+// equal to ys: either they are equal, or they are equal up to some
+// position where xs is less than ys.
+//
+// xs and ys must have the same non-zero length, and corresponding
+// elements the same sort. Elements may be Bools, ordered false before
+// true, Ints, Reals, or BVs, compared as unsigned.
+func LexLE(xs, ys []Value) Bool {
+	if len(xs) != len(ys) || len(xs) == 0 {
+		panic("z3: LexLE: vectors must have the same non-zero length")
+	}
+	// Build x[i:] <= y[i:] from the back, as
+	// x[i] <= y[i] && (x[i] < y[i] || x[i+1:] <= y[i+1:]).
+	var res Bool
+	for i := len(xs) - 1; i >= 0; i-- {
+		le, lt := lexCompare(xs[i], ys[i])
+		if i == len(xs)-1 {
+			res = le
+		} else {
+			res = le.And(lt.Or(res))
+		}
+	}
+	return res
+}
+
+// lexCompare returns x <= y and x < y.
+func lexCompare(x, y Value) (le, lt Bool) {
+	switch x := x.(type) {
+	case Bool:
+		y := y.(Bool)
+		return x.Implies(y), x.Not().And(y)
+	case Int:
+		y := y.(Int)
+		return x.LE(y), x.LT(y)
+	case Real:
+		y := y.(Real)
+		return x.LE(y), x.LT(y)
+	case BV:
+		y := y.(BV)
+		return x.ULE(y), x.ULT(y)
+	}
+	panic("z3: LexLE: sort " + x.Sort().String() + " is not ordered")
+}
+
+// LexLeader returns lex-leader symmetry-breaking constraints for
+// groups of interchangeable rows.
+//
+// Each group is a list of rows, such as the variables describing each
+// of several identical bins, machines, or colors, that can be permuted
+// in any solution to give another solution. The constraints require
+// the rows of each group to be in lexicographically non-decreasing
+// order (see LexLE), which keeps exactly one solution out of each set
+// of permutations of a group's rows. Removing this symmetry can make
+// unsatisfiable or optimization problems dramatically faster to solve,
+// since the solver no longer explores every permutation.
+//
+// For interchangeable single variables, use one-element rows; the
+// constraints then say x[0] <= x[1] <= ... <= x[n-1].
+//
+// The constraints preserve satisfiability only if every group really
+// is symmetric: permuting its rows must map solutions to solutions,
+// and the rows of different groups must not overlap.
+func LexLeader(groups ...[][]Value) []Bool {
+	var res []Bool
+	for _, rows := range groups {
+		for i := 1; i < len(rows); i++ {
+			res = append(res, LexLE(rows[i-1], rows[i]))
+		}
+	}
+	return res
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+// countModels returns the number of distinct assignments to vars that
+// satisfy s.
+func countModels(t *testing.T, s *Solver, vars []Value) int {
+	t.Helper()
+	s.Push()
+	defer s.Pop()
+	n := 0
+	for {
+		sat, err := s.Check()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sat {
+			return n
+		}
+		n++
+		m := s.Model()
+		var diff []Bool
+		for _, v := range vars {
+			diff = append(diff, s.ctx.Distinct(v, m.Eval(v, true)))
+		}
+		s.Assert(diff[0].Or(diff[1:]...))
+	}
+}
+
+func TestLexLE(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	xs := []Value{ctx.BVConst("x0", 2), ctx.BVConst("x1", 2)}
+	ys := []Value{ctx.BVConst("y0", 2), ctx.BVConst("y1", 2)}
+	s.Assert(LexLE(xs, ys))
+	// Of the 16*16 pairs of 4-bit numbers, 16*17/2 are ordered.
+	if n := countModels(t, s, append(xs, ys...)); n != 136 {
+		t.Errorf("got %d models, want 136", n)
+	}
+}
+
+func TestLexLeader(t *testing.T) {
+	ctx := NewContext(nil)
+
+	// Three interchangeable, distinct integers in [0, 2].
+	s := NewSolver(ctx)
+	var xs []Value
+	var rows [][]Value
+	for _, name := range []string{"x", "y", "z"} {
+		x := ctx.IntConst(name)
+		s.Assert(x.GE(ctx.Int(0)).And(x.LE(ctx.Int(2))))
+		xs = append(xs, x)
+		rows = append(rows, []Value{x})
+	}
+	s.Assert(ctx.Distinct(xs...))
+	if n := countModels(t, s, xs); n != 6 {
+		t.Fatalf("got %d models without symmetry breaking, want 6", n)
+	}
+	s.AssertAll(LexLeader(rows))
+	if n := countModels(t, s, xs); n != 1 {
+		t.Errorf("got %d models with symmetry breaking, want 1", n)
+	}
+
+	// Two bins of three Boolean slots, exactly two slots used.
+	s = NewSolver(ctx)
+	var bins [][]Value
+	var all []Bool
+	for i := 0; i < 2; i++ {
+		var bin []Value
+		for j := 0; j < 3; j++ {
+			b := ctx.FreshConst("slot", ctx.BoolSort()).(Bool)
+			bin = append(bin, b)
+			all = append(all, b)
+		}
+		bins = append(bins, bin)
+	}
+	s.Assert(ctx.AtMost(all, 2))
+	s.Assert(ctx.AtLeast(all, 2))
+	vars := make([]Value, len(all))
+	for i, b := range all {
+		vars[i] = b
+	}
+	// Of the 15 ways to pick two slots, 3 are within the first bin, 3
+	// within the second, and 9 across bins. Ordering the bins keeps
+	// the second bin's pairs, the 3 symmetric cross pairs, and half
+	// of the other 6.
+	s.AssertAll(LexLeader(bins))
+	if n := countModels(t, s, vars); n != 9 {
+		t.Errorf("got %d models with bins ordered, want 9", n)
+	}
+}
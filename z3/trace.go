@@ -0,0 +1,74 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// A TraceFunc is called after each call into Z3 made by a Context.
+// op names the method of this package that the caller invoked, such
+// as "Solver.Check" or "BV.Add", and dur is the time spent in Z3.
+//
+// args holds the operands of calls that build a Value from other
+// Values, such as l and r of BV.Add, and of calls that add assertions
+// to a Solver. It is nil for other calls. The String method of each
+// AST gives its SMT-LIB form.
+//
+// A method may call into Z3 more than once, in which case the
+// TraceFunc is called once for each call. A TraceFunc may use the
+// Context.
+type TraceFunc func(op string, args []AST, dur time.Duration)
+
+// SetTrace sets the function that is called after each call into Z3
+// made by ctx. This can be used to log or measure slow operations. If
+// f is nil, tracing is disabled, which is the default.
+func (ctx *Context) SetTrace(f TraceFunc) {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	ctx.trace = f
+}
+
+// tracePrefix is the prefix of the names of functions in this
+// package, as reported by the runtime.
+var tracePrefix = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndex(name, "/")
+	return name[:slash+strings.Index(name[slash:], ".")+1]
+}()
+
+// traceOp returns the name of the outermost exported function or
+// method of this package on the caller's stack, which is the API
+// entry point that led to the call into Z3.
+func traceOp() string {
+	op := "z3"
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for more := true; more; {
+		var frame runtime.Frame
+		frame, more = frames.Next()
+		if !strings.HasPrefix(frame.Function, tracePrefix) || strings.HasSuffix(frame.File, "_test.go") {
+			break
+		}
+		name := frame.Function[len(tracePrefix):]
+		// Strip closure suffixes like ".func1" or ".1".
+		for {
+			i := strings.LastIndex(name, ".")
+			if i < 0 || strings.TrimLeft(strings.TrimPrefix(name[i+1:], "func"), "0123456789") != "" {
+				break
+			}
+			name = name[:i]
+		}
+		last := name[strings.LastIndex(name, ".")+1:]
+		if last != "" && unicode.IsUpper(rune(last[0])) {
+			op = strings.NewReplacer("(*", "", ")", "").Replace(name)
+		}
+	}
+	return op
+}
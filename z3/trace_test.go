@@ -0,0 +1,56 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTrace(t *testing.T) {
+	ctx := NewContext(nil)
+	ops := make(map[string]int)
+	args := make(map[string][]string)
+	ctx.SetTrace(func(op string, asts []AST, dur time.Duration) {
+		if dur < 0 {
+			t.Errorf("%s: negative duration %v", op, dur)
+		}
+		ops[op]++
+		for _, ast := range asts {
+			args[op] = append(args[op], ast.String())
+		}
+	})
+	x := ctx.IntConst("x")
+	solver := NewSolver(ctx)
+	solver.Assert(x.GT(ctx.Int(1)))
+	solver.AssertAll([]Bool{x.LT(ctx.Int(5)), x.NE(ctx.Int(3))})
+	solver.Check()
+	for _, op := range []string{"Context.IntConst", "Int.GT", "Solver.Assert", "Solver.Check"} {
+		if ops[op] == 0 {
+			t.Errorf("no trace for %s; got %v", op, ops)
+		}
+	}
+	for op, want := range map[string][]string{
+		"Int.GT":           {"x", "1"},
+		"Solver.Assert":    {"(> x 1)"},
+		"Solver.AssertAll": {"(< x 5)", "(distinct x 3)"},
+		"Int.NE":           {"x", "3"},
+	} {
+		if !reflect.DeepEqual(args[op], want) {
+			t.Errorf("%s traced with %q, want %q", op, args[op], want)
+		}
+	}
+	if args["Solver.Check"] != nil {
+		t.Errorf("Solver.Check traced with %q", args["Solver.Check"])
+	}
+
+	ctx.SetTrace(nil)
+	n := len(ops)
+	x.Add(x)
+	if len(ops) != n {
+		t.Errorf("trace called after SetTrace(nil)")
+	}
+}
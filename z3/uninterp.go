@@ -39,4 +39,54 @@ func (ctx *Context) UninterpretedSort(name string) Sort {
 	return sort
 }
 
-//go:generate go run genwrap.go -t Uninterpreted $GOFILE
+// SizeAtLeast returns a constraint that uninterpreted sort s has at
+// least k elements. It says that k fresh constants of sort s are
+// distinct, so it is quantifier-free.
+func (s Sort) SizeAtLeast(k int) Bool {
+	s.checkUninterpreted("SizeAtLeast")
+	ctx := s.ctx
+	if k <= 1 {
+		return ctx.FromBool(true)
+	}
+	return ctx.Distinct(s.representatives(k)...)
+}
+
+// SizeAtMost returns a constraint that uninterpreted sort s has at
+// most k elements. It says that every element of s is equal to one of
+// k fresh constants, which requires a quantifier. Z3's model-based
+// quantifier instantiation handles it well, which makes it suitable
+// for finite model finding: asserting SizeAtMost for increasing k
+// finds the smallest model.
+//
+// Sorts are never empty, so SizeAtMost(0) is false.
+func (s Sort) SizeAtMost(k int) Bool {
+	s.checkUninterpreted("SizeAtMost")
+	ctx := s.ctx
+	if k <= 0 {
+		return ctx.FromBool(false)
+	}
+	x := ctx.FreshConst("x", s)
+	reps := s.representatives(k)
+	eqs := make([]Bool, k)
+	for i, r := range reps {
+		eqs[i] = valueEq(x, r)
+	}
+	return forall([]Value{x}, nil, eqs[0].Or(eqs[1:]...))
+}
+
+func (s Sort) checkUninterpreted(op string) {
+	if s.Kind() != KindUninterpreted {
+		panic("z3: " + op + ": sort " + s.String() + " is not uninterpreted")
+	}
+}
+
+// representatives returns k fresh constants of sort s.
+func (s Sort) representatives(k int) []Value {
+	reps := make([]Value, k)
+	for i := range reps {
+		reps[i] = s.ctx.FreshConst(s.String(), s)
+	}
+	return reps
+}
+
+//go:generate go run genwrap.go -t Uninterpreted -e $GOFILE
@@ -51,3 +51,43 @@ func TestUninterpreted(t *testing.T) {
 	// get an "invalid argument" panic.
 	wantPanic(t, "invalid argument", func() { m.SortUniverse(u2) })
 }
+
+func TestSortSize(t *testing.T) {
+	ctx := NewContext(nil)
+	u := ctx.UninterpretedSort("u")
+	a, b, c := ctx.Const("a", u), ctx.Const("b", u), ctx.Const("c", u)
+
+	s := NewSolver(ctx)
+	s.Assert(u.SizeAtMost(2))
+	s.Assert(ctx.Distinct(a, b))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("two elements: got %v, %v; want sat", sat, err)
+	}
+	if n := len(s.Model().SortUniverse(u)); n != 2 {
+		t.Errorf("universe has %d elements, want 2", n)
+	}
+	s.Assert(ctx.Distinct(a, b, c))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("three elements: got %v, %v; want unsat", sat, err)
+	}
+
+	s.Reset()
+	s.Assert(u.SizeAtLeast(3))
+	s.Assert(u.SizeAtMost(3))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("exactly three: got %v, %v; want sat", sat, err)
+	}
+	if n := len(s.Model().SortUniverse(u)); n != 3 {
+		t.Errorf("universe has %d elements, want 3", n)
+	}
+	s.Assert(u.SizeAtMost(2))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("at least 3 and at most 2: got %v, %v; want unsat", sat, err)
+	}
+
+	s.Reset()
+	s.Assert(u.SizeAtMost(0))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("empty sort: got %v, %v; want unsat", sat, err)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "math/rand"
+
+// xorCellMax is the largest cell that SampleUniform samples from. It
+// is also the pivot of the rejection step: a cell of size k is kept
+// with probability k/xorCellMax. Larger cells reject less often at
+// the cost of more Checks per cell.
+const xorCellMax = 32
+
+// SampleUniform draws n solutions to asserts uniformly at random from
+// all solutions over vars, for unbiased randomized testing. Repeated
+// Checks, or even random seeds, favor the solutions that are easiest
+// for the solver to find; SampleUniform does not. Each solution gives
+// the values of vars, in order. Solutions are drawn with replacement,
+// so they may repeat.
+//
+// vars must be Bools or BVs. SampleUniform uses hashing with random
+// XOR constraints, as in UniGen: each constraint fixes the parity of a
+// random subset of the bits of vars, and so keeps each solution with
+// probability 1/2. It adds constraints until the remaining cell of
+// solutions is small enough to enumerate. Since cells differ in size,
+// picking from the cell directly would favor solutions in small
+// cells, so SampleUniform picks one of xorCellMax slots and rejects
+// the cell if the slot is empty. Every solution is then equally
+// likely to be drawn, even when the space is far too large to
+// enumerate. If the whole space fits in one cell, it is sampled
+// directly.
+//
+// rnd is the source of randomness. If rnd is nil, SampleUniform uses a
+// fixed seed, so its results are reproducible.
+//
+// If asserts is unsatisfiable, SampleUniform returns no solutions. If
+// a Check fails, it returns the solutions found so far and the error.
+func (ctx *Context) SampleUniform(asserts []Bool, vars []Value, n int, rnd *rand.Rand) ([][]Value, error) {
+	if rnd == nil {
+		rnd = rand.New(rand.NewSource(1))
+	}
+	var bits []Bool
+	for _, v := range vars {
+		switch v := v.(type) {
+		case Bool:
+			bits = append(bits, v)
+		case BV:
+			one := ctx.FromInt(1, ctx.BVSort(1)).(BV)
+			for i := 0; i < v.Sort().BVSize(); i++ {
+				bits = append(bits, v.Extract(i, i).Eq(one))
+			}
+		default:
+			panic("z3: SampleUniform: vars must be Bools or BVs")
+		}
+	}
+
+	s := NewSolver(ctx)
+	defer s.Close()
+	s.AssertAll(asserts)
+	var sols [][]Value
+	m := 0 // Number of XOR constraints
+	for len(sols) < n {
+		s.Push()
+		for i := 0; i < m; i++ {
+			var subset []Bool
+			for _, b := range bits {
+				if rnd.Intn(2) == 1 {
+					subset = append(subset, b)
+				}
+			}
+			s.Assert(ctx.Xor(subset, rnd.Intn(2) == 1))
+		}
+		cell, err := enumerateCell(s, vars, xorCellMax+1)
+		s.Pop()
+		switch {
+		case err != nil:
+			return sols, err
+		case len(cell) > xorCellMax:
+			if m < len(bits) {
+				m++
+			}
+		case len(cell) == 0 && m == 0:
+			return sols, nil
+		case len(cell) == 0:
+			// Too many constraints for this hash; start the
+			// next one with fewer.
+			m--
+		case m == 0:
+			// The cell holds every solution.
+			sols = append(sols, cell[rnd.Intn(len(cell))])
+		default:
+			if i := rnd.Intn(xorCellMax); i < len(cell) {
+				sols = append(sols, cell[i])
+			}
+		}
+	}
+	return sols, nil
+}
+
+// enumerateCell returns up to limit solutions of s over vars. It
+// leaves blocking clauses on s, so the caller should Push and Pop
+// around it.
+func enumerateCell(s *Solver, vars []Value, limit int) ([][]Value, error) {
+	var cell [][]Value
+	for len(cell) < limit {
+		sat, err := s.Check()
+		if err != nil || !sat {
+			return cell, err
+		}
+		m := s.Model()
+		sol := make([]Value, len(vars))
+		block := s.ctx.FromBool(false)
+		for i, v := range vars {
+			sol[i] = m.Eval(v, true)
+			block = block.Or(s.ctx.Distinct(v, sol[i]))
+		}
+		m.Close()
+		cell = append(cell, sol)
+		s.Assert(block)
+	}
+	return cell, nil
+}
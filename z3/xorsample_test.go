@@ -0,0 +1,102 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestSampleUniform(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BVConst("x", 4)
+	var vars []Value
+	var bs []Bool
+	for i := 0; i < 3; i++ {
+		b := ctx.FreshConst("b", ctx.BoolSort()).(Bool)
+		vars = append(vars, b)
+		bs = append(bs, b)
+	}
+	vars = append(vars, x)
+	// 7 choices of bs times 12 values of x: more solutions than fit
+	// in one cell.
+	asserts := []Bool{
+		bs[0].Or(bs[1:]...),
+		x.ULT(ctx.FromInt(12, x.Sort()).(BV)),
+	}
+
+	sols, err := ctx.SampleUniform(asserts, vars, 100, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sols) != 100 {
+		t.Fatalf("got %d solutions, want 100", len(sols))
+	}
+	seen := make(map[string]bool)
+	for _, sol := range sols {
+		key := fmt.Sprint(sol)
+		seen[key] = true
+		if sol[0].String() == "false" && sol[1].String() == "false" && sol[2].String() == "false" {
+			t.Errorf("solution %s violates the assertions", key)
+		}
+		if v, _, _ := sol[3].(BV).AsUint64(); v >= 12 {
+			t.Errorf("solution %s violates the assertions", key)
+		}
+	}
+	// 100 uniform draws from 84 solutions give about 59 distinct
+	// ones.
+	if len(seen) < 40 {
+		t.Errorf("got %d distinct solutions, want at least 40", len(seen))
+	}
+
+	sols, err = ctx.SampleUniform([]Bool{ctx.FromBool(false)}, vars, 3, nil)
+	if len(sols) != 0 || err != nil {
+		t.Errorf("unsatisfiable assertions: got %v, %v", sols, err)
+	}
+}
+
+func TestSampleUniformSkewed(t *testing.T) {
+	ctx := NewContext(nil)
+	b := ctx.BoolConst("b")
+	x := ctx.BVConst("x", 6)
+	// b=true has 4 solutions and b=false has 64, so the space is
+	// far from evenly split between the two branches.
+	asserts := []Bool{b.Implies(x.ULT(ctx.FromInt(4, x.Sort()).(BV)))}
+
+	const n = 680
+	sols, err := ctx.SampleUniform(asserts, []Value{b, x}, n, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	small := 0
+	for _, sol := range sols {
+		counts[fmt.Sprint(sol)]++
+		if sol[0].String() == "true" {
+			small++
+		}
+	}
+	if len(counts) != 68 {
+		t.Errorf("got %d distinct solutions, want all 68", len(counts))
+	}
+	// Each solution is expected n/68 = 10 times. The chi-squared
+	// statistic has 67 degrees of freedom, so it is below 110 with
+	// probability 0.999.
+	chi2 := 0.0
+	for _, c := range counts {
+		d := float64(c) - n/68.0
+		chi2 += d * d / (n / 68.0)
+	}
+	chi2 += float64(68-len(counts)) * n / 68.0
+	if chi2 > 110 {
+		t.Errorf("chi-squared statistic %.1f, want at most 110", chi2)
+	}
+	// The b=true branch is expected 40 times, with standard
+	// deviation 6.
+	if small < 20 || small > 60 {
+		t.Errorf("got b=true %d times, want about 40", small)
+	}
+}
@@ -0,0 +1,458 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3log
+
+import "unsafe"
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// A funcDef is a Z3 API function that Replay supports.
+type funcDef struct {
+	name string
+
+	// sig gives the kinds of the function's arguments, one byte
+	// per argument:
+	//
+	//	c context     g config      a AST         s sort
+	//	d func decl   v solver      m model       p params
+	//	e AST vector  y symbol      t string      f double
+	//	u unsigned    i int         l int64       k uint64
+	//	b bool        A AST array   S sort array  o output AST
+	sig string
+
+	// res is the kind of the function's result, in the same
+	// notation, or '-' if calibration should not pass the result
+	// to later calls. It is 'L' for satisfiability checks.
+	res byte
+
+	call func(x *args) unsafe.Pointer
+}
+
+// funcs are the functions Replay supports.
+//
+// Calibration calls them in this order, passing each the most recent
+// result of the right kind, so a function must come after those that
+// create its arguments. Solvers, models, params, and AST vectors are
+// reference counted even in contexts created by Z3_mk_context, so
+// their inc_ref follows their creation and their dec_ref comes at the
+// end. Functions that create objects calibration should not keep
+// have result kind '-'.
+//
+// Some API functions, such as Z3_is_eq_ast and Z3_sort_to_string, are
+// not logged as themselves, so they have no entry.
+var funcs = []funcDef{
+	// Configs and contexts.
+	{"Z3_mk_config", "", 'g', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_config())
+	}},
+	{"Z3_set_param_value", "gtt", '-', func(x *args) unsafe.Pointer {
+		C.Z3_set_param_value(x.cfg(0), x.str(1), x.str(2))
+		return nil
+	}},
+	{"Z3_mk_context_rc", "g", 'c', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_context_rc(x.cfg(0)))
+	}},
+	{"Z3_del_context", "c", '-', func(x *args) unsafe.Pointer {
+		C.Z3_del_context(x.ctx(0))
+		return nil
+	}},
+	{"Z3_mk_context", "g", 'c', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_context(x.cfg(0)))
+	}},
+	{"Z3_del_config", "g", '-', func(x *args) unsafe.Pointer {
+		C.Z3_del_config(x.cfg(0))
+		return nil
+	}},
+	{"Z3_update_param_value", "ctt", '-', func(x *args) unsafe.Pointer {
+		C.Z3_update_param_value(x.ctx(0), x.str(1), x.str(2))
+		return nil
+	}},
+
+	// Symbols and sorts.
+	{"Z3_mk_string_symbol", "ct", 'y', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_string_symbol(x.ctx(0), x.str(1)))
+	}},
+	{"Z3_mk_int_symbol", "ci", '-', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_int_symbol(x.ctx(0), x.int(1)))
+	}},
+	{"Z3_mk_uninterpreted_sort", "cy", 's', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_uninterpreted_sort(x.ctx(0), x.sym(1)))
+	}},
+	{"Z3_mk_bool_sort", "c", 's', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_bool_sort(x.ctx(0)))
+	}},
+	{"Z3_mk_real_sort", "c", 's', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_real_sort(x.ctx(0)))
+	}},
+	{"Z3_mk_string_sort", "c", 's', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_string_sort(x.ctx(0)))
+	}},
+	{"Z3_mk_bv_sort", "cu", 's', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_bv_sort(x.ctx(0), x.uint(1)))
+	}},
+	{"Z3_mk_array_sort", "css", 's', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_array_sort(x.ctx(0), x.sort(1), x.sort(2)))
+	}},
+	{"Z3_mk_int_sort", "c", 's', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_int_sort(x.ctx(0)))
+	}},
+
+	// Declarations, constants, and numerals.
+	{"Z3_mk_func_decl", "cyuSs", 'd', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_func_decl(x.ctx(0), x.sym(1), x.uint(2), x.sorts(3), x.sort(4)))
+	}},
+	{"Z3_mk_fresh_func_decl", "ctuSs", 'd', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_fresh_func_decl(x.ctx(0), x.str(1), x.uint(2), x.sorts(3), x.sort(4)))
+	}},
+	{"Z3_mk_const", "cys", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_const(x.ctx(0), x.sym(1), x.sort(2)))
+	}},
+	{"Z3_mk_fresh_const", "cts", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_fresh_const(x.ctx(0), x.str(1), x.sort(2)))
+	}},
+	{"Z3_mk_app", "cduA", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_app(x.ctx(0), x.decl(1), x.uint(2), x.asts(3)))
+	}},
+	{"Z3_mk_numeral", "cts", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_numeral(x.ctx(0), x.str(1), x.sort(2)))
+	}},
+	{"Z3_mk_int", "cis", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_int(x.ctx(0), x.int(1), x.sort(2)))
+	}},
+	{"Z3_mk_unsigned_int", "cus", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_unsigned_int(x.ctx(0), x.uint(1), x.sort(2)))
+	}},
+	{"Z3_mk_int64", "cls", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_int64(x.ctx(0), x.int64(1), x.sort(2)))
+	}},
+	{"Z3_mk_unsigned_int64", "cks", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_unsigned_int64(x.ctx(0), x.uint64(1), x.sort(2)))
+	}},
+	{"Z3_mk_false", "c", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_false(x.ctx(0)))
+	}},
+
+	// Operators.
+	{"Z3_mk_not", "ca", 'a', op1(func(c C.Z3_context, a C.Z3_ast) C.Z3_ast { return C.Z3_mk_not(c, a) })},
+	{"Z3_mk_unary_minus", "ca", 'a', op1(func(c C.Z3_context, a C.Z3_ast) C.Z3_ast { return C.Z3_mk_unary_minus(c, a) })},
+	{"Z3_mk_int2real", "ca", 'a', op1(func(c C.Z3_context, a C.Z3_ast) C.Z3_ast { return C.Z3_mk_int2real(c, a) })},
+	{"Z3_mk_real2int", "ca", 'a', op1(func(c C.Z3_context, a C.Z3_ast) C.Z3_ast { return C.Z3_mk_real2int(c, a) })},
+	{"Z3_mk_is_int", "ca", 'a', op1(func(c C.Z3_context, a C.Z3_ast) C.Z3_ast { return C.Z3_mk_is_int(c, a) })},
+	{"Z3_mk_bvnot", "ca", 'a', op1(func(c C.Z3_context, a C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvnot(c, a) })},
+	{"Z3_mk_bvneg", "ca", 'a', op1(func(c C.Z3_context, a C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvneg(c, a) })},
+	{"Z3_mk_eq", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_eq(c, a, b) })},
+	{"Z3_mk_iff", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_iff(c, a, b) })},
+	{"Z3_mk_implies", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_implies(c, a, b) })},
+	{"Z3_mk_xor", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_xor(c, a, b) })},
+	{"Z3_mk_div", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_div(c, a, b) })},
+	{"Z3_mk_mod", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_mod(c, a, b) })},
+	{"Z3_mk_rem", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_rem(c, a, b) })},
+	{"Z3_mk_power", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_power(c, a, b) })},
+	{"Z3_mk_lt", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_lt(c, a, b) })},
+	{"Z3_mk_le", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_le(c, a, b) })},
+	{"Z3_mk_gt", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_gt(c, a, b) })},
+	{"Z3_mk_ge", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_ge(c, a, b) })},
+	{"Z3_mk_bvand", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvand(c, a, b) })},
+	{"Z3_mk_bvor", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvor(c, a, b) })},
+	{"Z3_mk_bvxor", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvxor(c, a, b) })},
+	{"Z3_mk_bvadd", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvadd(c, a, b) })},
+	{"Z3_mk_bvsub", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvsub(c, a, b) })},
+	{"Z3_mk_bvmul", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvmul(c, a, b) })},
+	{"Z3_mk_bvudiv", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvudiv(c, a, b) })},
+	{"Z3_mk_bvsdiv", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvsdiv(c, a, b) })},
+	{"Z3_mk_bvurem", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvurem(c, a, b) })},
+	{"Z3_mk_bvsrem", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvsrem(c, a, b) })},
+	{"Z3_mk_bvsmod", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvsmod(c, a, b) })},
+	{"Z3_mk_bvult", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvult(c, a, b) })},
+	{"Z3_mk_bvslt", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvslt(c, a, b) })},
+	{"Z3_mk_bvule", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvule(c, a, b) })},
+	{"Z3_mk_bvsle", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvsle(c, a, b) })},
+	{"Z3_mk_bvuge", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvuge(c, a, b) })},
+	{"Z3_mk_bvsge", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvsge(c, a, b) })},
+	{"Z3_mk_bvugt", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvugt(c, a, b) })},
+	{"Z3_mk_bvsgt", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvsgt(c, a, b) })},
+	{"Z3_mk_bvshl", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvshl(c, a, b) })},
+	{"Z3_mk_bvlshr", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvlshr(c, a, b) })},
+	{"Z3_mk_bvashr", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_bvashr(c, a, b) })},
+	{"Z3_mk_concat", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_concat(c, a, b) })},
+	{"Z3_mk_select", "caa", 'a', op2(func(c C.Z3_context, a, b C.Z3_ast) C.Z3_ast { return C.Z3_mk_select(c, a, b) })},
+	{"Z3_mk_ite", "caaa", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_ite(x.ctx(0), x.ast(1), x.ast(2), x.ast(3)))
+	}},
+	{"Z3_mk_store", "caaa", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_store(x.ctx(0), x.ast(1), x.ast(2), x.ast(3)))
+	}},
+	{"Z3_mk_distinct", "cuA", 'a', opN(func(c C.Z3_context, n C.uint, a *C.Z3_ast) C.Z3_ast { return C.Z3_mk_distinct(c, n, a) })},
+	{"Z3_mk_and", "cuA", 'a', opN(func(c C.Z3_context, n C.uint, a *C.Z3_ast) C.Z3_ast { return C.Z3_mk_and(c, n, a) })},
+	{"Z3_mk_or", "cuA", 'a', opN(func(c C.Z3_context, n C.uint, a *C.Z3_ast) C.Z3_ast { return C.Z3_mk_or(c, n, a) })},
+	{"Z3_mk_add", "cuA", 'a', opN(func(c C.Z3_context, n C.uint, a *C.Z3_ast) C.Z3_ast { return C.Z3_mk_add(c, n, a) })},
+	{"Z3_mk_mul", "cuA", 'a', opN(func(c C.Z3_context, n C.uint, a *C.Z3_ast) C.Z3_ast { return C.Z3_mk_mul(c, n, a) })},
+	{"Z3_mk_sub", "cuA", 'a', opN(func(c C.Z3_context, n C.uint, a *C.Z3_ast) C.Z3_ast { return C.Z3_mk_sub(c, n, a) })},
+	{"Z3_mk_extract", "cuua", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_extract(x.ctx(0), x.uint(1), x.uint(2), x.ast(3)))
+	}},
+	{"Z3_mk_zero_ext", "cua", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_zero_ext(x.ctx(0), x.uint(1), x.ast(2)))
+	}},
+	{"Z3_mk_sign_ext", "cua", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_sign_ext(x.ctx(0), x.uint(1), x.ast(2)))
+	}},
+	{"Z3_mk_int2bv", "cua", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_int2bv(x.ctx(0), x.uint(1), x.ast(2)))
+	}},
+	{"Z3_mk_bv2int", "cab", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_bv2int(x.ctx(0), x.ast(1), x.bool(2)))
+	}},
+	{"Z3_mk_const_array", "csa", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_const_array(x.ctx(0), x.sort(1), x.ast(2)))
+	}},
+	{"Z3_simplify", "ca", 'a', op1(func(c C.Z3_context, a C.Z3_ast) C.Z3_ast { return C.Z3_simplify(c, a) })},
+
+	// Accessors. Calibration passes them the latest AST, which is
+	// an application.
+	{"Z3_get_sort", "ca", 's', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_get_sort(x.ctx(0), x.ast(1)))
+	}},
+	{"Z3_to_app", "ca", '-', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_to_app(x.ctx(0), x.ast(1)))
+	}},
+	{"Z3_get_app_decl", "ca", 'd', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_get_app_decl(x.ctx(0), C.Z3_app(unsafe.Pointer(x.ast(1)))))
+	}},
+	{"Z3_get_app_arg", "cau", '-', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_get_app_arg(x.ctx(0), C.Z3_app(unsafe.Pointer(x.ast(1))), x.uint(2)))
+	}},
+	{"Z3_get_app_num_args", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_app_num_args(x.ctx(0), C.Z3_app(unsafe.Pointer(x.ast(1))))
+		return nil
+	}},
+	{"Z3_get_sort_kind", "cs", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_sort_kind(x.ctx(0), x.sort(1))
+		return nil
+	}},
+	{"Z3_get_bv_sort_size", "cs", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_bv_sort_size(x.ctx(0), x.sort(1))
+		return nil
+	}},
+	{"Z3_get_sort_id", "cs", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_sort_id(x.ctx(0), x.sort(1))
+		return nil
+	}},
+	{"Z3_get_ast_kind", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_ast_kind(x.ctx(0), x.ast(1))
+		return nil
+	}},
+	{"Z3_get_ast_id", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_ast_id(x.ctx(0), x.ast(1))
+		return nil
+	}},
+	{"Z3_is_app", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_is_app(x.ctx(0), x.ast(1))
+		return nil
+	}},
+	{"Z3_is_numeral_ast", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_is_numeral_ast(x.ctx(0), x.ast(1))
+		return nil
+	}},
+	{"Z3_get_bool_value", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_bool_value(x.ctx(0), x.ast(1))
+		return nil
+	}},
+	{"Z3_get_numeral_string", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_numeral_string(x.ctx(0), x.ast(1))
+		return nil
+	}},
+	{"Z3_get_decl_kind", "cd", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_decl_kind(x.ctx(0), x.decl(1))
+		return nil
+	}},
+	{"Z3_get_arity", "cd", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_arity(x.ctx(0), x.decl(1))
+		return nil
+	}},
+	{"Z3_get_decl_name", "cd", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_decl_name(x.ctx(0), x.decl(1))
+		return nil
+	}},
+	{"Z3_get_symbol_string", "cy", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_symbol_string(x.ctx(0), x.sym(1))
+		return nil
+	}},
+	{"Z3_ast_to_string", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_ast_to_string(x.ctx(0), x.ast(1))
+		return nil
+	}},
+	{"Z3_get_error_code", "c", '-', func(x *args) unsafe.Pointer {
+		C.Z3_get_error_code(x.ctx(0))
+		return nil
+	}},
+
+	// Params.
+	{"Z3_mk_params", "c", 'p', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_params(x.ctx(0)))
+	}},
+	{"Z3_params_inc_ref", "cp", '-', func(x *args) unsafe.Pointer {
+		C.Z3_params_inc_ref(x.ctx(0), x.params(1))
+		return nil
+	}},
+	{"Z3_params_set_bool", "cpyb", '-', func(x *args) unsafe.Pointer {
+		C.Z3_params_set_bool(x.ctx(0), x.params(1), x.sym(2), x.bool(3))
+		return nil
+	}},
+	{"Z3_params_set_uint", "cpyu", '-', func(x *args) unsafe.Pointer {
+		C.Z3_params_set_uint(x.ctx(0), x.params(1), x.sym(2), x.uint(3))
+		return nil
+	}},
+	{"Z3_params_set_double", "cpyf", '-', func(x *args) unsafe.Pointer {
+		C.Z3_params_set_double(x.ctx(0), x.params(1), x.sym(2), x.double(3))
+		return nil
+	}},
+	{"Z3_params_set_symbol", "cpyy", '-', func(x *args) unsafe.Pointer {
+		C.Z3_params_set_symbol(x.ctx(0), x.params(1), x.sym(2), x.sym(3))
+		return nil
+	}},
+
+	// Solvers. The latest AST is now true, so the checks are sat.
+	{"Z3_mk_true", "c", 'a', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_true(x.ctx(0)))
+	}},
+	{"Z3_mk_solver", "c", 'v', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_solver(x.ctx(0)))
+	}},
+	{"Z3_solver_inc_ref", "cv", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_inc_ref(x.ctx(0), x.solver(1))
+		return nil
+	}},
+	{"Z3_mk_simple_solver", "c", '-', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_simple_solver(x.ctx(0)))
+	}},
+	{"Z3_mk_solver_for_logic", "cy", '-', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_mk_solver_for_logic(x.ctx(0), x.sym(1)))
+	}},
+	{"Z3_solver_set_params", "cvp", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_set_params(x.ctx(0), x.solver(1), x.params(2))
+		return nil
+	}},
+	{"Z3_solver_push", "cv", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_push(x.ctx(0), x.solver(1))
+		return nil
+	}},
+	{"Z3_solver_assert", "cva", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_assert(x.ctx(0), x.solver(1), x.ast(2))
+		return nil
+	}},
+	{"Z3_solver_assert_and_track", "cvaa", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_assert_and_track(x.ctx(0), x.solver(1), x.ast(2), x.ast(3))
+		return nil
+	}},
+	{"Z3_solver_check_assumptions", "cvuA", 'L', func(x *args) unsafe.Pointer {
+		x.check(C.Z3_solver_check_assumptions(x.ctx(0), x.solver(1), x.uint(2), x.asts(3)))
+		return nil
+	}},
+	{"Z3_solver_check", "cv", 'L', func(x *args) unsafe.Pointer {
+		x.check(C.Z3_solver_check(x.ctx(0), x.solver(1)))
+		return nil
+	}},
+	{"Z3_solver_get_model", "cv", 'm', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_solver_get_model(x.ctx(0), x.solver(1)))
+	}},
+	{"Z3_model_inc_ref", "cm", '-', func(x *args) unsafe.Pointer {
+		C.Z3_model_inc_ref(x.ctx(0), x.model(1))
+		return nil
+	}},
+	{"Z3_solver_get_unsat_core", "cv", '-', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_solver_get_unsat_core(x.ctx(0), x.solver(1)))
+	}},
+	{"Z3_solver_get_assertions", "cv", 'e', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_solver_get_assertions(x.ctx(0), x.solver(1)))
+	}},
+	{"Z3_ast_vector_inc_ref", "ce", '-', func(x *args) unsafe.Pointer {
+		C.Z3_ast_vector_inc_ref(x.ctx(0), x.vec(1))
+		return nil
+	}},
+	{"Z3_ast_vector_size", "ce", '-', func(x *args) unsafe.Pointer {
+		C.Z3_ast_vector_size(x.ctx(0), x.vec(1))
+		return nil
+	}},
+	{"Z3_ast_vector_get", "ceu", '-', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_ast_vector_get(x.ctx(0), x.vec(1), x.uint(2)))
+	}},
+	{"Z3_solver_get_reason_unknown", "cv", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_get_reason_unknown(x.ctx(0), x.solver(1))
+		return nil
+	}},
+	{"Z3_solver_get_num_scopes", "cv", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_get_num_scopes(x.ctx(0), x.solver(1))
+		return nil
+	}},
+	{"Z3_solver_to_string", "cv", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_to_string(x.ctx(0), x.solver(1))
+		return nil
+	}},
+	{"Z3_solver_pop", "cvu", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_pop(x.ctx(0), x.solver(1), x.uint(2))
+		return nil
+	}},
+	{"Z3_solver_reset", "cv", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_reset(x.ctx(0), x.solver(1))
+		return nil
+	}},
+
+	// Models.
+	{"Z3_model_eval", "cmabo", '-', func(x *args) unsafe.Pointer {
+		C.Z3_model_eval(x.ctx(0), x.model(1), x.ast(2), x.bool(3), x.out(4))
+		return nil
+	}},
+	{"Z3_model_get_const_interp", "cmd", '-', func(x *args) unsafe.Pointer {
+		return unsafe.Pointer(C.Z3_model_get_const_interp(x.ctx(0), x.model(1), x.decl(2)))
+	}},
+	{"Z3_model_to_string", "cm", '-', func(x *args) unsafe.Pointer {
+		C.Z3_model_to_string(x.ctx(0), x.model(1))
+		return nil
+	}},
+
+	// Reference counts of ASTs, and releases of the objects above.
+	{"Z3_inc_ref", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_inc_ref(x.ctx(0), x.ast(1))
+		return nil
+	}},
+	{"Z3_dec_ref", "ca", '-', func(x *args) unsafe.Pointer {
+		C.Z3_dec_ref(x.ctx(0), x.ast(1))
+		return nil
+	}},
+	{"Z3_ast_vector_dec_ref", "ce", '-', func(x *args) unsafe.Pointer {
+		C.Z3_ast_vector_dec_ref(x.ctx(0), x.vec(1))
+		return nil
+	}},
+	{"Z3_model_dec_ref", "cm", '-', func(x *args) unsafe.Pointer {
+		C.Z3_model_dec_ref(x.ctx(0), x.model(1))
+		return nil
+	}},
+	{"Z3_solver_dec_ref", "cv", '-', func(x *args) unsafe.Pointer {
+		C.Z3_solver_dec_ref(x.ctx(0), x.solver(1))
+		return nil
+	}},
+	{"Z3_params_dec_ref", "cp", '-', func(x *args) unsafe.Pointer {
+		C.Z3_params_dec_ref(x.ctx(0), x.params(1))
+		return nil
+	}},
+}
+
+// op1, op2, and opN adapt unary, binary, and n-ary AST constructors.
+
+func op1(f func(C.Z3_context, C.Z3_ast) C.Z3_ast) func(*args) unsafe.Pointer {
+	return func(x *args) unsafe.Pointer { return unsafe.Pointer(f(x.ctx(0), x.ast(1))) }
+}
+
+func op2(f func(C.Z3_context, C.Z3_ast, C.Z3_ast) C.Z3_ast) func(*args) unsafe.Pointer {
+	return func(x *args) unsafe.Pointer { return unsafe.Pointer(f(x.ctx(0), x.ast(1), x.ast(2))) }
+}
+
+func opN(f func(C.Z3_context, C.uint, *C.Z3_ast) C.Z3_ast) func(*args) unsafe.Pointer {
+	return func(x *args) unsafe.Pointer { return unsafe.Pointer(f(x.ctx(0), x.uint(1), x.asts(2))) }
+}
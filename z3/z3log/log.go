@@ -4,10 +4,24 @@
 
 // Package z3log exposes Z3's interaction log.
 //
-// The interaction log is a low-level trace of all Z3 API calls.
+// The interaction log is a low-level trace of all Z3 API calls. Parse
+// reads a log, and Log.Replay re-executes it in this process, for
+// example to turn a log captured from a failing production run into a
+// deterministic regression test.
+//
+// Z3 keeps a single log for the whole process, so an open log records
+// the calls of every context, and logging cannot be limited to one
+// context. Log.Context instead extracts the calls of one context from
+// a parsed log, after the fact.
 package z3log
 
-import "unsafe"
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"unsafe"
+)
 
 /*
 #cgo LDFLAGS: -lz3
@@ -16,15 +30,73 @@ import "unsafe"
 */
 import "C"
 
-// Open creates a Z3 interaction log in a file called filename.
+var (
+	// mu protects capture.
+	mu sync.Mutex
+
+	// capture is the temporary file and destination of a log
+	// opened with OpenWriter, or nil.
+	capture *writerLog
+
+	// isOpen is set while a log is open.
+	isOpen bool
+)
+
+type writerLog struct {
+	file *os.File
+	w    io.Writer
+}
+
+// Open creates a Z3 interaction log in a file called filename. The log
+// records the calls of all contexts in the process.
 //
 // It returns false if it fails to open the log.
 func Open(filename string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	discardCapture()
+	isOpen = open(filename)
+	return isOpen
+}
+
+func open(filename string) bool {
 	cfilename := C.CString(filename)
 	defer C.free(unsafe.Pointer(cfilename))
 	return bool(C.Z3_open_log(cfilename))
 }
 
+// OpenWriter creates a Z3 interaction log that is written to w.
+//
+// Z3 can only log to a file, so the log is captured in a temporary
+// file and copied to w by Close. Errors writing to w are ignored.
+func OpenWriter(w io.Writer) error {
+	mu.Lock()
+	defer mu.Unlock()
+	discardCapture()
+	f, err := os.CreateTemp("", "z3log")
+	if err != nil {
+		return err
+	}
+	if !open(f.Name()) {
+		f.Close()
+		os.Remove(f.Name())
+		return errors.New("z3log: cannot open log")
+	}
+	capture = &writerLog{f, w}
+	isOpen = true
+	return nil
+}
+
+// discardCapture removes the temporary file of a log opened with
+// OpenWriter, if any. This must be called with mu held.
+func discardCapture() {
+	if capture != nil {
+		capture.file.Close()
+		os.Remove(capture.file.Name())
+		capture = nil
+	}
+}
+
 // Append emits text to the Z3 interaction log.
 func Append(text string) {
 	ctext := C.CString(text)
@@ -32,7 +104,15 @@ func Append(text string) {
 	C.Z3_append_log(ctext)
 }
 
-// Close closes the Z3 interaction log file.
+// Close closes the Z3 interaction log file. If the log was opened with
+// OpenWriter, Close copies it to the writer.
 func Close() {
+	mu.Lock()
+	defer mu.Unlock()
 	C.Z3_close_log()
+	isOpen = false
+	if capture != nil {
+		io.Copy(capture.w, capture.file)
+		discardCapture()
+	}
 }
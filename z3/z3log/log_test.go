@@ -5,7 +5,9 @@
 package z3log
 
 import (
+	"bytes"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -39,3 +41,15 @@ func TestZ3Log(t *testing.T) {
 		t.Log("Log file is empty (may be expected depending on Z3 logging behavior)")
 	}
 }
+
+func TestOpenWriter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := OpenWriter(&buf); err != nil {
+		t.Fatal(err)
+	}
+	Append("test log entry")
+	Close()
+	if !strings.Contains(buf.String(), "test log entry") {
+		t.Errorf("log does not contain appended text: %q", buf.String())
+	}
+}
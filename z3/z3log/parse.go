@@ -0,0 +1,197 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3log
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A Log is a parsed Z3 interaction log. Replay re-executes it.
+type Log struct {
+	// Version is the version of Z3 that wrote the log.
+	Version string
+
+	// Calls are the API calls in the log, in order.
+	Calls []Call
+
+	// Messages are the texts added with Append, in order.
+	Messages []string
+}
+
+// A Call is a single Z3 API call recorded in a Log.
+type Call struct {
+	// ID identifies the API function. IDs are assigned by Z3's
+	// build and differ between Z3 versions.
+	ID int
+
+	// Args are the arguments of the call.
+	Args []Arg
+
+	// Result is the pointer returned by the call, or "" if the
+	// call did not return a pointer.
+	Result string
+
+	// Outputs maps the positions of output arguments to the
+	// pointers the call stored in them. Elements stored in output
+	// arrays are not recorded.
+	Outputs map[int]string
+
+	// Line is the line number of the call in the log.
+	Line int
+}
+
+// An Arg is an argument of a Call.
+type Arg struct {
+	// Kind is the log's code for the argument's type: 'P'
+	// (pointer), 'S' (string), 'N' (null string), '$' (symbol),
+	// '#' (numeral symbol), 'I' (int), 'U' (uint), 'D' (double), or
+	// 'p', 's', 'u', 'i' for arrays of pointers, symbols, uints,
+	// and ints.
+	Kind byte
+
+	// Value is the argument's value in the log's syntax. It is
+	// empty for arrays.
+	Value string
+
+	// Elems are the elements of an array argument.
+	Elems []Arg
+}
+
+// Parse parses a Z3 interaction log from r.
+func Parse(r io.Reader) (*Log, error) {
+	log := &Log{}
+	var args []Arg
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<26)
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		if text == "" {
+			continue
+		}
+		kind, rest := text[0], strings.TrimSpace(text[1:])
+		errorf := func(format string, a ...interface{}) (*Log, error) {
+			return nil, fmt.Errorf("z3log: line %d: %s", line, fmt.Sprintf(format, a...))
+		}
+		switch kind {
+		case 'V', 'M':
+			s, err := strconv.Unquote(rest)
+			if err != nil {
+				return errorf("bad string %s", rest)
+			}
+			if kind == 'V' {
+				log.Version = s
+			} else {
+				log.Messages = append(log.Messages, s)
+			}
+		case 'R':
+			args = args[:0]
+		case 'P', 'S', '$', '#', 'I', 'U', 'D':
+			args = append(args, Arg{Kind: kind, Value: rest})
+		case 'N':
+			args = append(args, Arg{Kind: kind})
+		case 'p', 's', 'u', 'i':
+			n, err := strconv.Atoi(rest)
+			if err != nil || n < 0 || n > len(args) {
+				return errorf("bad array size %s", rest)
+			}
+			elems := append([]Arg(nil), args[len(args)-n:]...)
+			args = append(args[:len(args)-n], Arg{Kind: kind, Elems: elems})
+		case 'C':
+			id, err := strconv.Atoi(rest)
+			if err != nil {
+				return errorf("bad call ID %s", rest)
+			}
+			log.Calls = append(log.Calls, Call{ID: id, Args: append([]Arg(nil), args...), Line: line})
+		case '=':
+			if len(log.Calls) == 0 {
+				return errorf("result before call")
+			}
+			log.Calls[len(log.Calls)-1].Result = rest
+		case '*':
+			if len(log.Calls) == 0 {
+				return errorf("output before call")
+			}
+			f := strings.Fields(rest)
+			pos := -1
+			if len(f) == 2 {
+				pos, _ = strconv.Atoi(f[1])
+			}
+			if pos < 0 {
+				return errorf("bad output %s", rest)
+			}
+			c := &log.Calls[len(log.Calls)-1]
+			if c.Outputs == nil {
+				c.Outputs = make(map[int]string)
+			}
+			c.Outputs[pos] = f[0]
+		case '@':
+			// Elements of output arrays. No supported call
+			// has one.
+		default:
+			return errorf("unknown command %q", kind)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// Context returns the calls in l that were made on the Z3 context
+// with the given ID, including the call that created it and the calls
+// that made and set up its configuration, so that the result can be
+// replayed on its own. For a *z3.Context, the ID is returned by its
+// LogID method.
+//
+// Z3 can only log all contexts in the process at once, so Context is
+// the way to isolate the calls of one context after the fact, for
+// example in a service where many contexts are in use.
+func (l *Log) Context(id string) *Log {
+	keep := make([]bool, len(l.Calls))
+	for i, c := range l.Calls {
+		if c.Result == id || c.on(id) {
+			keep[i] = true
+		}
+		if c.Result == id && len(c.Args) > 0 && c.Args[0].Kind == 'P' {
+			l.keepConfig(keep, i, c.Args[0].Value)
+		}
+	}
+	res := &Log{Version: l.Version, Messages: l.Messages}
+	for i, c := range l.Calls {
+		if keep[i] {
+			res.Calls = append(res.Calls, c)
+		}
+	}
+	return res
+}
+
+// on reports whether c's first argument is the object ptr.
+func (c *Call) on(ptr string) bool {
+	return len(c.Args) > 0 && c.Args[0].Kind == 'P' && c.Args[0].Value == ptr
+}
+
+// keepConfig marks the calls on the configuration cfg passed to the
+// call at index create: the call that made cfg and every later call on
+// it, until another call returns the same pointer.
+func (l *Log) keepConfig(keep []bool, create int, cfg string) {
+	start := create
+	for start > 0 && l.Calls[start-1].Result != cfg {
+		start--
+	}
+	if start == 0 {
+		// cfg was not made in the log.
+		return
+	}
+	keep[start-1] = true
+	for i := start; i < len(l.Calls) && l.Calls[i].Result != cfg; i++ {
+		if l.Calls[i].on(cfg) {
+			keep[i] = true
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestParse(t *testing.T) {
+	var buf bytes.Buffer
+	if err := OpenWriter(&buf); err != nil {
+		t.Fatal(err)
+	}
+	ctx := z3.NewContext(nil)
+	s := z3.NewSolver(ctx)
+	s.Assert(ctx.IntConst("x").GT(ctx.Int(3)))
+	s.Check()
+	Append("done")
+	Close()
+
+	log, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log.Version == "" {
+		t.Error("missing version")
+	}
+	if len(log.Messages) != 1 || log.Messages[0] != "done" {
+		t.Errorf("Messages = %q", log.Messages)
+	}
+	var sym, num bool
+	for _, c := range log.Calls {
+		for _, a := range c.Args {
+			sym = sym || a.Kind == '$' && a.Value == "|x|"
+			num = num || a.Kind == 'I' && a.Value == "3"
+		}
+	}
+	if len(log.Calls) == 0 || !sym || !num {
+		t.Errorf("unexpected calls %v", log.Calls)
+	}
+}
+
+func TestParseArrays(t *testing.T) {
+	log, err := Parse(strings.NewReader("R\nP 0x1\nP 0x2\nP 0x3\np 2\nC 77\n= 0x4\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := log.Calls[0]
+	if c.ID != 77 || c.Result != "0x4" || len(c.Args) != 2 || len(c.Args[1].Elems) != 2 || c.Args[1].Elems[1].Value != "0x3" {
+		t.Errorf("unexpected call %+v", c)
+	}
+	log, err = Parse(strings.NewReader("R\nP 0x1\nP 0\nC 321\n* 0x5 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := log.Calls[0].Outputs; len(out) != 1 || out[1] != "0x5" {
+		t.Errorf("unexpected outputs %v", out)
+	}
+	if _, err := Parse(strings.NewReader("Q\n")); err == nil {
+		t.Error("want error for unknown command")
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	var buf bytes.Buffer
+	if err := OpenWriter(&buf); err != nil {
+		t.Fatal(err)
+	}
+	ctx1, ctx2 := z3.NewContext(nil), z3.NewContext(nil)
+	ctx1.IntConst("x")
+	ctx2.IntConst("y")
+	Close()
+
+	log, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		ctx       *z3.Context
+		want, not string
+	}{{ctx1, "|x|", "|y|"}, {ctx2, "|y|", "|x|"}} {
+		var found bool
+		calls := log.Context(tc.ctx.LogID()).Calls
+		for _, c := range calls {
+			for _, a := range c.Args {
+				found = found || a.Value == tc.want
+				if a.Value == tc.not {
+					t.Errorf("log for %s contains %s", tc.want, tc.not)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("log for %s not found in %v", tc.want, calls)
+		}
+		// The context's configuration comes first, then the
+		// context.
+		var created bool
+		for _, c := range calls {
+			if c.Result == tc.ctx.LogID() {
+				created = true
+				break
+			}
+		}
+		if !created {
+			t.Errorf("log for %s does not contain context creation", tc.want)
+		}
+		if len(calls) == 0 || calls[0].Result == "" || calls[0].Result == tc.ctx.LogID() {
+			t.Errorf("log for %s does not start with config creation", tc.want)
+		}
+	}
+}
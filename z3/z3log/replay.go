@@ -0,0 +1,486 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+/*
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// A Check is the result of a satisfiability check in a replayed log.
+type Check struct {
+	// Line is the line number of the call in the log.
+	Line int
+
+	// Func is the name of the API function, such as
+	// "Z3_solver_check".
+	Func string
+
+	// Result is "sat", "unsat", or "unknown".
+	Result string
+}
+
+// Replay re-executes the calls in l in this process, against fresh Z3
+// contexts, and returns the results of its satisfiability checks in
+// order. Contexts that l creates but does not delete are deleted when
+// Replay returns.
+//
+// Replay supports the common API calls for building terms over
+// Booleans, integers, reals, bit-vectors, and arrays, and for solving
+// them with Solvers; it returns an error at the first call it does not
+// support, naming the call's line in the log. It also stops at the
+// first call that fails in Z3, since a program using package z3 would
+// have panicked there, unless it recovered with Context.Catch.
+//
+// Calls are identified in the log by IDs that differ between versions
+// of Z3, so l must have been written by the same version of Z3 that
+// Replay uses. To learn the IDs, the first Replay in a process writes
+// a log of its own, so it must not be called while a log is open.
+func (l *Log) Replay() ([]Check, error) {
+	ids, version, err := calibrate()
+	if err != nil {
+		return nil, err
+	}
+	if l.Version != version {
+		return nil, fmt.Errorf("z3log: log written by Z3 %s, but replaying with Z3 %s", l.Version, version)
+	}
+	r := newReplayer(false)
+	defer r.close()
+	for i := range l.Calls {
+		c := &l.Calls[i]
+		f := ids[c.ID]
+		if f == nil {
+			return r.checks, fmt.Errorf("z3log: line %d: unsupported call ID %d", c.Line, c.ID)
+		}
+		if err := r.replay(f, c); err != nil {
+			return r.checks, fmt.Errorf("z3log: line %d: %s: %v", c.Line, f.name, err)
+		}
+	}
+	return r.checks, nil
+}
+
+// A replayer executes calls, mapping the pointers in a log to the
+// objects it has created.
+type replayer struct {
+	// probe is set during calibration, which must not make any
+	// calls other than those of funcs, since they would be logged.
+	probe bool
+
+	objs    map[uint64]unsafe.Pointer
+	configs map[C.Z3_config]bool
+	ctxs    map[C.Z3_context]bool
+	checks  []Check
+}
+
+func newReplayer(probe bool) *replayer {
+	return &replayer{
+		probe:   probe,
+		objs:    make(map[uint64]unsafe.Pointer),
+		configs: make(map[C.Z3_config]bool),
+		ctxs:    make(map[C.Z3_context]bool),
+	}
+}
+
+// replay executes the logged call c of f.
+func (r *replayer) replay(f *funcDef, c *Call) error {
+	if len(c.Args) != len(f.sig) {
+		return fmt.Errorf("got %d arguments, want %d", len(c.Args), len(f.sig))
+	}
+	x := &args{vals: make([]argVal, len(f.sig))}
+	defer x.free()
+	for i, a := range c.Args {
+		if err := r.decode(x, i, f.sig[i], a); err != nil {
+			return fmt.Errorf("argument %d: %v", i, err)
+		}
+	}
+	res, err := r.call(f, x)
+	if err != nil {
+		return err
+	}
+	if c.Result != "" {
+		if key, err := parsePtr(c.Result); err == nil {
+			r.objs[key] = res
+		}
+	}
+	for i, p := range c.Outputs {
+		if key, err := parsePtr(p); err == nil && i < len(x.vals) {
+			r.objs[key] = unsafe.Pointer(x.vals[i].out)
+		}
+	}
+	if f.res == 'L' {
+		r.checks = append(r.checks, Check{c.Line, f.name, x.result})
+	}
+	return nil
+}
+
+// call calls f with x, tracking the configs and contexts it creates
+// and deletes, and returns its result. Outside calibration, it
+// returns an error if the call failed in Z3.
+func (r *replayer) call(f *funcDef, x *args) (unsafe.Pointer, error) {
+	res := f.call(x)
+	switch f.name {
+	case "Z3_mk_config":
+		r.configs[C.Z3_config(res)] = true
+	case "Z3_del_config":
+		delete(r.configs, x.cfg(0))
+	case "Z3_mk_context", "Z3_mk_context_rc":
+		// Record errors instead of calling Z3's default
+		// handler, which exits the process.
+		ctx := C.Z3_context(res)
+		C.Z3_set_error_handler(ctx, nil)
+		r.ctxs[ctx] = true
+	case "Z3_del_context":
+		delete(r.ctxs, x.ctx(0))
+		return res, nil
+	}
+	if !r.probe && f.sig != "" && f.sig[0] == 'c' {
+		ctx := x.ctx(0)
+		if code := C.Z3_get_error_code(ctx); code != C.Z3_OK {
+			return nil, errors.New(C.GoString(C.Z3_get_error_msg(ctx, code)))
+		}
+	}
+	return res, nil
+}
+
+// close deletes the contexts and configs that r created and did not
+// delete.
+func (r *replayer) close() {
+	for ctx := range r.ctxs {
+		C.Z3_del_context(ctx)
+	}
+	for cfg := range r.configs {
+		C.Z3_del_config(cfg)
+	}
+}
+
+// decode decodes argument i of kind kind from the log into x.
+func (r *replayer) decode(x *args, i int, kind byte, a Arg) error {
+	v := &x.vals[i]
+	switch kind {
+	case 'c', 'g', 'a', 's', 'd', 'v', 'm', 'p', 'e':
+		if a.Kind != 'P' {
+			return fmt.Errorf("got kind %c, want pointer", a.Kind)
+		}
+		p, err := r.obj(a.Value)
+		if err != nil {
+			return err
+		}
+		v.p = p
+	case 'o':
+		// The log records the initial value, which is unused.
+	case 'y':
+		ctx := x.ctx(0)
+		switch a.Kind {
+		case '$':
+			s, err := unescape(a.Value, '|')
+			if err != nil {
+				return err
+			}
+			cs := C.CString(s)
+			defer C.free(unsafe.Pointer(cs))
+			v.p = unsafe.Pointer(C.Z3_mk_string_symbol(ctx, cs))
+		case '#':
+			n, err := strconv.Atoi(a.Value)
+			if err != nil {
+				return err
+			}
+			v.p = unsafe.Pointer(C.Z3_mk_int_symbol(ctx, C.int(n)))
+		case 'N':
+		default:
+			return fmt.Errorf("got kind %c, want symbol", a.Kind)
+		}
+	case 't':
+		switch a.Kind {
+		case 'S':
+			s, err := unescape(a.Value, '"')
+			if err != nil {
+				return err
+			}
+			v.s = C.CString(s)
+		case 'N':
+		default:
+			return fmt.Errorf("got kind %c, want string", a.Kind)
+		}
+	case 'u', 'i', 'l', 'k', 'b':
+		var err error
+		switch a.Kind {
+		case 'U':
+			v.n, err = strconv.ParseUint(a.Value, 10, 64)
+		case 'I':
+			var n int64
+			n, err = strconv.ParseInt(a.Value, 10, 64)
+			v.n = uint64(n)
+		default:
+			err = fmt.Errorf("got kind %c, want number", a.Kind)
+		}
+		if err != nil {
+			return err
+		}
+	case 'f':
+		if a.Kind != 'D' {
+			return fmt.Errorf("got kind %c, want double", a.Kind)
+		}
+		var err error
+		if v.f, err = strconv.ParseFloat(a.Value, 64); err != nil {
+			return err
+		}
+	case 'A', 'S':
+		if a.Kind != 'p' {
+			return fmt.Errorf("got kind %c, want pointer array", a.Kind)
+		}
+		for _, e := range a.Elems {
+			p, err := r.obj(e.Value)
+			if err != nil {
+				return err
+			}
+			v.arr = append(v.arr, p)
+		}
+	default:
+		panic("bad argument kind " + string(kind))
+	}
+	return nil
+}
+
+// obj returns the object replayed for the logged pointer p.
+func (r *replayer) obj(p string) (unsafe.Pointer, error) {
+	key, err := parsePtr(p)
+	if err != nil {
+		return nil, err
+	}
+	if key == 0 {
+		return nil, nil
+	}
+	obj, ok := r.objs[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown object %s", p)
+	}
+	return obj, nil
+}
+
+// parsePtr parses a pointer as printed in a log.
+func parsePtr(p string) (uint64, error) {
+	if p == "0" || p == "(nil)" {
+		return 0, nil
+	}
+	key, err := strconv.ParseUint(strings.TrimPrefix(p, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad pointer %s", p)
+	}
+	return key, nil
+}
+
+// unescape decodes a string or symbol as written in a log: enclosed
+// in quote, with unusual bytes written as a backslash and three
+// decimal digits.
+func unescape(s string, quote byte) (string, error) {
+	if len(s) < 2 || s[0] != quote || s[len(s)-1] != quote {
+		return "", fmt.Errorf("bad string %s", s)
+	}
+	s = s[1 : len(s)-1]
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+4 > len(s) {
+			return "", fmt.Errorf("bad escape in %s", s)
+		}
+		n, err := strconv.ParseUint(s[i+1:i+4], 10, 8)
+		if err != nil {
+			return "", fmt.Errorf("bad escape in %s", s)
+		}
+		b.WriteByte(byte(n))
+		i += 3
+	}
+	return b.String(), nil
+}
+
+// args are the decoded arguments of a call.
+type args struct {
+	vals []argVal
+
+	// result is the result of a satisfiability check.
+	result string
+}
+
+type argVal struct {
+	p   unsafe.Pointer
+	n   uint64
+	f   float64
+	s   *C.char
+	arr []unsafe.Pointer
+	out C.Z3_ast
+}
+
+func (x *args) free() {
+	for _, v := range x.vals {
+		if v.s != nil {
+			C.free(unsafe.Pointer(v.s))
+		}
+	}
+}
+
+func (x *args) ctx(i int) C.Z3_context    { return C.Z3_context(x.vals[i].p) }
+func (x *args) cfg(i int) C.Z3_config     { return C.Z3_config(x.vals[i].p) }
+func (x *args) ast(i int) C.Z3_ast        { return C.Z3_ast(x.vals[i].p) }
+func (x *args) sort(i int) C.Z3_sort      { return C.Z3_sort(x.vals[i].p) }
+func (x *args) decl(i int) C.Z3_func_decl { return C.Z3_func_decl(x.vals[i].p) }
+func (x *args) solver(i int) C.Z3_solver  { return C.Z3_solver(x.vals[i].p) }
+func (x *args) model(i int) C.Z3_model    { return C.Z3_model(x.vals[i].p) }
+func (x *args) params(i int) C.Z3_params  { return C.Z3_params(x.vals[i].p) }
+func (x *args) vec(i int) C.Z3_ast_vector { return C.Z3_ast_vector(x.vals[i].p) }
+func (x *args) sym(i int) C.Z3_symbol     { return C.Z3_symbol(x.vals[i].p) }
+func (x *args) str(i int) C.Z3_string     { return x.vals[i].s }
+func (x *args) uint(i int) C.uint         { return C.uint(x.vals[i].n) }
+func (x *args) int(i int) C.int           { return C.int(int64(x.vals[i].n)) }
+func (x *args) int64(i int) C.int64_t     { return C.int64_t(int64(x.vals[i].n)) }
+func (x *args) uint64(i int) C.uint64_t   { return C.uint64_t(x.vals[i].n) }
+func (x *args) bool(i int) C.bool         { return x.vals[i].n != 0 }
+func (x *args) double(i int) C.double     { return C.double(x.vals[i].f) }
+func (x *args) out(i int) *C.Z3_ast       { return &x.vals[i].out }
+func (x *args) asts(i int) *C.Z3_ast      { return (*C.Z3_ast)(x.array(i)) }
+func (x *args) sorts(i int) *C.Z3_sort    { return (*C.Z3_sort)(x.array(i)) }
+func (x *args) array(i int) unsafe.Pointer {
+	if len(x.vals[i].arr) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&x.vals[i].arr[0])
+}
+
+func (x *args) check(res C.Z3_lbool) {
+	switch res {
+	case C.Z3_L_TRUE:
+		x.result = "sat"
+	case C.Z3_L_FALSE:
+		x.result = "unsat"
+	default:
+		x.result = "unknown"
+	}
+}
+
+var (
+	// calibrated is set once calibration has succeeded.
+	calibrated bool
+
+	// callIDs maps the call IDs of this version of Z3 to funcs.
+	callIDs map[int]*funcDef
+
+	// z3Version is the version of Z3 as written in logs.
+	z3Version string
+)
+
+// calibrate returns the call IDs of funcs and the version of Z3.
+//
+// It learns them by logging a call of each of funcs, in order, on a
+// context of its own. Other goroutines may use Z3 meanwhile; their
+// calls are recognized by their context and ignored.
+func calibrate() (map[int]*funcDef, string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if calibrated {
+		return callIDs, z3Version, nil
+	}
+	if isOpen {
+		return nil, "", errors.New("z3log: cannot learn call IDs while a log is open")
+	}
+	f, err := os.CreateTemp("", "z3log")
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if !open(f.Name()) {
+		return nil, "", errors.New("z3log: cannot open log")
+	}
+	mine, probeCtx := probe()
+	C.Z3_close_log()
+	if probeCtx != nil {
+		C.Z3_del_context(probeCtx)
+	}
+
+	log, err := Parse(f)
+	if err != nil {
+		return nil, "", err
+	}
+	var calls []Call
+	for _, c := range log.Calls {
+		res, _ := parsePtr(c.Result)
+		var arg uint64
+		if len(c.Args) > 0 && c.Args[0].Kind == 'P' {
+			arg, _ = parsePtr(c.Args[0].Value)
+		}
+		if mine[res] && res != 0 || mine[arg] && arg != 0 {
+			calls = append(calls, c)
+		}
+	}
+	if len(calls) != len(funcs) {
+		return nil, "", fmt.Errorf("z3log: learning call IDs: logged %d calls, want %d", len(calls), len(funcs))
+	}
+	ids := make(map[int]*funcDef)
+	for i, c := range calls {
+		if ids[c.ID] != nil {
+			return nil, "", fmt.Errorf("z3log: learning call IDs: %s and %s have the same ID", ids[c.ID].name, funcs[i].name)
+		}
+		ids[c.ID] = &funcs[i]
+	}
+	calibrated, callIDs, z3Version = true, ids, log.Version
+	return ids, log.Version, nil
+}
+
+// probe calls each of funcs once, passing each argument the most
+// recent result of the right kind, and returns the configs and
+// contexts it used, as they appear in the log, and the context it
+// left for the caller to delete once logging has stopped.
+func probe() (mine map[uint64]bool, ctx C.Z3_context) {
+	r := newReplayer(true)
+	mine = make(map[uint64]bool)
+	latest := make(map[byte]unsafe.Pointer)
+	for i := range funcs {
+		f := &funcs[i]
+		x := &args{vals: make([]argVal, len(f.sig))}
+		nstr := 0
+		for j := 0; j < len(f.sig); j++ {
+			v := &x.vals[j]
+			switch kind := f.sig[j]; kind {
+			case 'u', 'i', 'l', 'k', 'b':
+				v.n = 1
+			case 't':
+				v.s = C.CString([]string{"model", "true"}[nstr])
+				nstr++
+			case 'A':
+				v.arr = []unsafe.Pointer{latest['a']}
+			case 'S':
+				v.arr = []unsafe.Pointer{latest['s']}
+			case 'f', 'o':
+			default:
+				if v.p = latest[kind]; v.p == nil {
+					panic("z3log: no argument of kind " + string(kind) + " for " + f.name)
+				}
+			}
+		}
+		res, _ := r.call(f, x)
+		x.free()
+		if res != nil && f.res != '-' && f.res != 'L' {
+			latest[f.res] = res
+			if f.res == 'g' || f.res == 'c' {
+				mine[uint64(uintptr(res))] = true
+			}
+		}
+	}
+	// The context from Z3_mk_context is still live, since
+	// Z3_del_context was called on the one from Z3_mk_context_rc.
+	return mine, C.Z3_context(latest['c'])
+}
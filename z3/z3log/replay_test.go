@@ -0,0 +1,162 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3log
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ralscha/go-z3/z3"
+)
+
+func TestReplay(t *testing.T) {
+	var buf bytes.Buffer
+	if err := OpenWriter(&buf); err != nil {
+		t.Fatal(err)
+	}
+	ctx := z3.NewContext(nil)
+	s := z3.NewSolver(ctx)
+	x := ctx.IntConst("x")
+	y := ctx.BVConst("y|\"q", 8)
+	s.Assert(x.GT(ctx.Int(3)).And(y.UGT(ctx.FromInt(3, ctx.BVSort(8)).(z3.BV))))
+	s.Push()
+	s.Assert(x.LT(ctx.Int(2)))
+	var want []string
+	for _, pop := range []bool{false, true} {
+		if pop {
+			s.Pop()
+		}
+		sat, err := s.Check()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, map[bool]string{true: "sat", false: "unsat"}[sat])
+	}
+	s.Model().Eval(x, true)
+	Close()
+
+	log, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks, err := log.Replay()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range checks {
+		if c.Func != "Z3_solver_check" || c.Line == 0 {
+			t.Errorf("unexpected check %+v", c)
+		}
+		got = append(got, c.Result)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got checks %v, want %v", got, want)
+	}
+
+	// Replay again, since the first Replay learned the call IDs.
+	if checks, err := log.Replay(); err != nil || len(checks) != len(want) {
+		t.Errorf("second replay: got %v, %v", checks, err)
+	}
+
+	// A log from another version of Z3 is rejected.
+	other := *log
+	other.Version = "0.0.0.0"
+	if _, err := other.Replay(); err == nil || !strings.Contains(err.Error(), "0.0.0.0") {
+		t.Errorf("want version error, got %v", err)
+	}
+	// So are calls Replay does not support.
+	unsupported := *log
+	unsupported.Calls = []Call{{ID: -1, Line: 7}}
+	if _, err := unsupported.Replay(); err == nil || !strings.Contains(err.Error(), "line 7") {
+		t.Errorf("want unsupported call error, got %v", err)
+	}
+}
+
+func TestReplayContext(t *testing.T) {
+	var buf bytes.Buffer
+	if err := OpenWriter(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// Interleave two contexts, one of them configured.
+	ctx1 := z3.NewContext(z3.NewContextConfig().SetUint("timeout", 10000))
+	ctx2 := z3.NewContext(nil)
+	s1, s2 := z3.NewSolver(ctx1), z3.NewSolver(ctx2)
+	x1, x2 := ctx1.IntConst("x"), ctx2.IntConst("x")
+	s1.Assert(x1.GT(ctx1.Int(3)))
+	s2.Assert(x2.GT(ctx2.Int(3)))
+	s2.Assert(x2.LT(ctx2.Int(2)))
+	s1.Check()
+	s2.Check()
+	Close()
+
+	log, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		ctx  *z3.Context
+		want string
+	}{{ctx1, "sat"}, {ctx2, "unsat"}} {
+		checks, err := log.Context(tc.ctx.LogID()).Replay()
+		if err != nil {
+			t.Errorf("replaying context %s: %v", tc.ctx.LogID(), err)
+			continue
+		}
+		if len(checks) != 1 || checks[0].Result != tc.want {
+			t.Errorf("context %s: got checks %+v, want %s", tc.ctx.LogID(), checks, tc.want)
+		}
+	}
+}
+
+func TestReplayError(t *testing.T) {
+	ids, version, err := calibrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := func(name string) int {
+		for id, f := range ids {
+			if f.name == name {
+				return id
+			}
+		}
+		t.Fatalf("no ID for %s", name)
+		return 0
+	}
+	p := func(v string) Arg { return Arg{Kind: 'P', Value: v} }
+	// Adding Ints as bit-vectors is a sort error in Z3.
+	log := &Log{Version: version, Calls: []Call{
+		{ID: id("Z3_mk_config"), Result: "0x10", Line: 1},
+		{ID: id("Z3_mk_context_rc"), Args: []Arg{p("0x10")}, Result: "0x20", Line: 2},
+		{ID: id("Z3_mk_int_sort"), Args: []Arg{p("0x20")}, Result: "0x30", Line: 3},
+		{ID: id("Z3_mk_int64"), Args: []Arg{p("0x20"), {Kind: 'I', Value: "-5"}, p("0x30")}, Result: "0x40", Line: 4},
+		{ID: id("Z3_mk_bvadd"), Args: []Arg{p("0x20"), p("0x40"), p("0x40")}, Result: "0x50", Line: 5},
+	}}
+	if _, err := log.Replay(); err == nil || !strings.Contains(err.Error(), "line 5: Z3_mk_bvadd") {
+		t.Errorf("want sort error, got %v", err)
+	}
+	log.Calls[4].Args[1] = p("0x99")
+	if _, err := log.Replay(); err == nil || !strings.Contains(err.Error(), "unknown object 0x99") {
+		t.Errorf("want unknown object error, got %v", err)
+	}
+}
+
+func TestUnescape(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{`|x|`, "x"},
+		{`|y\124\034q|`, `y|"q`},
+	} {
+		if got, err := unescape(tc.in, '|'); got != tc.want || err != nil {
+			t.Errorf("unescape(%s) = %q, %v; want %q", tc.in, got, err, tc.want)
+		}
+	}
+	for _, in := range []string{`x`, `|x\12|`, `|\abc|`} {
+		if _, err := unescape(in, '|'); err == nil {
+			t.Errorf("unescape(%s) succeeded", in)
+		}
+	}
+}